@@ -1,5 +1,10 @@
 package helix
 
+import (
+	"fmt"
+	"time"
+)
+
 type AdLengthEnum int
 
 const (
@@ -14,36 +19,91 @@ const (
 
 type StartCommercialParams struct {
 	BroadcasterID string       `query:"broadcaster_id"`
-	Length        AdLengthEnum `query:"length"`
+	Length        AdLengthEnum `query:"length"` // Maximum: 180. Twitch rounds down to the nearest multiple of 30.
 }
 
 type AdDetails struct {
 	Length     AdLengthEnum `json:"length"`
 	Message    string       `json:"message"`
-	RetryAfter int          `json:"retry_after"`
+	RetryAfter int          `json:"retry_after"` // Seconds until the next commercial can be started
+}
+
+// RetryAfterDuration returns how long to wait before starting the next commercial.
+func (d AdDetails) RetryAfterDuration() time.Duration {
+	return time.Duration(d.RetryAfter) * time.Second
 }
 
 type ManyAdDetails struct {
 	AdDetails []AdDetails `json:"data"`
 }
 
-type StartCommercialResponse struct {
-	ResponseCommon
-	Data ManyAdDetails
-}
+type StartCommercialResponse = ResponseOf[ManyAdDetails]
 
 // StartCommercial starts a commercial on a specified channel
 // OAuth Token required
 // Requires channel:edit:commercial scope
 func (c *Client) StartCommercial(params *StartCommercialParams) (*StartCommercialResponse, error) {
-	resp, err := c.post("/channels/commercial", &ManyAdDetails{}, params)
-	if err != nil {
-		return nil, err
+	if params.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided"}
+	}
+	if params.Length < AdLen30 || params.Length > AdLen180 {
+		return nil, &ValidationError{Field: "length", Message: fmt.Sprintf("length must be between %d and %d seconds", AdLen30, AdLen180)}
 	}
 
-	commercials := &StartCommercialResponse{}
-	resp.HydrateResponseCommon(&commercials.ResponseCommon)
-	commercials.Data.AdDetails = resp.Data.(*ManyAdDetails).AdDetails
+	return postResponse[ManyAdDetails](c, "/channels/commercial", params)
+}
+
+type GetAdScheduleParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+}
+
+type AdSchedule struct {
+	NextAdAt        Time `json:"next_ad_at"`        // Zero if no ad is scheduled
+	LastAdAt        Time `json:"last_ad_at"`        // Zero if the channel hasn't run an ad or it's outside the current stream
+	Duration        int  `json:"duration"`          // Length in seconds of the next scheduled ad
+	PrerollFreeTime int  `json:"preroll_free_time"` // Seconds of pre-roll free time remaining
+	SnoozeCount     int  `json:"snooze_count"`      // Number of snoozes available
+	SnoozeRefreshAt Time `json:"snooze_refresh_at"` // When another snooze becomes available
+}
+
+type ManyAdSchedules struct {
+	AdSchedules []AdSchedule `json:"data"`
+}
+
+type GetAdScheduleResponse = ResponseOf[ManyAdSchedules]
+
+// GetAdSchedule returns ad schedule related information, including snooze counts and the time of the next ad
+// Required scope: channel:read:ads
+func (c *Client) GetAdSchedule(params *GetAdScheduleParams) (*GetAdScheduleResponse, error) {
+	if params.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided"}
+	}
+
+	return getResponse[ManyAdSchedules](c, "/channels/ads", params)
+}
+
+type SnoozeNextAdParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+}
+
+type SnoozedAd struct {
+	SnoozeCount     int  `json:"snooze_count"`
+	SnoozeRefreshAt Time `json:"snooze_refresh_at"`
+	NextAdAt        Time `json:"next_ad_at"`
+}
+
+type ManySnoozedAds struct {
+	SnoozedAds []SnoozedAd `json:"data"`
+}
+
+type SnoozeNextAdResponse = ResponseOf[ManySnoozedAds]
+
+// SnoozeNextAd pushes back the next scheduled ad by 5 minutes, if a snooze is available
+// Required scope: channel:manage:ads
+func (c *Client) SnoozeNextAd(params *SnoozeNextAdParams) (*SnoozeNextAdResponse, error) {
+	if params.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided"}
+	}
 
-	return commercials, nil
+	return postResponse[ManySnoozedAds](c, "/channels/ads/schedule/snooze", params)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestClient_StartCommercial(t *testing.T) {
@@ -86,6 +87,204 @@ func TestClient_StartCommercial(t *testing.T) {
 			t.Error("expected an empty error message, got \"#{commercialDetails.Message}\"")
 		}
 
+		if commercialDetails.RetryAfterDuration() != 480*time.Second {
+			t.Errorf("expected retry after to be \"%s\", got \"%s\"", 480*time.Second, commercialDetails.RetryAfterDuration())
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.StartCommercial(&StartCommercialParams{BroadcasterID: "41245072", Length: AdLen30})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+
+	// Test validation errors
+	validationCases := []struct {
+		params *StartCommercialParams
+		err    string
+	}{
+		{&StartCommercialParams{Length: AdLen30}, "error: broadcaster id must be provided"},
+		{&StartCommercialParams{BroadcasterID: "41245072"}, "error: length must be between 30 and 180 seconds"},
+		{&StartCommercialParams{BroadcasterID: "41245072", Length: 240}, "error: length must be between 30 and 180 seconds"},
+	}
+
+	for _, validationCase := range validationCases {
+		_, err := c.StartCommercial(validationCase.params)
+		if err == nil || err.Error() != validationCase.err {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", validationCase.err, err)
+		}
+	}
+}
+
+func TestGetAdSchedule(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetAdScheduleParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			&GetAdScheduleParams{},
+			``,
+			"error: broadcaster id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			&GetAdScheduleParams{BroadcasterID: "123"},
+			`{"data":[{"next_ad_at":"2023-08-01T23:08:18+00:00","last_ad_at":"2023-08-01T23:08:18+00:00","duration":60,"preroll_free_time":90,"snooze_count":1,"snooze_refresh_at":"2023-08-01T23:08:18+00:00"}]}`,
+			"",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			&GetAdScheduleParams{BroadcasterID: "123"},
+			`{"data":[{"next_ad_at":1690931298,"last_ad_at":0,"duration":60,"preroll_free_time":90,"snooze_count":1,"snooze_refresh_at":1690931298}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetAdSchedule(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.AdSchedules) != 1 {
+			t.Errorf("expected number of results to be \"%d\", got \"%d\"", 1, len(resp.Data.AdSchedules))
+			continue
+		}
+
+		schedule := resp.Data.AdSchedules[0]
+		if schedule.NextAdAt.Unix() != 1690931298 {
+			t.Errorf("expected next ad at to be \"%d\", got \"%d\"", 1690931298, schedule.NextAdAt.Unix())
+		}
+
+		if schedule.SnoozeCount != 1 {
+			t.Errorf("expected snooze count to be \"%d\", got \"%d\"", 1, schedule.SnoozeCount)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetAdSchedule(&GetAdScheduleParams{BroadcasterID: "123"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestSnoozeNextAd(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode     int
+		options        *Options
+		params         *SnoozeNextAdParams
+		respBody       string
+		validationErr  string
+		expectedErrMsg string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			&SnoozeNextAdParams{},
+			``,
+			"error: broadcaster id must be provided",
+			"",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			&SnoozeNextAdParams{BroadcasterID: "123"},
+			`{"data":[{"snooze_count":1,"snooze_refresh_at":"2023-08-01T23:08:18+00:00","next_ad_at":"2023-08-01T23:08:18+00:00"}]}`,
+			"",
+			"",
+		},
+		{
+			http.StatusTooManyRequests,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			&SnoozeNextAdParams{BroadcasterID: "123"},
+			`{"error":"Too Many Requests","status":429,"message":"The channel has no snoozes left."}`,
+			"",
+			"The channel has no snoozes left.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.SnoozeNextAd(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			if resp.ErrorMessage != testCase.expectedErrMsg {
+				t.Errorf("expected error message to be \"%s\", got \"%s\"", testCase.expectedErrMsg, resp.ErrorMessage)
+			}
+			continue
+		}
+
+		if len(resp.Data.SnoozedAds) != 1 {
+			t.Errorf("expected number of results to be \"%d\", got \"%d\"", 1, len(resp.Data.SnoozedAds))
+			continue
+		}
+
+		if resp.Data.SnoozedAds[0].NextAdAt.IsZero() {
+			t.Error("expected next ad at not to be zero")
+		}
 	}
 
 	// Test with HTTP Failure
@@ -100,7 +299,7 @@ func TestClient_StartCommercial(t *testing.T) {
 		ctx:  context.Background(),
 	}
 
-	_, err := c.StartCommercial(&StartCommercialParams{})
+	_, err := c.SnoozeNextAd(&SnoozeNextAdParams{BroadcasterID: "123"})
 	if err == nil {
 		t.Error("expected error but got nil")
 	}
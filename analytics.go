@@ -1,5 +1,26 @@
 package helix
 
+// Types of analytics reports
+const (
+	AnalyticsTypeOverviewV2 = "overview_v2"
+)
+
+func validateAnalyticsDateRange(first int, startedAt, endedAt Time) error {
+	if err := validateFirst(first, 100); err != nil {
+		return err
+	}
+
+	if startedAt.IsZero() != endedAt.IsZero() {
+		return &ValidationError{Field: "started_at", Message: "started at and ended at must be specified together"}
+	}
+
+	if endedAt.Before(startedAt.Time) {
+		return &ValidationError{Field: "ended_at", Message: "ended at must not be before started at"}
+	}
+
+	return nil
+}
+
 type ExtensionAnalytic struct {
 	ExtensionID string    `json:"extension_id"`
 	URL         string    `json:"URL"`
@@ -12,33 +33,28 @@ type ManyExtensionAnalytics struct {
 	Pagination         Pagination          `json:"pagination"`
 }
 
-type ExtensionAnalyticsResponse struct {
-	ResponseCommon
-	Data ManyExtensionAnalytics
-}
+type ExtensionAnalyticsResponse = ResponseOf[ManyExtensionAnalytics]
 
 type ExtensionAnalyticsParams struct {
-	ExtensionID string `query:"extension_id"`
-	First       int    `query:"first,20"`
+	ExtensionID string `query:"extension_id"` // Optional, all extensions are returned if not set
+	First       int    `query:"first,20"`     // Limit 100, only used if ExtensionID isn't set
 	After       string `query:"after"`
-	StartedAt   Time   `query:"started_at"`
-	EndedAt     Time   `query:"ended_at"`
-	Type        string `query:"type"`
+	StartedAt   Time   `query:"started_at"` // Requires EndedAt
+	EndedAt     Time   `query:"ended_at"`   // Requires StartedAt
+	Type        string `query:"type"`       // Valid values: "overview_v2"
 }
 
 // GetExtensionAnalytics returns a URL to the downloadable CSV file
-// containing analytics data. Valid for 5 minutes.
+// containing analytics data. Valid for 5 minutes. Each report also has
+// its type and the date range it covers.
+//
+// Required scope: analytics:read:extensions
 func (c *Client) GetExtensionAnalytics(params *ExtensionAnalyticsParams) (*ExtensionAnalyticsResponse, error) {
-	resp, err := c.get("/analytics/extensions", &ManyExtensionAnalytics{}, params)
-	if err != nil {
+	if err := validateAnalyticsDateRange(params.First, params.StartedAt, params.EndedAt); err != nil {
 		return nil, err
 	}
 
-	users := &ExtensionAnalyticsResponse{}
-	resp.HydrateResponseCommon(&users.ResponseCommon)
-	users.Data.ExtensionAnalytics = resp.Data.(*ManyExtensionAnalytics).ExtensionAnalytics
-	users.Data.Pagination = resp.Data.(*ManyExtensionAnalytics).Pagination
-	return users, nil
+	return getResponse[ManyExtensionAnalytics](c, "/analytics/extensions", params)
 }
 
 type GameAnalytic struct {
@@ -53,33 +69,26 @@ type ManyGameAnalytics struct {
 	Pagination    Pagination     `json:"pagination"`
 }
 
-type GameAnalyticsResponse struct {
-	ResponseCommon
-	Data ManyGameAnalytics
-}
+type GameAnalyticsResponse = ResponseOf[ManyGameAnalytics]
 
 type GameAnalyticsParams struct {
-	GameID    string `query:"game_id"`
-	First     int    `query:"first,20"`
+	GameID    string `query:"game_id"`  // Optional, all games are returned if not set
+	First     int    `query:"first,20"` // Limit 100, only used if GameID isn't set
 	After     string `query:"after"`
-	StartedAt Time   `query:"started_at"`
-	EndedAt   Time   `query:"ended_at"`
-	Type      string `query:"type"`
+	StartedAt Time   `query:"started_at"` // Requires EndedAt
+	EndedAt   Time   `query:"ended_at"`   // Requires StartedAt
+	Type      string `query:"type"`       // Valid values: "overview_v2"
 }
 
 // GetGameAnalytics returns a URL to the downloadable CSV file
 // containing analytics data for the specified game. Valid for 5 minutes.
+// Each report also has its type and the date range it covers.
+//
+// Required scope: analytics:read:games
 func (c *Client) GetGameAnalytics(params *GameAnalyticsParams) (*GameAnalyticsResponse, error) {
-
-	resp, err := c.get("/analytics/games", &ManyGameAnalytics{}, params)
-	if err != nil {
+	if err := validateAnalyticsDateRange(params.First, params.StartedAt, params.EndedAt); err != nil {
 		return nil, err
 	}
 
-	users := &GameAnalyticsResponse{}
-	resp.HydrateResponseCommon(&users.ResponseCommon)
-	users.Data.GameAnalytics = resp.Data.(*ManyGameAnalytics).GameAnalytics
-	users.Data.Pagination = resp.Data.(*ManyGameAnalytics).Pagination
-
-	return users, nil
+	return getResponse[ManyGameAnalytics](c, "/analytics/games", params)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetExtensionAnalytics(t *testing.T) {
@@ -179,3 +180,72 @@ func TestGetGameAnalytics(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestGetAnalyticsDateRange(t *testing.T) {
+	t.Parallel()
+
+	startedAt := Time{time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	endedAt := Time{time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC)}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("started_at") != "2018-01-01T00:00:00Z" || query.Get("ended_at") != "2018-03-01T00:00:00Z" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"game_id":"493057","URL":"https://example.com/report.csv","type":"overview_v2","date_range":{"started_at":"2018-01-01T00:00:00Z","ended_at":"2018-03-01T00:00:00Z"}},{"game_id":"9821","URL":"https://example.com/report2.csv","type":"overview_v2","date_range":{"started_at":"2018-01-01T00:00:00Z","ended_at":"2018-03-01T00:00:00Z"}}],"pagination":{"cursor":"eyJiIjpudWxsLJxhIjoiIn0gf5"}}`))
+	})
+
+	resp, err := c.GetGameAnalytics(&GameAnalyticsParams{
+		First:     2,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Type:      AnalyticsTypeOverviewV2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.GameAnalytics) != 2 {
+		t.Fatalf("expected 2 reports, got %d", len(resp.Data.GameAnalytics))
+	}
+
+	report := resp.Data.GameAnalytics[0]
+	if report.Type != AnalyticsTypeOverviewV2 || !report.DateRange.StartedAt.Equal(startedAt.Time) || !report.DateRange.EndedAt.Equal(endedAt.Time) {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	if resp.Data.Pagination.Cursor != "eyJiIjpudWxsLJxhIjoiIn0gf5" {
+		t.Errorf("expected pagination cursor to be set, got \"%s\"", resp.Data.Pagination.Cursor)
+	}
+
+	testCases := []struct {
+		params        *ExtensionAnalyticsParams
+		validationErr string
+	}{
+		{
+			&ExtensionAnalyticsParams{First: 101},
+			"error: first must not be greater than 100",
+		},
+		{
+			&ExtensionAnalyticsParams{StartedAt: startedAt},
+			"error: started at and ended at must be specified together",
+		},
+		{
+			&ExtensionAnalyticsParams{EndedAt: endedAt},
+			"error: started at and ended at must be specified together",
+		},
+		{
+			&ExtensionAnalyticsParams{StartedAt: endedAt, EndedAt: startedAt},
+			"error: ended at must not be before started at",
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := c.GetExtensionAnalytics(testCase.params)
+		if err == nil || err.Error() != testCase.validationErr {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", testCase.validationErr, err)
+		}
+	}
+}
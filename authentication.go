@@ -1,42 +1,158 @@
 package helix
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 )
 
 var authPaths = map[string]string{
 	"token":    "/token",
 	"revoke":   "/revoke",
 	"validate": "/validate",
+	"device":   "/device",
+	"keys":     "/keys",
+
+	// The Twitch CLI mock server issues user access tokens here
+	"authorize": "/authorize",
 }
 
+const (
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// Twitch returns these messages while the user has not yet completed
+	// the device code flow.
+	deviceAuthorizationPending = "authorization_pending"
+	deviceSlowDown             = "slow_down"
+
+	defaultDevicePollInterval = 5 * time.Second
+)
+
+// Response types of the authorization URL. The OIDC response types require
+// the "openid" scope.
+const (
+	ResponseTypeCode         = "code"
+	ResponseTypeToken        = "token"
+	ResponseTypeIDToken      = "id_token"
+	ResponseTypeTokenIDToken = "token id_token"
+	ResponseTypeCodeIDToken  = "code id_token"
+)
+
 type AuthorizationURLParams struct {
-	ResponseType string   // (Required) Options: "code" or "token"
+	ResponseType string   // (Required) Options: "code", "token", "id_token", "token id_token" or "code id_token"
 	Scopes       []string // (Required)
 	State        string   // (Optional)
 	ForceVerify  bool     // (Optional)
+
+	// (Optional) PKCE code challenge, see NewPKCE
+	CodeChallenge       string
+	CodeChallengeMethod string // Defaults to "S256" if CodeChallenge is set
+
+	// (Optional) OIDC parameters, only used with the "openid" scope
+	Nonce  string      // Returned in the ID token's nonce claim, see IDTokenVerifier
+	Claims *OIDCClaims // Additional claims to include in the ID token and UserInfo response
 }
 
 func (c *Client) GetAuthorizationURL(params *AuthorizationURLParams) string {
-	url := AuthBaseURL + "/authorize"
-	url += "?response_type=" + params.ResponseType
-	url += "&client_id=" + c.opts.ClientID
-	url += "&redirect_uri=" + c.opts.RedirectURI
+	authURL := c.getAuthBaseURL() + authPaths["authorize"]
+	authURL += "?response_type=" + strings.ReplaceAll(params.ResponseType, " ", "%20")
+	authURL += "&client_id=" + c.opts.ClientID
+	authURL += "&redirect_uri=" + c.opts.RedirectURI
 
 	if params.State != "" {
-		url += "&state=" + params.State
+		authURL += "&state=" + params.State
 	}
 
 	if params.ForceVerify {
-		url += "&force_verify=true"
+		authURL += "&force_verify=true"
 	}
 
 	if len(params.Scopes) != 0 {
-		url += "&scope=" + strings.Join(params.Scopes, "%20")
+		authURL += "&scope=" + strings.Join(params.Scopes, "%20")
+	}
+
+	if params.CodeChallenge != "" {
+		method := params.CodeChallengeMethod
+		if method == "" {
+			method = PKCEMethodS256
+		}
+
+		authURL += "&code_challenge=" + params.CodeChallenge
+		authURL += "&code_challenge_method=" + method
+	}
+
+	if params.Nonce != "" {
+		authURL += "&nonce=" + url.QueryEscape(params.Nonce)
+	}
+
+	if claims := params.Claims.encode(); claims != "" {
+		authURL += "&claims=" + url.QueryEscape(claims)
+	}
+
+	return authURL
+}
+
+const (
+	PKCEMethodS256  = "S256"
+	PKCEMethodPlain = "plain"
+
+	pkceVerifierBytes = 32
+)
+
+// A code verifier may only contain unreserved characters and must be
+// between 43 and 128 characters long, see RFC 7636 section 4.1.
+var pkceCodeVerifierRegexp = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// PKCE holds a code verifier and its derived code challenge for use with
+// the authorization code flow.
+type PKCE struct {
+	CodeVerifier        string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// NewPKCE generates a random code verifier along with its S256 code challenge.
+// Pass the challenge to GetAuthorizationURL and keep the verifier around until
+// exchanging the authorization code with RequestUserAccessTokenWithPKCE.
+func NewPKCE() (*PKCE, error) {
+	b := make([]byte, pkceVerifierBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	verifier := base64.RawURLEncoding.EncodeToString(b)
+
+	return &PKCE{
+		CodeVerifier:        verifier,
+		CodeChallenge:       PKCECodeChallengeS256(verifier),
+		CodeChallengeMethod: PKCEMethodS256,
+	}, nil
+}
+
+// PKCECodeChallengeS256 derives the S256 code challenge for the given code verifier.
+func PKCECodeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// VerifyPKCECodeChallenge reports whether codeChallenge was derived from
+// codeVerifier using the given method.
+func VerifyPKCECodeChallenge(codeVerifier, codeChallenge, method string) bool {
+	switch method {
+	case PKCEMethodS256, "":
+		return PKCECodeChallengeS256(codeVerifier) == codeChallenge
+	case PKCEMethodPlain:
+		return codeVerifier == codeChallenge
 	}
 
-	return url
+	return false
 }
 
 type AccessCredentials struct {
@@ -44,12 +160,10 @@ type AccessCredentials struct {
 	RefreshToken string   `json:"refresh_token"`
 	ExpiresIn    int      `json:"expires_in"`
 	Scopes       []string `json:"scope"`
+	IDToken      string   `json:"id_token"` // Only set when the "openid" scope was requested
 }
 
-type AppAccessTokenResponse struct {
-	ResponseCommon
-	Data AccessCredentials
-}
+type AppAccessTokenResponse = ResponseOf[AccessCredentials]
 
 func (c *Client) RequestAppAccessToken(scopes []string) (*AppAccessTokenResponse, error) {
 	opts := c.opts
@@ -61,28 +175,14 @@ func (c *Client) RequestAppAccessToken(scopes []string) (*AppAccessTokenResponse
 		Scopes:       strings.Join(scopes, " "),
 	}
 
-	resp, err := c.post(authPaths["token"], &AccessCredentials{}, data)
-	if err != nil {
-		return nil, err
-	}
-
-	token := &AppAccessTokenResponse{}
-	resp.HydrateResponseCommon(&token.ResponseCommon)
-	token.Data.AccessToken = resp.Data.(*AccessCredentials).AccessToken
-	token.Data.RefreshToken = resp.Data.(*AccessCredentials).RefreshToken
-	token.Data.ExpiresIn = resp.Data.(*AccessCredentials).ExpiresIn
-	token.Data.Scopes = resp.Data.(*AccessCredentials).Scopes
-
-	return token, nil
+	return c.requestAccessCredentials(authPaths["token"], data)
 }
 
-type UserAccessTokenResponse struct {
-	ResponseCommon
-	Data AccessCredentials
-}
+type UserAccessTokenResponse = ResponseOf[AccessCredentials]
 
 type accessTokenRequestData struct {
 	Code         string `query:"code"`
+	CodeVerifier string `query:"code_verifier"`
 	ClientID     string `query:"client_id"`
 	ClientSecret string `query:"client_secret"`
 	RedirectURI  string `query:"redirect_uri"`
@@ -91,35 +191,62 @@ type accessTokenRequestData struct {
 }
 
 func (c *Client) RequestUserAccessToken(code string) (*UserAccessTokenResponse, error) {
+	return c.requestUserAccessToken(code, "")
+}
+
+// RequestUserAccessTokenWithPKCE exchanges an authorization code obtained with
+// a PKCE code challenge. The client secret is optional here, which allows
+// public clients to complete the authorization code flow.
+func (c *Client) RequestUserAccessTokenWithPKCE(code, codeVerifier string) (*UserAccessTokenResponse, error) {
+	if !pkceCodeVerifierRegexp.MatchString(codeVerifier) {
+		return nil, &ValidationError{Field: "code_verifier", Message: "code verifier must be 43 to 128 unreserved characters"}
+	}
+
+	return c.requestUserAccessToken(code, codeVerifier)
+}
+
+func (c *Client) requestUserAccessToken(code, codeVerifier string) (*UserAccessTokenResponse, error) {
 	opts := c.opts
 	data := &accessTokenRequestData{
 		Code:         code,
+		CodeVerifier: codeVerifier,
 		ClientID:     opts.ClientID,
 		ClientSecret: opts.ClientSecret,
 		RedirectURI:  opts.RedirectURI,
 		GrantType:    "authorization_code",
 	}
 
-	resp, err := c.post(authPaths["token"], &AccessCredentials{}, data)
-	if err != nil {
-		return nil, err
-	}
-
-	token := &UserAccessTokenResponse{}
-	resp.HydrateResponseCommon(&token.ResponseCommon)
-	token.Data.AccessToken = resp.Data.(*AccessCredentials).AccessToken
-	token.Data.RefreshToken = resp.Data.(*AccessCredentials).RefreshToken
-	token.Data.ExpiresIn = resp.Data.(*AccessCredentials).ExpiresIn
-	token.Data.Scopes = resp.Data.(*AccessCredentials).Scopes
+	return c.requestAccessCredentials(authPaths["token"], data)
+}
 
-	return token, nil
+type twitchCLIMockUserAccessTokenRequestData struct {
+	ClientID     string `query:"client_id"`
+	ClientSecret string `query:"client_secret"`
+	GrantType    string `query:"grant_type"`
+	UserID       string `query:"user_id"`
+	Scopes       string `query:"scope"`
 }
 
-type RefreshTokenResponse struct {
-	ResponseCommon
-	Data AccessCredentials
+// RequestTwitchCLIMockUserAccessToken requests a user access token for the
+// given user from the Twitch CLI mock server, which issues them without the
+// authorization code flow. The client must use TwitchCLIMockAuthBaseURL, and
+// ClientID and ClientSecret must be those of a client listed by
+// "twitch mock-api generate".
+func (c *Client) RequestTwitchCLIMockUserAccessToken(userID string, scopes []string) (*UserAccessTokenResponse, error) {
+	opts := c.opts
+	data := &twitchCLIMockUserAccessTokenRequestData{
+		ClientID:     opts.ClientID,
+		ClientSecret: opts.ClientSecret,
+		GrantType:    "user_token",
+		UserID:       userID,
+		Scopes:       strings.Join(scopes, " "),
+	}
+
+	return c.requestAccessCredentials(authPaths["authorize"], data)
 }
 
+type RefreshTokenResponse = ResponseOf[AccessCredentials]
+
 type refreshTokenRequestData struct {
 	ClientID     string `query:"client_id"`
 	ClientSecret string `query:"client_secret"`
@@ -140,19 +267,124 @@ func (c *Client) RefreshUserAccessToken(refreshToken string) (*RefreshTokenRespo
 		RefreshToken: refreshToken,
 	}
 
-	resp, err := c.post(authPaths["token"], &AccessCredentials{}, data)
+	return c.requestAccessCredentials(authPaths["token"], data)
+}
+
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+
+	// Scopes holds the scopes the device code was requested with. They
+	// have to be sent again when exchanging the device code for a token.
+	Scopes []string `json:"-"`
+}
+
+type DeviceCodeResponse = ResponseOf[DeviceCode]
+
+type deviceCodeRequestData struct {
+	ClientID string `query:"client_id"`
+	Scopes   string `query:"scopes"`
+}
+
+// RequestDeviceCode starts the OAuth device code flow. The returned user code
+// should be presented to the user along with the verification URI, after which
+// WaitForDeviceToken can be used to obtain the user access token.
+//
+// No client secret is needed, so this flow may be used by public clients.
+func (c *Client) RequestDeviceCode(scopes []string) (*DeviceCodeResponse, error) {
+	data := &deviceCodeRequestData{
+		ClientID: c.opts.ClientID,
+		Scopes:   strings.Join(scopes, " "),
+	}
+
+	resp, err := c.post(authPaths["device"], &DeviceCode{}, data)
 	if err != nil {
 		return nil, err
 	}
 
-	refresh := &RefreshTokenResponse{}
-	resp.HydrateResponseCommon(&refresh.ResponseCommon)
-	refresh.Data.AccessToken = resp.Data.(*AccessCredentials).AccessToken
-	refresh.Data.RefreshToken = resp.Data.(*AccessCredentials).RefreshToken
-	refresh.Data.ExpiresIn = resp.Data.(*AccessCredentials).ExpiresIn
-	refresh.Data.Scopes = resp.Data.(*AccessCredentials).Scopes
+	deviceCode := &DeviceCodeResponse{}
+	resp.HydrateResponseCommon(&deviceCode.ResponseCommon)
+	deviceCode.Data = *resp.Data.(*DeviceCode)
+	deviceCode.Data.Scopes = scopes
+
+	return deviceCode, nil
+}
+
+type deviceAccessTokenRequestData struct {
+	ClientID     string `query:"client_id"`
+	ClientSecret string `query:"client_secret"`
+	DeviceCode   string `query:"device_code"`
+	GrantType    string `query:"grant_type"`
+	Scopes       string `query:"scopes"`
+}
+
+// RequestDeviceAccessToken makes a single attempt at exchanging a device code
+// for a user access token. While the user has not yet authorized the device,
+// Twitch responds with a 400 status and an "authorization_pending" message.
+func (c *Client) RequestDeviceAccessToken(deviceCode *DeviceCode) (*UserAccessTokenResponse, error) {
+	opts := c.opts
+	data := &deviceAccessTokenRequestData{
+		ClientID:     opts.ClientID,
+		ClientSecret: opts.ClientSecret,
+		DeviceCode:   deviceCode.DeviceCode,
+		GrantType:    deviceCodeGrantType,
+		Scopes:       strings.Join(deviceCode.Scopes, " "),
+	}
+
+	return c.requestAccessCredentials(authPaths["token"], data)
+}
+
+// WaitForDeviceToken polls Twitch at the interval returned by RequestDeviceCode
+// until the user completes the device code flow, the device code expires, or
+// ctx is done.
+//
+// Once Twitch stops reporting the authorization as pending, its response is
+// returned as is. So a denied or otherwise invalid device code is surfaced
+// through the response's error fields, like any other request.
+func (c *Client) WaitForDeviceToken(ctx context.Context, deviceCode *DeviceCode) (*UserAccessTokenResponse, error) {
+	if deviceCode == nil || deviceCode.DeviceCode == "" {
+		return nil, &ValidationError{Field: "device_code", Message: "device code must be specified"}
+	}
+
+	interval := time.Duration(deviceCode.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	var expiresAt time.Time
+	if deviceCode.ExpiresIn > 0 {
+		expiresAt = c.now().Add(time.Duration(deviceCode.ExpiresIn) * time.Second)
+	}
 
-	return refresh, nil
+	for {
+		resp, err := keepFailedResponse(c.RequestDeviceAccessToken(deviceCode))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusBadRequest {
+			return resp, nil
+		}
+
+		switch resp.ErrorMessage {
+		case deviceAuthorizationPending:
+		case deviceSlowDown:
+			interval += defaultDevicePollInterval
+		default:
+			return resp, nil
+		}
+
+		if err := c.sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		if !expiresAt.IsZero() && !c.now().Before(expiresAt) {
+			return nil, errors.New("error: device code expired before authorization was completed")
+		}
+	}
 }
 
 type RevokeAccessTokenResponse struct {
@@ -169,6 +401,10 @@ type revokeAccessTokenRequestData struct {
 // Both successful requests and requests with bad tokens return 200 OK with
 // no body. Requests with bad tokens return the same response, as there is no
 // meaningful action a client can take after sending a bad token.
+//
+// When the revoked token is the client's current user access token, it is
+// cleared from the client along with the refresh token, so the client doesn't
+// keep using or refreshing it after a logout.
 func (c *Client) RevokeUserAccessToken(accessToken string) (*RevokeAccessTokenResponse, error) {
 	data := &revokeAccessTokenRequestData{
 		ClientID:    c.opts.ClientID,
@@ -180,41 +416,89 @@ func (c *Client) RevokeUserAccessToken(accessToken string) (*RevokeAccessTokenRe
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusOK && accessToken != "" {
+		c.mu.Lock()
+		c.forgetToken(accessToken)
+		c.mu.Unlock()
+
+		if accessToken == c.GetUserAccessToken() {
+			c.SetUserAccessToken("")
+			c.SetRefreshToken("")
+		}
+	}
+
 	revoke := &RevokeAccessTokenResponse{}
 	resp.HydrateResponseCommon(&revoke.ResponseCommon)
 
 	return revoke, nil
 }
 
-type ValidateTokenResponse struct {
-	ResponseCommon
-	Data validateTokenDetails
-}
+// UserAccessTokenValidationInterval is how often Twitch requires apps to
+// validate the user access tokens they use.
+const UserAccessTokenValidationInterval = time.Hour
 
-type validateTokenDetails struct {
+type ValidateTokenResponse = ResponseOf[ValidateTokenDetails]
+
+// ValidateTokenDetails describes a validated access token. Login and UserID
+// are empty for app access tokens.
+type ValidateTokenDetails struct {
 	ClientID  string   `json:"client_id"`
 	Login     string   `json:"login"`
 	Scopes    []string `json:"scopes"`
 	UserID    string   `json:"user_id"`
-	ExpiresIn int      `json:"expires_in"`
+	ExpiresIn int      `json:"expires_in"` // Seconds until the token expires
+}
+
+// HasScopes reports whether the token was granted all of the given scopes.
+func (d *ValidateTokenDetails) HasScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		found := false
+		for _, granted := range d.Scopes {
+			if granted == scope {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Expiry returns the time until the token expires.
+func (d *ValidateTokenDetails) Expiry() time.Duration {
+	return time.Duration(d.ExpiresIn) * time.Second
 }
 
 // ValidateToken - Validate access token
+//
+// The token is only sent with the validation request, the client's own
+// tokens and its token store are left as they are. Validating the client's
+// user access token remembers its scopes, see SetUserAccessTokenScopes.
 func (c *Client) ValidateToken(accessToken string) (bool, *ValidateTokenResponse, error) {
-	// Reset to original token after request
-	currentToken := c.opts.UserAccessToken
-	c.SetUserAccessToken(accessToken)
-	defer c.SetUserAccessToken(currentToken)
+	var data ValidateTokenDetails
+	resp := &Response{Data: &data}
 
-	var data validateTokenDetails
-	resp, err := c.get(authPaths["validate"], &data, nil)
+	req, err := c.newRequest(http.MethodGet, authPaths["validate"], nil, false)
 	if err != nil {
 		return false, nil, err
 	}
+	req = req.WithContext(withRequestToken(req.Context(), accessToken))
+
+	if err := c.doRequest(req, resp); err != nil {
+		return false, nil, err
+	}
+
+	isValid := resp.StatusCode == http.StatusOK
+	if isValid {
+		c.recordTokenMetadata(accessToken, data.UserID, data.Scopes, data.Expiry())
 
-	var isValid bool
-	if resp.StatusCode == http.StatusOK {
-		isValid = true
+		if accessToken != "" && accessToken == c.GetUserAccessToken() {
+			c.SetUserAccessTokenScopes(data.Scopes)
+		}
 	}
 
 	tokenResp := &ValidateTokenResponse{
@@ -224,3 +508,35 @@ func (c *Client) ValidateToken(accessToken string) (bool, *ValidateTokenResponse
 
 	return isValid, tokenResp, nil
 }
+
+// ValidateUserAccessTokenPeriodically validates the client's user access token
+// right away and then at every interval, as Twitch requires apps to do hourly.
+// If interval is zero, UserAccessTokenValidationInterval is used.
+//
+// onInvalid is called with Twitch's response whenever the token is no longer
+// valid, or with the error whenever the request fails. If the client is able
+// to refresh the token, an expired token is refreshed as part of the validation.
+//
+// It blocks until ctx is done, so it is usually run in its own goroutine.
+func (c *Client) ValidateUserAccessTokenPeriodically(ctx context.Context, interval time.Duration, onInvalid func(*ValidateTokenResponse, error)) error {
+	if interval <= 0 {
+		interval = UserAccessTokenValidationInterval
+	}
+
+	for {
+		isValid, resp, err := c.ValidateToken(c.GetUserAccessToken())
+		if err == nil && !isValid && resp.StatusCode == http.StatusUnauthorized && c.canRefreshToken() {
+			// The token expired, validate the refreshed one instead
+			if refreshErr := c.refreshToken(); refreshErr == nil {
+				isValid, resp, err = c.ValidateToken(c.GetUserAccessToken())
+			}
+		}
+		if (err != nil || !isValid) && onInvalid != nil {
+			onInvalid(resp, err)
+		}
+
+		if err := c.sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
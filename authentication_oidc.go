@@ -0,0 +1,217 @@
+package helix
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// OIDCIssuer is the issuer of the ID tokens Twitch returns.
+const OIDCIssuer = AuthBaseURL
+
+// Claims that can be requested in addition to the default ID token claims
+const (
+	OIDCClaimEmail             = "email"
+	OIDCClaimEmailVerified     = "email_verified"
+	OIDCClaimPicture           = "picture"
+	OIDCClaimPreferredUsername = "preferred_username"
+	OIDCClaimUpdatedAt         = "updated_at"
+)
+
+// OIDCClaims lists the claims to request for the ID token and the UserInfo
+// response, see the OIDCClaim constants.
+type OIDCClaims struct {
+	IDToken  []string
+	UserInfo []string
+}
+
+// encode returns the claims as the JSON object expected by the claims
+// parameter, e.g. {"id_token":{"email":null}}.
+func (c *OIDCClaims) encode() string {
+	if c == nil || (len(c.IDToken) == 0 && len(c.UserInfo) == 0) {
+		return ""
+	}
+
+	toMap := func(claims []string) map[string]interface{} {
+		if len(claims) == 0 {
+			return nil
+		}
+
+		m := make(map[string]interface{}, len(claims))
+		for _, claim := range claims {
+			m[claim] = nil
+		}
+		return m
+	}
+
+	b, _ := json.Marshal(struct {
+		IDToken  map[string]interface{} `json:"id_token,omitempty"`
+		UserInfo map[string]interface{} `json:"userinfo,omitempty"`
+	}{
+		IDToken:  toMap(c.IDToken),
+		UserInfo: toMap(c.UserInfo),
+	})
+
+	return string(b)
+}
+
+// IDTokenClaims are the claims of an ID token. Email, EmailVerified,
+// Picture, PreferredUsername and UpdatedAt are only set when they were
+// requested with OIDCClaims.
+type IDTokenClaims struct {
+	jwt.StandardClaims
+	AuthorizedParty   string `json:"azp"`
+	Nonce             string `json:"nonce"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Picture           string `json:"picture"`
+	PreferredUsername string `json:"preferred_username"`
+	UpdatedAt         Time   `json:"updated_at"`
+}
+
+// JSONWebKey is a public key that Twitch signs ID tokens with.
+type JSONWebKey struct {
+	Alg string `json:"alg"`
+	E   string `json:"e"`
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	Use string `json:"use"`
+}
+
+// JSONWebKeySet is the response data in GetOIDCKeysResponse
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type GetOIDCKeysResponse = ResponseOf[JSONWebKeySet]
+
+// GetOIDCKeys gets the public keys that Twitch signs ID tokens with.
+func (c *Client) GetOIDCKeys() (*GetOIDCKeysResponse, error) {
+	return getResponse[JSONWebKeySet](c, authPaths["keys"], nil)
+}
+
+// RSAPublicKey decodes the key's modulus and exponent.
+func (k *JSONWebKey) RSAPublicKey() (*rsa.PublicKey, error) {
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("error: unsupported key type %s", k.Kty)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// IDTokenVerifier verifies the signature and claims of ID tokens. Twitch's
+// keys are fetched on first use and fetched again when a token is signed
+// with a key that isn't known yet.
+type IDTokenVerifier struct {
+	client *Client
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey
+}
+
+// NewIDTokenVerifier returns a verifier for ID tokens issued to the client's
+// ClientID.
+func (c *Client) NewIDTokenVerifier() *IDTokenVerifier {
+	return &IDTokenVerifier{client: c}
+}
+
+// Verify checks that the ID token was signed by Twitch, was issued to the
+// client, hasn't expired and, if nonce isn't empty, carries the nonce that
+// was passed to GetAuthorizationURL.
+func (v *IDTokenVerifier) Verify(idToken, nonce string) (*IDTokenClaims, error) {
+	if err := validateRequired("id_token", "id token", idToken); err != nil {
+		return nil, err
+	}
+
+	parsedToken, err := jwt.ParseWithClaims(idToken, &IDTokenClaims{}, func(tkn *jwt.Token) (interface{}, error) {
+		if tkn.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("Unexpected signing method: %s", tkn.Header["alg"])
+		}
+
+		kid, _ := tkn.Header["kid"].(string)
+		return v.key(kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := parsedToken.Claims.(*IDTokenClaims)
+	if !ok || !parsedToken.Valid {
+		return nil, errors.New("error: could not parse id token")
+	}
+
+	if !claims.VerifyIssuer(OIDCIssuer, true) {
+		return nil, fmt.Errorf("error: unexpected id token issuer %s", claims.Issuer)
+	}
+
+	if !claims.VerifyAudience(v.client.opts.ClientID, true) {
+		return nil, fmt.Errorf("error: id token was issued to %s", claims.Audience)
+	}
+
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, errors.New("error: id token nonce does not match")
+	}
+
+	return claims, nil
+}
+
+func (v *IDTokenVerifier) key(kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+
+	resp, err := v.client.GetOIDCKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resp.Err(); err != nil {
+		return nil, fmt.Errorf("error: could not get oidc keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(resp.Data.Keys))
+	for _, jwk := range resp.Data.Keys {
+		key, err := jwk.RSAPublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	v.keys = keys
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+
+	// Tokens without a key id can only be verified against a single key
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("error: unknown id token key %q", kid)
+}
@@ -0,0 +1,191 @@
+package helix
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func newOIDCTestKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks, err := json.Marshal(JSONWebKeySet{Keys: []JSONWebKey{{
+		Alg: "RS256",
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		Kid: "1",
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		Use: "sig",
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, string(jwks)
+}
+
+func signOIDCTestToken(t *testing.T, key *rsa.PrivateKey, claims *IDTokenClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "1"
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func TestIDTokenVerifier(t *testing.T) {
+	t.Parallel()
+
+	key, jwks := newOIDCTestKey(t)
+	now := time.Now()
+
+	validClaims := func() *IDTokenClaims {
+		return &IDTokenClaims{
+			StandardClaims: jwt.StandardClaims{
+				Audience:  "my-client-id",
+				ExpiresAt: now.Add(time.Hour).Unix(),
+				IssuedAt:  now.Unix(),
+				Issuer:    OIDCIssuer,
+				Subject:   "713936733",
+			},
+			AuthorizedParty:   "my-client-id",
+			Nonce:             "some-nonce",
+			PreferredUsername: "twitchdev",
+		}
+	}
+
+	testCases := []struct {
+		modify func(*IDTokenClaims)
+		nonce  string
+		errMsg string
+	}{
+		{
+			func(*IDTokenClaims) {},
+			"some-nonce",
+			"",
+		},
+		{
+			func(*IDTokenClaims) {},
+			"",
+			"",
+		},
+		{
+			func(*IDTokenClaims) {},
+			"other-nonce",
+			"error: id token nonce does not match",
+		},
+		{
+			func(claims *IDTokenClaims) { claims.Audience = "other-client-id" },
+			"some-nonce",
+			"error: id token was issued to other-client-id",
+		},
+		{
+			func(claims *IDTokenClaims) { claims.Issuer = "https://example.com" },
+			"some-nonce",
+			"error: unexpected id token issuer https://example.com",
+		},
+		{
+			func(claims *IDTokenClaims) { claims.ExpiresAt = now.Add(-time.Hour).Unix() },
+			"some-nonce",
+			"token is expired by",
+		},
+	}
+
+	var keyRequests int32
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth2/keys" {
+			t.Errorf("expected path to be %s, got %s", "/oauth2/keys", r.URL.Path)
+		}
+
+		atomic.AddInt32(&keyRequests, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(jwks))
+	})
+	verifier := c.NewIDTokenVerifier()
+
+	for _, testCase := range testCases {
+		claims := validClaims()
+		testCase.modify(claims)
+
+		verified, err := verifier.Verify(signOIDCTestToken(t, key, claims), testCase.nonce)
+		if err != nil {
+			if testCase.errMsg == "" || !strings.HasPrefix(err.Error(), testCase.errMsg) {
+				t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.errMsg, err)
+			}
+			continue
+		}
+
+		if testCase.errMsg != "" {
+			t.Errorf("expected error '%v' but got nil", testCase.errMsg)
+			continue
+		}
+
+		if verified.Subject != "713936733" || verified.PreferredUsername != "twitchdev" {
+			t.Errorf("unexpected claims %+v", verified)
+		}
+	}
+
+	// Keys are only fetched once
+	if atomic.LoadInt32(&keyRequests) != 1 {
+		t.Errorf("expected keys to be requested %d times, got %d", 1, atomic.LoadInt32(&keyRequests))
+	}
+
+	// A missing token is a validation error
+	_, err := verifier.Verify("", "some-nonce")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "id_token" {
+		t.Errorf("expected a ValidationError for id_token, got %v", err)
+	} else if err.Error() != "error: id token must be specified" {
+		t.Errorf("unexpected error %q", err)
+	}
+
+	// Tokens not signed with RS256 are rejected
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = verifier.Verify(hmacToken, "some-nonce")
+	if err == nil || err.Error() != "Unexpected signing method: HS256" {
+		t.Errorf("expected unexpected signing method error, got %v", err)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.NewIDTokenVerifier().Verify(signOIDCTestToken(t, key, validClaims()), "some-nonce")
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Errorf("expected error does match return error, got %v", err)
+	}
+}
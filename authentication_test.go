@@ -3,7 +3,9 @@ package helix
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestGetAuthorizationURL(t *testing.T) {
@@ -40,6 +42,45 @@ func TestGetAuthorizationURL(t *testing.T) {
 			},
 			"https://id.twitch.tv/oauth2/authorize?response_type=token&client_id=my-client-id&redirect_uri=https://example.com/auth/callback&state=some-state&force_verify=true&scope=analytics:read:games%20bits:read%20clips:edit%20user:edit%20user:read:email",
 		},
+		{
+			&AuthorizationURLParams{
+				ResponseType:  "code",
+				Scopes:        []string{"user:read:email"},
+				CodeChallenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+			},
+			&Options{
+				ClientID:    "my-client-id",
+				RedirectURI: "https://example.com/auth/callback",
+			},
+			"https://id.twitch.tv/oauth2/authorize?response_type=code&client_id=my-client-id&redirect_uri=https://example.com/auth/callback&scope=user:read:email&code_challenge=E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM&code_challenge_method=S256",
+		},
+		{
+			&AuthorizationURLParams{
+				ResponseType: ResponseTypeTokenIDToken,
+				Scopes:       []string{"openid", "user:read:email"},
+				Nonce:        "some-nonce",
+				Claims: &OIDCClaims{
+					IDToken: []string{OIDCClaimEmail},
+				},
+			},
+			&Options{
+				ClientID:    "my-client-id",
+				RedirectURI: "https://example.com/auth/callback",
+			},
+			"https://id.twitch.tv/oauth2/authorize?response_type=token%20id_token&client_id=my-client-id&redirect_uri=https://example.com/auth/callback&scope=openid%20user:read:email&nonce=some-nonce&claims=%7B%22id_token%22%3A%7B%22email%22%3Anull%7D%7D",
+		},
+		{
+			&AuthorizationURLParams{
+				ResponseType: "code",
+				Scopes:       []string{"user:read:email"},
+			},
+			&Options{
+				ClientID:    "my-client-id",
+				RedirectURI: "http://localhost:3000/callback",
+				AuthBaseURL: TwitchCLIMockAuthBaseURL,
+			},
+			"http://localhost:8080/auth/authorize?response_type=code&client_id=my-client-id&redirect_uri=http://localhost:3000/callback&scope=user:read:email",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -302,6 +343,94 @@ func TestRequestUserAccessToken(t *testing.T) {
 	}
 }
 
+func TestNewPKCE(t *testing.T) {
+	t.Parallel()
+
+	pkce, err := NewPKCE()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(pkce.CodeVerifier) != 43 {
+		t.Errorf("expected code verifier length to be \"%d\", got \"%d\"", 43, len(pkce.CodeVerifier))
+	}
+
+	if pkce.CodeChallengeMethod != PKCEMethodS256 {
+		t.Errorf("expected code challenge method to be \"%s\", got \"%s\"", PKCEMethodS256, pkce.CodeChallengeMethod)
+	}
+
+	if !VerifyPKCECodeChallenge(pkce.CodeVerifier, pkce.CodeChallenge, pkce.CodeChallengeMethod) {
+		t.Errorf("expected code challenge \"%s\" to match code verifier \"%s\"", pkce.CodeChallenge, pkce.CodeVerifier)
+	}
+
+	other, err := NewPKCE()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if other.CodeVerifier == pkce.CodeVerifier {
+		t.Error("expected code verifiers to be random")
+	}
+}
+
+func TestPKCECodeChallengeS256(t *testing.T) {
+	t.Parallel()
+
+	// Example from RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	expectedChallenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if challenge := PKCECodeChallengeS256(verifier); challenge != expectedChallenge {
+		t.Errorf("expected code challenge to be \"%s\", got \"%s\"", expectedChallenge, challenge)
+	}
+
+	if !VerifyPKCECodeChallenge(verifier, verifier, PKCEMethodPlain) {
+		t.Error("expected plain code challenge to match code verifier")
+	}
+
+	if VerifyPKCECodeChallenge(verifier, verifier, PKCEMethodS256) {
+		t.Error("expected S256 code challenge not to match code verifier")
+	}
+}
+
+func TestRequestUserAccessTokenWithPKCE(t *testing.T) {
+	t.Parallel()
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+	c := newMockClient(&Options{
+		ClientID:    "valid-client-id",
+		RedirectURI: "https://example.com/auth/callback",
+	}, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("code_verifier") != verifier {
+			t.Errorf("expected code_verifier to be \"%s\", got \"%s\"", verifier, query.Get("code_verifier"))
+		}
+
+		if query.Has("client_secret") {
+			t.Error("expected client_secret not to be sent")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"access_token":"kagsfkgiuowegfkjsbdcuiwebf","expires_in":14146,"refresh_token":"fiuhgaofohofhohdflhoiwephvlhowiehfoi"}`))
+	})
+
+	resp, err := c.RequestUserAccessTokenWithPKCE("valid-auth-code", verifier)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if resp.Data.AccessToken != "kagsfkgiuowegfkjsbdcuiwebf" {
+		t.Errorf("expected access token to be \"%s\", got \"%s\"", "kagsfkgiuowegfkjsbdcuiwebf", resp.Data.AccessToken)
+	}
+
+	// Test invalid code verifier
+	_, err = c.RequestUserAccessTokenWithPKCE("valid-auth-code", "too-short")
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+}
+
 func TestRefreshUserAccessToken(t *testing.T) {
 	t.Parallel()
 
@@ -534,6 +663,49 @@ func TestRevokeUserAccessToken(t *testing.T) {
 	}
 }
 
+func TestRevokeUserAccessTokenClearsClientToken(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{
+		ClientID:        "valid-client-id",
+		UserAccessToken: "current-token",
+		RefreshToken:    "refresh-token",
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth2/revoke" {
+			t.Errorf("expected path to be %s, got %s", "/oauth2/revoke", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("client_id") != "valid-client-id" {
+			t.Errorf("expected client_id to be %s, got %s", "valid-client-id", r.URL.Query().Get("client_id"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Revoking some other token leaves the client's tokens alone
+	_, err := c.RevokeUserAccessToken("other-token")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if c.GetUserAccessToken() != "current-token" {
+		t.Errorf("expected user token to be %s, got %s", "current-token", c.GetUserAccessToken())
+	}
+
+	_, err = c.RevokeUserAccessToken("current-token")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if c.GetUserAccessToken() != "" {
+		t.Errorf("expected user token to be cleared, got %s", c.GetUserAccessToken())
+	}
+
+	if c.GetRefreshToken() != "" {
+		t.Errorf("expected refresh token to be cleared, got %s", c.GetRefreshToken())
+	}
+}
+
 func TestValidateToken(t *testing.T) {
 	t.Parallel()
 
@@ -613,6 +785,18 @@ func TestValidateToken(t *testing.T) {
 		if c.opts.UserAccessToken != initialUserToken {
 			t.Errorf("expected user token to be %s, got %s", initialUserToken, c.opts.UserAccessToken)
 		}
+
+		if !resp.Data.HasScopes("user:read:email") {
+			t.Errorf("expected token to have scope %s, got %v", "user:read:email", resp.Data.Scopes)
+		}
+
+		if resp.Data.HasScopes("user:read:email", "bits:read") {
+			t.Errorf("expected token not to have scope %s", "bits:read")
+		}
+
+		if resp.Data.Expiry() != 5243778*time.Second {
+			t.Errorf("expected expiry to be %s, got %s", 5243778*time.Second, resp.Data.Expiry())
+		}
 	}
 
 	// Test with HTTP Failure
@@ -636,3 +820,368 @@ func TestValidateToken(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestValidateUserAccessTokenPeriodically(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newMockClient(&Options{UserAccessToken: "expired-token"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "OAuth expired-token" {
+			t.Errorf("expected authorization header to be %s, got %s", "OAuth expired-token", r.Header.Get("Authorization"))
+		}
+
+		if atomic.AddInt32(&requests, 1) == 3 {
+			cancel()
+		}
+
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"status":401,"message":"invalid access token"}`))
+	})
+
+	var invalid int32
+	err := c.ValidateUserAccessTokenPeriodically(ctx, time.Millisecond, func(resp *ValidateTokenResponse, err error) {
+		atomic.AddInt32(&invalid, 1)
+
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if resp.ErrorMessage != "invalid access token" {
+			t.Errorf("expected error message to be %s, got %s", "invalid access token", resp.ErrorMessage)
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("expected error to be %v, got %v", context.Canceled, err)
+	}
+
+	if atomic.LoadInt32(&invalid) != 3 {
+		t.Errorf("expected token to be reported invalid %d times, got %d", 3, atomic.LoadInt32(&invalid))
+	}
+
+	if c.GetUserAccessToken() != "expired-token" {
+		t.Errorf("expected user token to be %s, got %s", "expired-token", c.GetUserAccessToken())
+	}
+}
+
+func TestRequestTwitchCLIMockUserAccessToken(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{
+		ClientID:     "mock-client-id",
+		ClientSecret: "mock-client-secret",
+		APIBaseURL:   TwitchCLIMockAPIBaseURL,
+		AuthBaseURL:  TwitchCLIMockAuthBaseURL,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Scheme+"://"+r.URL.Host+r.URL.Path != TwitchCLIMockAuthBaseURL+"/authorize" {
+			t.Errorf("expected request to %s/authorize, got %s", TwitchCLIMockAuthBaseURL, r.URL)
+		}
+
+		query := r.URL.Query()
+		if query.Get("grant_type") != "user_token" || query.Get("user_id") != "12345" || query.Get("scope") != "user:read:email bits:read" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+
+		if query.Get("client_id") != "mock-client-id" || query.Get("client_secret") != "mock-client-secret" {
+			t.Errorf("unexpected client credentials %s", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"access_token":"mock-access-token","refresh_token":"","expires_in":86399,"scope":["user:read:email","bits:read"],"token_type":"bearer"}`))
+	})
+
+	resp, err := c.RequestTwitchCLIMockUserAccessToken("12345", []string{"user:read:email", "bits:read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Data.AccessToken != "mock-access-token" || len(resp.Data.Scopes) != 2 {
+		t.Errorf("unexpected token %+v", resp.Data)
+	}
+
+	// Other authentication requests use the overridden base url as well
+	c = newMockClient(&Options{
+		ClientID:    "mock-client-id",
+		AuthBaseURL: TwitchCLIMockAuthBaseURL,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.String() != TwitchCLIMockAuthBaseURL+"/validate" {
+			t.Errorf("expected request to %s/validate, got %s", TwitchCLIMockAuthBaseURL, r.URL)
+		}
+
+		if r.Header.Get("Authorization") != "OAuth mock-access-token" {
+			t.Errorf("expected authorization header to be %s, got %s", "OAuth mock-access-token", r.Header.Get("Authorization"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"client_id":"mock-client-id","login":"","scopes":[],"user_id":"12345","expires_in":86399}`))
+	})
+
+	if _, _, err := c.ValidateToken("mock-access-token"); err != nil {
+		t.Error(err)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.RequestTwitchCLIMockUserAccessToken("12345", nil)
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestRequestDeviceCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode     int
+		options        *Options
+		scopes         []string
+		respBody       string
+		expectedErrMsg string
+	}{
+		{
+			http.StatusBadRequest,
+			&Options{
+				ClientID: "invalid-client-id", // invalid client id
+			},
+			[]string{"user:read:email"},
+			`{"status":400,"message":"invalid client"}`,
+			"invalid client",
+		},
+		{
+			http.StatusOK,
+			&Options{
+				ClientID: "valid-client-id",
+			},
+			[]string{"user:read:email"},
+			`{"device_code":"ike3GM8QIdYZs43KdrWPIO36LofILoCyFEzjlQ91","expires_in":1800,"interval":5,"user_code":"ABCDEFGH","verification_uri":"https://www.twitch.tv/activate?public=true&device-code=ABCDEFGH"}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.RequestDeviceCode(testCase.scopes)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		// Test error cases
+		if resp.StatusCode != http.StatusOK {
+			if resp.ErrorMessage != testCase.expectedErrMsg {
+				t.Errorf("expected error message to be \"%s\", got \"%s\"", testCase.expectedErrMsg, resp.ErrorMessage)
+			}
+
+			continue
+		}
+
+		// Test success cases
+		if resp.Data.DeviceCode != "ike3GM8QIdYZs43KdrWPIO36LofILoCyFEzjlQ91" {
+			t.Errorf("expected device code to be \"%s\", got \"%s\"", "ike3GM8QIdYZs43KdrWPIO36LofILoCyFEzjlQ91", resp.Data.DeviceCode)
+		}
+
+		if resp.Data.UserCode != "ABCDEFGH" {
+			t.Errorf("expected user code to be \"%s\", got \"%s\"", "ABCDEFGH", resp.Data.UserCode)
+		}
+
+		if resp.Data.Interval != 5 {
+			t.Errorf("expected interval to be \"%d\", got \"%d\"", 5, resp.Data.Interval)
+		}
+
+		if len(resp.Data.Scopes) != len(testCase.scopes) {
+			t.Errorf("expected number of scopes to be \"%d\", got \"%d\"", len(testCase.scopes), len(resp.Data.Scopes))
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.RequestDeviceCode([]string{"user:read:email"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestWaitForDeviceToken(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	pendingThenAuthorized := func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		if r.URL.Query().Get("grant_type") != "urn:ietf:params:oauth:grant-type:device_code" {
+			t.Errorf("expected grant_type to be the device code grant, got \"%s\"", r.URL.Query().Get("grant_type"))
+		}
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":400,"message":"authorization_pending"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"access_token":"kagsfkgiuowegfkjsbdcuiwebf","expires_in":14124,"refresh_token":"fiuhgaofohofhohdflhoiwephvlhowiehfoi","scope":["user:read:email"],"token_type":"bearer"}`))
+	}
+
+	c := newMockClient(&Options{ClientID: "valid-client-id"}, pendingThenAuthorized)
+
+	resp, err := c.WaitForDeviceToken(context.Background(), &DeviceCode{
+		DeviceCode: "valid-device-code",
+		ExpiresIn:  1800,
+		Interval:   1,
+		Scopes:     []string{"user:read:email"},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status code to be \"%d\", got \"%d\"", http.StatusOK, resp.StatusCode)
+	}
+
+	if attempts != 2 {
+		t.Errorf("expected %d token requests, got %d", 2, attempts)
+	}
+
+	if resp.Data.AccessToken != "kagsfkgiuowegfkjsbdcuiwebf" {
+		t.Errorf("expected access token to be \"%s\", got \"%s\"", "kagsfkgiuowegfkjsbdcuiwebf", resp.Data.AccessToken)
+	}
+
+	// Test invalid device code
+	c = newMockClient(&Options{ClientID: "valid-client-id"}, newMockHandler(http.StatusBadRequest, `{"status":400,"message":"invalid device code"}`, nil))
+
+	resp, err = c.WaitForDeviceToken(context.Background(), &DeviceCode{DeviceCode: "invalid-device-code"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if resp.ErrorMessage != "invalid device code" {
+		t.Errorf("expected error message to be \"%s\", got \"%s\"", "invalid device code", resp.ErrorMessage)
+	}
+
+	// Test cancelled context
+	c = newMockClient(&Options{ClientID: "valid-client-id"}, newMockHandler(http.StatusBadRequest, `{"status":400,"message":"authorization_pending"}`, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.WaitForDeviceToken(ctx, &DeviceCode{DeviceCode: "valid-device-code"})
+	if err != context.Canceled {
+		t.Errorf("expected error to be \"%v\", got \"%v\"", context.Canceled, err)
+	}
+
+	// Test missing device code
+	_, err = c.WaitForDeviceToken(context.Background(), &DeviceCode{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+}
+
+func TestValidateTokenLeavesClientTokens(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryTokenStore()
+	if err := store.SetToken(TokenStoreKeyUser, &StoredToken{AccessToken: "user-access-token", RefreshToken: "refresh-token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newMockClient(&Options{
+		ClientID:        "my-client-id",
+		UserAccessToken: "user-access-token",
+		TokenStore:      store,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "OAuth other-access-token" {
+			t.Errorf("expected the validated token to be sent, got %q", r.Header.Get("Authorization"))
+		}
+
+		// Nothing was written to the store while the request is in flight
+		token, err := store.GetToken(TokenStoreKeyUser)
+		if err != nil || token.AccessToken != "user-access-token" || token.RefreshToken != "refresh-token" {
+			t.Errorf("expected the stored token to be unchanged, got %+v", token)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"client_id":"my-client-id","login":"other","scopes":["chat:read"],"user_id":"2","expires_in":3600}`))
+	})
+
+	isValid, _, err := c.ValidateToken("other-access-token")
+	if err != nil || !isValid {
+		t.Fatalf("expected the token to be valid, got %t and %v", isValid, err)
+	}
+
+	if c.GetUserAccessToken() != "user-access-token" || c.GetUserAccessTokenScopes() != nil {
+		t.Errorf("expected the client's token and scopes to be unchanged, got %q and %v", c.GetUserAccessToken(), c.GetUserAccessTokenScopes())
+	}
+}
+
+func TestValidateUserAccessTokenPeriodicallyRefreshes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newMockClient(&Options{
+		ClientID:        "my-client-id",
+		ClientSecret:    "my-client-secret",
+		UserAccessToken: "expired-token",
+		RefreshToken:    "refresh-token",
+	}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "OAuth expired-token":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":401,"message":"invalid access token"}`))
+		case "OAuth new-token":
+			cancel()
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"client_id":"my-client-id","login":"user","scopes":[],"user_id":"1","expires_in":3600}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"new-token","refresh_token":"new-refresh-token","expires_in":14400}`))
+		}
+	})
+
+	err := c.ValidateUserAccessTokenPeriodically(ctx, time.Millisecond, func(resp *ValidateTokenResponse, err error) {
+		t.Errorf("expected the refreshed token to be valid, got %+v and %v", resp, err)
+	})
+	if err != context.Canceled {
+		t.Errorf("expected error to be %v, got %v", context.Canceled, err)
+	}
+
+	if c.GetUserAccessToken() != "new-token" {
+		t.Errorf("expected the token to be refreshed, got %q", c.GetUserAccessToken())
+	}
+}
@@ -0,0 +1,212 @@
+package helix
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBatchWait is how long a BatchLoader collects lookups before sending
+// them, unless a batch fills up first.
+const DefaultBatchWait = 10 * time.Millisecond
+
+// maxBatchSize is the number of IDs or logins the batched endpoints accept.
+const maxBatchSize = 100
+
+// BatchLoader coalesces concurrent lookups of users, games and streams into
+// batched requests of up to 100 IDs. Each lookup waits for its batch, which is
+// sent once it is full or the wait has passed since its first lookup, so many
+// goroutines resolving users one at a time share a handful of requests.
+type BatchLoader struct {
+	usersByID    *batcher
+	usersByLogin *batcher
+	games        *batcher
+	streams      *batcher
+}
+
+// NewBatchLoader returns a loader that collects lookups for wait before
+// sending them, DefaultBatchWait if wait isn't positive.
+func (c *Client) NewBatchLoader(wait time.Duration) *BatchLoader {
+	if wait <= 0 {
+		wait = DefaultBatchWait
+	}
+
+	return &BatchLoader{
+		usersByID:    &batcher{wait: wait, fetch: c.batchUsers(false), afterFunc: c.afterFunc},
+		usersByLogin: &batcher{wait: wait, fetch: c.batchUsers(true), afterFunc: c.afterFunc},
+		games:        &batcher{wait: wait, fetch: c.batchGames, afterFunc: c.afterFunc},
+		streams:      &batcher{wait: wait, fetch: c.batchStreams, afterFunc: c.afterFunc},
+	}
+}
+
+// LoadUser returns the user with the given ID, or nil if there is none.
+func (l *BatchLoader) LoadUser(id string) (*User, error) {
+	v, err := l.usersByID.load(id)
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(*User), nil
+}
+
+// LoadUserByLogin returns the user with the given login, or nil if there is
+// none.
+func (l *BatchLoader) LoadUserByLogin(login string) (*User, error) {
+	v, err := l.usersByLogin.load(strings.ToLower(login))
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(*User), nil
+}
+
+// LoadGame returns the game with the given ID, or nil if there is none.
+func (l *BatchLoader) LoadGame(id string) (*Game, error) {
+	v, err := l.games.load(id)
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(*Game), nil
+}
+
+// LoadStream returns the live stream of the user with the given ID, or nil
+// if the user isn't live.
+func (l *BatchLoader) LoadStream(userID string) (*Stream, error) {
+	v, err := l.streams.load(userID)
+	if v == nil || err != nil {
+		return nil, err
+	}
+
+	return v.(*Stream), nil
+}
+
+func (c *Client) batchUsers(byLogin bool) func(keys []string) (map[string]interface{}, error) {
+	return func(keys []string) (map[string]interface{}, error) {
+		params := &UsersParams{IDs: keys}
+		if byLogin {
+			params = &UsersParams{Logins: keys}
+		}
+
+		resp, err := c.GetUsers(params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := resp.Err(); err != nil {
+			return nil, fmt.Errorf("error: could not get users: %w", err)
+		}
+
+		results := make(map[string]interface{}, len(resp.Data.Users))
+		for i := range resp.Data.Users {
+			user := &resp.Data.Users[i]
+			if byLogin {
+				results[strings.ToLower(user.Login)] = user
+			} else {
+				results[user.ID] = user
+			}
+		}
+
+		return results, nil
+	}
+}
+
+func (c *Client) batchGames(keys []string) (map[string]interface{}, error) {
+	resp, err := c.GetGames(&GamesParams{IDs: keys})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resp.Err(); err != nil {
+		return nil, fmt.Errorf("error: could not get games: %w", err)
+	}
+
+	results := make(map[string]interface{}, len(resp.Data.Games))
+	for i := range resp.Data.Games {
+		results[resp.Data.Games[i].ID] = &resp.Data.Games[i]
+	}
+
+	return results, nil
+}
+
+func (c *Client) batchStreams(keys []string) (map[string]interface{}, error) {
+	resp, err := c.GetStreams(&StreamsParams{UserIDs: keys, First: maxBatchSize})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resp.Err(); err != nil {
+		return nil, fmt.Errorf("error: could not get streams: %w", err)
+	}
+
+	results := make(map[string]interface{}, len(resp.Data.Streams))
+	for i := range resp.Data.Streams {
+		results[resp.Data.Streams[i].UserID] = &resp.Data.Streams[i]
+	}
+
+	return results, nil
+}
+
+// batcher collects keys into batches and fetches each batch once.
+type batcher struct {
+	wait      time.Duration
+	fetch     func(keys []string) (map[string]interface{}, error)
+	afterFunc func(d time.Duration, f func()) Timer
+
+	mu      sync.Mutex
+	current *batch
+}
+
+type batch struct {
+	keys []string
+	seen map[string]bool
+
+	once    sync.Once
+	done    chan struct{}
+	results map[string]interface{}
+	err     error
+}
+
+func (b *batcher) load(key string) (interface{}, error) {
+	b.mu.Lock()
+	current := b.current
+	if current == nil {
+		current = &batch{seen: map[string]bool{}, done: make(chan struct{})}
+		b.current = current
+		b.afterFunc(b.wait, func() { b.dispatch(current) })
+	}
+
+	if !current.seen[key] {
+		current.seen[key] = true
+		current.keys = append(current.keys, key)
+	}
+
+	if len(current.keys) == maxBatchSize {
+		b.current = nil
+		go b.run(current)
+	}
+	b.mu.Unlock()
+
+	<-current.done
+
+	return current.results[key], current.err
+}
+
+// dispatch sends a batch whose wait has passed, unless it filled up before.
+func (b *batcher) dispatch(current *batch) {
+	b.mu.Lock()
+	if b.current == current {
+		b.current = nil
+	}
+	b.mu.Unlock()
+
+	b.run(current)
+}
+
+func (b *batcher) run(current *batch) {
+	current.once.Do(func() {
+		current.results, current.err = b.fetch(current.keys)
+		close(current.done)
+	})
+}
@@ -0,0 +1,141 @@
+package helix
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchLoaderUsers(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		var users []string
+		for _, id := range r.URL.Query()["id"] {
+			// Odd IDs don't exist
+			if n, _ := strconv.Atoi(id); n%2 == 0 {
+				users = append(users, fmt.Sprintf(`{"id":"%s","login":"user%s"}`, id, id))
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[` + strings.Join(users, ",") + `]}`))
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, handler)
+	loader := c.NewBatchLoader(200 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 150; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+
+			user, err := loader.LoadUser(id)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			n, _ := strconv.Atoi(id)
+			if n%2 == 1 {
+				if user != nil {
+					t.Errorf("expected no user for %s, got %+v", id, user)
+				}
+				return
+			}
+
+			if user == nil || user.ID != id || user.Login != "user"+id {
+				t.Errorf("unexpected user for %s: %+v", id, user)
+			}
+		}(strconv.Itoa(i % 120))
+	}
+	wg.Wait()
+
+	// 120 distinct IDs fit into two batches
+	if requests != 2 {
+		t.Errorf("expected 2 requests to be sent, got %d", requests)
+	}
+}
+
+func TestBatchLoaderUserByLogin(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[{"id":"26301881","login":"sodapoppin"}]}`, nil))
+	loader := c.NewBatchLoader(0)
+
+	user, err := loader.LoadUserByLogin("SodaPoppin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user == nil || user.ID != "26301881" {
+		t.Errorf("unexpected user %+v", user)
+	}
+}
+
+func TestBatchLoaderGamesAndStreams(t *testing.T) {
+	t.Parallel()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		switch r.URL.Path {
+		case "/games":
+			w.Write([]byte(`{"data":[{"id":"509658","name":"Just Chatting"}]}`))
+		case "/streams":
+			if r.URL.Query().Get("first") != "100" {
+				t.Errorf("expected streams to be requested with first=100, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data":[{"id":"1","user_id":"26301881","type":"live"}]}`))
+		}
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, handler)
+	loader := c.NewBatchLoader(time.Millisecond)
+
+	game, err := loader.LoadGame("509658")
+	if err != nil || game == nil || game.Name != "Just Chatting" {
+		t.Errorf("unexpected game %+v (%v)", game, err)
+	}
+
+	stream, err := loader.LoadStream("26301881")
+	if err != nil || stream == nil || stream.Type != "live" {
+		t.Errorf("unexpected stream %+v (%v)", stream, err)
+	}
+
+	stream, err = loader.LoadStream("18074328")
+	if err != nil || stream != nil {
+		t.Errorf("expected no stream for an offline user, got %+v (%v)", stream, err)
+	}
+}
+
+func TestBatchLoaderErrors(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusUnauthorized, `{"error":"Unauthorized","status":401,"message":"OAuth token is missing"}`, nil))
+	loader := c.NewBatchLoader(time.Millisecond)
+
+	_, err := loader.LoadUser("26301881")
+	if err == nil || err.Error() != "error: could not get users: 401 Unauthorized: OAuth token is missing" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// Test with HTTP Failure
+	c = newMockClient(&Options{ClientID: "my-client-id"}, nil)
+	c.opts.HTTPClient = &badMockHTTPClient{newMockHandler(0, "", nil)}
+	loader = c.NewBatchLoader(time.Millisecond)
+
+	_, err = loader.LoadGame("509658")
+	if err == nil || err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -1,6 +1,17 @@
 package helix
 
-import "time"
+import (
+	"time"
+)
+
+// Time periods of a bits leaderboard
+const (
+	BitsLeaderboardPeriodAll   = "all"
+	BitsLeaderboardPeriodDay   = "day"
+	BitsLeaderboardPeriodWeek  = "week"
+	BitsLeaderboardPeriodMonth = "month"
+	BitsLeaderboardPeriodYear  = "year"
+)
 
 type UserBitTotal struct {
 	UserID    string `json:"user_id"`
@@ -16,10 +27,7 @@ type ManyUserBitTotals struct {
 	UserBitTotals []UserBitTotal `json:"data"`
 }
 
-type BitsLeaderboardResponse struct {
-	ResponseCommon
-	Data ManyUserBitTotals
-}
+type BitsLeaderboardResponse = ResponseOf[ManyUserBitTotals]
 
 type BitsLeaderboardParams struct {
 	Count     int       `query:"count,10"`   // Maximum 100
@@ -28,25 +36,55 @@ type BitsLeaderboardParams struct {
 	UserID    string    `query:"user_id"`
 }
 
+func isValidBitsLeaderboardPeriod(period string) bool {
+	switch period {
+	case "", BitsLeaderboardPeriodAll, BitsLeaderboardPeriodDay, BitsLeaderboardPeriodWeek,
+		BitsLeaderboardPeriodMonth, BitsLeaderboardPeriodYear:
+		return true
+	}
+
+	return false
+}
+
 // GetBitsLeaderboard gets a ranked list of Bits leaderboard
 // information for an authorized broadcaster.
 //
+// StartedAt selects the period that the leaderboard covers, e.g. the week
+// containing StartedAt when Period is "week". It is ignored by Twitch when
+// Period is "all", so setting it without another period is rejected.
+//
 // Required Scope: bits:read
 func (c *Client) GetBitsLeaderboard(params *BitsLeaderboardParams) (*BitsLeaderboardResponse, error) {
-	resp, err := c.get("/bits/leaderboard", &ManyUserBitTotals{}, params)
-	if err != nil {
-		return nil, err
+	if params.Count > 100 {
+		return nil, &ValidationError{Field: "count", Message: "count must not be greater than 100"}
+	}
+
+	if !isValidBitsLeaderboardPeriod(params.Period) {
+		return nil, &ValidationError{Field: "period", Message: "period must be one of all, day, week, month or year"}
 	}
 
-	bits := &BitsLeaderboardResponse{}
-	resp.HydrateResponseCommon(&bits.ResponseCommon)
-	bits.Data.Total = resp.Data.(*ManyUserBitTotals).Total
-	bits.Data.DateRange = resp.Data.(*ManyUserBitTotals).DateRange
-	bits.Data.UserBitTotals = resp.Data.(*ManyUserBitTotals).UserBitTotals
+	if !params.StartedAt.IsZero() && (params.Period == "" || params.Period == BitsLeaderboardPeriodAll) {
+		return nil, &ValidationError{Field: "started_at", Message: "started at can't be used with the all period"}
+	}
 
-	return bits, nil
+	return getResponse[ManyUserBitTotals](c, "/bits/leaderboard", params)
 }
 
+// Themes, formats and scales of cheermote images
+const (
+	CheermoteThemeDark  = "dark"
+	CheermoteThemeLight = "light"
+
+	CheermoteFormatAnimated = "animated"
+	CheermoteFormatStatic   = "static"
+
+	CheermoteScale1   = "1"
+	CheermoteScale1_5 = "1.5"
+	CheermoteScale2   = "2"
+	CheermoteScale3   = "3"
+	CheermoteScale4   = "4"
+)
+
 type CheermotesParams struct {
 	BroadcasterID string `query:"broadcaster_id"` // optional
 }
@@ -78,6 +116,48 @@ type CheermoteTiers struct {
 	ShowInBitsCard bool                `json:"show_in_bits_card"`
 }
 
+// URL returns the image url for the given scale, or an empty string
+// if the scale is unknown.
+func (t TierImages) URL(scale string) string {
+	switch scale {
+	case CheermoteScale1:
+		return t.Image1
+	case CheermoteScale1_5:
+		return t.Image1_5
+	case CheermoteScale2:
+		return t.Image2
+	case CheermoteScale3:
+		return t.Image3
+	case CheermoteScale4:
+		return t.Image4
+	}
+
+	return ""
+}
+
+// URL returns the image url for the given theme, format and scale, or an
+// empty string if any of them is unknown.
+func (i CheermoteTierImages) URL(theme, format, scale string) string {
+	var types TierImageTypes
+	switch theme {
+	case CheermoteThemeDark:
+		types = i.Dark
+	case CheermoteThemeLight:
+		types = i.Light
+	default:
+		return ""
+	}
+
+	switch format {
+	case CheermoteFormatAnimated:
+		return types.Animated.URL(scale)
+	case CheermoteFormatStatic:
+		return types.Static.URL(scale)
+	}
+
+	return ""
+}
+
 type Cheermotes struct {
 	Prefix       string           `json:"prefix"`
 	Tiers        []CheermoteTiers `json:"tiers"`
@@ -87,24 +167,46 @@ type Cheermotes struct {
 	IsCharitable bool             `json:"is_charitable"`
 }
 
-type ManyCheermotes struct {
-	Cheermotes []Cheermotes `json:"data"`
-}
+// TierForBits returns the tier used when cheering the given amount of bits,
+// which is the tier with the highest MinBits not greater than bits.
+// It returns nil if bits is lower than every tier.
+func (c *Cheermotes) TierForBits(bits uint) *CheermoteTiers {
+	var tier *CheermoteTiers
+	for i := range c.Tiers {
+		if c.Tiers[i].MinBits > bits {
+			continue
+		}
+
+		if tier == nil || c.Tiers[i].MinBits > tier.MinBits {
+			tier = &c.Tiers[i]
+		}
+	}
 
-type CheermotesResponse struct {
-	ResponseCommon
-	Data ManyCheermotes
+	return tier
 }
 
-func (c *Client) GetCheermotes(params *CheermotesParams) (*CheermotesResponse, error) {
-	resp, err := c.get("/bits/cheermotes", &ManyCheermotes{}, params)
-	if err != nil {
-		return nil, err
+// ImageURL returns the url of the image shown when cheering the given amount of
+// bits with this cheermote, e.g. ImageURL(150, CheermoteThemeDark, CheermoteFormatAnimated, CheermoteScale2)
+// for the dark animated image of the 100 bits tier. It returns an empty string if
+// no tier matches or the theme, format or scale is unknown.
+func (c *Cheermotes) ImageURL(bits uint, theme, format, scale string) string {
+	tier := c.TierForBits(bits)
+	if tier == nil {
+		return ""
 	}
 
-	cheermoteResp := &CheermotesResponse{}
-	resp.HydrateResponseCommon(&cheermoteResp.ResponseCommon)
-	cheermoteResp.Data.Cheermotes = resp.Data.(*ManyCheermotes).Cheermotes
+	return tier.Images.URL(theme, format, scale)
+}
+
+type ManyCheermotes struct {
+	Cheermotes []Cheermotes `json:"data"`
+}
+
+type CheermotesResponse = ResponseOf[ManyCheermotes]
 
-	return cheermoteResp, nil
+// GetCheermotes gets a list of Cheermotes that users can use to cheer Bits in any
+// Bits-enabled channel's chat room. If BroadcasterID is set, the broadcaster's
+// custom Cheermotes are included.
+func (c *Client) GetCheermotes(params *CheermotesParams) (*CheermotesResponse, error) {
+	return getResponse[ManyCheermotes](c, "/bits/cheermotes", params)
 }
@@ -2,6 +2,7 @@ package helix
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
@@ -642,3 +643,89 @@ func TestClient_GetCheermotes(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestClient_GetBitsLeaderboardValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		params        *BitsLeaderboardParams
+		validationErr string
+	}{
+		{
+			&BitsLeaderboardParams{Count: 101},
+			"error: count must not be greater than 100",
+		},
+		{
+			&BitsLeaderboardParams{Period: "decade"},
+			"error: period must be one of all, day, week, month or year",
+		},
+		{
+			&BitsLeaderboardParams{StartedAt: time.Now()},
+			"error: started at can't be used with the all period",
+		},
+		{
+			&BitsLeaderboardParams{Period: BitsLeaderboardPeriodAll, StartedAt: time.Now()},
+			"error: started at can't be used with the all period",
+		},
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	for _, testCase := range testCases {
+		_, err := c.GetBitsLeaderboard(testCase.params)
+		if err == nil || err.Error() != testCase.validationErr {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", testCase.validationErr, err)
+		}
+	}
+}
+
+func TestCheermotes_ImageURL(t *testing.T) {
+	t.Parallel()
+
+	newTier := func(minBits uint) CheermoteTiers {
+		id := fmt.Sprintf("%d", minBits)
+		return CheermoteTiers{
+			MinBits: minBits,
+			ID:      id,
+			Images: CheermoteTierImages{
+				Dark: TierImageTypes{
+					Animated: TierImages{Image2: "https://example.com/dark/animated/" + id + "/2.gif"},
+				},
+				Light: TierImageTypes{
+					Static: TierImages{Image1_5: "https://example.com/light/static/" + id + "/1.5.png"},
+				},
+			},
+		}
+	}
+
+	cheermote := &Cheermotes{
+		Prefix: "Cheer",
+		Tiers:  []CheermoteTiers{newTier(1000), newTier(1), newTier(100)},
+	}
+
+	testCases := []struct {
+		bits   uint
+		theme  string
+		format string
+		scale  string
+		url    string
+	}{
+		{0, CheermoteThemeDark, CheermoteFormatAnimated, CheermoteScale2, ""},
+		{1, CheermoteThemeDark, CheermoteFormatAnimated, CheermoteScale2, "https://example.com/dark/animated/1/2.gif"},
+		{150, CheermoteThemeDark, CheermoteFormatAnimated, CheermoteScale2, "https://example.com/dark/animated/100/2.gif"},
+		{1000, CheermoteThemeLight, CheermoteFormatStatic, CheermoteScale1_5, "https://example.com/light/static/1000/1.5.png"},
+		{150, "sepia", CheermoteFormatStatic, CheermoteScale1_5, ""},
+		{150, CheermoteThemeLight, CheermoteFormatStatic, "5", ""},
+	}
+
+	for _, testCase := range testCases {
+		url := cheermote.ImageURL(testCase.bits, testCase.theme, testCase.format, testCase.scale)
+		if url != testCase.url {
+			t.Errorf("expected url for %d bits to be \"%s\", got \"%s\"", testCase.bits, testCase.url, url)
+		}
+	}
+
+	if tier := cheermote.TierForBits(99999); tier == nil || tier.ID != "1000" {
+		t.Errorf("expected tier 1000, got %+v", tier)
+	}
+}
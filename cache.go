@@ -0,0 +1,224 @@
+package helix
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores responses of GET requests, see Options.Cache. Entries are
+// only replaced or evicted by the cache, the client checks their expiry.
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// CachedResponse is a successful response stored in a Cache.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	ExpiresAt  time.Time
+}
+
+// DefaultCacheTTLs returns the endpoints whose responses are cached when a
+// Cache is set, along with how long they are fresh. Use Options.CacheTTLs to
+// change them, the returned map is a copy.
+func DefaultCacheTTLs() map[string]time.Duration {
+	ttls := make(map[string]time.Duration, len(defaultCacheTTLs))
+	for endpoint, ttl := range defaultCacheTTLs {
+		ttls[endpoint] = ttl
+	}
+
+	return ttls
+}
+
+var defaultCacheTTLs = map[string]time.Duration{
+	"/users":                         5 * time.Minute,
+	"/games":                         time.Hour,
+	"/chat/emotes":                   time.Hour,
+	"/chat/emotes/global":            time.Hour,
+	"/chat/emotes/set":               time.Hour,
+	"/chat/badges":                   time.Hour,
+	"/chat/badges/global":            time.Hour,
+	"/content_classification_labels": 24 * time.Hour,
+}
+
+// cachedRoundTrip serves GET requests to cacheable endpoints from the cache,
+// and sends all others with roundTrip. Stale responses that carry an ETag are
+// revalidated with If-None-Match. Cache hits don't pass through the
+// middleware, nor do they update the rate limits with the cached headers.
+func (c *Client) cachedRoundTrip(req *http.Request) (*http.Response, error) {
+	cache := c.opts.Cache
+	if cache == nil || req.Method != http.MethodGet {
+		return c.roundTrip(req)
+	}
+
+	ttl := c.cacheTTL(req.URL)
+	if ttl <= 0 {
+		return c.roundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached, ok := cache.Get(key)
+	if ok && c.now().Before(cached.ExpiresAt) {
+		return cached.response(req), nil
+	}
+
+	etag := ""
+	if ok {
+		etag = cached.Header.Get("ETag")
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	response, err := c.roundTrip(req)
+	req.Header.Del("If-None-Match")
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusNotModified && etag != "" {
+		response.Body.Close()
+
+		revalidated := *cached
+		revalidated.ExpiresAt = c.now().Add(ttl)
+		cache.Set(key, &revalidated)
+
+		return revalidated.response(req), nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return response, nil
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	cache.Set(key, &CachedResponse{
+		StatusCode: response.StatusCode,
+		Header:     response.Header.Clone(),
+		Body:       body,
+		ExpiresAt:  c.now().Add(ttl),
+	})
+
+	return response, nil
+}
+
+// cacheTTL returns how long responses of the API endpoint requested with u
+// are fresh, zero if they aren't cached.
+func (c *Client) cacheTTL(u *url.URL) time.Duration {
+	base, err := url.Parse(c.opts.APIBaseURL)
+	if err != nil || u.Host != base.Host || !strings.HasPrefix(u.Path, base.Path) {
+		return 0
+	}
+
+	endpoint := strings.TrimPrefix(u.Path, base.Path)
+	if ttl, ok := c.opts.CacheTTLs[endpoint]; ok {
+		return ttl
+	}
+
+	return defaultCacheTTLs[endpoint]
+}
+
+// cacheKey identifies a request by its URL and a hash of its credentials, so
+// responses that depend on the token aren't shared between tokens.
+func cacheKey(req *http.Request) string {
+	identity := sha256.Sum256([]byte(req.Header.Get("Client-ID") + " " + req.Header.Get("Authorization")))
+
+	return hex.EncodeToString(identity[:8]) + " " + req.URL.String()
+}
+
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode),
+		StatusCode: r.StatusCode,
+		Header:     r.Header.Clone(),
+		Body:       ioutil.NopCloser(bytes.NewReader(r.Body)),
+		Request:    req,
+	}
+}
+
+// LRUCache is an in-memory Cache that evicts the least recently used
+// response once it holds its capacity. It is safe for concurrent use.
+type LRUCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewLRUCache returns a cache holding at most capacity responses.
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// Get returns the response stored with key.
+func (l *LRUCache) Get(key string) (*CachedResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+
+	return elem.Value.(*lruEntry).resp, true
+}
+
+// Set stores resp with key, evicting the least recently used response if the
+// cache is full.
+func (l *LRUCache) Set(key string, resp *CachedResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.entries[key]; ok {
+		elem.Value.(*lruEntry).resp = resp
+		l.order.MoveToFront(elem)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, resp: resp})
+
+	if l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.order.Len()
+}
@@ -0,0 +1,208 @@
+package helix
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachedGetRequests(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"26301881","login":"sodapoppin","display_name":"sodapoppin"}]}`))
+	}
+
+	options := &Options{
+		ClientID:        "my-client-id",
+		UserAccessToken: "my-user-access-token",
+		Cache:           NewLRUCache(10),
+	}
+	c := newMockClient(options, handler)
+
+	for i := 0; i < 3; i++ {
+		resp, err := c.GetUsers(&UsersParams{IDs: []string{"26301881"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusOK || len(resp.Data.Users) != 1 || resp.Data.Users[0].Login != "sodapoppin" {
+			t.Errorf("unexpected response %+v", resp)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request to be sent, got %d", requests)
+	}
+
+	// Other parameters and other tokens aren't served from the cache
+	if _, err := c.GetUsers(&UsersParams{IDs: []string{"18074328"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetUserAccessToken("other-user-access-token")
+	if _, err := c.GetUsers(&UsersParams{IDs: []string{"26301881"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests to be sent, got %d", requests)
+	}
+
+	// Endpoints without a TTL aren't cached
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetStreams(&StreamsParams{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests != 5 {
+		t.Errorf("expected 5 requests to be sent, got %d", requests)
+	}
+}
+
+func TestCacheTTLs(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[]}`))
+	}
+
+	options := &Options{
+		ClientID:  "my-client-id",
+		Cache:     NewLRUCache(10),
+		CacheTTLs: map[string]time.Duration{"/users": 0, "/streams": time.Minute},
+	}
+	c := newMockClient(options, handler)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetUsers(&UsersParams{IDs: []string{"26301881"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := c.GetStreams(&StreamsParams{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests to be sent, got %d", requests)
+	}
+}
+
+func TestDefaultCacheTTLs(t *testing.T) {
+	t.Parallel()
+
+	ttls := DefaultCacheTTLs()
+	if ttls["/users"] != 5*time.Minute {
+		t.Errorf("expected users to be cached for 5m, got %v", ttls["/users"])
+	}
+
+	// Changing the returned map doesn't change what the client caches
+	ttls["/users"] = 0
+	if DefaultCacheTTLs()["/users"] != 5*time.Minute {
+		t.Error("expected the default TTLs not to be changed")
+	}
+}
+
+func TestCacheHitsBypassMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var sent int32
+	options := &Options{
+		ClientID: "my-client-id",
+		Cache:    NewLRUCache(10),
+		Middleware: []Middleware{func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&sent, 1)
+				return next(req)
+			}
+		}},
+	}
+	c := newMockClient(options, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetUsers(&UsersParams{IDs: []string{"26301881"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if sent != 1 {
+		t.Errorf("expected the middleware to see 1 request, got %d", sent)
+	}
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	t.Parallel()
+
+	var requests, notModified int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"509658","name":"Just Chatting"}]}`))
+	}
+
+	options := &Options{
+		ClientID:  "my-client-id",
+		Cache:     NewLRUCache(10),
+		CacheTTLs: map[string]time.Duration{"/games": time.Nanosecond},
+	}
+	c := newMockClient(options, handler)
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.GetGames(&GamesParams{IDs: []string{"509658"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusOK || len(resp.Data.Games) != 1 || resp.Data.Games[0].Name != "Just Chatting" {
+			t.Errorf("unexpected response %+v", resp)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if requests != 2 || notModified != 1 {
+		t.Errorf("expected 2 requests with 1 revalidation, got %d and %d", requests, notModified)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewLRUCache(2)
+	cache.Set("a", &CachedResponse{StatusCode: http.StatusOK})
+	cache.Set("b", &CachedResponse{StatusCode: http.StatusOK})
+
+	// "a" becomes the most recently used, so "b" is evicted
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("expected a to be cached")
+	}
+	cache.Set("c", &CachedResponse{StatusCode: http.StatusOK})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+
+	if _, ok := cache.Get("c"); !ok {
+		t.Error("expected c to be cached")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("expected cache to hold 2 responses, got %d", cache.Len())
+	}
+}
@@ -21,22 +21,11 @@ type Category struct {
 }
 
 // SearchCategoriesResponse is the response from SearchCategories
-type SearchCategoriesResponse struct {
-	ResponseCommon
-	Data ManySearchCategories
-}
+type SearchCategoriesResponse = ResponseOf[ManySearchCategories]
 
-// SearchCategories searches for Twitch categories based on the given search query
+// SearchCategories searches for Twitch categories based on the given search query.
+// A category matches if its name contains the query. Use Data.Pagination.Cursor
+// as After to get the next page of results.
 func (c *Client) SearchCategories(params *SearchCategoriesParams) (*SearchCategoriesResponse, error) {
-	resp, err := c.get("/search/categories", &ManySearchCategories{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	categories := &SearchCategoriesResponse{}
-	resp.HydrateResponseCommon(&categories.ResponseCommon)
-	categories.Data.Categories = resp.Data.(*ManySearchCategories).Categories
-	categories.Data.Pagination = resp.Data.(*ManySearchCategories).Pagination
-
-	return categories, nil
+	return getResponse[ManySearchCategories](c, "/search/categories", params)
 }
@@ -69,6 +69,10 @@ func TestSearchCategories(t *testing.T) {
 			t.Errorf("expected \"%d\" streams, got \"%d\"", testCase.First, len(resp.Data.Categories))
 		}
 
+		if resp.Data.Pagination.Cursor != "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6Mn19" {
+			t.Errorf("expected pagination cursor to be set, got \"%s\"", resp.Data.Pagination.Cursor)
+		}
+
 		for i, category := range resp.Data.Categories {
 			if category.ID != testCase.parsed[i].ID {
 				t.Errorf("Expected struct field ID = %s, was %s", testCase.parsed[i].ID, category.ID)
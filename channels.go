@@ -1,11 +1,19 @@
 package helix
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
 // SearchChannelsParams is parameters for SearchChannels
 type SearchChannelsParams struct {
 	Channel  string `query:"query"`
 	After    string `query:"after"`
-	First    int    `query:"first,20"` // Limit 100
-	LiveOnly bool   `query:"live_only"`
+	First    int    `query:"first,20"`  // Limit 100
+	LiveOnly bool   `query:"live_only"` // Only return channels that are streaming live
 }
 
 // ManySearchChannels is the response data from SearchChannels
@@ -26,15 +34,13 @@ type Channel struct {
 	Tags             []string `json:"tags"`
 	ThumbnailURL     string   `json:"thumbnail_url"`
 	IsLive           bool     `json:"is_live"`
-	StartedAt        Time     `json:"started_at"`
-	TagIDs           []string `json:"tag_ids"`
+	StartedAt        Time     `json:"started_at"` // Zero if the channel isn't live
+	// Deprecated: Twitch no longer returns tag ids, use Tags instead.
+	TagIDs []string `json:"tag_ids"`
 }
 
 // SearchChannelsResponse is the response from SearchChannels
-type SearchChannelsResponse struct {
-	ResponseCommon
-	Data ManySearchChannels
-}
+type SearchChannelsResponse = ResponseOf[ManySearchChannels]
 
 type GetChannelFollowsParams struct {
 	BroadcasterID string `query:"broadcaster_id"` // required
@@ -43,20 +49,18 @@ type GetChannelFollowsParams struct {
 	After         string `query:"after"`
 }
 
-// SearchChannelsResponse is the response from SearchChannels
-type GetChannelFollowersResponse struct {
-	ResponseCommon
-	Data ManyChannelFollows
-}
+// GetChannelFollowersResponse is the response from GetChannelFollowers
+type GetChannelFollowersResponse = ResponseOf[ManyChannelFollows]
 
-// ManySearchChannels is the response data from SearchChannels
+// ManyChannelFollows is the response data from GetChannelFollowers.
+// Total is the total number of users that follow the broadcaster.
 type ManyChannelFollows struct {
 	Channels   []ChannelFollow `json:"data"`
 	Pagination Pagination      `json:"pagination"`
 	Total      int             `json:"total"`
 }
 
-// Channel describes a follow of a channel
+// ChannelFollow describes a user that follows a channel
 type ChannelFollow struct {
 	UserID    string `json:"user_id"`
 	Username  string `json:"user_name"`
@@ -71,20 +75,18 @@ type GetFollowedChannelParams struct {
 	After         string `query:"after"`
 }
 
-// SearchChannelsResponse is the response from SearchChannels
-type GetFollowedChannelResponse struct {
-	ResponseCommon
-	Data ManyFollowedChannels
-}
+// GetFollowedChannelResponse is the response from GetFollowedChannels
+type GetFollowedChannelResponse = ResponseOf[ManyFollowedChannels]
 
-// ManySearchChannels is the response data from SearchChannels
+// ManyFollowedChannels is the response data from GetFollowedChannels.
+// Total is the total number of broadcasters that the user follows.
 type ManyFollowedChannels struct {
 	FollowedChannels []FollowedChannel `json:"data"`
 	Pagination       Pagination        `json:"pagination"`
 	Total            int64             `json:"total"`
 }
 
-// Channel describes a followed channel
+// FollowedChannel describes a channel followed by a user
 type FollowedChannel struct {
 	BroadcasterID   string `json:"broadcaster_id"`
 	BroadcasterName string `json:"broadcaster_name"`
@@ -95,17 +97,7 @@ type FollowedChannel struct {
 // SearchChannels searches for Twitch channels based on the given search
 // parameters. Unlike GetStreams, this can also return offline channels.
 func (c *Client) SearchChannels(params *SearchChannelsParams) (*SearchChannelsResponse, error) {
-	resp, err := c.get("/search/channels", &ManySearchChannels{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	channels := &SearchChannelsResponse{}
-	resp.HydrateResponseCommon(&channels.ResponseCommon)
-	channels.Data.Channels = resp.Data.(*ManySearchChannels).Channels
-	channels.Data.Pagination = resp.Data.(*ManySearchChannels).Pagination
-
-	return channels, nil
+	return getResponse[ManySearchChannels](c, "/search/channels", params)
 }
 
 type GetChannelInformationParams struct {
@@ -114,20 +106,80 @@ type GetChannelInformationParams struct {
 	BroadcasterIDs []string `query:"broadcaster_id"` // Limit 100
 }
 
+// Limits of the tags of a channel
+const (
+	MaxChannelTags      = 10
+	MaxChannelTagLength = 25
+)
+
+// EditChannelInformationParams is parameters for EditChannelInformation.
+// Tags replace all tags of the channel, a nil slice leaves them unchanged
+// and an empty, non-nil slice removes them.
 type EditChannelInformationParams struct {
-	BroadcasterID       string   `query:"broadcaster_id" json:"-"`
-	GameID              string   `json:"game_id,omitempty"`
-	BroadcasterLanguage string   `json:"broadcaster_language,omitempty"`
-	Title               string   `json:"title,omitempty"`
-	Delay               int      `json:"delay,omitempty"`
-	Tags                []string `json:"tags,omitempty"`
+	BroadcasterID               string                            `query:"broadcaster_id" json:"-"`
+	GameID                      string                            `json:"game_id,omitempty"`
+	BroadcasterLanguage         string                            `json:"broadcaster_language,omitempty"`
+	Title                       string                            `json:"title,omitempty"`
+	Delay                       int                               `json:"delay,omitempty"`
+	Tags                        []string                          `json:"tags,omitempty"`
+	ContentClassificationLabels []ContentClassificationLabelParam `json:"content_classification_labels,omitempty"`
+	IsBrandedContent            *bool                             `json:"is_branded_content,omitempty"`
 }
 
-type GetChannelInformationResponse struct {
-	ResponseCommon
-	Data ManyChannelInformation
+// MarshalJSON sends an empty, non-nil Tags slice as an empty array, which
+// omitempty would drop, so that the tags can be removed.
+func (p EditChannelInformationParams) MarshalJSON() ([]byte, error) {
+	type params EditChannelInformationParams
+	if p.Tags == nil || len(p.Tags) > 0 {
+		return json.Marshal(params(p))
+	}
+
+	return json.Marshal(struct {
+		params
+		Tags []string `json:"tags"`
+	}{params(p), p.Tags})
+}
+
+// validateChannelTags checks the tags against the limits Twitch enforces.
+func validateChannelTags(tags []string) error {
+	if len(tags) > MaxChannelTags {
+		return &ValidationError{
+			Field:   "tags",
+			Message: fmt.Sprintf("a channel can't have more than %d tags, got %d", MaxChannelTags, len(tags)),
+		}
+	}
+
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		length := utf8.RuneCountInString(tag)
+		if length == 0 || length > MaxChannelTagLength {
+			return &ValidationError{
+				Field:   "tags",
+				Message: fmt.Sprintf("tag %q must be 1 to %d characters long", tag, MaxChannelTagLength),
+			}
+		}
+
+		if seen[tag] {
+			return &ValidationError{
+				Field:   "tags",
+				Message: fmt.Sprintf("tag %q is duplicated", tag),
+			}
+		}
+		seen[tag] = true
+	}
+
+	return nil
+}
+
+// ContentClassificationLabelParam adds (IsEnabled true) or removes (IsEnabled false)
+// a content classification label on the channel
+type ContentClassificationLabelParam struct {
+	ID        string `json:"id"`
+	IsEnabled bool   `json:"is_enabled"`
 }
 
+type GetChannelInformationResponse = ResponseOf[ManyChannelInformation]
+
 type EditChannelInformationResponse struct {
 	ResponseCommon
 }
@@ -137,30 +189,42 @@ type ManyChannelInformation struct {
 }
 
 type ChannelInformation struct {
-	BroadcasterID       string   `json:"broadcaster_id"`
-	BroadcasterName     string   `json:"broadcaster_name"`
-	BroadcasterLanguage string   `json:"broadcaster_language"`
-	GameID              string   `json:"game_id"`
-	GameName            string   `json:"game_name"`
-	Title               string   `json:"title"`
-	Delay               int      `json:"delay"`
-	Tags                []string `json:"tags"`
+	BroadcasterID               string   `json:"broadcaster_id"`
+	BroadcasterName             string   `json:"broadcaster_name"`
+	BroadcasterLanguage         string   `json:"broadcaster_language"`
+	GameID                      string   `json:"game_id"`
+	GameName                    string   `json:"game_name"`
+	Title                       string   `json:"title"`
+	Delay                       int      `json:"delay"`
+	Tags                        []string `json:"tags"`
+	ContentClassificationLabels []string `json:"content_classification_labels"`
+	IsBrandedContent            bool     `json:"is_branded_content"`
 }
 
 func (c *Client) GetChannelInformation(params *GetChannelInformationParams) (*GetChannelInformationResponse, error) {
-	resp, err := c.get("/channels", &ManyChannelInformation{}, params)
-	if err != nil {
+	if err := validateMaxValues("broadcaster_id", "broadcaster ids", params.BroadcasterIDs, 100); err != nil {
 		return nil, err
 	}
 
-	channels := &GetChannelInformationResponse{}
-	resp.HydrateResponseCommon(&channels.ResponseCommon)
-	channels.Data.Channels = resp.Data.(*ManyChannelInformation).Channels
-
-	return channels, nil
+	return getResponse[ManyChannelInformation](c, "/channels", params)
 }
 
+// EditChannelInformation updates the channel's properties, only the set
+// fields are changed. Tags are checked against MaxChannelTags and
+// MaxChannelTagLength before the request is sent, a *ValidationError is
+// returned if they are exceeded.
+// Required scope: channel:manage:broadcast
 func (c *Client) EditChannelInformation(params *EditChannelInformationParams) (*EditChannelInformationResponse, error) {
+	if err := validateChannelTags(params.Tags); err != nil {
+		return nil, err
+	}
+
+	for _, label := range params.ContentClassificationLabels {
+		if label.ID == ContentClassificationLabelMatureGame {
+			return nil, &ValidationError{Field: "content_classification_labels", Message: "the MatureGame content classification label can't be set"}
+		}
+	}
+
 	resp, err := c.patchAsJSON("/channels", &EditChannelInformationResponse{}, params)
 	if err != nil {
 		return nil, err
@@ -172,38 +236,91 @@ func (c *Client) EditChannelInformation(params *EditChannelInformationParams) (*
 	return channels, nil
 }
 
-// GetChannelFollows Gets a list of users that follow the specified broadcaster.
-// You can also use this endpoint to see whether a specific user follows the broadcaster..
-// requires moderator:read:followers
-func (c *Client) GetChannelFollows(params *GetChannelFollowsParams) (*GetChannelFollowersResponse, error) {
-	resp, err := c.get("/channels/followers", &ManyChannelFollows{}, params)
+// GetChannelFollowers gets a list of users that follow the specified broadcaster.
+// You can also use this endpoint to see whether a specific user follows the broadcaster.
+// The total number of followers is returned in Data.Total, use Data.Pagination.Cursor
+// to page through the results.
+// Required scope: moderator:read:followers
+func (c *Client) GetChannelFollowers(params *GetChannelFollowsParams) (*GetChannelFollowersResponse, error) {
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyChannelFollows](c, "/channels/followers", params)
+}
+
+// StreamChannelFollowers gets a page of the followers of a broadcaster like
+// GetChannelFollowers, but passes each follow to handle as it is decoded
+// instead of keeping the page in memory. An error returned by handle stops
+// the request and is returned.
+// Required scope: moderator:read:followers
+func (c *Client) StreamChannelFollowers(params *GetChannelFollowsParams, handle func(follow ChannelFollow) error) (*StreamedResponse, error) {
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
+
+	return streamResponse(c, "/channels/followers", params, handle)
+}
+
+// ErrNotFollowing is returned by GetFollowAge when the user doesn't follow
+// the broadcaster.
+var ErrNotFollowing = errors.New("error: user does not follow the broadcaster")
+
+// FollowAge is how long a user has followed a broadcaster.
+type FollowAge struct {
+	FollowedAt time.Time
+	Duration   time.Duration
+}
+
+// GetFollowAge gets when the user followed the broadcaster and how long ago
+// that was. It returns ErrNotFollowing if the user doesn't follow the
+// broadcaster, and the error of the response if the request was rejected.
+// Required scope: moderator:read:followers
+func (c *Client) GetFollowAge(broadcasterID, userID string) (*FollowAge, error) {
+	if broadcasterID == "" || userID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and user id must be specified"}
+	}
+
+	resp, err := c.GetChannelFollowers(&GetChannelFollowsParams{
+		BroadcasterID: broadcasterID,
+		UserID:        userID,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	channelFollows := &GetChannelFollowersResponse{}
-	resp.HydrateResponseCommon(&channelFollows.ResponseCommon)
-	channelFollows.Data.Total = resp.Data.(*ManyChannelFollows).Total
-	channelFollows.Data.Channels = resp.Data.(*ManyChannelFollows).Channels
-	channelFollows.Data.Pagination = resp.Data.(*ManyChannelFollows).Pagination
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data.Channels) == 0 {
+		return nil, ErrNotFollowing
+	}
+
+	followedAt := resp.Data.Channels[0].Followed.Time
+
+	return &FollowAge{
+		FollowedAt: followedAt,
+		Duration:   c.now().Sub(followedAt),
+	}, nil
+}
 
-	return channelFollows, nil
+// GetChannelFollows gets a list of users that follow the specified broadcaster.
+//
+// Deprecated: use GetChannelFollowers instead.
+func (c *Client) GetChannelFollows(params *GetChannelFollowsParams) (*GetChannelFollowersResponse, error) {
+	return c.GetChannelFollowers(params)
 }
 
-// GetFollowedChannels Gets a list of broadcasters that the specified user follows.
+// GetFollowedChannels gets a list of broadcasters that the specified user follows.
 // You can also use this endpoint to see whether a user follows a specific broadcaster.
-// requires user:read:follows
+// The total number of followed broadcasters is returned in Data.Total, use
+// Data.Pagination.Cursor to page through the results.
+// Required scope: user:read:follows
 func (c *Client) GetFollowedChannels(params *GetFollowedChannelParams) (*GetFollowedChannelResponse, error) {
-	resp, err := c.get("/channels/followed", &ManyFollowedChannels{}, params)
-	if err != nil {
+	if err := validateFirst(params.First, 100); err != nil {
 		return nil, err
 	}
 
-	followedChannels := &GetFollowedChannelResponse{}
-	resp.HydrateResponseCommon(&followedChannels.ResponseCommon)
-	followedChannels.Data.Total = resp.Data.(*ManyFollowedChannels).Total
-	followedChannels.Data.FollowedChannels = resp.Data.(*ManyFollowedChannels).FollowedChannels
-	followedChannels.Data.Pagination = resp.Data.(*ManyFollowedChannels).Pagination
-
-	return followedChannels, nil
+	return getResponse[ManyFollowedChannels](c, "/channels/followed", params)
 }
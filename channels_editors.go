@@ -15,22 +15,10 @@ type ChannelEditor struct {
 	CreatedAt Time   `json:"created_at"`
 }
 
-type ChannelEditorsResponse struct {
-	ResponseCommon
-	Data ManyChannelEditors
-}
+type ChannelEditorsResponse = ResponseOf[ManyChannelEditors]
 
 // GetChannelEditors Get a list of users who have editor permissions for a specific channel
 // Required scope: channel:read:editors
 func (c *Client) GetChannelEditors(params *ChannelEditorsParams) (*ChannelEditorsResponse, error) {
-	resp, err := c.get("/channels/editors", &ManyChannelEditors{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	editors := &ChannelEditorsResponse{}
-	resp.HydrateResponseCommon(&editors.ResponseCommon)
-	editors.Data.ChannelEditors = resp.Data.(*ManyChannelEditors).ChannelEditors
-
-	return editors, nil
+	return getResponse[ManyChannelEditors](c, "/channels/editors", params)
 }
@@ -1,5 +1,31 @@
 package helix
 
+// CustomRewardRedemptionStatus is the status of a custom reward redemption.
+type CustomRewardRedemptionStatus string
+
+// Statuses of a custom reward redemption
+const (
+	CustomRewardRedemptionStatusUnfulfilled CustomRewardRedemptionStatus = "UNFULFILLED"
+	CustomRewardRedemptionStatusFulfilled   CustomRewardRedemptionStatus = "FULFILLED"
+	CustomRewardRedemptionStatusCanceled    CustomRewardRedemptionStatus = "CANCELED"
+)
+
+// IsValid reports whether the status is one Twitch documents.
+func (s CustomRewardRedemptionStatus) IsValid() bool {
+	switch s {
+	case CustomRewardRedemptionStatusUnfulfilled, CustomRewardRedemptionStatusFulfilled, CustomRewardRedemptionStatusCanceled:
+		return true
+	}
+
+	return false
+}
+
+// Sort orders accepted by GetCustomRewardRedemptions
+const (
+	CustomRewardRedemptionSortOldest = "OLDEST"
+	CustomRewardRedemptionSortNewest = "NEWEST"
+)
+
 type ChannelCustomRewardsParams struct {
 	BroadcasterID                     string `query:"broadcaster_id"`
 	Title                             string `json:"title"`
@@ -33,6 +59,7 @@ type UpdateChannelCustomRewardsParams struct {
 	IsGlobalCooldownEnabled           bool   `json:"is_global_cooldown_enabled"`
 	GlobalCooldownSeconds             int    `json:"global_cooldown_seconds"`
 	ShouldRedemptionsSkipRequestQueue bool   `json:"should_redemptions_skip_request_queue"`
+	IsPaused                          *bool  `json:"is_paused,omitempty"` // optional, left unchanged if nil
 }
 
 type DeleteCustomRewardsParams struct {
@@ -70,7 +97,7 @@ type ChannelCustomReward struct {
 	IsInStock                         bool                        `json:"is_in_stock"`
 	ShouldRedemptionsSkipRequestQueue bool                        `json:"should_redemptions_skip_request_queue"`
 	RedemptionsRedeemedCurrentStream  int                         `json:"redemptions_redeemed_current_stream"`
-	CooldownExpiresAt                 string                      `json:"cooldown_expires_at"`
+	CooldownExpiresAt                 Time                        `json:"cooldown_expires_at"` // Zero if the reward isn't on cooldown
 }
 
 type RewardImage struct {
@@ -94,10 +121,7 @@ type GlobalCooldownSettings struct {
 	GlobalCooldownSeconds int  `json:"global_cooldown_seconds"`
 }
 
-type ChannelCustomRewardResponse struct {
-	ResponseCommon
-	Data ManyChannelCustomRewards
-}
+type ChannelCustomRewardResponse = ResponseOf[ManyChannelCustomRewards]
 
 // Response for removing a custom reward
 type DeleteCustomRewardsResponse struct {
@@ -105,63 +129,58 @@ type DeleteCustomRewardsResponse struct {
 }
 
 type UpdateChannelCustomRewardsRedemptionStatusParams struct {
-	ID            string `query:"id"`
+	ID            string                       `query:"id"`
+	BroadcasterID string                       `query:"broadcaster_id"`
+	RewardID      string                       `query:"reward_id"`
+	Status        CustomRewardRedemptionStatus `json:"status"` // Must be CustomRewardRedemptionStatusFulfilled or CustomRewardRedemptionStatusCanceled
+}
+
+type GetCustomRewardRedemptionsParams struct {
+	// Required
 	BroadcasterID string `query:"broadcaster_id"`
 	RewardID      string `query:"reward_id"`
-	Status        string `json:"status"`
-}
 
-type ChannelCustomRewardsRedemptionResponse struct {
-	ResponseCommon
-	Data ManyChannelCustomRewardsRedemptions
+	// Required if ID is not set
+	Status CustomRewardRedemptionStatus `query:"status"`
+
+	// Optional
+	ID    []string `query:"id"`   // Limit 50
+	Sort  string   `query:"sort"` // "OLDEST" (default) or "NEWEST"
+	After string   `query:"after"`
+	First int      `query:"first"` // Limit 50
 }
 
+type ChannelCustomRewardsRedemptionResponse = ResponseOf[ManyChannelCustomRewardsRedemptions]
+
 type ManyChannelCustomRewardsRedemptions struct {
 	Redemptions []ChannelCustomRewardsRedemption `json:"data"`
+	Pagination  Pagination                       `json:"pagination"`
 }
 
 type ChannelCustomRewardsRedemption struct {
-	ID               string              `json:"id"`
-	BroadcasterID    string              `json:"broadcaster_id"`
-	BroadcasterLogin string              `json:"broadcaster_login"`
-	BroadcasterName  string              `json:"broadcaster_name"`
-	UserID           string              `json:"user_id"`
-	UserName         string              `json:"user_name"`
-	UserLogin        string              `json:"user_login"`
-	UserInput        string              `json:"user_input"`
-	Status           string              `json:"status"`
-	RedeemedAt       Time                `json:"redeemed_at"`
-	Reward           ChannelCustomReward `json:"reward"`
+	ID               string                       `json:"id"`
+	BroadcasterID    string                       `json:"broadcaster_id"`
+	BroadcasterLogin string                       `json:"broadcaster_login"`
+	BroadcasterName  string                       `json:"broadcaster_name"`
+	UserID           string                       `json:"user_id"`
+	UserName         string                       `json:"user_name"`
+	UserLogin        string                       `json:"user_login"`
+	UserInput        string                       `json:"user_input"`
+	Status           CustomRewardRedemptionStatus `json:"status"`
+	RedeemedAt       Time                         `json:"redeemed_at"`
+	Reward           ChannelCustomReward          `json:"reward"`
 }
 
 // CreateCustomReward : Creates a Custom Reward on a channel.
 // Required scope: channel:manage:redemptions
 func (c *Client) CreateCustomReward(params *ChannelCustomRewardsParams) (*ChannelCustomRewardResponse, error) {
-	resp, err := c.postAsJSON("/channel_points/custom_rewards", &ManyChannelCustomRewards{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	reward := &ChannelCustomRewardResponse{}
-	resp.HydrateResponseCommon(&reward.ResponseCommon)
-	reward.Data.ChannelCustomRewards = resp.Data.(*ManyChannelCustomRewards).ChannelCustomRewards
-
-	return reward, nil
+	return postAsJSONResponse[ManyChannelCustomRewards](c, "/channel_points/custom_rewards", params)
 }
 
 // UpdateCustomReward : Update a Custom Reward on a channel.
 // Required scope: channel:manage:redemptions
 func (c *Client) UpdateCustomReward(params *UpdateChannelCustomRewardsParams) (*ChannelCustomRewardResponse, error) {
-	resp, err := c.patchAsJSON("/channel_points/custom_rewards", &ManyChannelCustomRewards{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	reward := &ChannelCustomRewardResponse{}
-	resp.HydrateResponseCommon(&reward.ResponseCommon)
-	reward.Data.ChannelCustomRewards = resp.Data.(*ManyChannelCustomRewards).ChannelCustomRewards
-
-	return reward, nil
+	return patchAsJSONResponse[ManyChannelCustomRewards](c, "/channel_points/custom_rewards", params)
 }
 
 // DeleteCustomRewards : Deletes a Custom Rewards on a channel
@@ -181,29 +200,30 @@ func (c *Client) DeleteCustomRewards(params *DeleteCustomRewardsParams) (*Delete
 // GetCustomRewards : Get Custom Rewards on a channel
 // Required scope: channel:read:redemptions
 func (c *Client) GetCustomRewards(params *GetCustomRewardsParams) (*ChannelCustomRewardResponse, error) {
-	resp, err := c.get("/channel_points/custom_rewards", &ManyChannelCustomRewards{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	rewards := &ChannelCustomRewardResponse{}
-	resp.HydrateResponseCommon(&rewards.ResponseCommon)
-	rewards.Data.ChannelCustomRewards = resp.Data.(*ManyChannelCustomRewards).ChannelCustomRewards
-
-	return rewards, nil
+	return getResponse[ManyChannelCustomRewards](c, "/channel_points/custom_rewards", params)
 }
 
 // UpdateChannelCustomRewardsRedemptionStatus : Update a Custom Reward Redemption status on a channel.
 // Required scope: channel:manage:redemptions
 func (c *Client) UpdateChannelCustomRewardsRedemptionStatus(params *UpdateChannelCustomRewardsRedemptionStatusParams) (*ChannelCustomRewardsRedemptionResponse, error) {
-	resp, err := c.patchAsJSON("/channel_points/custom_rewards/redemptions", &ManyChannelCustomRewardsRedemptions{}, params)
-	if err != nil {
+	return patchAsJSONResponse[ManyChannelCustomRewardsRedemptions](c, "/channel_points/custom_rewards/redemptions", params)
+}
+
+// GetCustomRewardRedemptions : Get redemptions for a Custom Reward on a channel
+// Required scope: channel:read:redemptions or channel:manage:redemptions
+func (c *Client) GetCustomRewardRedemptions(params *GetCustomRewardRedemptionsParams) (*ChannelCustomRewardsRedemptionResponse, error) {
+	if params.BroadcasterID == "" || params.RewardID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and reward id must be provided"}
+	}
+	if params.Status == "" && len(params.ID) == 0 {
+		return nil, &ValidationError{Field: "status", Message: "status must be provided if no ids are specified"}
+	}
+	if len(params.ID) > 50 {
+		return nil, &ValidationError{Field: "id", Message: "a maximum of 50 ids can be provided"}
+	}
+	if err := validateFirst(params.First, 50); err != nil {
 		return nil, err
 	}
 
-	redemptions := &ChannelCustomRewardsRedemptionResponse{}
-	resp.HydrateResponseCommon(&redemptions.ResponseCommon)
-	redemptions.Data.Redemptions = resp.Data.(*ManyChannelCustomRewardsRedemptions).Redemptions
-
-	return redemptions, nil
+	return getResponse[ManyChannelCustomRewardsRedemptions](c, "/channel_points/custom_rewards/redemptions", params)
 }
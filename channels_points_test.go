@@ -350,4 +350,146 @@ func TestUpdateCustomRewardRedemptionStatus(t *testing.T) {
 			}
 		}
 	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.UpdateChannelCustomRewardsRedemptionStatus(&UpdateChannelCustomRewardsRedemptionStatusParams{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestGetCustomRewardRedemptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetCustomRewardRedemptionsParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetCustomRewardRedemptionsParams{RewardID: "92af127c-7326-4483-a52b-b0da0be61c01", Status: CustomRewardRedemptionStatusCanceled},
+			``,
+			"error: broadcaster id and reward id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetCustomRewardRedemptionsParams{BroadcasterID: "274637212", RewardID: "92af127c-7326-4483-a52b-b0da0be61c01"},
+			``,
+			"error: status must be provided if no ids are specified",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetCustomRewardRedemptionsParams{BroadcasterID: "274637212", RewardID: "92af127c-7326-4483-a52b-b0da0be61c01", Status: CustomRewardRedemptionStatusCanceled, First: 51},
+			``,
+			"error: first must not be greater than 50",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetCustomRewardRedemptionsParams{
+				BroadcasterID: "274637212",
+				RewardID:      "92af127c-7326-4483-a52b-b0da0be61c01",
+				Status:        CustomRewardRedemptionStatusCanceled,
+				Sort:          CustomRewardRedemptionSortNewest,
+			},
+			`{"data": [{"broadcaster_name": "torpedo09", "broadcaster_login": "torpedo09", "broadcaster_id": "274637212", "id": "17fa2df1-ad76-4804-bfa5-a40ef63efe63", "user_login": "torpedo09", "user_id": "274637212", "user_name": "torpedo09", "user_input": "", "status": "CANCELED", "redeemed_at": "2020-07-01T18:37:32Z", "reward": {"id": "92af127c-7326-4483-a52b-b0da0be61c01", "title": "game analysis", "prompt": "", "cost": 50000}}], "pagination": {"cursor": "eyJiIjpudWxsLCJhIjp7IkN1cnNvciI6Ik1UZG1ZVEprWmpFdFlXUTNOaTAwT0RBMExXSm1ZVFV0WVRRd1pXWTJNMlZtWlRZelgxOHlNREl3TFRBM0xUQXhWREU0T2pNM09qTXlMakl6TXpFeU56RTFOMW89In19"}}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetCustomRewardRedemptions(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Redemptions) != 1 {
+			t.Errorf("expected 1 redemption, got %d", len(resp.Data.Redemptions))
+			continue
+		}
+
+		if resp.Data.Redemptions[0].Status != CustomRewardRedemptionStatusCanceled {
+			t.Errorf("expected redemption status to be \"%s\", got \"%s\"", CustomRewardRedemptionStatusCanceled, resp.Data.Redemptions[0].Status)
+		}
+
+		if resp.Data.Pagination.Cursor == "" {
+			t.Errorf("expected pagination cursor not to be empty")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetCustomRewardRedemptions(&GetCustomRewardRedemptionsParams{
+		BroadcasterID: "274637212",
+		RewardID:      "92af127c-7326-4483-a52b-b0da0be61c01",
+		Status:        CustomRewardRedemptionStatusUnfulfilled,
+	})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestCustomRewardRedemptionStatusIsValid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		status CustomRewardRedemptionStatus
+		valid  bool
+	}{
+		{CustomRewardRedemptionStatusUnfulfilled, true},
+		{CustomRewardRedemptionStatusCanceled, true},
+		{"fulfilled", false},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		if valid := testCase.status.IsValid(); valid != testCase.valid {
+			t.Errorf("expected %q to be valid: %t, got %t", testCase.status, testCase.valid, valid)
+		}
+	}
 }
@@ -2,8 +2,11 @@ package helix
 
 import (
 	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestSearchChannels(t *testing.T) {
@@ -134,6 +137,50 @@ func TestSearchChannels(t *testing.T) {
 	}
 }
 
+func TestSearchChannelsLiveOnly(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/channels" {
+			t.Errorf("expected path to be %s, got %s", "/search/channels", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("query") != "ninja" || query.Get("live_only") != "true" || query.Get("after") != "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6Mn19" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"broadcaster_language":"en","broadcaster_login":"ninja","display_name":"Ninja","game_id":"33214","game_name":"Fortnite","id":"19571641","is_live":true,"tags":["English","Gaming"],"thumbnail_url":"https://static-cdn.jtvnw.net/jtv_user_pictures/ninja-profile_image.png","title":"live now","started_at":"2023-03-06T15:07:45Z"}],"pagination":{}}`))
+	})
+
+	resp, err := c.SearchChannels(&SearchChannelsParams{
+		Channel:  "ninja",
+		LiveOnly: true,
+		After:    "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6Mn19",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.Channels) != 1 {
+		t.Fatalf("expected 1 channel, got %d", len(resp.Data.Channels))
+	}
+
+	channel := resp.Data.Channels[0]
+	if !channel.IsLive || channel.StartedAt.IsZero() {
+		t.Errorf("expected channel to be live with a start time, got %+v", channel)
+	}
+
+	if len(channel.Tags) != 2 || channel.Tags[0] != "English" {
+		t.Errorf("expected tags to be [English Gaming], got %v", channel.Tags)
+	}
+
+	if resp.Data.Pagination.Cursor != "" {
+		t.Errorf("expected no cursor on the last page, got %s", resp.Data.Pagination.Cursor)
+	}
+}
+
 func TestGetChannelInformation(t *testing.T) {
 	t.Parallel()
 
@@ -382,6 +429,112 @@ func TestEditChannelInformation(t *testing.T) {
 	}
 }
 
+func TestEditChannelInformationContentClassificationLabels(t *testing.T) {
+	t.Parallel()
+
+	isBrandedContent := true
+	params := &EditChannelInformationParams{
+		BroadcasterID: "123",
+		ContentClassificationLabels: []ContentClassificationLabelParam{
+			{ID: ContentClassificationLabelGambling, IsEnabled: true},
+			{ID: ContentClassificationLabelProfanityVulgarity, IsEnabled: false},
+		},
+		IsBrandedContent: &isBrandedContent,
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		expectedBody := `{"content_classification_labels":[{"id":"Gambling","is_enabled":true},{"id":"ProfanityVulgarity","is_enabled":false}],"is_branded_content":true}`
+		if string(body) != expectedBody {
+			t.Errorf("expected body to be %s, got %s", expectedBody, body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := c.EditChannelInformation(params)
+	if err != nil {
+		t.Error(err)
+	} else if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be \"%d\", got \"%d\"", http.StatusNoContent, resp.StatusCode)
+	}
+
+	params.ContentClassificationLabels = []ContentClassificationLabelParam{{ID: ContentClassificationLabelMatureGame, IsEnabled: true}}
+	_, err = c.EditChannelInformation(params)
+	if err == nil || err.Error() != "error: the MatureGame content classification label can't be set" {
+		t.Errorf("expected MatureGame error, got %v", err)
+	}
+}
+
+func TestEditChannelInformationTags(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		tags         []string
+		expectedBody string
+		field        string
+	}{
+		{nil, `{"title":"Test title"}`, ""},
+		{[]string{}, `{"title":"Test title","tags":[]}`, ""},
+		{[]string{"English", "Speedrun"}, `{"title":"Test title","tags":["English","Speedrun"]}`, ""},
+		{[]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}, "", "tags"},
+		{[]string{"ThisTagIsLongerThan25Chars"}, "", "tags"},
+		{[]string{""}, "", "tags"},
+		{[]string{"English", "English"}, "", "tags"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		sent := false
+
+		c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+			sent = true
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if string(body) != testCase.expectedBody {
+				t.Errorf("expected body to be %s, got %s", testCase.expectedBody, body)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		_, err := c.EditChannelInformation(&EditChannelInformationParams{
+			BroadcasterID: "123",
+			Title:         "Test title",
+			Tags:          testCase.tags,
+		})
+
+		if testCase.field == "" {
+			if err != nil {
+				t.Errorf("expected no error for tags %v, got %v", testCase.tags, err)
+			}
+			continue
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error for tags %v, got %v", testCase.tags, err)
+			continue
+		}
+
+		if validationErr.Field != testCase.field {
+			t.Errorf("expected field to be %s, got %s", testCase.field, validationErr.Field)
+		}
+
+		if sent {
+			t.Errorf("expected tags %v not to be sent", testCase.tags)
+		}
+	}
+}
+
 func TestChannelFollows(t *testing.T) {
 	t.Parallel()
 
@@ -454,6 +607,221 @@ func TestChannelFollows(t *testing.T) {
 	}
 }
 
+func TestChannelFollowers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		params     *GetChannelFollowsParams
+		respBody   string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetChannelFollowsParams{
+				BroadcasterID: "123",
+			},
+			`{ "total": 8, "data": [{ "user_id": "11111", "user_name": "UserDisplayName", "user_login": "userloginname", "followed_at": "2022-05-24T22:22:08Z" }], "pagination": { "cursor": "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19" } }`,
+		},
+		{
+			http.StatusBadRequest,
+			&Options{ClientID: "my-client-id"},
+			&GetChannelFollowsParams{
+				BroadcasterID: "",
+			},
+			`{"error":"Bad Request","status":400,"message":"the broadcaster id was not provided"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetChannelFollowers(testCase.params)
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Test Bad Request Responses
+		if resp.StatusCode == http.StatusBadRequest {
+			broadcasterIDErrStr := "the broadcaster id was not provided"
+
+			if resp.ErrorMessage != broadcasterIDErrStr {
+				t.Errorf("expected error message to be \"%s\", got \"%s\"", broadcasterIDErrStr, resp.ErrorMessage)
+				continue
+			}
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetChannelFollowers(&GetChannelFollowsParams{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestChannelFollowersTotalAndPagination(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/followers" {
+			t.Errorf("expected path to be %s, got %s", "/channels/followers", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("after") != "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19" {
+			t.Errorf("expected after query param to be %s, got %s", "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19", query.Get("after"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{ "total": 8, "data": [{ "user_id": "11111", "user_name": "UserDisplayName", "user_login": "userloginname", "followed_at": "2022-05-24T22:22:08Z" }], "pagination": { "cursor": "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6MTB9fQ" } }`))
+	})
+
+	resp, err := c.GetChannelFollowers(&GetChannelFollowsParams{
+		BroadcasterID: "123",
+		After:         "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Data.Total != 8 {
+		t.Errorf("expected total to be 8, got %d", resp.Data.Total)
+	}
+
+	if len(resp.Data.Channels) != 1 || resp.Data.Channels[0].UserLogin != "userloginname" {
+		t.Errorf("expected 1 follower with login userloginname, got %+v", resp.Data.Channels)
+	}
+
+	if resp.Data.Pagination.Cursor != "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6MTB9fQ" {
+		t.Errorf("expected cursor to be set, got %s", resp.Data.Pagination.Cursor)
+	}
+
+	_, err = c.GetChannelFollowers(&GetChannelFollowsParams{BroadcasterID: "123", First: 101})
+	if err == nil || err.Error() != "error: first must not be greater than 100" {
+		t.Errorf("expected first error, got %v", err)
+	}
+
+	_, err = c.GetFollowedChannels(&GetFollowedChannelParams{UserID: "123", First: 101})
+	if err == nil || err.Error() != "error: first must not be greater than 100" {
+		t.Errorf("expected first error, got %v", err)
+	}
+}
+
+func TestGetFollowAge(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	followedAt := clock.Now().Add(-48 * time.Hour)
+
+	testCases := []struct {
+		statusCode    int
+		broadcasterID string
+		userID        string
+		respBody      string
+		expectedErr   error
+		expectedMsg   string
+	}{
+		{
+			http.StatusOK,
+			"123",
+			"",
+			``,
+			nil,
+			"error: broadcaster id and user id must be specified",
+		},
+		{
+			http.StatusOK,
+			"123",
+			"11111",
+			`{"total":8,"data":[{"user_id":"11111","user_name":"UserDisplayName","user_login":"userloginname","followed_at":"` + followedAt.Format(time.RFC3339) + `"}],"pagination":{}}`,
+			nil,
+			"",
+		},
+		{
+			http.StatusOK,
+			"123",
+			"22222",
+			`{"total":8,"data":[],"pagination":{}}`,
+			ErrNotFollowing,
+			"error: user does not follow the broadcaster",
+		},
+		{
+			http.StatusUnauthorized,
+			"123",
+			"11111",
+			`{"error":"Unauthorized","status":401,"message":"Missing scope: moderator:read:followers"}`,
+			nil,
+			"401 Unauthorized: Missing scope: moderator:read:followers",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id", Clock: clock}, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("user_id") != testCase.userID {
+				t.Errorf("expected user_id to be %s, got %s", testCase.userID, r.URL.Query().Get("user_id"))
+			}
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		followAge, err := c.GetFollowAge(testCase.broadcasterID, testCase.userID)
+		if err != nil {
+			if err.Error() != testCase.expectedMsg {
+				t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.expectedMsg, err)
+			}
+			if testCase.expectedErr != nil && !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error to be %v, got %v", testCase.expectedErr, err)
+			}
+			continue
+		}
+
+		if !followAge.FollowedAt.Equal(followedAt) || followAge.Duration != 48*time.Hour {
+			t.Errorf("unexpected follow age %+v", followAge)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetFollowAge("123", "11111")
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
 func TestFollowedChannels(t *testing.T) {
 	t.Parallel()
 
@@ -1,10 +1,11 @@
 package helix
 
 type GetChannelVipsParams struct {
-	UserID        string `query:"user_id"`
-	BroadcasterID string `query:"broadcaster_id"` // required
-	First         int    `query:"first"`
-	After         string `query:"after"`
+	UserID        string   `query:"user_id"`
+	UserIDs       []string `query:"user_id"`        // Limit 100, used together with UserID
+	BroadcasterID string   `query:"broadcaster_id"` // required
+	First         int      `query:"first"`          // Limit 100
+	After         string   `query:"after"`
 }
 
 type ManyChannelVips struct {
@@ -18,10 +19,7 @@ type ChannelVips struct {
 	UserLogin string `json:"user_login"`
 }
 
-type ChannelVipsResponse struct {
-	ResponseCommon
-	Data ManyChannelVips
-}
+type ChannelVipsResponse = ResponseOf[ManyChannelVips]
 
 type AddChannelVipParams struct {
 	UserID        string `query:"user_id"`        // required
@@ -41,26 +39,32 @@ type RemoveChannelVipResponse struct {
 	ResponseCommon
 }
 
-// GetChannelVips Gets a list of the broadcaster’s VIPs.
-// Required scope: channel:read:vips
-func (c *Client) GetChannelVips(params *GetChannelVipsParams) (*ChannelVipsResponse, error) {
-	resp, err := c.get("/channels/vips", &ManyChannelVips{}, params)
-	if err != nil {
+// GetVIPs Gets a list of the broadcaster’s VIPs.
+// To get the next page, set After to the Pagination cursor of the previous response.
+// Required scope: channel:read:vips or channel:manage:vips
+func (c *Client) GetVIPs(params *GetChannelVipsParams) (*ChannelVipsResponse, error) {
+	if err := validateMaxValues("user_id", "user ids", params.UserIDs, 100); err != nil {
 		return nil, err
 	}
 
-	vips := &ChannelVipsResponse{}
-	resp.HydrateResponseCommon(&vips.ResponseCommon)
-	vips.Data.ChannelsVips = resp.Data.(*ManyChannelVips).ChannelsVips
-	vips.Data.Pagination = resp.Data.(*ManyChannelVips).Pagination
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
 
-	return vips, nil
+	return getResponse[ManyChannelVips](c, "/channels/vips", params)
 }
 
-// AddChannelVip Adds the specified user as a VIP in the broadcaster’s channel.
+// GetChannelVips Gets a list of the broadcaster’s VIPs.
+//
+// Deprecated: use GetVIPs instead.
+func (c *Client) GetChannelVips(params *GetChannelVipsParams) (*ChannelVipsResponse, error) {
+	return c.GetVIPs(params)
+}
+
+// AddChannelVIP Adds the specified user as a VIP in the broadcaster’s channel.
 // Required scope: channel:manage:vips
 // Rate Limits: The broadcaster may add a maximum of 10 VIPs within a 10-second window.
-func (c *Client) AddChannelVip(params *AddChannelVipParams) (*AddChannelVipResponse, error) {
+func (c *Client) AddChannelVIP(params *AddChannelVipParams) (*AddChannelVipResponse, error) {
 	resp, err := c.post("/channels/vips", nil, params)
 	if err != nil {
 		return nil, err
@@ -72,10 +76,17 @@ func (c *Client) AddChannelVip(params *AddChannelVipParams) (*AddChannelVipRespo
 	return vips, nil
 }
 
-// RemoveChannelVip : Removes the specified user as a VIP in the broadcaster’s channel.
+// AddChannelVip Adds the specified user as a VIP in the broadcaster’s channel.
+//
+// Deprecated: use AddChannelVIP instead.
+func (c *Client) AddChannelVip(params *AddChannelVipParams) (*AddChannelVipResponse, error) {
+	return c.AddChannelVIP(params)
+}
+
+// RemoveChannelVIP Removes the specified user as a VIP in the broadcaster’s channel.
 // Required scope: channel:manage:vips
 // Rate Limits: The broadcaster may remove a maximum of 10 VIPs within a 10-second window.
-func (c *Client) RemoveChannelVip(params *RemoveChannelVipParams) (*RemoveChannelVipResponse, error) {
+func (c *Client) RemoveChannelVIP(params *RemoveChannelVipParams) (*RemoveChannelVipResponse, error) {
 	resp, err := c.delete("/channels/vips", nil, params)
 	if err != nil {
 		return nil, err
@@ -86,3 +97,10 @@ func (c *Client) RemoveChannelVip(params *RemoveChannelVipParams) (*RemoveChanne
 
 	return vips, nil
 }
+
+// RemoveChannelVip Removes the specified user as a VIP in the broadcaster’s channel.
+//
+// Deprecated: use RemoveChannelVIP instead.
+func (c *Client) RemoveChannelVip(params *RemoveChannelVipParams) (*RemoveChannelVipResponse, error) {
+	return c.RemoveChannelVIP(params)
+}
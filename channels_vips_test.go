@@ -217,3 +217,43 @@ func TestRemoveChannelVips(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestGetVIPsPagination(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if len(query["user_id"]) != 2 {
+			t.Errorf("expected 2 user_id query params, got %d", len(query["user_id"]))
+		}
+
+		if query.Get("after") != "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19" {
+			t.Errorf("expected after query param to be %s, got %s", "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19", query.Get("after"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"user_id":"11111","user_name":"UserDisplayName","user_login":"userloginname"}],"pagination":{}}`))
+	})
+
+	resp, err := c.GetVIPs(&GetChannelVipsParams{
+		BroadcasterID: "123",
+		UserIDs:       []string{"11111", "22222"},
+		After:         "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.ChannelsVips) != 1 {
+		t.Errorf("expected 1 vip, got %d", len(resp.Data.ChannelsVips))
+	}
+
+	if resp.Data.Pagination.Cursor != "" {
+		t.Errorf("expected no cursor on the last page, got %s", resp.Data.Pagination.Cursor)
+	}
+
+	_, err = c.GetVIPs(&GetChannelVipsParams{BroadcasterID: "123", First: 101})
+	if err == nil || err.Error() != "error: first must not be greater than 100" {
+		t.Errorf("expected first error, got %v", err)
+	}
+}
@@ -1,5 +1,11 @@
 package helix
 
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
 type CharityDonationData struct {
 	CampaignID string                `json:"campaign_id"`
 	DonationID string                `json:"id"`
@@ -20,15 +26,45 @@ type CharityDonationParams struct {
 	First         int    `query:"first,20"` // Limit 100
 }
 
-type CharityDonationsResponse struct {
-	ResponseCommon
-	Data ManyCharityDonations
-}
+type CharityDonationsResponse = ResponseOf[ManyCharityDonations]
 
+// CharityCampaignAmount is a monetary amount in minor units. For example, an
+// amount of $5.50 USD has a Value of 550 and DecimalPlaces of 2.
 type CharityCampaignAmount struct {
 	Value         int64  `json:"value"`
 	DecimalPlaces int64  `json:"decimal_places"`
-	Currency      string `json:"currency"`
+	Currency      string `json:"currency"` // ISO-4217 currency code
+}
+
+// Float64 returns the amount in major units, e.g. 5.5 for $5.50.
+func (a CharityCampaignAmount) Float64() float64 {
+	return float64(a.Value) / math.Pow10(int(a.DecimalPlaces))
+}
+
+// Decimal returns the exact amount in major units as a decimal string, e.g. "5.50" for $5.50.
+func (a CharityCampaignAmount) Decimal() string {
+	value := a.Value
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	digits := strconv.FormatInt(value, 10)
+	places := int(a.DecimalPlaces)
+	if places <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+}
+
+// String returns the amount followed by its currency code, e.g. "5.50 USD".
+func (a CharityCampaignAmount) String() string {
+	return a.Decimal() + " " + a.Currency
 }
 
 type CharityCampaignData struct {
@@ -49,10 +85,7 @@ type ManyCharityCampaigns struct {
 	Pagination Pagination            `json:"pagination"`
 }
 
-type CharityCampaignsResponse struct {
-	ResponseCommon
-	Data ManyCharityCampaigns
-}
+type CharityCampaignsResponse = ResponseOf[ManyCharityCampaigns]
 
 type CharityCampaignsParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
@@ -60,32 +93,14 @@ type CharityCampaignsParams struct {
 	First         int    `query:"first,20"` // Limit 100
 }
 
+// GetCharityCampaigns gets information about the charity campaign that a broadcaster is running.
 // Required scope: channel:read:charity
 func (c *Client) GetCharityCampaigns(params *CharityCampaignsParams) (*CharityCampaignsResponse, error) {
-	resp, err := c.get("/charity/campaigns", &ManyCharityCampaigns{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	events := &CharityCampaignsResponse{}
-	resp.HydrateResponseCommon(&events.ResponseCommon)
-	events.Data.Campaigns = resp.Data.(*ManyCharityCampaigns).Campaigns
-	events.Data.Pagination = resp.Data.(*ManyCharityCampaigns).Pagination
-
-	return events, nil
+	return getResponse[ManyCharityCampaigns](c, "/charity/campaigns", params)
 }
 
+// GetCharityDonations gets the list of donations that users have made to the broadcaster’s active charity campaign.
 // Required scope: channel:read:charity
 func (c *Client) GetCharityDonations(params *CharityDonationParams) (*CharityDonationsResponse, error) {
-	resp, err := c.get("/charity/donations", &ManyCharityDonations{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	events := &CharityDonationsResponse{}
-	resp.HydrateResponseCommon(&events.ResponseCommon)
-	events.Data.Donations = resp.Data.(*ManyCharityDonations).Donations
-	events.Data.Pagination = resp.Data.(*ManyCharityDonations).Pagination
-
-	return events, nil
+	return getResponse[ManyCharityDonations](c, "/charity/donations", params)
 }
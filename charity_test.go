@@ -167,3 +167,33 @@ func TestGetCharityDonations(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestCharityCampaignAmount(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		amount  CharityCampaignAmount
+		float   float64
+		decimal string
+		str     string
+	}{
+		{CharityCampaignAmount{Value: 550, DecimalPlaces: 2, Currency: "USD"}, 5.5, "5.50", "5.50 USD"},
+		{CharityCampaignAmount{Value: 1500000, DecimalPlaces: 2, Currency: "USD"}, 15000, "15000.00", "15000.00 USD"},
+		{CharityCampaignAmount{Value: 5, DecimalPlaces: 3, Currency: "KWD"}, 0.005, "0.005", "0.005 KWD"},
+		{CharityCampaignAmount{Value: 1200, DecimalPlaces: 0, Currency: "JPY"}, 1200, "1200", "1200 JPY"},
+	}
+
+	for _, testCase := range testCases {
+		if f := testCase.amount.Float64(); f != testCase.float {
+			t.Errorf("expected float to be %v, got %v", testCase.float, f)
+		}
+
+		if d := testCase.amount.Decimal(); d != testCase.decimal {
+			t.Errorf("expected decimal to be %s, got %s", testCase.decimal, d)
+		}
+
+		if str := testCase.amount.String(); str != testCase.str {
+			t.Errorf("expected string to be %s, got %s", testCase.str, str)
+		}
+	}
+}
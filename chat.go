@@ -1,12 +1,14 @@
 package helix
 
-import "errors"
+import (
+	"strings"
+)
 
 type GetChatChattersParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
 	ModeratorID   string `query:"moderator_id"`
 	After         string `query:"after"`
-	First         string `query:"first"`
+	First         string `query:"first"` // Default: 100. Maximum: 1000.
 }
 
 type ChatChatter struct {
@@ -18,41 +20,39 @@ type ChatChatter struct {
 type ManyChatChatters struct {
 	Chatters   []ChatChatter `json:"data"`
 	Pagination Pagination    `json:"pagination"`
-	Total      int           `json:"total"`
+	Total      int           `json:"total"` // The total number of users connected to chat, across all pages
 }
 
-type GetChatChattersResponse struct {
-	ResponseCommon
-	Data ManyChatChatters
-}
+type GetChatChattersResponse = ResponseOf[ManyChatChatters]
 
+// GetChannelChatChatters gets the list of users that are connected to the broadcaster’s chat session.
+// The moderator must be the broadcaster or one of the broadcaster’s moderators.
 // Required scope: moderator:read:chatters
 func (c *Client) GetChannelChatChatters(params *GetChatChattersParams) (*GetChatChattersResponse, error) {
 	if params.BroadcasterID == "" || params.ModeratorID == "" {
-		return nil, errors.New("error: broadcaster and moderator identifiers must be provided")
-	}
-	resp, err := c.get("/chat/chatters", &ManyChatChatters{}, params)
-	if err != nil {
-		return nil, err
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster and moderator identifiers must be provided"}
 	}
+	return getResponse[ManyChatChatters](c, "/chat/chatters", params)
+}
 
-	chatters := &GetChatChattersResponse{}
-	resp.HydrateResponseCommon(&chatters.ResponseCommon)
-	chatters.Data.Chatters = resp.Data.(*ManyChatChatters).Chatters
-	chatters.Data.Total = resp.Data.(*ManyChatChatters).Total
-	chatters.Data.Pagination = resp.Data.(*ManyChatChatters).Pagination
+// StreamChannelChatChatters gets a page of the users in a broadcaster's chat
+// like GetChannelChatChatters, but passes each chatter to handle as it is
+// decoded instead of keeping the page in memory. An error returned by handle
+// stops the request and is returned.
+// Required scope: moderator:read:chatters
+func (c *Client) StreamChannelChatChatters(params *GetChatChattersParams, handle func(chatter ChatChatter) error) (*StreamedResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster and moderator identifiers must be provided"}
+	}
 
-	return chatters, nil
+	return streamResponse(c, "/chat/chatters", params, handle)
 }
 
 type GetChatBadgeParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
 }
 
-type GetChatBadgeResponse struct {
-	ResponseCommon
-	Data ManyChatBadge
-}
+type GetChatBadgeResponse = ResponseOf[ManyChatBadge]
 
 type ManyChatBadge struct {
 	Badges []ChatBadge `json:"data"`
@@ -64,36 +64,24 @@ type ChatBadge struct {
 }
 
 type BadgeVersion struct {
-	ID         string `json:"id"`
-	ImageUrl1x string `json:"image_url_1x"`
-	ImageUrl2x string `json:"image_url_2x"`
-	ImageUrl4x string `json:"image_url_4x"`
+	ID          string `json:"id"`
+	ImageUrl1x  string `json:"image_url_1x"`
+	ImageUrl2x  string `json:"image_url_2x"`
+	ImageUrl4x  string `json:"image_url_4x"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ClickAction string `json:"click_action"` // Empty if the badge has no action
+	ClickURL    string `json:"click_url"`    // Set if ClickAction is "visit_url"
 }
 
+// GetChannelChatBadges gets the broadcaster’s list of custom chat badges.
 func (c *Client) GetChannelChatBadges(params *GetChatBadgeParams) (*GetChatBadgeResponse, error) {
-	resp, err := c.get("/chat/badges", &ManyChatBadge{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	channels := &GetChatBadgeResponse{}
-	resp.HydrateResponseCommon(&channels.ResponseCommon)
-	channels.Data.Badges = resp.Data.(*ManyChatBadge).Badges
-
-	return channels, nil
+	return getResponse[ManyChatBadge](c, "/chat/badges", params)
 }
 
+// GetGlobalChatBadges gets Twitch’s list of chat badges, which users may use in any channel’s chat room.
 func (c *Client) GetGlobalChatBadges() (*GetChatBadgeResponse, error) {
-	resp, err := c.get("/chat/badges/global", &ManyChatBadge{}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	channels := &GetChatBadgeResponse{}
-	resp.HydrateResponseCommon(&channels.ResponseCommon)
-	channels.Data.Badges = resp.Data.(*ManyChatBadge).Badges
-
-	return channels, nil
+	return getResponse[ManyChatBadge](c, "/chat/badges/global", nil)
 }
 
 type GetChannelEmotesParams struct {
@@ -104,6 +92,15 @@ type GetEmoteSetsParams struct {
 	EmoteSetIDs []string `query:"emote_set_id"` // Minimum: 1. Maximum: 25.
 }
 
+// Colors accepted by SendChatAnnouncement
+const (
+	ChatAnnouncementColorPrimary = "primary"
+	ChatAnnouncementColorBlue    = "blue"
+	ChatAnnouncementColorGreen   = "green"
+	ChatAnnouncementColorOrange  = "orange"
+	ChatAnnouncementColorPurple  = "purple"
+)
+
 type SendChatAnnouncementParams struct {
 	BroadcasterID string `query:"broadcaster_id"` // required
 	ModeratorID   string `query:"moderator_id"`   // required
@@ -116,22 +113,18 @@ type SendChatAnnouncementResponse struct {
 	ResponseCommon
 }
 
-type GetChannelEmotesResponse struct {
-	ResponseCommon
-	Data ManyEmotes
-}
+type GetChannelEmotesResponse = ResponseOf[ManyEmotes]
 
-type GetEmoteSetsResponse struct {
-	ResponseCommon
-	Data ManyEmotesWithOwner
-}
+type GetEmoteSetsResponse = ResponseOf[ManyEmotesWithOwner]
 
 type ManyEmotes struct {
-	Emotes []Emote `json:"data"`
+	Emotes   []Emote `json:"data"`
+	Template string  `json:"template"`
 }
 
 type ManyEmotesWithOwner struct {
-	Emotes []EmoteWithOwner `json:"data"`
+	Emotes   []EmoteWithOwner `json:"data"`
+	Template string           `json:"template"`
 }
 
 type Emote struct {
@@ -141,6 +134,38 @@ type Emote struct {
 	Tier       string     `json:"tier"`
 	EmoteType  string     `json:"emote_type"`
 	EmoteSetId string     `json:"emote_set_id"`
+	Format     []string   `json:"format"`     // "static", and "animated" if the emote is animated
+	Scale      []string   `json:"scale"`      // "1.0", "2.0" and/or "3.0"
+	ThemeMode  []string   `json:"theme_mode"` // "light" and/or "dark"
+}
+
+// Values for the emote image template placeholders
+const (
+	EmoteFormatStatic   = "static"
+	EmoteFormatAnimated = "animated"
+
+	EmoteScale1x = "1.0"
+	EmoteScale2x = "2.0"
+	EmoteScale3x = "3.0"
+
+	EmoteThemeModeLight = "light"
+	EmoteThemeModeDark  = "dark"
+)
+
+// DefaultEmoteTemplate is the emote image template returned by the emote endpoints.
+// It can be used to build image URLs for emotes that aren't fetched through those
+// endpoints, such as the ones in EventSub chat message fragments.
+const DefaultEmoteTemplate = "https://static-cdn.jtvnw.net/emoticons/v2/{{id}}/{{format}}/{{theme_mode}}/{{scale}}"
+
+// EmoteImageURL builds the CDN URL of an emote image by filling in the placeholders
+// of the template returned alongside the emotes.
+func EmoteImageURL(template, emoteID, format, themeMode, scale string) string {
+	return strings.NewReplacer(
+		"{{id}}", emoteID,
+		"{{format}}", format,
+		"{{theme_mode}}", themeMode,
+		"{{scale}}", scale,
+	).Replace(template)
 }
 
 type EmoteWithOwner struct {
@@ -154,49 +179,35 @@ type EmoteImage struct {
 	Url4x string `json:"url_4x"`
 }
 
+// GetChannelEmotes gets the broadcaster’s list of custom emotes.
 func (c *Client) GetChannelEmotes(params *GetChannelEmotesParams) (*GetChannelEmotesResponse, error) {
-	resp, err := c.get("/chat/emotes", &ManyEmotes{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	emotes := &GetChannelEmotesResponse{}
-	resp.HydrateResponseCommon(&emotes.ResponseCommon)
-	emotes.Data.Emotes = resp.Data.(*ManyEmotes).Emotes
-
-	return emotes, nil
+	return getResponse[ManyEmotes](c, "/chat/emotes", params)
 }
 
+// GetGlobalEmotes gets the list of global emotes.
 func (c *Client) GetGlobalEmotes() (*GetChannelEmotesResponse, error) {
-	resp, err := c.get("/chat/emotes/global", &ManyEmotes{}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	emotes := &GetChannelEmotesResponse{}
-	resp.HydrateResponseCommon(&emotes.ResponseCommon)
-	emotes.Data.Emotes = resp.Data.(*ManyEmotes).Emotes
-
-	return emotes, nil
+	return getResponse[ManyEmotes](c, "/chat/emotes/global", nil)
 }
 
-// GetEmoteSets
+// GetEmoteSets gets emotes for one or more specified emote sets.
 func (c *Client) GetEmoteSets(params *GetEmoteSetsParams) (*GetEmoteSetsResponse, error) {
-	resp, err := c.get("/chat/emotes/set", &ManyEmotesWithOwner{}, params)
-	if err != nil {
+	if err := validateMaxValues("emote_set_id", "emote set ids", params.EmoteSetIDs, 25); err != nil {
 		return nil, err
 	}
 
-	emotes := &GetEmoteSetsResponse{}
-	resp.HydrateResponseCommon(&emotes.ResponseCommon)
-	emotes.Data.Emotes = resp.Data.(*ManyEmotesWithOwner).Emotes
-
-	return emotes, nil
+	return getResponse[ManyEmotesWithOwner](c, "/chat/emotes/set", params)
 }
 
 // SendChatAnnouncement sends an announcement to the broadcaster’s chat room.
 // Required scope: moderator:manage:announcements
 func (c *Client) SendChatAnnouncement(params *SendChatAnnouncementParams) (*SendChatAnnouncementResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster and moderator identifiers must be provided"}
+	}
+	if err := validateRequired("message", "message", params.Message); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.postAsJSON("/chat/announcements", nil, params)
 	if err != nil {
 		return nil, err
@@ -249,27 +260,15 @@ type ManyChatSettings struct {
 	Settings []ChatSettings `json:"data"`
 }
 
-type GetChatSettingsResponse struct {
-	ResponseCommon
-	Data ManyChatSettings
-}
+type GetChatSettingsResponse = ResponseOf[ManyChatSettings]
 
 // GetChatSettings gets the chat settings for the broadcaster's chat room.
 // Optional scope: moderator:read:chat_settings
 func (c *Client) GetChatSettings(params *GetChatSettingsParams) (*GetChatSettingsResponse, error) {
-	if params.BroadcasterID == "" {
-		return nil, errors.New("error: broadcaster id must be specified")
-	}
-	resp, err := c.get("/chat/settings", &ManyChatSettings{}, params)
-	if err != nil {
+	if err := validateRequired("broadcaster_id", "broadcaster id", params.BroadcasterID); err != nil {
 		return nil, err
 	}
-
-	settings := &GetChatSettingsResponse{}
-	resp.HydrateResponseCommon(&settings.ResponseCommon)
-	settings.Data.Settings = resp.Data.(*ManyChatSettings).Settings
-
-	return settings, nil
+	return getResponse[ManyChatSettings](c, "/chat/settings", params)
 }
 
 type UpdateChatSettingsParams struct {
@@ -300,7 +299,7 @@ type UpdateChatSettingsParams struct {
 
 	// Optional, time in seconds before messages appear for non-moderators
 	// If unset (i.e. nil), no change to this setting will be made
-	// If set, FollowerMode must be set to true.
+	// If set, NonModeratorChatDelay must be set to true.
 	// Possible values are 2, 4, or 6
 	NonModeratorChatDelayDuration *int `json:"non_moderator_chat_delay_duration,omitempty"`
 
@@ -323,41 +322,26 @@ type UpdateChatSettingsParams struct {
 	UniqueChatMode *bool `json:"unique_chat_mode,omitempty"`
 }
 
-type UpdateChatSettingsResponse struct {
-	ResponseCommon
-	Data ManyChatSettings
-}
+type UpdateChatSettingsResponse = ResponseOf[ManyChatSettings]
 
 // UpdateChatSettings updates the broadcaster's chat settings.
 // Required scope: moderator:manage:chat_settings
 func (c *Client) UpdateChatSettings(params *UpdateChatSettingsParams) (*UpdateChatSettingsResponse, error) {
-	if params.BroadcasterID == "" {
-		return nil, errors.New("error: broadcaster id must be specified")
-	}
-	if params.ModeratorID == "" {
-		return nil, errors.New("error: moderator id must be specified")
+	if err := validateRequired("broadcaster_id", "broadcaster id", params.BroadcasterID); err != nil {
+		return nil, err
 	}
-	resp, err := c.patchAsJSON("/chat/settings", &ManyChatSettings{}, params)
-	if err != nil {
+	if err := validateRequired("moderator_id", "moderator id", params.ModeratorID); err != nil {
 		return nil, err
 	}
-
-	settings := &UpdateChatSettingsResponse{}
-	resp.HydrateResponseCommon(&settings.ResponseCommon)
-	settings.Data.Settings = resp.Data.(*ManyChatSettings).Settings
-
-	return settings, nil
+	return patchAsJSONResponse[ManyChatSettings](c, "/chat/settings", params)
 }
 
 // UserChatColorResponse is the response from GetUserChatColor
-type UserChatColorResponse struct {
-	ResponseCommon
-	Data GetUserChatColorResponse
-}
+type UserChatColorResponse = ResponseOf[GetUserChatColorResponse]
 
 // GetUserChatColorParams are the parameters for GetUserChatColor
 type GetUserChatColorParams struct {
-	UserID string `json:"user_id"`
+	UserID string `query:"user_id"`
 }
 
 // GetUserChatColorResponse is the response data in UserChatColorResponse
@@ -370,20 +354,16 @@ type GetUserChatColorUser struct {
 	UserID    string `json:"user_id"`
 	UserLogin string `json:"user_login"`
 	UserName  string `json:"user_name"`
-	Color     string `json:"color"`
+	Color     string `json:"color"` // Hex color code, empty if the user never set one
 }
 
 // GetUserChatColor fetches the color used for the user’s name in chat.
 func (c *Client) GetUserChatColor(params *GetUserChatColorParams) (*UserChatColorResponse, error) {
-	resp, err := c.get("/chat/color", &GetUserChatColorResponse{}, params)
-	if err != nil {
+	if err := validateRequired("user_id", "user id", params.UserID); err != nil {
 		return nil, err
 	}
 
-	userColor := &UserChatColorResponse{}
-	resp.HydrateResponseCommon(&userColor.ResponseCommon)
-
-	return userColor, nil
+	return getResponse[GetUserChatColorResponse](c, "/chat/color", params)
 }
 
 // UpdateUserChatColorResponse is the response for UpdateUserChatColor
@@ -391,33 +371,79 @@ type UpdateUserChatColorResponse struct {
 	ResponseCommon
 }
 
+// ChatColor is a color for the user’s name in chat. Prime and Turbo users can
+// use any Hex color code, such as ChatColor("#9146FF"); everyone else is
+// limited to the named colors below.
+type ChatColor string
+
+// Named chat colors available to all users
+const (
+	ChatColorBlue        ChatColor = "blue"
+	ChatColorBlueViolet  ChatColor = "blue_violet"
+	ChatColorCadetBlue   ChatColor = "cadet_blue"
+	ChatColorChocolate   ChatColor = "chocolate"
+	ChatColorCoral       ChatColor = "coral"
+	ChatColorDodgerBlue  ChatColor = "dodger_blue"
+	ChatColorFirebrick   ChatColor = "firebrick"
+	ChatColorGoldenRod   ChatColor = "golden_rod"
+	ChatColorGreen       ChatColor = "green"
+	ChatColorHotPink     ChatColor = "hot_pink"
+	ChatColorOrangeRed   ChatColor = "orange_red"
+	ChatColorRed         ChatColor = "red"
+	ChatColorSeaGreen    ChatColor = "sea_green"
+	ChatColorSpringGreen ChatColor = "spring_green"
+	ChatColorYellowGreen ChatColor = "yellow_green"
+)
+
+// NamedChatColors lists the chat colors that don't require Prime or Turbo.
+var NamedChatColors = []ChatColor{
+	ChatColorBlue,
+	ChatColorBlueViolet,
+	ChatColorCadetBlue,
+	ChatColorChocolate,
+	ChatColorCoral,
+	ChatColorDodgerBlue,
+	ChatColorFirebrick,
+	ChatColorGoldenRod,
+	ChatColorGreen,
+	ChatColorHotPink,
+	ChatColorOrangeRed,
+	ChatColorRed,
+	ChatColorSeaGreen,
+	ChatColorSpringGreen,
+	ChatColorYellowGreen,
+}
+
+// IsNamed reports whether the color is one of the NamedChatColors.
+func (c ChatColor) IsNamed() bool {
+	for _, color := range NamedChatColors {
+		if c == color {
+			return true
+		}
+	}
+
+	return false
+}
+
 // UpdateUserChatColorParams are the parameters for UpdateUserChatColor
 type UpdateUserChatColorParams struct {
-	UserID string `query:"user_id"`
-	Color  string `query:"color"`
+	UserID string    `query:"user_id"`
+	Color  ChatColor `query:"color"`
 }
 
-// UpdateUserChatcolor updates the color used for the user’s name in chat.
+// UpdateUserChatColor updates the color used for the user’s name in chat.
 //
 // Required scope: user:manage:chat_color
 //
-// Prime and Turbo users can specify a Hex color code, everyone can use the default colors:
-//   - blue
-//   - blue_violet
-//   - cadet_blue
-//   - chocolate
-//   - coral
-//   - dodger_blue
-//   - firebrick
-//   - golden_rod
-//   - green
-//   - hot_pink
-//   - orange_red
-//   - red
-//   - sea_green
-//   - spring_green
-//   - yellow_green
+// Prime and Turbo users can specify a Hex color code, everyone can use the NamedChatColors.
 func (c *Client) UpdateUserChatColor(params *UpdateUserChatColorParams) (*UpdateUserChatColorResponse, error) {
+	if err := validateRequired("user_id", "user id", params.UserID); err != nil {
+		return nil, err
+	}
+	if err := validateRequired("color", "color", string(params.Color)); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.put("/chat/color", nil, params)
 	if err != nil {
 		return nil, err
@@ -446,6 +472,10 @@ type SendChatMessageParams struct {
 
 	// The ID of the chat message being replied to
 	ReplyParentMessageID string `json:"reply_parent_message_id,omitempty"`
+
+	// Optional, only applies during a shared chat session. If true, the message is only
+	// sent to the source channel's chat. If unset (i.e. nil), Twitch's default is used
+	ForSourceOnly *bool `json:"for_source_only,omitempty"`
 }
 
 type ChatMessageResponse struct {
@@ -466,11 +496,7 @@ type ChatMessage struct {
 	IsSent bool `json:"is_sent"`
 
 	// The reason the message was dropped, if any
-	DropReasons ManyDropReasons `json:"drop_reason"`
-}
-
-type ManyDropReasons struct {
-	Data DropReason
+	DropReason DropReason `json:"drop_reason"`
 }
 
 type DropReason struct {
@@ -485,11 +511,14 @@ type DropReason struct {
 // If app access token used, then additionally requires user:bot scope from chatting user,
 // and either channel:bot scope from broadcaster or moderator status
 func (c *Client) SendChatMessage(params *SendChatMessageParams) (*ChatMessageResponse, error) {
-	if params.BroadcasterID == "" {
-		return nil, errors.New("error: broadcaster id must be specified")
+	if err := validateRequired("broadcaster_id", "broadcaster id", params.BroadcasterID); err != nil {
+		return nil, err
 	}
-	if params.SenderID == "" {
-		return nil, errors.New("error: sender id must be specified")
+	if err := validateRequired("sender_id", "sender id", params.SenderID); err != nil {
+		return nil, err
+	}
+	if err := validateRequired("message", "message", params.Message); err != nil {
+		return nil, err
 	}
 
 	resp, err := c.postAsJSON("/chat/messages", &ManyChatMessages{}, params)
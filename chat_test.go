@@ -3,10 +3,11 @@ package helix
 import (
 	"context"
 	"net/http"
+	"reflect"
 	"testing"
 )
 
-func TestGetChannelChatChatterss(t *testing.T) {
+func TestGetChannelChatChatters(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
@@ -27,7 +28,7 @@ func TestGetChannelChatChatterss(t *testing.T) {
 			http.StatusOK,
 			&Options{ClientID: "my-client-id"},
 			&GetChatChattersParams{BroadcasterID: "121445595", ModeratorID: "1234"},
-			`{"data": [{"user_login": "smittysmithers", "user_name": "example", "user_id": "100249558"}]}`,
+			`{"data": [{"user_login": "smittysmithers", "user_name": "example", "user_id": "100249558"}], "pagination": {"cursor": "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19"}, "total": 8}`,
 			"",
 		},
 		{
@@ -73,6 +74,35 @@ func TestGetChannelChatChatterss(t *testing.T) {
 		if resp.Data.Chatters[0].UserID != "100249558" {
 			t.Errorf("expected %s chatters got %s", "100249558", resp.Data.Chatters[0].UserID)
 		}
+
+		if resp.Data.Total != 8 {
+			t.Errorf("expected total to be %d, got %d", 8, resp.Data.Total)
+		}
+
+		if resp.Data.Pagination.Cursor == "" {
+			t.Error("expected pagination cursor not to be empty")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetChannelChatChatters(&GetChatChattersParams{BroadcasterID: "121445595", ModeratorID: "1234"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
 	}
 }
 
@@ -242,7 +272,7 @@ func TestGetChannelEmotes(t *testing.T) {
 			http.StatusOK,
 			&Options{ClientID: "my-client-id"},
 			&GetChannelEmotesParams{BroadcasterID: "121445595"},
-			`{"data":[{"id":"300678378","name":"scoorfPoko","images":{"url_1x":"https://static-cdn.jtvnw.net/emoticons/v1/300678378/1.0","url_2x":"https://static-cdn.jtvnw.net/emoticons/v1/300678378/2.0","url_4x":"https://static-cdn.jtvnw.net/emoticons/v1/300678378/3.0"},"tier":"1000","emote_type":"subscriptions","emote_set_id":"1347400"}]}`,
+			`{"data":[{"id":"300678378","name":"scoorfPoko","images":{"url_1x":"https://static-cdn.jtvnw.net/emoticons/v1/300678378/1.0","url_2x":"https://static-cdn.jtvnw.net/emoticons/v1/300678378/2.0","url_4x":"https://static-cdn.jtvnw.net/emoticons/v1/300678378/3.0"},"tier":"1000","emote_type":"subscriptions","emote_set_id":"1347400","format":["static"],"scale":["1.0","2.0","3.0"],"theme_mode":["light","dark"]}],"template":"https://static-cdn.jtvnw.net/emoticons/v2/{{id}}/{{format}}/{{theme_mode}}/{{scale}}"}`,
 		},
 	}
 
@@ -274,6 +304,19 @@ func TestGetChannelEmotes(t *testing.T) {
 
 			continue
 		}
+
+		if resp.Data.Template != DefaultEmoteTemplate {
+			t.Errorf("expected template to be %s, got %s", DefaultEmoteTemplate, resp.Data.Template)
+		}
+
+		if len(resp.Data.Emotes) != 1 {
+			t.Errorf("expected %d emotes, got %d", 1, len(resp.Data.Emotes))
+			continue
+		}
+
+		if len(resp.Data.Emotes[0].ThemeMode) != 2 {
+			t.Errorf("expected %d theme modes, got %d", 2, len(resp.Data.Emotes[0].ThemeMode))
+		}
 	}
 
 	// Test with HTTP Failure
@@ -445,7 +488,7 @@ func TestGetEmoteSets(t *testing.T) {
 		} else {
 			for i, expectedEmote := range testCase.expectedEmotes {
 				actualEmote := resp.Data.Emotes[i]
-				if expectedEmote != actualEmote {
+				if !reflect.DeepEqual(expectedEmote, actualEmote) {
 					t.Errorf("mismatching emotes %#v != %#v", expectedEmote, actualEmote)
 				}
 			}
@@ -539,7 +582,7 @@ func TestSendChatAnnouncement(t *testing.T) {
 		ctx:  context.Background(),
 	}
 
-	_, err := c.SendChatAnnouncement(&SendChatAnnouncementParams{})
+	_, err := c.SendChatAnnouncement(&SendChatAnnouncementParams{BroadcasterID: "100249558", ModeratorID: "100249558", Message: "hello twitch chat"})
 	if err == nil {
 		t.Error("expected error but got nil")
 	}
@@ -547,6 +590,32 @@ func TestSendChatAnnouncement(t *testing.T) {
 	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
 		t.Error("expected error does match return error")
 	}
+
+	// Test validation errors
+	validationCases := []struct {
+		params *SendChatAnnouncementParams
+		err    string
+	}{
+		{
+			&SendChatAnnouncementParams{ModeratorID: "100249558", Message: "hello twitch chat"},
+			"error: broadcaster and moderator identifiers must be provided",
+		},
+		{
+			&SendChatAnnouncementParams{BroadcasterID: "100249558", Message: "hello twitch chat"},
+			"error: broadcaster and moderator identifiers must be provided",
+		},
+		{
+			&SendChatAnnouncementParams{BroadcasterID: "100249558", ModeratorID: "100249558", Color: ChatAnnouncementColorPurple},
+			"error: message must be specified",
+		},
+	}
+
+	for _, validationCase := range validationCases {
+		_, err := c.SendChatAnnouncement(validationCase.params)
+		if err == nil || err.Error() != validationCase.err {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", validationCase.err, err)
+		}
+	}
 }
 
 func TestGetChatSettings(t *testing.T) {
@@ -851,7 +920,14 @@ func TestGetUserChatColor(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("user_id") != testCase.UserID {
+				t.Errorf("expected user_id query param to be %s, got %s", testCase.UserID, r.URL.Query().Get("user_id"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
 
 		resp, err := c.GetUserChatColor(&GetUserChatColorParams{
 			UserID: testCase.UserID,
@@ -880,6 +956,41 @@ func TestGetUserChatColor(t *testing.T) {
 
 			continue
 		}
+
+		if len(resp.Data.Data) != 2 {
+			t.Errorf("expected %d users, got %d", 2, len(resp.Data.Data))
+			continue
+		}
+
+		if resp.Data.Data[0].Color != "#9146FF" {
+			t.Errorf("expected color to be %s, got %s", "#9146FF", resp.Data.Data[0].Color)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetUserChatColor(&GetUserChatColorParams{UserID: "22484632"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+
+	_, err = c.GetUserChatColor(&GetUserChatColorParams{})
+	if err == nil || err.Error() != "error: user id must be specified" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: user id must be specified", err)
 	}
 }
 
@@ -890,14 +1001,14 @@ func TestUpdateUserChatColor(t *testing.T) {
 		statusCode int
 		options    *Options
 		UserID     string
-		Color      string
+		Color      ChatColor
 		respBody   string
 	}{
 		{
 			http.StatusOK,
 			&Options{ClientID: "my-client-id"},
 			"22484632",
-			"blue",
+			ChatColorBlue,
 			``,
 		},
 		{
@@ -941,6 +1052,44 @@ func TestUpdateUserChatColor(t *testing.T) {
 			continue
 		}
 	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.UpdateUserChatColor(&UpdateUserChatColorParams{UserID: "22484632", Color: ChatColorBlue})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+
+	_, err = c.UpdateUserChatColor(&UpdateUserChatColorParams{UserID: "22484632"})
+	if err == nil || err.Error() != "error: color must be specified" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: color must be specified", err)
+	}
+}
+
+func TestChatColorIsNamed(t *testing.T) {
+	t.Parallel()
+
+	if !ChatColorHotPink.IsNamed() {
+		t.Errorf("expected %s to be a named color", ChatColorHotPink)
+	}
+
+	if ChatColor("#9146FF").IsNamed() {
+		t.Errorf("expected %s not to be a named color", "#9146FF")
+	}
 }
 
 func TestSendChatMessage(t *testing.T) {
@@ -964,6 +1113,29 @@ func TestSendChatMessage(t *testing.T) {
 			`{"data":[{"message_id": "abc-123-def","is_sent": true}]}`,
 			``,
 		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&SendChatMessageParams{
+				BroadcasterID:        "1234",
+				SenderID:             "5678",
+				Message:              "Hello, world! twitchdevHype",
+				ReplyParentMessageID: "aabbcc-dd",
+			},
+			`{"data":[{"message_id": "","is_sent": false,"drop_reason":{"code":"msg_duplicate","message":"Your message was not sent because it is identical to the previous one you sent, less than 30 seconds ago."}}]}`,
+			``,
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&SendChatMessageParams{
+				BroadcasterID: "1234",
+				SenderID:      "5678",
+				Message:       "",
+			},
+			``,
+			`error: message must be specified`,
+		},
 		{
 			http.StatusOK,
 			&Options{ClientID: "my-client-id"},
@@ -1035,6 +1207,14 @@ func TestSendChatMessage(t *testing.T) {
 			t.Errorf("Expected the number of messages to be a positive number")
 		}
 
+		if !resp.Data.Messages[0].IsSent {
+			if resp.Data.Messages[0].DropReason.Code != "msg_duplicate" {
+				t.Errorf("expected drop reason code to be \"%s\", got \"%s\"", "msg_duplicate", resp.Data.Messages[0].DropReason.Code)
+			}
+
+			continue
+		}
+
 		if len(resp.Data.Messages[0].MessageID) == 0 {
 			t.Errorf("Expected message_id not to be empty")
 		}
@@ -1063,3 +1243,13 @@ func TestSendChatMessage(t *testing.T) {
 		t.Errorf("expected error does match return error, got '%s'", err.Error())
 	}
 }
+
+func TestEmoteImageURL(t *testing.T) {
+	t.Parallel()
+
+	url := EmoteImageURL(DefaultEmoteTemplate, "300678378", EmoteFormatStatic, EmoteThemeModeDark, EmoteScale3x)
+	expected := "https://static-cdn.jtvnw.net/emoticons/v2/300678378/static/dark/3.0"
+	if url != expected {
+		t.Errorf("expected emote image url to be %s, got %s", expected, url)
+	}
+}
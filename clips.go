@@ -1,5 +1,10 @@
 package helix
 
+import (
+	"fmt"
+	"regexp"
+)
+
 type Clip struct {
 	ID              string  `json:"id"`
 	URL             string  `json:"url"`
@@ -14,9 +19,23 @@ type Clip struct {
 	Language        string  `json:"language"`
 	Title           string  `json:"title"`
 	ViewCount       int     `json:"view_count"`
-	CreatedAt       string  `json:"created_at"`
+	CreatedAt       Time    `json:"created_at"`
 	ThumbnailURL    string  `json:"thumbnail_url"`
 	VodOffset       int     `json:"vod_offset"`
+	IsFeatured      bool    `json:"is_featured"`
+}
+
+var clipThumbnailPreviewRegexp = regexp.MustCompile(`-preview-\d+x\d+\.jpg$`)
+
+// ThumbnailURLWithSize returns the clip's thumbnail url resized to the given
+// width and height. It returns an empty string if the thumbnail url doesn't
+// use the "-preview-{width}x{height}.jpg" format.
+func (c *Clip) ThumbnailURLWithSize(width, height int) string {
+	if !clipThumbnailPreviewRegexp.MatchString(c.ThumbnailURL) {
+		return ""
+	}
+
+	return clipThumbnailPreviewRegexp.ReplaceAllString(c.ThumbnailURL, fmt.Sprintf("-preview-%dx%d.jpg", width, height))
 }
 
 type ManyClips struct {
@@ -24,10 +43,7 @@ type ManyClips struct {
 	Pagination Pagination `json:"pagination"`
 }
 
-type ClipsResponse struct {
-	ResponseCommon
-	Data ManyClips
-}
+type ClipsResponse = ResponseOf[ManyClips]
 
 type ClipsParams struct {
 	// One of the below
@@ -36,26 +52,38 @@ type ClipsParams struct {
 	IDs           []string `query:"id"` // Limit 100
 
 	// Optional
-	First     int    `query:"first,20"` // Maximum 100
-	After     string `query:"after"`
-	Before    string `query:"before"`
-	StartedAt Time   `query:"started_at"`
-	EndedAt   Time   `query:"ended_at"`
+	First      int    `query:"first,20"` // Maximum 100
+	After      string `query:"after"`
+	Before     string `query:"before"`
+	StartedAt  Time   `query:"started_at"`
+	EndedAt    Time   `query:"ended_at"` // Requires StartedAt
+	IsFeatured *bool  `query:"is_featured"`
 }
 
 // GetClips returns information about a specified clip.
+// Clips of a broadcaster or game can be filtered to those created between
+// StartedAt and EndedAt, and by whether they are featured. If EndedAt isn't
+// set, Twitch uses a week after StartedAt.
 func (c *Client) GetClips(params *ClipsParams) (*ClipsResponse, error) {
-	resp, err := c.get("/clips", &ManyClips{}, params)
-	if err != nil {
+	if err := validateMaxValues("id", "clip ids", params.IDs, 100); err != nil {
 		return nil, err
 	}
 
-	clips := &ClipsResponse{}
-	resp.HydrateResponseCommon(&clips.ResponseCommon)
-	clips.Data.Clips = resp.Data.(*ManyClips).Clips
-	clips.Data.Pagination = resp.Data.(*ManyClips).Pagination
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
 
-	return clips, nil
+	if !params.EndedAt.IsZero() {
+		if params.StartedAt.IsZero() {
+			return nil, &ValidationError{Field: "started_at", Message: "started at must be provided when ended at is set"}
+		}
+
+		if params.EndedAt.Before(params.StartedAt.Time) {
+			return nil, &ValidationError{Field: "ended_at", Message: "ended at must not be before started at"}
+		}
+	}
+
+	return getResponse[ManyClips](c, "/clips", params)
 }
 
 type ClipEditURL struct {
@@ -88,7 +116,7 @@ type CreateClipParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
 
 	// Optional
-	HasDelay bool `query:"has_delay,false"`
+	HasDelay bool `query:"has_delay,false"` // Add a delay before capturing the clip, matching the broadcast delay
 }
 
 // CreateClip creates a clip programmatically. This returns both an ID and
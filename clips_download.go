@@ -0,0 +1,221 @@
+package helix
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultClipDownloadRetries is the number of times DownloadClip resumes an
+// interrupted download if no number of retries is given.
+const DefaultClipDownloadRetries = 3
+
+type ClipDownload struct {
+	ClipID               string `json:"clip_id"`
+	LandscapeDownloadURL string `json:"landscape_download_url"`
+	PortraitDownloadURL  string `json:"portrait_download_url"`
+}
+
+// DownloadURL returns the url of the clip's landscape video file, or of the
+// portrait one if portrait is set. It returns an empty string if that
+// version of the clip can't be downloaded.
+func (d *ClipDownload) DownloadURL(portrait bool) string {
+	if portrait {
+		return d.PortraitDownloadURL
+	}
+
+	return d.LandscapeDownloadURL
+}
+
+type ManyClipDownloads struct {
+	ClipDownloads []ClipDownload `json:"data"`
+}
+
+type ClipsDownloadResponse = ResponseOf[ManyClipDownloads]
+
+type ClipsDownloadParams struct {
+	EditorID      string   `query:"editor_id"`
+	BroadcasterID string   `query:"broadcaster_id"`
+	ClipIDs       []string `query:"clip_id"` // Limit 10
+}
+
+// GetClipsDownload returns the urls of the video files of a broadcaster's
+// clips. EditorID must be the broadcaster or one of their editors, and the
+// user the user access token belongs to. The urls are only valid for a short
+// time and are empty for clips that can't be downloaded.
+//
+// Required scope: editor:manage:clips or channel:manage:clips
+func (c *Client) GetClipsDownload(params *ClipsDownloadParams) (*ClipsDownloadResponse, error) {
+	if err := validateRequired("editor_id", "editor id", params.EditorID); err != nil {
+		return nil, err
+	}
+
+	if err := validateRequired("broadcaster_id", "broadcaster id", params.BroadcasterID); err != nil {
+		return nil, err
+	}
+
+	if len(params.ClipIDs) == 0 {
+		return nil, &ValidationError{Field: "clip_id", Message: "at least one clip id must be specified"}
+	}
+
+	if err := validateMaxValues("clip_id", "clip ids", params.ClipIDs, 10); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyClipDownloads](c, "/clips/downloads", params)
+}
+
+type DownloadClipParams struct {
+	EditorID      string // required
+	BroadcasterID string // required
+	ClipID        string // required
+
+	// Portrait downloads the portrait version of the clip instead of the
+	// landscape one.
+	Portrait bool
+
+	// Offset is the number of bytes of the clip that were already written,
+	// to resume an earlier download.
+	Offset int64
+
+	// Retries is the number of times in a row an interrupted download is
+	// resumed, DefaultClipDownloadRetries if zero.
+	Retries int
+}
+
+// DownloadClip looks up the download url of a clip with GetClipsDownload and
+// writes its video file to w. The file is requested in ranges, so a download
+// that is interrupted is resumed where it stopped, and one that failed can be
+// resumed later by setting params.Offset to the bytes written so far. It
+// returns the number of bytes written by this call, also when it fails or ctx
+// is done.
+//
+// The download url is signed, so the file is requested without the client's
+// access tokens and middleware.
+//
+// Required scope: editor:manage:clips or channel:manage:clips
+func (c *Client) DownloadClip(ctx context.Context, params *DownloadClipParams, w io.Writer) (int64, error) {
+	if err := validateRequired("clip_id", "clip id", params.ClipID); err != nil {
+		return 0, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	resp, err := c.GetClipsDownload(&ClipsDownloadParams{
+		EditorID:      params.EditorID,
+		BroadcasterID: params.BroadcasterID,
+		ClipIDs:       []string{params.ClipID},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("error: could not get the download url of clip %s: %w", params.ClipID, resp.Err())
+	}
+
+	if len(resp.Data.ClipDownloads) == 0 {
+		return 0, fmt.Errorf("error: clip %s not found", params.ClipID)
+	}
+
+	url := resp.Data.ClipDownloads[0].DownloadURL(params.Portrait)
+	if url == "" {
+		return 0, fmt.Errorf("error: clip %s can't be downloaded", params.ClipID)
+	}
+
+	retries := params.Retries
+	if retries <= 0 {
+		retries = DefaultClipDownloadRetries
+	}
+
+	offset := params.Offset
+	var written int64
+	failures := 0
+	for {
+		n, retry, err := c.downloadClipRange(ctx, url, offset, w)
+		written += n
+		offset += n
+		if err == nil {
+			return written, nil
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return written, ctxErr
+		}
+
+		if n > 0 {
+			failures = 0
+		}
+
+		failures++
+		if !retry || failures > retries {
+			return written, err
+		}
+
+		if err := c.sleep(ctx, time.Duration(failures)*time.Second); err != nil {
+			return written, err
+		}
+	}
+}
+
+// downloadClipRange writes the clip file at url to w from offset on. It
+// returns the number of bytes written and whether a failed download may be
+// resumed.
+func (c *Client) downloadClipRange(ctx context.Context, url string, offset int64, w io.Writer) (int64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The range was ignored, skip what was already written
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			return 0, true, err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing is left after offset, the file was already written
+		return 0, false, nil
+	default:
+		return 0, resp.StatusCode >= http.StatusInternalServerError, fmt.Errorf("error: could not download clip: %s", resp.Status)
+	}
+
+	dw := &downloadWriter{w: w}
+	n, err := io.Copy(dw, resp.Body)
+	if err != nil {
+		// Only reading the file can fail in a way that resuming fixes
+		return n, dw.err == nil, err
+	}
+
+	return n, false, nil
+}
+
+// downloadWriter tells errors of the writer apart from those of the body.
+type downloadWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	if err != nil {
+		d.err = err
+	}
+
+	return n, err
+}
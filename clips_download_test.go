@@ -0,0 +1,391 @@
+package helix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const clipDownloadFileURL = "https://production.assets.clips.twitchcdn.net/landscape.mp4?sig=abc"
+
+// clipHTTPClient serves the clip API from a mock handler and the clip files
+// from serveFile.
+type clipHTTPClient struct {
+	api       *mockHTTPClient
+	serveFile func(req *http.Request) *http.Response
+}
+
+func (c *clipHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, ".mp4") {
+		return c.serveFile(req), nil
+	}
+
+	return c.api.Do(req)
+}
+
+// failingReader returns err once r is read.
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+
+	return n, err
+}
+
+func TestGetClipsDownload(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode  int
+		params      *ClipsDownloadParams
+		respBody    string
+		expectedErr string
+	}{
+		{
+			http.StatusOK,
+			&ClipsDownloadParams{EditorID: "141981764", BroadcasterID: "141981764", ClipIDs: []string{"AwkwardHelplessSalamanderSwiftRage"}},
+			`{"data":[{"clip_id":"AwkwardHelplessSalamanderSwiftRage","landscape_download_url":"` + clipDownloadFileURL + `","portrait_download_url":null}]}`,
+			"",
+		},
+		{
+			http.StatusOK,
+			&ClipsDownloadParams{BroadcasterID: "141981764", ClipIDs: []string{"AwkwardHelplessSalamanderSwiftRage"}},
+			"",
+			"error: editor id must be specified",
+		},
+		{
+			http.StatusOK,
+			&ClipsDownloadParams{EditorID: "141981764", BroadcasterID: "141981764"},
+			"",
+			"error: at least one clip id must be specified",
+		},
+		{
+			http.StatusOK,
+			&ClipsDownloadParams{EditorID: "141981764", BroadcasterID: "141981764", ClipIDs: make([]string, 11)},
+			"",
+			"error: only 10 clip ids can be specified",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/clips/downloads" || r.URL.Query().Get("editor_id") != "141981764" || r.URL.Query().Get("clip_id") != "AwkwardHelplessSalamanderSwiftRage" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			newMockHandler(testCase.statusCode, testCase.respBody, nil)(w, r)
+		})
+
+		resp, err := c.GetClipsDownload(testCase.params)
+		if testCase.expectedErr != "" {
+			if err == nil || err.Error() != testCase.expectedErr {
+				t.Errorf("expected error %q, got %v", testCase.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(resp.Data.ClipDownloads) != 1 || resp.Data.ClipDownloads[0].LandscapeDownloadURL != clipDownloadFileURL {
+			t.Errorf("unexpected clip downloads %+v", resp.Data.ClipDownloads)
+		}
+	}
+}
+
+func TestClipDownloadURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		download ClipDownload
+		portrait bool
+		expected string
+	}{
+		{ClipDownload{LandscapeDownloadURL: "https://example.com/landscape.mp4", PortraitDownloadURL: "https://example.com/portrait.mp4"}, false, "https://example.com/landscape.mp4"},
+		{ClipDownload{LandscapeDownloadURL: "https://example.com/landscape.mp4", PortraitDownloadURL: "https://example.com/portrait.mp4"}, true, "https://example.com/portrait.mp4"},
+		{ClipDownload{LandscapeDownloadURL: "https://example.com/landscape.mp4"}, true, ""},
+	}
+
+	for _, testCase := range testCases {
+		if url := testCase.download.DownloadURL(testCase.portrait); url != testCase.expected {
+			t.Errorf("expected download url to be \"%s\", got \"%s\"", testCase.expected, url)
+		}
+	}
+}
+
+func TestDownloadClip(t *testing.T) {
+	t.Parallel()
+
+	file := "0123456789abcdef"
+	clipJSON := `{"data":[{"clip_id":"AwkwardHelplessSalamanderSwiftRage","landscape_download_url":"` + clipDownloadFileURL + `","portrait_download_url":""}]}`
+
+	testCases := []struct {
+		name        string
+		clipJSON    string
+		offset      int64
+		serveFile   func(requests int, req *http.Request) *http.Response
+		expected    string
+		expectedErr string
+		requests    int
+	}{
+		{
+			"file is streamed to the writer",
+			clipJSON,
+			0,
+			func(requests int, req *http.Request) *http.Response {
+				if req.Header.Get("Range") != "" {
+					return &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Body: http.NoBody}
+				}
+
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(file))}
+			},
+			file,
+			"",
+			1,
+		},
+		{
+			"interrupted download is resumed",
+			clipJSON,
+			0,
+			func(requests int, req *http.Request) *http.Response {
+				if requests == 1 {
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(&failingReader{strings.NewReader(file[:5]), io.ErrUnexpectedEOF})}
+				}
+
+				if req.Header.Get("Range") != "bytes=5-" {
+					return &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Body: http.NoBody}
+				}
+
+				return &http.Response{StatusCode: http.StatusPartialContent, Body: io.NopCloser(strings.NewReader(file[5:]))}
+			},
+			file,
+			"",
+			2,
+		},
+		{
+			"earlier download is resumed",
+			clipJSON,
+			4,
+			func(requests int, req *http.Request) *http.Response {
+				if req.Header.Get("Range") != "bytes=4-" {
+					return &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Body: http.NoBody}
+				}
+
+				return &http.Response{StatusCode: http.StatusPartialContent, Body: io.NopCloser(strings.NewReader(file[4:]))}
+			},
+			file[4:],
+			"",
+			1,
+		},
+		{
+			"ignored range is skipped",
+			clipJSON,
+			4,
+			func(requests int, req *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(file))}
+			},
+			file[4:],
+			"",
+			1,
+		},
+		{
+			"already downloaded",
+			clipJSON,
+			int64(len(file)),
+			func(requests int, req *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusRequestedRangeNotSatisfiable, Body: http.NoBody}
+			},
+			"",
+			"",
+			1,
+		},
+		{
+			"missing file is not retried",
+			clipJSON,
+			0,
+			func(requests int, req *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: http.NoBody}
+			},
+			"",
+			"error: could not download clip: 404 Not Found",
+			1,
+		},
+		{
+			"failing server is retried",
+			clipJSON,
+			0,
+			func(requests int, req *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Body: http.NoBody}
+			},
+			"",
+			"error: could not download clip: 503 Service Unavailable",
+			DefaultClipDownloadRetries + 1,
+		},
+		{
+			"clip not found",
+			`{"data":[]}`,
+			0,
+			nil,
+			"",
+			"error: clip AwkwardHelplessSalamanderSwiftRage not found",
+			0,
+		},
+		{
+			"clip without download url",
+			`{"data":[{"clip_id":"AwkwardHelplessSalamanderSwiftRage","landscape_download_url":null,"portrait_download_url":null}]}`,
+			0,
+			nil,
+			"",
+			"error: clip AwkwardHelplessSalamanderSwiftRage can't be downloaded",
+			0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		requests := 0
+		c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "my-user-access-token", Sleeper: newFakeClock()}, nil)
+		c.opts.HTTPClient = &clipHTTPClient{
+			api: &mockHTTPClient{newMockHandler(http.StatusOK, testCase.clipJSON, nil)},
+			serveFile: func(req *http.Request) *http.Response {
+				requests++
+				if req.Header.Get("Authorization") != "" || req.Header.Get("Client-Id") != "" {
+					t.Errorf("%s: expected no credentials to be sent with the file request", testCase.name)
+				}
+				return testCase.serveFile(requests, req)
+			},
+		}
+
+		var buf bytes.Buffer
+		n, err := c.DownloadClip(context.Background(), &DownloadClipParams{
+			EditorID:      "141981764",
+			BroadcasterID: "141981764",
+			ClipID:        "AwkwardHelplessSalamanderSwiftRage",
+			Offset:        testCase.offset,
+		}, &buf)
+
+		if testCase.expectedErr != "" {
+			if err == nil || err.Error() != testCase.expectedErr {
+				t.Errorf("%s: expected error %q, got %v", testCase.name, testCase.expectedErr, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: expected no error, got %v", testCase.name, err)
+		}
+
+		if buf.String() != testCase.expected || n != int64(len(testCase.expected)) {
+			t.Errorf("%s: expected %q to be written, got %q (%d bytes)", testCase.name, testCase.expected, buf.String(), n)
+		}
+
+		if requests != testCase.requests {
+			t.Errorf("%s: expected %d file requests, got %d", testCase.name, testCase.requests, requests)
+		}
+	}
+
+	// Test missing clip id
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, clipJSON, nil))
+	_, err := c.DownloadClip(context.Background(), &DownloadClipParams{EditorID: "141981764", BroadcasterID: "141981764"}, io.Discard)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "clip_id" {
+		t.Errorf("expected a validation error of clip_id, got %v", err)
+	}
+
+	// Test writer errors are not retried
+	writes := 0
+	c = newMockClient(&Options{ClientID: "my-client-id"}, nil)
+	c.opts.HTTPClient = &clipHTTPClient{
+		api: &mockHTTPClient{newMockHandler(http.StatusOK, clipJSON, nil)},
+		serveFile: func(req *http.Request) *http.Response {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(file))}
+		},
+	}
+	_, err = c.DownloadClip(context.Background(), &DownloadClipParams{EditorID: "141981764", BroadcasterID: "141981764", ClipID: "AwkwardHelplessSalamanderSwiftRage"}, writerFunc(func(p []byte) (int, error) {
+		writes++
+		return 0, errors.New("disk full")
+	}))
+	if err == nil || err.Error() != "disk full" || writes != 1 {
+		t.Errorf("expected a single failed write, got %v after %d writes", err, writes)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.DownloadClip(context.Background(), &DownloadClipParams{EditorID: "141981764", BroadcasterID: "141981764", ClipID: "AwkwardHelplessSalamanderSwiftRage"}, io.Discard)
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error(fmt.Sprintf("expected error does match return error, got '%s'", err.Error()))
+	}
+}
+
+func TestDownloadClipCancelled(t *testing.T) {
+	t.Parallel()
+
+	clipJSON := `{"data":[{"clip_id":"AwkwardHelplessSalamanderSwiftRage","landscape_download_url":"` + clipDownloadFileURL + `","portrait_download_url":""}]}`
+	params := &DownloadClipParams{EditorID: "141981764", BroadcasterID: "141981764", ClipID: "AwkwardHelplessSalamanderSwiftRage"}
+
+	// A cancelled download isn't resumed
+	ctx, cancel := context.WithCancel(context.Background())
+	requests := 0
+	c := newMockClient(&Options{ClientID: "my-client-id", Sleeper: newFakeClock()}, nil)
+	c.opts.HTTPClient = &clipHTTPClient{
+		api: &mockHTTPClient{newMockHandler(http.StatusOK, clipJSON, nil)},
+		serveFile: func(req *http.Request) *http.Response {
+			requests++
+			if req.Context() != ctx {
+				t.Error("expected the file request to use ctx")
+			}
+
+			// The connection is interrupted by the cancellation
+			cancel()
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(&failingReader{strings.NewReader("01234"), context.Canceled})}
+		},
+	}
+
+	var buf bytes.Buffer
+	n, err := c.DownloadClip(ctx, params, &buf)
+	if err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+	if n != 5 || buf.String() != "01234" {
+		t.Errorf("expected the 5 bytes written before the cancellation to be reported, got %d", n)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 file request, got %d", requests)
+	}
+
+	// Nothing is requested once ctx is done
+	c.opts.HTTPClient = &clipHTTPClient{
+		api: &mockHTTPClient{func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request after ctx is done")
+		}},
+	}
+	if _, err := c.DownloadClip(ctx, params, io.Discard); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
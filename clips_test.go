@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetClips(t *testing.T) {
@@ -203,3 +204,120 @@ func TestCreateClip(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestGetClipsFilters(t *testing.T) {
+	t.Parallel()
+
+	startedAt := Time{time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	endedAt := Time{time.Date(2023, time.March, 8, 0, 0, 0, 0, time.UTC)}
+	isFeatured := true
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("started_at") != "2023-03-01T00:00:00Z" {
+			t.Errorf("expected started_at query param to be %s, got %s", "2023-03-01T00:00:00Z", query.Get("started_at"))
+		}
+
+		if query.Get("ended_at") != "2023-03-08T00:00:00Z" {
+			t.Errorf("expected ended_at query param to be %s, got %s", "2023-03-08T00:00:00Z", query.Get("ended_at"))
+		}
+
+		if query.Get("is_featured") != "true" {
+			t.Errorf("expected is_featured query param to be true, got %s", query.Get("is_featured"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"EncouragingPluckySlothSSSsss","broadcaster_id":"26490481","is_featured":true}],"pagination":{}}`))
+	})
+
+	resp, err := c.GetClips(&ClipsParams{
+		BroadcasterID: "26490481",
+		StartedAt:     startedAt,
+		EndedAt:       endedAt,
+		IsFeatured:    &isFeatured,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.Clips) != 1 || !resp.Data.Clips[0].IsFeatured {
+		t.Errorf("expected 1 featured clip, got %+v", resp.Data.Clips)
+	}
+
+	testCases := []struct {
+		params        *ClipsParams
+		validationErr string
+	}{
+		{
+			&ClipsParams{BroadcasterID: "26490481", First: 101},
+			"error: first must not be greater than 100",
+		},
+		{
+			&ClipsParams{IDs: make([]string, 101)},
+			"error: only 100 clip ids can be specified",
+		},
+		{
+			&ClipsParams{BroadcasterID: "26490481", EndedAt: endedAt},
+			"error: started at must be provided when ended at is set",
+		},
+		{
+			&ClipsParams{BroadcasterID: "26490481", StartedAt: endedAt, EndedAt: startedAt},
+			"error: ended at must not be before started at",
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := c.GetClips(testCase.params)
+		if err == nil || err.Error() != testCase.validationErr {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", testCase.validationErr, err)
+		}
+	}
+}
+
+func TestCreateClipHasDelay(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("has_delay") != "true" {
+			t.Errorf("expected has_delay query param to be true, got %s", r.URL.Query().Get("has_delay"))
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[{"id":"IronicHedonisticOryxSquadGoals","edit_url":"https://clips.twitch.tv/IronicHedonisticOryxSquadGoals/edit"}]}`))
+	})
+
+	_, err := c.CreateClip(&CreateClipParams{BroadcasterID: "26490481", HasDelay: true})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClipThumbnailURLWithSize(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		thumbnailURL string
+		resizedURL   string
+	}{
+		{
+			"https://clips-media-assets2.twitch.tv/182509178-preview-480x272.jpg",
+			"https://clips-media-assets2.twitch.tv/182509178-preview-260x147.jpg",
+		},
+		{
+			"https://clips-media-assets2.twitch.tv/AT-cm%7C182509178-preview-480x272.jpg",
+			"https://clips-media-assets2.twitch.tv/AT-cm%7C182509178-preview-260x147.jpg",
+		},
+		{
+			"https://static-cdn.jtvnw.net/twitch-clips-thumbnails-prod/EncouragingPluckySlothSSSsss/preview.jpg",
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		clip := &Clip{ThumbnailURL: testCase.thumbnailURL}
+
+		if url := clip.ThumbnailURLWithSize(260, 147); url != testCase.resizedURL {
+			t.Errorf("expected thumbnail url to be \"%s\", got \"%s\"", testCase.resizedURL, url)
+		}
+	}
+}
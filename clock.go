@@ -0,0 +1,90 @@
+package helix
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time to the client, see Options.Clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// TimerClock is a Clock that also runs the client's timers, such as the
+// keepalive timeout of EventSub websockets and the wait of a BatchLoader.
+// Timers use the system clock if Options.Clock doesn't implement it.
+type TimerClock interface {
+	Clock
+	// AfterFunc calls f in its own goroutine once d has passed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer started by TimerClock.AfterFunc, *time.Timer implements
+// it. Stop reports whether it stopped the timer before it fired.
+type Timer interface {
+	Stop() bool
+}
+
+// Sleeper waits between the polls and validations of the client, see
+// Options.Sleeper. Sleep returns ctx.Err() if ctx is done before d has
+// passed.
+type Sleeper interface {
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+type systemSleeper struct{}
+
+func (systemSleeper) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) now() time.Time {
+	if c.opts.Clock != nil {
+		return c.opts.Clock.Now()
+	}
+
+	return systemClock{}.Now()
+}
+
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if c.opts.Sleeper != nil {
+		return c.opts.Sleeper.Sleep(ctx, d)
+	}
+
+	return systemSleeper{}.Sleep(ctx, d)
+}
+
+func (c *Client) afterFunc(d time.Duration, f func()) Timer {
+	if clock, ok := c.opts.Clock.(TimerClock); ok {
+		return clock.AfterFunc(d, f)
+	}
+
+	return systemClock{}.AfterFunc(d, f)
+}
+
+// after returns a channel that is closed once d has passed, and the timer
+// that closes it.
+func (c *Client) after(d time.Duration) (<-chan struct{}, Timer) {
+	done := make(chan struct{})
+	timer := c.afterFunc(d, func() { close(done) })
+
+	return done, timer
+}
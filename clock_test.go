@@ -0,0 +1,304 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a TimerClock and Sleeper whose sleeps fast-forward its time.
+// Its timers fire when its time is advanced past them.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock    *fakeClock
+	deadline time.Time
+	f        func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.Advance(d)
+
+	c.mu.Lock()
+	c.sleeps = append(c.sleeps, d)
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{clock: c, deadline: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+
+	return timer
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+
+	var due []*fakeTimer
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(c.now) {
+			pending = append(pending, timer)
+		} else {
+			due = append(due, timer)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	for _, timer := range due {
+		go timer.f()
+	}
+}
+
+// pendingTimers returns the number of timers that haven't fired or been
+// stopped.
+func (c *fakeClock) pendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+func TestFakeClockDeviceCodeExpiry(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	options := &Options{
+		ClientID: "valid-client-id",
+		Clock:    clock,
+		Sleeper:  clock,
+	}
+
+	c := newMockClient(options, newMockHandler(http.StatusBadRequest, `{"status":400,"message":"authorization_pending"}`, nil))
+
+	_, err := c.WaitForDeviceToken(context.Background(), &DeviceCode{
+		DeviceCode: "valid-device-code",
+		ExpiresIn:  1800,
+		Interval:   5,
+	})
+	if err == nil || err.Error() != "error: device code expired before authorization was completed" {
+		t.Errorf("expected the device code to expire, got %v", err)
+	}
+
+	if len(clock.sleeps) != 360 || clock.sleeps[0] != 5*time.Second {
+		t.Errorf("expected 360 polls 5 seconds apart, got %d", len(clock.sleeps))
+	}
+}
+
+func TestFakeClockValidationInterval(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	validations := 0
+	clock := newFakeClock()
+	options := &Options{
+		ClientID:        "my-client-id",
+		UserAccessToken: "my-user-access-token",
+		Clock:           clock,
+		Sleeper:         clock,
+	}
+
+	c := newMockClient(options, func(w http.ResponseWriter, r *http.Request) {
+		validations++
+		if validations == 3 {
+			cancel()
+		}
+		w.Write([]byte(`{"client_id":"my-client-id","login":"twitchdev","scopes":[],"user_id":"141981764","expires_in":5520838}`))
+	})
+
+	err := c.ValidateUserAccessTokenPeriodically(ctx, 0, nil)
+	if err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	if validations != 3 || clock.Now().Sub(newFakeClock().Now()) != 2*UserAccessTokenValidationInterval {
+		t.Errorf("expected 3 validations over 2 hours, got %d over %v", validations, clock.Now().Sub(newFakeClock().Now()))
+	}
+}
+
+func TestFakeClockCacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	clock := newFakeClock()
+	options := &Options{
+		ClientID:  "my-client-id",
+		Cache:     NewLRUCache(10),
+		CacheTTLs: map[string]time.Duration{"/users": time.Minute},
+		Clock:     clock,
+	}
+
+	c := newMockClient(options, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	for _, advance := range []time.Duration{0, 30 * time.Second, 31 * time.Second, 0} {
+		clock.Advance(advance)
+		if _, err := c.GetUsers(&UsersParams{IDs: []string{"26301881"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("expected 2 requests to be sent, got %d", requests)
+	}
+}
+
+func TestFakeClockRateLimitReset(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	reset := clock.Now().Add(30 * time.Second).Unix()
+
+	c := newMockClient(&Options{ClientID: "my-client-id", Clock: clock}, newMockHandler(http.StatusTooManyRequests, `{"error":"Too Many Requests","status":429,"message":"Request limit exceeded"}`, map[string]string{
+		"Ratelimit-Limit":     "800",
+		"Ratelimit-Remaining": "0",
+		"Ratelimit-Reset":     strconv.FormatInt(reset, 10),
+	}))
+
+	resp, err := c.GetUsers(&UsersParams{IDs: []string{"26301881"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The reset is long past by the system clock, only the fake one tells
+	// how long is left
+	var rateLimitErr *RateLimitError
+	if !errors.As(resp.Err(), &rateLimitErr) || rateLimitErr.RetryAfter != 30*time.Second {
+		t.Errorf("expected to retry after 30s, got %v", resp.Err())
+	}
+}
+
+func TestFakeClockBatchWait(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	clock := newFakeClock()
+	c := newMockClient(&Options{ClientID: "my-client-id", Clock: clock}, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"data":[{"id":"26301881","login":"sodapoppin"}]}`))
+	})
+	loader := c.NewBatchLoader(time.Second)
+
+	loaded := make(chan *User)
+	go func() {
+		user, err := loader.LoadUser("26301881")
+		if err != nil {
+			t.Error(err)
+		}
+		loaded <- user
+	}()
+
+	waitFor(t, func() bool { return clock.pendingTimers() == 1 })
+
+	clock.Advance(time.Second - time.Millisecond)
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Errorf("expected the batch to wait, got %d requests", requests)
+	}
+
+	clock.Advance(time.Millisecond)
+	if user := <-loaded; user == nil || user.Login != "sodapoppin" {
+		t.Errorf("unexpected user %+v", user)
+	}
+}
+
+func TestFakeClockWebsocketKeepalive(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	c := newMockClient(&Options{ClientID: "my-client-id", Clock: clock, Sleeper: clock}, nil)
+
+	ws := c.NewEventSubWebsocketClient(NewEventSubRouter())
+	ws.MaxRetries = 1
+	ws.Dial = func(ctx context.Context, url string) (EventSubWebsocketConn, error) {
+		return newFakeWebsocketConn(true, websocketWelcome("session")), nil
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- ws.Run(context.Background())
+	}()
+
+	// Only the keepalive timer is left once the session is welcomed
+	waitFor(t, func() bool {
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+
+		return ws.sessionID != "" && clock.pendingTimers() == 1
+	})
+
+	clock.Advance(15*time.Second - time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("expected the connection to be kept alive, got %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Millisecond)
+	if err := <-done; err == nil || err.Error() != "error: could not connect to eventsub websocket: error: eventsub websocket keepalive timed out" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// waitFor polls cond until it holds, failing the test if it doesn't within a
+// few seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,382 @@
+// Command helix manages EventSub subscriptions from the command line, using
+// the same code paths as the library. It lists, creates and deletes
+// subscriptions, validates access tokens and prints the events received over
+// an EventSub websocket, which helps debugging subscriptions in production.
+//
+// Credentials are read from the environment:
+//
+//	HELIX_CLIENT_ID          client ID of the application (required)
+//	HELIX_CLIENT_SECRET      client secret, used to refresh tokens
+//	HELIX_APP_ACCESS_TOKEN   app access token, for webhook subscriptions
+//	HELIX_USER_ACCESS_TOKEN  user access token, for websocket subscriptions
+//	HELIX_API_BASE_URL       overrides the API base URL, e.g. for a mock server
+//	HELIX_AUTH_BASE_URL      overrides the authentication base URL
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+)
+
+const usage = `usage: helix <command> [flags]
+
+commands:
+  list      list EventSub subscriptions
+  create    create an EventSub subscription
+  delete    delete EventSub subscriptions by ID
+  validate  validate an access token
+  tail      print events received over an EventSub websocket
+
+Run "helix <command> -h" for the flags of a command.
+`
+
+// errUsage is returned for invalid arguments, after the usage was printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
+
+// run executes a command and returns the exit code.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	commands := map[string]func(context.Context, *helix.Client, []string, io.Writer, io.Writer) error{
+		"list":     list,
+		"create":   create,
+		"delete":   remove,
+		"validate": validate,
+		"tail":     tail,
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	client, err := newClient(getenv)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if err := command(ctx, client, args[1:], stdout, stderr); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func newClient(getenv func(string) string) (*helix.Client, error) {
+	if getenv("HELIX_CLIENT_ID") == "" {
+		return nil, errors.New("error: HELIX_CLIENT_ID must be set")
+	}
+
+	return helix.NewClient(&helix.Options{
+		ClientID:        getenv("HELIX_CLIENT_ID"),
+		ClientSecret:    getenv("HELIX_CLIENT_SECRET"),
+		AppAccessToken:  getenv("HELIX_APP_ACCESS_TOKEN"),
+		UserAccessToken: getenv("HELIX_USER_ACCESS_TOKEN"),
+		APIBaseURL:      getenv("HELIX_API_BASE_URL"),
+		AuthBaseURL:     getenv("HELIX_AUTH_BASE_URL"),
+	})
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet("helix "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	return flags
+}
+
+// stringsFlag is a flag that may be given more than once.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// parseCondition builds a condition from key=value pairs, the keys are the
+// JSON names of the condition fields, such as broadcaster_user_id.
+func parseCondition(pairs []string) (helix.EventSubCondition, error) {
+	fields := map[string]string{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return helix.EventSubCondition{}, fmt.Errorf("error: condition %q must be key=value", pair)
+		}
+		fields[key] = value
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return helix.EventSubCondition{}, err
+	}
+
+	var condition helix.EventSubCondition
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&condition); err != nil {
+		return helix.EventSubCondition{}, fmt.Errorf("error: invalid condition: %w", err)
+	}
+
+	return condition, nil
+}
+
+func list(ctx context.Context, client *helix.Client, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("list", stderr)
+	status := flags.String("status", "", "only list subscriptions with this status")
+	subType := flags.String("type", "", "only list subscriptions of this type")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tVERSION\tSTATUS\tCOST\tTRANSPORT")
+
+	params := &helix.EventSubSubscriptionsParams{Status: helix.EventSubSubscriptionStatus(*status), Type: *subType}
+	for {
+		resp, err := client.GetEventSubSubscriptions(params)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("error: could not get eventsub subscriptions: %w", resp.Err())
+		}
+
+		for _, sub := range resp.Data.EventSubSubscriptions {
+			transport := sub.Transport.Callback
+			if sub.Transport.Method == "websocket" {
+				transport = "websocket " + sub.Transport.SessionID
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", sub.ID, sub.Type, sub.Version, sub.Status, sub.Cost, transport)
+		}
+
+		if resp.Data.Pagination.Cursor == "" {
+			break
+		}
+		params.After = resp.Data.Pagination.Cursor
+	}
+
+	return w.Flush()
+}
+
+func create(ctx context.Context, client *helix.Client, args []string, stdout, stderr io.Writer) error {
+	var conditions stringsFlag
+
+	flags := newFlagSet("create", stderr)
+	subType := flags.String("type", "", "subscription type, e.g. channel.follow (required)")
+	version := flags.String("version", "", "subscription version, defaults to the latest known version")
+	callback := flags.String("callback", "", "webhook callback URL")
+	secret := flags.String("secret", "", "webhook secret, generated if empty")
+	session := flags.String("session", "", "websocket session ID, instead of a callback")
+	flags.Var(&conditions, "condition", "condition as key=value, e.g. broadcaster_user_id=1234 (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *subType == "" || (*callback == "") == (*session == "") {
+		fmt.Fprintln(stderr, "-type and either -callback or -session are required")
+		flags.Usage()
+		return errUsage
+	}
+
+	condition, err := parseCondition(conditions)
+	if err != nil {
+		return err
+	}
+
+	sub := &helix.EventSubSubscription{
+		Type:      *subType,
+		Version:   *version,
+		Condition: condition,
+		Transport: helix.EventSubTransport{
+			Method:    "websocket",
+			SessionID: *session,
+		},
+	}
+
+	if *callback != "" {
+		sub.Transport = helix.EventSubTransport{
+			Method:   "webhook",
+			Callback: *callback,
+			Secret:   *secret,
+		}
+
+		if *secret == "" {
+			if sub.Transport.Secret, err = helix.GenerateEventSubSecret(); err != nil {
+				return err
+			}
+			fmt.Fprintf(stderr, "generated secret %s\n", sub.Transport.Secret)
+		}
+	}
+
+	resp, err := client.CreateEventSubSubscription(sub)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("error: could not create %s eventsub subscription: %w", sub.Type, resp.Err())
+	}
+
+	for _, created := range resp.Data.EventSubSubscriptions {
+		fmt.Fprintf(stdout, "%s\t%s\n", created.ID, created.Status)
+	}
+
+	return nil
+}
+
+func remove(ctx context.Context, client *helix.Client, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("delete", stderr)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		fmt.Fprintln(stderr, "at least one subscription ID is required")
+		return errUsage
+	}
+
+	for _, id := range flags.Args() {
+		resp, err := client.RemoveEventSubSubscription(id)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusNoContent {
+			return fmt.Errorf("error: could not remove eventsub subscription %s: %w", id, resp.Err())
+		}
+
+		fmt.Fprintf(stdout, "deleted %s\n", id)
+	}
+
+	return nil
+}
+
+func validate(ctx context.Context, client *helix.Client, args []string, stdout, stderr io.Writer) error {
+	flags := newFlagSet("validate", stderr)
+	token := flags.String("token", "", "token to validate, defaults to the user access token, then the app access token")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	accessToken := *token
+	if accessToken == "" {
+		accessToken = client.GetUserAccessToken()
+	}
+	if accessToken == "" {
+		accessToken = client.GetAppAccessToken()
+	}
+	if accessToken == "" {
+		return errors.New("error: no access token to validate")
+	}
+
+	isValid, resp, err := client.ValidateToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	if !isValid {
+		return fmt.Errorf("error: token is invalid: %w", resp.Err())
+	}
+
+	details := resp.Data
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "client id:\t%s\n", details.ClientID)
+	if details.UserID != "" {
+		fmt.Fprintf(w, "user:\t%s (%s)\n", details.Login, details.UserID)
+	}
+	fmt.Fprintf(w, "scopes:\t%s\n", strings.Join(details.Scopes, " "))
+	fmt.Fprintf(w, "expires in:\t%s\n", time.Duration(details.ExpiresIn)*time.Second)
+
+	return w.Flush()
+}
+
+func tail(ctx context.Context, client *helix.Client, args []string, stdout, stderr io.Writer) error {
+	var types, conditions stringsFlag
+
+	flags := newFlagSet("tail", stderr)
+	version := flags.String("version", "", "subscription version, defaults to the latest known version of each type")
+	url := flags.String("url", helix.DefaultEventSubWebsocketURL, "websocket URL")
+	flags.Var(&types, "type", "subscription type, e.g. channel.follow (required, repeatable)")
+	flags.Var(&conditions, "condition", "condition as key=value, shared by all types (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(types) == 0 {
+		fmt.Fprintln(stderr, "at least one -type is required")
+		flags.Usage()
+		return errUsage
+	}
+
+	condition, err := parseCondition(conditions)
+	if err != nil {
+		return err
+	}
+
+	router := helix.NewEventSubRouter()
+	router.OnUnhandled(func(event json.RawMessage, sub helix.EventSubSubscription) {
+		fmt.Fprintf(stdout, "%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), sub.Type, event)
+	})
+	router.OnRevocation(func(sub helix.EventSubSubscription) {
+		fmt.Fprintf(stderr, "revoked %s subscription %s: %s\n", sub.Type, sub.ID, sub.Status)
+	})
+
+	ws := client.NewEventSubWebsocketClient(router)
+	ws.URL = *url
+	ws.OnResubscribe(func(session helix.EventSubWebsocketSession) {
+		fmt.Fprintf(stderr, "resubscribed with session %s\n", session.ID)
+	})
+	ws.OnError(func(err error) {
+		fmt.Fprintln(stderr, err)
+	})
+
+	for _, subType := range types {
+		if err := ws.Subscribe(helix.EventSubSubscription{
+			Type:      subType,
+			Version:   *version,
+			Condition: condition,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := ws.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nicklaw5/helix/v2/helixmock"
+)
+
+func newTestEnv(t *testing.T) (*helixmock.Server, func(string) string) {
+	s := helixmock.NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddAppAccessToken("my-app-access-token")
+
+	env := map[string]string{
+		"HELIX_CLIENT_ID":        s.ClientID,
+		"HELIX_APP_ACCESS_TOKEN": "my-app-access-token",
+		"HELIX_API_BASE_URL":     s.URL + "/helix",
+		"HELIX_AUTH_BASE_URL":    s.URL + "/oauth2",
+	}
+
+	return s, func(key string) string { return env[key] }
+}
+
+func runCommand(getenv func(string) string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, getenv, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestSubscriptionCommands(t *testing.T) {
+	t.Parallel()
+
+	s, getenv := newTestEnv(t)
+
+	code, stdout, stderr := runCommand(getenv, "create",
+		"-type", "channel.follow",
+		"-condition", "broadcaster_user_id=1337",
+		"-condition", "moderator_user_id=1337",
+		"-callback", "https://example.com/eventsub",
+	)
+	if code != 0 {
+		t.Fatalf("expected create to succeed, got %d: %s", code, stderr)
+	}
+
+	if !strings.HasPrefix(stderr, "generated secret ") {
+		t.Errorf("expected the generated secret to be printed, got %q", stderr)
+	}
+
+	subs := s.EventSubSubscriptions()
+	if len(subs) != 1 || subs[0].Version != "2" || subs[0].Condition.ModeratorUserID != "1337" {
+		t.Fatalf("expected a channel.follow subscription to be created, got %+v", subs)
+	}
+
+	if !strings.HasPrefix(stdout, subs[0].ID) {
+		t.Errorf("expected the subscription ID to be printed, got %q", stdout)
+	}
+
+	code, stdout, _ = runCommand(getenv, "list", "-type", "channel.follow")
+	if code != 0 || !strings.Contains(stdout, subs[0].ID+"  channel.follow  2        enabled  1     https://example.com/eventsub") {
+		t.Errorf("expected the subscription to be listed, got %d: %q", code, stdout)
+	}
+
+	code, stdout, _ = runCommand(getenv, "delete", subs[0].ID)
+	if code != 0 || stdout != "deleted "+subs[0].ID+"\n" {
+		t.Errorf("expected the subscription to be deleted, got %d: %q", code, stdout)
+	}
+
+	code, _, stderr = runCommand(getenv, "delete", subs[0].ID)
+	if code != 1 || !strings.Contains(stderr, "404 Not Found: subscription not found") {
+		t.Errorf("expected deleting an unknown subscription to fail, got %d: %q", code, stderr)
+	}
+}
+
+func TestInvalidUsage(t *testing.T) {
+	t.Parallel()
+
+	_, getenv := newTestEnv(t)
+
+	testCases := []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{nil, 2, "usage: helix <command> [flags]"},
+		{[]string{"unknown"}, 2, `unknown command "unknown"`},
+		{[]string{"create", "-type", "channel.follow"}, 2, "-type and either -callback or -session are required"},
+		{[]string{"create", "-type", "channel.follow", "-session", "abc", "-condition", "broadcaster_user_id"}, 1, `error: condition "broadcaster_user_id" must be key=value`},
+		{[]string{"create", "-type", "channel.follow", "-session", "abc", "-condition", "channel=1"}, 1, `error: invalid condition: json: unknown field "channel"`},
+		{[]string{"delete"}, 2, "at least one subscription ID is required"},
+		{[]string{"tail"}, 2, "at least one -type is required"},
+	}
+
+	for _, testCase := range testCases {
+		code, _, stderr := runCommand(getenv, testCase.args...)
+		if code != testCase.code || !strings.Contains(stderr, testCase.stderr) {
+			t.Errorf("expected %v to exit with %d and print %q, got %d: %q", testCase.args, testCase.code, testCase.stderr, code, stderr)
+		}
+	}
+
+	code, _, stderr := runCommand(func(string) string { return "" }, "list")
+	if code != 1 || stderr != "error: HELIX_CLIENT_ID must be set\n" {
+		t.Errorf("expected a missing client ID to fail, got %d: %q", code, stderr)
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	t.Parallel()
+
+	_, getenv := newTestEnv(t)
+
+	code, stdout, stderr := runCommand(getenv, "validate")
+	if code != 0 || !strings.Contains(stdout, "client id:   helixmock-client-id") || !strings.Contains(stdout, "expires in:  4h0m0s") {
+		t.Errorf("expected the token to be valid, got %d: %q %q", code, stdout, stderr)
+	}
+
+	code, _, stderr = runCommand(getenv, "validate", "-token", "unknown-token")
+	if code != 1 || stderr != "error: token is invalid: 401 Unauthorized: invalid access token\n" {
+		t.Errorf("expected an unknown token to be invalid, got %d: %q", code, stderr)
+	}
+}
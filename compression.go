@@ -0,0 +1,72 @@
+package helix
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is the Accept-Encoding header of requests, unless
+// Options.DisableCompression is set.
+const acceptEncoding = "gzip, deflate"
+
+// decompress asks for a compressed response and decompresses it, unless the
+// request already set its own Accept-Encoding, in which case the response is
+// left as is. net/http only does this for gzip, and only with its own
+// transport, so it is done here for every HTTPClient.
+func (c *Client) decompress(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.opts.DisableCompression || req.Header.Get("Accept-Encoding") != "" {
+			return next(req)
+		}
+
+		// Removed again so that a retry of the request is compressed too
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		response, err := next(req)
+		req.Header.Del("Accept-Encoding")
+		if err != nil {
+			return nil, err
+		}
+
+		var body io.ReadCloser
+		switch strings.ToLower(response.Header.Get("Content-Encoding")) {
+		case "gzip":
+			body, err = gzip.NewReader(response.Body)
+		case "deflate":
+			body, err = zlib.NewReader(response.Body)
+		default:
+			return response, nil
+		}
+		switch {
+		case err == io.EOF:
+			// Nothing was compressed, e.g. the body of a 304 Not Modified
+			response.Body.Close()
+			response.Body = http.NoBody
+		case err != nil:
+			response.Body.Close()
+			return nil, err
+		default:
+			response.Body = &decompressedBody{ReadCloser: body, compressed: response.Body}
+		}
+
+		response.Header.Del("Content-Encoding")
+		response.Header.Del("Content-Length")
+		response.ContentLength = -1
+		response.Uncompressed = true
+
+		return response, nil
+	}
+}
+
+// decompressedBody closes both the decompressor and the compressed body.
+type decompressedBody struct {
+	io.ReadCloser
+	compressed io.ReadCloser
+}
+
+func (b *decompressedBody) Close() error {
+	b.ReadCloser.Close()
+	return b.compressed.Close()
+}
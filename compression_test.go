@@ -0,0 +1,119 @@
+package helix
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	body := `{"data":[{"id":"1234","login":"summit1g"}]}`
+
+	compress := func(encoding string) string {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		default:
+			return body
+		}
+		w.Write([]byte(body))
+		w.Close()
+
+		return buf.String()
+	}
+
+	testCases := []struct {
+		encoding           string
+		disableCompression bool
+		acceptEncoding     string
+	}{
+		{"gzip", false, "gzip, deflate"},
+		{"deflate", false, "gzip, deflate"},
+		{"", false, "gzip, deflate"},
+		{"", true, ""},
+	}
+
+	for _, testCase := range testCases {
+		var acceptEncoding string
+		c := newMockClient(&Options{ClientID: "my-client-id", DisableCompression: testCase.disableCompression}, func(w http.ResponseWriter, r *http.Request) {
+			acceptEncoding = r.Header.Get("Accept-Encoding")
+			if testCase.encoding != "" {
+				w.Header().Set("Content-Encoding", testCase.encoding)
+			}
+			w.Write([]byte(compress(testCase.encoding)))
+		})
+
+		resp, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+		if err != nil {
+			t.Errorf("%q: %v", testCase.encoding, err)
+			continue
+		}
+
+		if acceptEncoding != testCase.acceptEncoding {
+			t.Errorf("expected Accept-Encoding %q, got %q", testCase.acceptEncoding, acceptEncoding)
+		}
+
+		if len(resp.Data.Users) != 1 || resp.Data.Users[0].Login != "summit1g" {
+			t.Errorf("%q: expected the response to be decoded, got %+v", testCase.encoding, resp.Data.Users)
+		}
+
+		if resp.Header.Get("Content-Encoding") != "" {
+			t.Errorf("%q: expected Content-Encoding to be removed, got %q", testCase.encoding, resp.Header.Get("Content-Encoding"))
+		}
+	}
+}
+
+func TestCompressionKeepsOwnAcceptEncoding(t *testing.T) {
+	t.Parallel()
+
+	var acceptEncoding string
+	c := newMockClient(&Options{
+		ClientID: "my-client-id",
+		Middleware: []Middleware{func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("Accept-Encoding", "identity")
+				return next(req)
+			}
+		}},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "identity")
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	resp, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if acceptEncoding != "identity" || resp.Header.Get("Content-Encoding") != "identity" {
+		t.Errorf("expected the Accept-Encoding of the middleware to be kept, got %q", acceptEncoding)
+	}
+}
+
+func TestCompressionEmptyBody(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := c.DeleteVideos(&DeleteVideosParams{IDs: []string{"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+}
@@ -0,0 +1,103 @@
+package helix
+
+// Statuses of a conduit shard
+const (
+	ConduitShardStatusEnabled                            = "enabled"
+	ConduitShardStatusWebhookCallbackVerificationPending = "webhook_callback_verification_pending"
+	ConduitShardStatusWebhookCallbackVerificationFailed  = "webhook_callback_verification_failed"
+	ConduitShardStatusNotificationFailuresExceeded       = "notification_failures_exceeded"
+	ConduitShardStatusWebsocketDisconnected              = "websocket_disconnected"
+	ConduitShardStatusWebsocketFailedPingPong            = "websocket_failed_ping_pong"
+	ConduitShardStatusWebsocketReceivedInboundTraffic    = "websocket_received_inbound_traffic"
+	ConduitShardStatusWebsocketConnectionUnused          = "websocket_connection_unused"
+	ConduitShardStatusWebsocketInternalError             = "websocket_internal_error"
+	ConduitShardStatusWebsocketNetworkTimeout            = "websocket_network_timeout"
+	ConduitShardStatusWebsocketNetworkError              = "websocket_network_error"
+	ConduitShardStatusWebsocketFailedToReconnect         = "websocket_failed_to_reconnect"
+)
+
+// ConduitShard is a shard of a conduit and the transport it sends events to.
+type ConduitShard struct {
+	ID        string                `json:"id"`
+	Status    string                `json:"status"`
+	Transport ConduitShardTransport `json:"transport"`
+}
+
+// Healthy reports whether Twitch can send events to the shard's transport.
+// Shards whose webhook callback is still being verified count as healthy.
+func (s *ConduitShard) Healthy() bool {
+	return s.Status == ConduitShardStatusEnabled || s.Status == ConduitShardStatusWebhookCallbackVerificationPending
+}
+
+// ConduitShardTransport is the transport of a conduit shard. Callback is
+// only set for webhooks, the session and connection times only for websockets.
+type ConduitShardTransport struct {
+	Method         string `json:"method"`
+	Callback       string `json:"callback,omitempty"`
+	SessionID      string `json:"session_id,omitempty"`
+	ConnectedAt    Time   `json:"connected_at"`
+	DisconnectedAt Time   `json:"disconnected_at"`
+}
+
+type GetConduitShardsParams struct {
+	ConduitID string `query:"conduit_id"` // required
+	Status    string `query:"status"`
+	After     string `query:"after"`
+}
+
+type ManyConduitShards struct {
+	Shards     []ConduitShard `json:"data"`
+	Pagination Pagination     `json:"pagination"`
+}
+
+type GetConduitShardsResponse = ResponseOf[ManyConduitShards]
+
+// GetConduitShards gets the shards of a conduit, optionally filtered by
+// status. Requires an app access token.
+func (c *Client) GetConduitShards(params *GetConduitShardsParams) (*GetConduitShardsResponse, error) {
+	if err := validateRequired("conduit_id", "conduit id", params.ConduitID); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyConduitShards](c, "/eventsub/conduits/shards", params)
+}
+
+// ConduitShardUpdate assigns a transport to a shard. Secret is required for
+// webhooks, SessionID for websockets.
+type ConduitShardUpdate struct {
+	ID        string            `json:"id"`
+	Transport EventSubTransport `json:"transport"`
+}
+
+type UpdateConduitShardsParams struct {
+	ConduitID string               `json:"conduit_id"`
+	Shards    []ConduitShardUpdate `json:"shards"`
+}
+
+// ConduitShardUpdateError describes a shard that couldn't be updated.
+type ConduitShardUpdateError struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+type ManyUpdatedConduitShards struct {
+	Shards []ConduitShard            `json:"data"`
+	Errors []ConduitShardUpdateError `json:"errors"`
+}
+
+type UpdateConduitShardsResponse = ResponseOf[ManyUpdatedConduitShards]
+
+// UpdateConduitShards assigns transports to shards of a conduit. Shards that
+// couldn't be updated are listed in Data.Errors. Requires an app access token.
+func (c *Client) UpdateConduitShards(params *UpdateConduitShardsParams) (*UpdateConduitShardsResponse, error) {
+	if err := validateRequired("conduit_id", "conduit id", params.ConduitID); err != nil {
+		return nil, err
+	}
+
+	if len(params.Shards) == 0 {
+		return nil, &ValidationError{Field: "shards", Message: "at least one shard must be specified"}
+	}
+
+	return patchAsJSONResponse[ManyUpdatedConduitShards](c, "/eventsub/conduits/shards", params)
+}
@@ -0,0 +1,142 @@
+package helix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultConduitShardCheckInterval is how often MonitorConduitShards checks
+// the shards of a conduit if no interval is given.
+const DefaultConduitShardCheckInterval = time.Minute
+
+// ConduitShardMonitorParams configures MonitorConduitShards.
+type ConduitShardMonitorParams struct {
+	ConduitID string        // required
+	Interval  time.Duration // DefaultConduitShardCheckInterval if zero
+
+	// Transport returns a healthy transport to reassign an unhealthy shard
+	// to, for example a connected websocket session. If it is nil or returns
+	// false, the shard is left alone.
+	Transport func(shard ConduitShard) (EventSubTransport, bool)
+
+	// OnUnhealthy is called with the shards that are still unhealthy after
+	// reassigning them, so the application can repair them itself.
+	OnUnhealthy func(shards []ConduitShard)
+
+	// OnError is called whenever getting or updating the shards fails.
+	OnError func(err error)
+}
+
+// MonitorConduitShards checks the shards of a conduit right away and then at
+// every interval. Shards whose websocket disconnected, whose webhook callback
+// failed verification or that are unhealthy for any other reason are
+// reassigned to the transports returned by params.Transport, and the
+// remaining ones are passed to params.OnUnhealthy.
+//
+// It blocks until ctx is done, so it is usually run in its own goroutine.
+func (c *Client) MonitorConduitShards(ctx context.Context, params *ConduitShardMonitorParams) error {
+	if err := validateRequired("conduit_id", "conduit id", params.ConduitID); err != nil {
+		return err
+	}
+
+	interval := params.Interval
+	if interval <= 0 {
+		interval = DefaultConduitShardCheckInterval
+	}
+
+	for {
+		unhealthy, err := c.checkConduitShards(params)
+		if err != nil && params.OnError != nil {
+			params.OnError(err)
+		}
+
+		if len(unhealthy) > 0 && params.OnUnhealthy != nil {
+			params.OnUnhealthy(unhealthy)
+		}
+
+		if err := c.sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// checkConduitShards reassigns the unhealthy shards of a conduit and returns
+// those that couldn't be reassigned.
+func (c *Client) checkConduitShards(params *ConduitShardMonitorParams) ([]ConduitShard, error) {
+	unhealthy, err := c.getUnhealthyConduitShards(params.ConduitID)
+	if err != nil || len(unhealthy) == 0 || params.Transport == nil {
+		return unhealthy, err
+	}
+
+	var remaining []ConduitShard
+	var updates []ConduitShardUpdate
+	for _, shard := range unhealthy {
+		transport, ok := params.Transport(shard)
+		if !ok {
+			remaining = append(remaining, shard)
+			continue
+		}
+
+		updates = append(updates, ConduitShardUpdate{ID: shard.ID, Transport: transport})
+	}
+
+	if len(updates) == 0 {
+		return remaining, nil
+	}
+
+	resp, err := c.UpdateConduitShards(&UpdateConduitShardsParams{
+		ConduitID: params.ConduitID,
+		Shards:    updates,
+	})
+	if err == nil && resp.StatusCode != http.StatusAccepted {
+		err = fmt.Errorf("error: could not update conduit shards: %w", resp.Err())
+	}
+
+	if err != nil {
+		// None of the shards were reassigned
+		return unhealthy, err
+	}
+
+	failed := make(map[string]bool, len(resp.Data.Errors))
+	for _, updateErr := range resp.Data.Errors {
+		failed[updateErr.ID] = true
+	}
+
+	for _, shard := range unhealthy {
+		if failed[shard.ID] {
+			remaining = append(remaining, shard)
+		}
+	}
+
+	return remaining, nil
+}
+
+// getUnhealthyConduitShards pages through the shards of a conduit.
+func (c *Client) getUnhealthyConduitShards(conduitID string) ([]ConduitShard, error) {
+	var unhealthy []ConduitShard
+
+	params := &GetConduitShardsParams{ConduitID: conduitID}
+	for {
+		resp, err := c.GetConduitShards(params)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("error: could not get conduit shards: %w", resp.Err())
+		}
+
+		for _, shard := range resp.Data.Shards {
+			if !shard.Healthy() {
+				unhealthy = append(unhealthy, shard)
+			}
+		}
+
+		if resp.Data.Pagination.Cursor == "" {
+			return unhealthy, nil
+		}
+		params.After = resp.Data.Pagination.Cursor
+	}
+}
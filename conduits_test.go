@@ -0,0 +1,246 @@
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetConduitShards(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		ConduitID  string
+		respBody   string
+		shards     int
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			"bfcfc993-26b1-b876-44d9-afe75a379dac",
+			`{"data":[{"id":"0","status":"enabled","transport":{"method":"webhook","callback":"https://this-is-a-callback.com"}},{"id":"1","status":"websocket_disconnected","transport":{"method":"websocket","session_id":"9fd5164a-a958-4c60-b7f4-6a7202506ca0","connected_at":"2020-11-10T14:32:18.730260295Z","disconnected_at":"2020-11-11T14:32:18.730260295Z"}}],"pagination":{}}`,
+			2,
+		},
+		{
+			http.StatusNotFound,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			"unknown-conduit",
+			`{"error":"Not Found","status":404,"message":"conduit not found"}`,
+			0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetConduitShards(&GetConduitShardsParams{ConduitID: testCase.ConduitID})
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Shards) != testCase.shards {
+			t.Errorf("expected %d shards, got %d", testCase.shards, len(resp.Data.Shards))
+		}
+
+		if testCase.shards > 0 && (!resp.Data.Shards[0].Healthy() || resp.Data.Shards[1].Healthy()) {
+			t.Errorf("expected only the first shard to be healthy, got %+v", resp.Data.Shards)
+		}
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, "", nil))
+	_, err := c.GetConduitShards(&GetConduitShardsParams{})
+	if err == nil || err.Error() != "error: conduit id must be specified" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.GetConduitShards(&GetConduitShardsParams{ConduitID: "bfcfc993-26b1-b876-44d9-afe75a379dac"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestUpdateConduitShards(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		params     *UpdateConduitShardsParams
+		respBody   string
+		errors     int
+	}{
+		{
+			http.StatusAccepted,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			&UpdateConduitShardsParams{
+				ConduitID: "bfcfc993-26b1-b876-44d9-afe75a379dac",
+				Shards: []ConduitShardUpdate{
+					{ID: "0", Transport: EventSubTransport{Method: "webhook", Callback: "https://this-is-a-callback.com", Secret: "s3cre7w0rd"}},
+					{ID: "3", Transport: EventSubTransport{Method: "websocket", SessionID: "8fd5164a-a958-4c60-b7f4-6a7202506ca0"}},
+				},
+			},
+			`{"data":[{"id":"0","status":"enabled","transport":{"method":"webhook","callback":"https://this-is-a-callback.com"}}],"errors":[{"id":"3","message":"The websocket session is not connected","code":"websocket_not_connected"}]}`,
+			1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.UpdateConduitShards(testCase.params)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Errors) != testCase.errors {
+			t.Errorf("expected %d errors, got %d", testCase.errors, len(resp.Data.Errors))
+		}
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, "", nil))
+	_, err := c.UpdateConduitShards(&UpdateConduitShardsParams{ConduitID: "bfcfc993-26b1-b876-44d9-afe75a379dac"})
+	if err == nil || err.Error() != "error: at least one shard must be specified" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.UpdateConduitShards(testCases[0].params)
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestMonitorConduitShards(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checks := 0
+	var updates []ConduitShardUpdate
+	clock := newFakeClock()
+	c := newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token", Sleeper: clock}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			var params UpdateConduitShardsParams
+			json.NewDecoder(r.Body).Decode(&params)
+			updates = append(updates, params.Shards...)
+
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"data":[{"id":"1","status":"enabled","transport":{"method":"websocket","session_id":"new-session"}}],"errors":[{"id":"2","message":"The websocket session is not connected","code":"websocket_not_connected"}]}`))
+			return
+		}
+
+		checks++
+		if checks == 1 && r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"data":[{"id":"0","status":"enabled","transport":{"method":"websocket","session_id":"session-0"}},{"id":"1","status":"websocket_disconnected","transport":{"method":"websocket","session_id":"session-1"}}],"pagination":{"cursor":"next"}}`))
+			return
+		}
+
+		if checks == 2 {
+			w.Write([]byte(`{"data":[{"id":"2","status":"websocket_network_timeout","transport":{"method":"websocket","session_id":"session-2"}},{"id":"3","status":"webhook_callback_verification_failed","transport":{"method":"webhook","callback":"https://this-is-a-callback.com"}}],"pagination":{}}`))
+			return
+		}
+
+		cancel()
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`))
+	})
+
+	var unhealthy []string
+	var errs []string
+	err := c.MonitorConduitShards(ctx, &ConduitShardMonitorParams{
+		ConduitID: "bfcfc993-26b1-b876-44d9-afe75a379dac",
+		Transport: func(shard ConduitShard) (EventSubTransport, bool) {
+			if shard.Transport.Method != "websocket" {
+				return EventSubTransport{}, false
+			}
+			return EventSubTransport{Method: "websocket", SessionID: "new-session"}, true
+		},
+		OnUnhealthy: func(shards []ConduitShard) {
+			for _, shard := range shards {
+				unhealthy = append(unhealthy, shard.ID)
+			}
+		},
+		OnError: func(err error) {
+			errs = append(errs, err.Error())
+		},
+	})
+	if err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	if len(updates) != 2 || updates[0].ID != "1" || updates[1].ID != "2" {
+		t.Errorf("expected shards 1 and 2 to be reassigned, got %+v", updates)
+	}
+
+	// Shard 2 couldn't be updated, shard 3 has no transport to move to
+	if len(unhealthy) != 2 || unhealthy[0] != "3" || unhealthy[1] != "2" {
+		t.Errorf("expected shards 3 and 2 to be reported, got %v", unhealthy)
+	}
+
+	if len(errs) != 1 || errs[0] != "error: could not get conduit shards: 401 Unauthorized: Invalid OAuth token" {
+		t.Errorf("unexpected errors %v", errs)
+	}
+}
+
+func TestMonitorConduitShardsWithoutConduitID(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	err := c.MonitorConduitShards(context.Background(), &ConduitShardMonitorParams{})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "conduit_id" {
+		t.Fatalf("expected a ValidationError for conduit_id, got %v", err)
+	}
+
+	if err.Error() != "error: conduit id must be specified" {
+		t.Errorf("unexpected error %q", err)
+	}
+}
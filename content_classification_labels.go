@@ -0,0 +1,40 @@
+package helix
+
+// Content classification label IDs. ContentClassificationLabelMatureGame is
+// applied automatically based on the game and can't be set.
+const (
+	ContentClassificationLabelDebatedSocialIssuesAndPolitics = "DebatedSocialIssuesAndPolitics"
+	ContentClassificationLabelDrugsIntoxication              = "DrugsIntoxication"
+	ContentClassificationLabelSexualThemes                   = "SexualThemes"
+	ContentClassificationLabelViolentGraphic                 = "ViolentGraphic"
+	ContentClassificationLabelGambling                       = "Gambling"
+	ContentClassificationLabelProfanityVulgarity             = "ProfanityVulgarity"
+	ContentClassificationLabelMatureGame                     = "MatureGame"
+)
+
+// ContentClassificationLabel describes a label that can be applied to a channel
+type ContentClassificationLabel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ManyContentClassificationLabels is the response data in GetContentClassificationLabelsResponse
+type ManyContentClassificationLabels struct {
+	Labels []ContentClassificationLabel `json:"data"`
+}
+
+// GetContentClassificationLabelsParams are the parameters for GetContentClassificationLabels
+type GetContentClassificationLabelsParams struct {
+	Locale string `query:"locale"` // Optional, defaults to "en-US"
+}
+
+// GetContentClassificationLabelsResponse is the response from GetContentClassificationLabels
+type GetContentClassificationLabelsResponse = ResponseOf[ManyContentClassificationLabels]
+
+// GetContentClassificationLabels gets information about Twitch content classification labels,
+// with the names and descriptions localized to the requested locale.
+// Requires an app access token or user access token.
+func (c *Client) GetContentClassificationLabels(params *GetContentClassificationLabelsParams) (*GetContentClassificationLabelsResponse, error) {
+	return getResponse[ManyContentClassificationLabels](c, "/content_classification_labels", params)
+}
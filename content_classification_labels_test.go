@@ -0,0 +1,84 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetContentClassificationLabels(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		params     *GetContentClassificationLabelsParams
+		respBody   string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetContentClassificationLabelsParams{},
+			`{"data":[{"id":"DrugsIntoxication","description":"Excessive tobacco glorification or promotion, any marijuana consumption/use, legal drug and alcohol induced intoxication, discussions of illegal drugs.","name":"Drugs, Intoxication, or Excessive Tobacco Use"},{"id":"Gambling","description":"Participating in online or in-person gambling, poker or fantasy sports, that involve the exchange of real money.","name":"Gambling"}]}`,
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetContentClassificationLabelsParams{Locale: "de-DE"},
+			`{"data":[{"id":"Gambling","description":"Teilnahme an Online- oder Offline-Glücksspielen, Poker oder Fantasy-Sport, bei denen echtes Geld eingesetzt wird.","name":"Glücksspiel"}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("locale") != testCase.params.Locale {
+				t.Errorf("expected locale query param to be %s, got %s", testCase.params.Locale, r.URL.Query().Get("locale"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.GetContentClassificationLabels(testCase.params)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Labels) == 0 {
+			t.Error("expected labels but got none")
+			continue
+		}
+
+		for _, label := range resp.Data.Labels {
+			if label.ID == "" || label.Name == "" {
+				t.Errorf("expected label id and name to be set, got %+v", label)
+			}
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetContentClassificationLabels(&GetContentClassificationLabelsParams{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
@@ -1,12 +1,28 @@
 package helix
 
+// Fulfillment statuses of an entitlement
+const (
+	EntitlementFulfillmentStatusClaimed   = "CLAIMED"
+	EntitlementFulfillmentStatusFulfilled = "FULFILLED"
+)
+
+// Statuses of an UpdatedEntitlementSet
+const (
+	EntitlementUpdateStatusSuccess      = "SUCCESS"
+	EntitlementUpdateStatusInvalidID    = "INVALID_ID"
+	EntitlementUpdateStatusNotFound     = "NOT_FOUND"
+	EntitlementUpdateStatusUnauthorized = "UNAUTHORIZED"
+	EntitlementUpdateStatusUpdateFailed = "UPDATE_FAILED"
+)
+
 type GetDropEntitlementsParams struct {
-	ID                string `query:"id"`
-	UserID            string `query:"user_id"`
-	GameID            string `query:"game_id"`
-	FulfillmentStatus string `query:"fulfillment_status"` // Valid values "CLAIMED", "FULFILLED"
-	After             string `query:"after"`
-	First             int    `query:"first,20"` // Limit 1000
+	ID                string   `query:"id"`
+	IDs               []string `query:"id"` // Limit 100, used together with ID
+	UserID            string   `query:"user_id"`
+	GameID            string   `query:"game_id"`
+	FulfillmentStatus string   `query:"fulfillment_status"` // Valid values "CLAIMED", "FULFILLED"
+	After             string   `query:"after"`
+	First             int      `query:"first,20"` // Limit 1000
 }
 
 type UpdateDropsEntitlementsParams struct {
@@ -14,6 +30,10 @@ type UpdateDropsEntitlementsParams struct {
 	FulfillmentStatus string   `json:"fulfillment_status"` // Valid values "CLAIMED", "FULFILLED"
 }
 
+func isValidEntitlementFulfillmentStatus(status string) bool {
+	return status == EntitlementFulfillmentStatusClaimed || status == EntitlementFulfillmentStatusFulfilled
+}
+
 type Entitlement struct {
 	ID                string `json:"id"`
 	BenefitID         string `json:"benefit_id"`
@@ -41,14 +61,21 @@ type ManyEntitlementsWithPagination struct {
 	Pagination `json:"pagination"`
 }
 
-type GetDropsEntitlementsResponse struct {
-	ResponseCommon
-	Data ManyEntitlementsWithPagination
-}
+type GetDropsEntitlementsResponse = ResponseOf[ManyEntitlementsWithPagination]
+
+type UpdateDropsEntitlementsResponse = ResponseOf[ManyUpdatedEntitlementSet]
+
+// IDsWithStatus returns the requested entitlement ids that were grouped under the given status,
+// e.g. EntitlementUpdateStatusUpdateFailed for the ids that should be retried.
+func (m *ManyUpdatedEntitlementSet) IDsWithStatus(status string) []string {
+	var ids []string
+	for _, set := range m.EntitlementSets {
+		if set.Status == status {
+			ids = append(ids, set.IDs...)
+		}
+	}
 
-type UpdateDropsEntitlementsResponse struct {
-	ResponseCommon
-	Data ManyUpdatedEntitlementSet
+	return ids
 }
 
 // GetDropsEntitlements returns a list of entitlements, which have been awarded to users by your organization.
@@ -59,17 +86,19 @@ type UpdateDropsEntitlementsResponse struct {
 // Entitlements are digital items that users are entitled to use. Twitch entitlements are granted based on viewership
 // engagement with a content creator, based on the game developers' campaign.
 func (c *Client) GetDropsEntitlements(params *GetDropEntitlementsParams) (*GetDropsEntitlementsResponse, error) {
-	resp, err := c.get("/entitlements/drops", &ManyEntitlementsWithPagination{}, params)
-	if err != nil {
+	if params.FulfillmentStatus != "" && !isValidEntitlementFulfillmentStatus(params.FulfillmentStatus) {
+		return nil, &ValidationError{Field: "fulfillment_status", Message: "fulfillment status must be CLAIMED or FULFILLED"}
+	}
+
+	if err := validateMaxValues("id", "entitlement ids", params.IDs, 100); err != nil {
 		return nil, err
 	}
 
-	entitlements := &GetDropsEntitlementsResponse{}
-	resp.HydrateResponseCommon(&entitlements.ResponseCommon)
-	entitlements.Data.Entitlements = resp.Data.(*ManyEntitlementsWithPagination).Entitlements
-	entitlements.Data.Pagination = resp.Data.(*ManyEntitlementsWithPagination).Pagination
+	if err := validateFirst(params.First, 1000); err != nil {
+		return nil, err
+	}
 
-	return entitlements, nil
+	return getResponse[ManyEntitlementsWithPagination](c, "/entitlements/drops", params)
 }
 
 // UpdateDropsEntitlements updates the fulfillment status of a set of entitlements, owned by the authenticated user or
@@ -82,14 +111,13 @@ func (c *Client) GetDropsEntitlements(params *GetDropEntitlementsParams) (*GetDr
 // Entitlements are digital items that users are entitled to use. Twitch entitlements are granted based on viewership
 // engagement with a content creator, based on the game developers' campaign.
 func (c *Client) UpdateDropsEntitlements(params *UpdateDropsEntitlementsParams) (*UpdateDropsEntitlementsResponse, error) {
-	resp, err := c.patchAsJSON("/entitlements/drops", &ManyUpdatedEntitlementSet{}, params)
-	if err != nil {
-		return nil, err
+	if len(params.EntitlementIDs) > 100 {
+		return nil, &ValidationError{Field: "entitlement_ids", Message: "only 100 entitlement ids can be updated at once"}
 	}
 
-	entitlementSets := &UpdateDropsEntitlementsResponse{}
-	resp.HydrateResponseCommon(&entitlementSets.ResponseCommon)
-	entitlementSets.Data.EntitlementSets = resp.Data.(*ManyUpdatedEntitlementSet).EntitlementSets
+	if params.FulfillmentStatus != "" && !isValidEntitlementFulfillmentStatus(params.FulfillmentStatus) {
+		return nil, &ValidationError{Field: "fulfillment_status", Message: "fulfillment status must be CLAIMED or FULFILLED"}
+	}
 
-	return entitlementSets, nil
+	return patchAsJSONResponse[ManyUpdatedEntitlementSet](c, "/entitlements/drops", params)
 }
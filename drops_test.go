@@ -217,6 +217,67 @@ func TestUpdateDropsEntitlements(t *testing.T) {
 	}
 }
 
+func TestDropsEntitlementsValidation(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query()["id"]
+		if len(ids) != 2 {
+			t.Errorf("expected 2 id query params, got %d", len(ids))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"pagination":{}}`))
+	})
+
+	_, err := c.GetDropsEntitlements(&GetDropEntitlementsParams{
+		IDs: []string{"fb78259e-fb81-4d1b-8333-34a06ffc24c0", "862750a5-265e-4ab6-9f0a-c64df3d54dd0"},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = c.GetDropsEntitlements(&GetDropEntitlementsParams{FulfillmentStatus: "PENDING"})
+	if err == nil || err.Error() != "error: fulfillment status must be CLAIMED or FULFILLED" {
+		t.Errorf("expected fulfillment status error, got %v", err)
+	}
+
+	_, err = c.GetDropsEntitlements(&GetDropEntitlementsParams{First: 1001})
+	if err == nil || err.Error() != "error: first must not be greater than 1000" {
+		t.Errorf("expected first error, got %v", err)
+	}
+
+	_, err = c.UpdateDropsEntitlements(&UpdateDropsEntitlementsParams{EntitlementIDs: make([]string, 101)})
+	if err == nil || err.Error() != "error: only 100 entitlement ids can be updated at once" {
+		t.Errorf("expected entitlement ids error, got %v", err)
+	}
+
+	_, err = c.UpdateDropsEntitlements(&UpdateDropsEntitlementsParams{FulfillmentStatus: "claimed"})
+	if err == nil || err.Error() != "error: fulfillment status must be CLAIMED or FULFILLED" {
+		t.Errorf("expected fulfillment status error, got %v", err)
+	}
+}
+
+func TestUpdatedEntitlementSetIDsWithStatus(t *testing.T) {
+	t.Parallel()
+
+	sets := &ManyUpdatedEntitlementSet{
+		EntitlementSets: []UpdatedEntitlementSet{
+			{Status: EntitlementUpdateStatusSuccess, IDs: []string{"a", "b"}},
+			{Status: EntitlementUpdateStatusUpdateFailed, IDs: []string{"c"}},
+			{Status: EntitlementUpdateStatusSuccess, IDs: []string{"d"}},
+		},
+	}
+
+	if ids := sets.IDsWithStatus(EntitlementUpdateStatusSuccess); len(ids) != 3 {
+		t.Errorf("expected 3 successful ids, got %d", len(ids))
+	}
+
+	if ids := sets.IDsWithStatus(EntitlementUpdateStatusNotFound); len(ids) != 0 {
+		t.Errorf("expected no not found ids, got %d", len(ids))
+	}
+}
+
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
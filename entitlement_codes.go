@@ -29,26 +29,18 @@ type ManyCodes struct {
 	Codes []CodeStatus `json:"data"`
 }
 
-type CodeResponse struct {
-	ResponseCommon
-	Data ManyCodes
-}
+type CodeResponse = ResponseOf[ManyCodes]
 
 // GetEntitlementCodeStatus
 // Per https://dev.twitch.tv/docs/api/reference#get-code-status
 // Access is controlled via an app access token on the calling service. The client ID associated with the app access token must be approved by Twitch as part of a contracted arrangement.
 // Callers with an app access token are authorized to redeem codes on behalf of any Twitch user account.
 func (c *Client) GetEntitlementCodeStatus(params *CodesParams) (*CodeResponse, error) {
-	resp, err := c.get("/entitlements/codes", &ManyCodes{}, params)
-	if err != nil {
+	if err := validateMaxValues("code", "codes", params.Codes, 20); err != nil {
 		return nil, err
 	}
 
-	codes := &CodeResponse{}
-	resp.HydrateResponseCommon(&codes.ResponseCommon)
-	codes.Data.Codes = resp.Data.(*ManyCodes).Codes
-
-	return codes, nil
+	return getResponse[ManyCodes](c, "/entitlements/codes", params)
 }
 
 // RedeemEntitlementCode
@@ -56,14 +48,5 @@ func (c *Client) GetEntitlementCodeStatus(params *CodesParams) (*CodeResponse, e
 // Access is controlled via an app access token on the calling service. The client ID associated with the app access token must be approved by Twitch.
 // Callers with an app access token are authorized to redeem codes on behalf of any Twitch user account.
 func (c *Client) RedeemEntitlementCode(params *CodesParams) (*CodeResponse, error) {
-	resp, err := c.post("/entitlements/code", &ManyCodes{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	codes := &CodeResponse{}
-	resp.HydrateResponseCommon(&codes.ResponseCommon)
-	codes.Data.Codes = resp.Data.(*ManyCodes).Codes
-
-	return codes, nil
+	return postResponse[ManyCodes](c, "/entitlements/code", params)
 }
@@ -13,10 +13,7 @@ type ManyEntitlementsUploadURLs struct {
 	URLs []EntitlementsUploadURL `json:"data"`
 }
 
-type EntitlementsUploadResponse struct {
-	ResponseCommon
-	Data ManyEntitlementsUploadURLs
-}
+type EntitlementsUploadResponse = ResponseOf[ManyEntitlementsUploadURLs]
 
 // CreateEntitlementsUploadURL return a URL where you can upload a manifest
 // file and notify users that they have an entitlement. Entitlements are digital
@@ -28,14 +25,5 @@ func (c *Client) CreateEntitlementsUploadURL(manifestID, entitlementType string)
 		Type:       entitlementType,
 	}
 
-	resp, err := c.post("/entitlements/upload", &ManyEntitlementsUploadURLs{}, data)
-	if err != nil {
-		return nil, err
-	}
-
-	url := &EntitlementsUploadResponse{}
-	resp.HydrateResponseCommon(&url.ResponseCommon)
-	url.Data.URLs = resp.Data.(*ManyEntitlementsUploadURLs).URLs
-
-	return url, nil
+	return postResponse[ManyEntitlementsUploadURLs](c, "/entitlements/upload", data)
 }
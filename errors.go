@@ -0,0 +1,194 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIError is a request that Twitch rejected, see ResponseCommon.Err.
+type APIError struct {
+	Status    int    // The HTTP status code, e.g. 400
+	Code      string // The "error" field of the response, e.g. "Bad Request"
+	Message   string // The "message" field of the response
+	RequestID string // The X-Request-Id header of the response, if any
+
+	response *ResponseCommon // The failed response, see keepFailedResponse
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.Status, e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+
+	return msg
+}
+
+// RateLimitError is a request that was rejected with 429 Too Many Requests.
+type RateLimitError struct {
+	*APIError
+
+	// RetryAfter is how long until the rate limit bucket is refilled,
+	// according to the Ratelimit-Reset header. Zero if the header is missing.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
+
+// AuthError is a request that was rejected with 401 Unauthorized, or that
+// wasn't sent because the user access token is known to lack the scopes the
+// endpoint requires, see Options.UserAccessTokenScopes.
+type AuthError struct {
+	*APIError // nil if the request wasn't sent
+
+	Endpoint       string   // The method and path of the request, e.g. "GET /subscriptions"
+	RequiredScopes []string // The scopes the token lacks, any one of them is enough
+}
+
+func (e *AuthError) Error() string {
+	if e.APIError != nil {
+		return e.APIError.Error()
+	}
+
+	if len(e.RequiredScopes) == 1 {
+		return fmt.Sprintf("error: %s requires a user access token with the %s scope", e.Endpoint, e.RequiredScopes[0])
+	}
+
+	return fmt.Sprintf("error: %s requires a user access token with one of the %s scopes", e.Endpoint, strings.Join(e.RequiredScopes, ", "))
+}
+
+func (e *AuthError) Unwrap() error {
+	if e.APIError == nil {
+		return nil
+	}
+
+	return e.APIError
+}
+
+// ValidationError is a request that wasn't sent because one of its
+// parameters is missing, conflicts with another one or breaks a limit that
+// Twitch would reject it for.
+type ValidationError struct {
+	Field   string // The query or JSON name of the invalid parameter, e.g. "tags"
+	Message string
+
+	// bare leaves out the "error: " prefix. Only checks whose error strings
+	// predate ValidationError set it, as callers may compare them.
+	bare bool
+}
+
+func (e *ValidationError) Error() string {
+	if e.bare {
+		return e.Message
+	}
+
+	return "error: " + e.Message
+}
+
+// EventSubCostError is an EventSub subscription that wasn't created because
+// the total cost of the existing subscriptions reached the maximum, see
+// Options.LimitEventSubCost.
+type EventSubCostError struct {
+	TotalCost    int
+	MaxTotalCost int
+}
+
+func (e *EventSubCostError) Error() string {
+	return fmt.Sprintf("error: eventsub subscriptions already cost %d of %d", e.TotalCost, e.MaxTotalCost)
+}
+
+// Err returns the error of a failed request, or nil if the request succeeded.
+// The error is a *RateLimitError for 429 responses, an *AuthError for 401
+// responses and an *APIError otherwise. Use errors.As to branch on them:
+//
+//	var rateLimitErr *helix.RateLimitError
+//	if errors.As(resp.Err(), &rateLimitErr) {
+//		time.Sleep(rateLimitErr.RetryAfter)
+//	}
+//
+// The *APIError can be unwrapped from all three. Set Options.ReturnAPIErrors
+// to have the client's methods return these errors instead of the response.
+func (rc *ResponseCommon) Err() error {
+	if rc.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	apiErr := &APIError{
+		Status:    rc.StatusCode,
+		Code:      rc.Error,
+		Message:   rc.ErrorMessage,
+		RequestID: rc.RequestID,
+	}
+	if apiErr.Code == "" {
+		apiErr.Code = http.StatusText(rc.StatusCode)
+	}
+
+	switch rc.StatusCode {
+	case http.StatusTooManyRequests:
+		rateLimitErr := &RateLimitError{APIError: apiErr}
+		if reset := rc.GetRateLimitReset(); reset > 0 {
+			// Responses the client received are timed by its clock
+			receivedAt := rc.receivedAt
+			if receivedAt.IsZero() {
+				receivedAt = time.Now()
+			}
+
+			if retryAfter := time.Unix(int64(reset), 0).Sub(receivedAt); retryAfter > 0 {
+				rateLimitErr.RetryAfter = retryAfter
+			}
+		}
+		return rateLimitErr
+	case http.StatusUnauthorized:
+		return &AuthError{APIError: apiErr}
+	}
+
+	return apiErr
+}
+
+// responseError returns the error of a failed response if
+// Options.ReturnAPIErrors is set, and nil otherwise.
+func (c *Client) responseError(rc *ResponseCommon) error {
+	if !c.opts.ReturnAPIErrors {
+		return nil
+	}
+
+	err := rc.Err()
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.response = rc
+	}
+
+	return err
+}
+
+// keepFailedResponse undoes Options.ReturnAPIErrors for the client's own
+// flows, which check the status of failed responses themselves: it returns
+// the response a call failed with instead of its error.
+func keepFailedResponse[R any, P interface {
+	*R
+	setResponseCommon(rc ResponseCommon)
+}](resp P, err error) (P, error) {
+	var apiErr *APIError
+	if resp != nil || !errors.As(err, &apiErr) || apiErr.response == nil {
+		return resp, err
+	}
+
+	resp = new(R)
+	resp.setResponseCommon(*apiErr.response)
+
+	return resp, nil
+}
+
+func (rc *ResponseCommon) setResponseCommon(source ResponseCommon) {
+	*rc = source
+}
@@ -0,0 +1,292 @@
+package helix
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestResponseCommonErr(t *testing.T) {
+	t.Parallel()
+
+	reset := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+
+	testCases := []struct {
+		statusCode    int
+		respBody      string
+		headers       map[string]string
+		expectedErr   string
+		isAPIError    bool
+		isRateLimit   bool
+		isAuthError   bool
+		minRetryAfter time.Duration
+	}{
+		{
+			http.StatusOK,
+			`{"data":[]}`,
+			nil,
+			"",
+			false,
+			false,
+			false,
+			0,
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":"Bad Request","status":400,"message":"Invalid login names, emails or IDs in request"}`,
+			nil,
+			"400 Bad Request: Invalid login names, emails or IDs in request",
+			true,
+			false,
+			false,
+			0,
+		},
+		{
+			http.StatusUnauthorized,
+			`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`,
+			nil,
+			"401 Unauthorized: Invalid OAuth token",
+			true,
+			false,
+			true,
+			0,
+		},
+		{
+			http.StatusTooManyRequests,
+			`{"error":"Too Many Requests","status":429,"message":""}`,
+			map[string]string{"Ratelimit-Reset": reset},
+			"429 Too Many Requests",
+			true,
+			true,
+			false,
+			20 * time.Second,
+		},
+		{
+			http.StatusInternalServerError,
+			``,
+			nil,
+			"500 Internal Server Error",
+			true,
+			false,
+			false,
+			0,
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":"Bad Request","status":400,"message":"Malformed query params"}`,
+			map[string]string{"X-Request-Id": "01HZ5V2Q9K"},
+			"400 Bad Request: Malformed query params (request id 01HZ5V2Q9K)",
+			true,
+			false,
+			false,
+			0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(testCase.statusCode, testCase.respBody, testCase.headers))
+
+		resp, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		err = resp.Err()
+		if testCase.expectedErr == "" {
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			continue
+		}
+
+		if err == nil || err.Error() != testCase.expectedErr {
+			t.Errorf("expected error %q, got %v", testCase.expectedErr, err)
+			continue
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) != testCase.isAPIError {
+			t.Errorf("expected errors.As APIError to be %t for %d", testCase.isAPIError, testCase.statusCode)
+		} else if apiErr.Status != testCase.statusCode {
+			t.Errorf("expected status to be %d, got %d", testCase.statusCode, apiErr.Status)
+		}
+
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) != testCase.isRateLimit {
+			t.Errorf("expected errors.As RateLimitError to be %t for %d", testCase.isRateLimit, testCase.statusCode)
+		}
+
+		if rateLimitErr != nil && (rateLimitErr.RetryAfter < testCase.minRetryAfter || rateLimitErr.RetryAfter > 30*time.Second) {
+			t.Errorf("expected retry after to be about 30s, got %v", rateLimitErr.RetryAfter)
+		}
+
+		var authErr *AuthError
+		if errors.As(err, &authErr) != testCase.isAuthError {
+			t.Errorf("expected errors.As AuthError to be %t for %d", testCase.isAuthError, testCase.statusCode)
+		}
+	}
+}
+
+func TestAuthErrorMissingScopes(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{
+		ClientID:              "my-client-id",
+		UserAccessToken:       "my-user-access-token",
+		UserAccessTokenScopes: []string{ScopeUserReadEmail},
+	}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	_, err := c.GetSubscriptions(&SubscriptionsParams{BroadcasterID: "123"})
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected an AuthError, got %v", err)
+	}
+
+	if authErr.Endpoint != "GET /subscriptions" {
+		t.Errorf("expected endpoint to be %s, got %s", "GET /subscriptions", authErr.Endpoint)
+	}
+
+	if len(authErr.RequiredScopes) != 1 || authErr.RequiredScopes[0] != ScopeChannelReadSubscriptions {
+		t.Errorf("unexpected required scopes %v", authErr.RequiredScopes)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		t.Errorf("expected no APIError for a request that wasn't sent")
+	}
+}
+
+func TestReturnAPIErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode  int
+		respBody    string
+		expectedErr string
+		isRateLimit bool
+		isAuthError bool
+	}{
+		{
+			http.StatusBadRequest,
+			`{"error":"Bad Request","status":400,"message":"Invalid login names, emails or IDs in request"}`,
+			"400 Bad Request: Invalid login names, emails or IDs in request",
+			false,
+			false,
+		},
+		{
+			http.StatusUnauthorized,
+			`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`,
+			"401 Unauthorized: Invalid OAuth token",
+			false,
+			true,
+		},
+		{
+			http.StatusTooManyRequests,
+			`{"error":"Too Many Requests","status":429,"message":""}`,
+			"429 Too Many Requests",
+			true,
+			false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id", ReturnAPIErrors: true}, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+		if resp != nil {
+			t.Errorf("expected no response for %d, got %+v", testCase.statusCode, resp)
+		}
+
+		if err == nil || err.Error() != testCase.expectedErr {
+			t.Errorf("expected error %q, got %v", testCase.expectedErr, err)
+			continue
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("expected an APIError for %d, got %T", testCase.statusCode, err)
+		} else if apiErr.Status != testCase.statusCode {
+			t.Errorf("expected status to be %d, got %d", testCase.statusCode, apiErr.Status)
+		}
+
+		var rateLimitErr *RateLimitError
+		if errors.As(err, &rateLimitErr) != testCase.isRateLimit {
+			t.Errorf("expected errors.As RateLimitError to be %t for %d", testCase.isRateLimit, testCase.statusCode)
+		}
+
+		var authErr *AuthError
+		if errors.As(err, &authErr) != testCase.isAuthError {
+			t.Errorf("expected errors.As AuthError to be %t for %d", testCase.isAuthError, testCase.statusCode)
+		}
+	}
+
+	// Successful responses are returned as usual
+	c := newMockClient(&Options{ClientID: "my-client-id", ReturnAPIErrors: true}, newMockHandler(http.StatusOK, `{"data":[{"id":"26301881","login":"summit1g"}]}`, nil))
+
+	resp, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.Users) != 1 {
+		t.Errorf("expected 1 user, got %d", len(resp.Data.Users))
+	}
+}
+
+func TestReturnAPIErrorsStatusesWithMeaning(t *testing.T) {
+	t.Parallel()
+
+	// CheckUserSubscription responds to users who aren't subscribed with 404
+	c := newMockClient(&Options{ClientID: "my-client-id", ReturnAPIErrors: true}, newMockHandler(http.StatusNotFound, `{"error":"Not Found","status":404,"message":"summit1g has no subscription to lirik"}`, nil))
+
+	subscription, err := c.CheckUserSubscription(&UserSubscriptionsParams{BroadcasterID: "23161357", UserID: "26301881"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if subscription.Data.IsSubscribed {
+		t.Error("expected the user not to be subscribed")
+	}
+
+	c = newMockClient(&Options{ClientID: "my-client-id", ReturnAPIErrors: true}, newMockHandler(http.StatusUnauthorized, `{"error":"Unauthorized","status":401,"message":"Missing scope: user:read:subscriptions"}`, nil))
+
+	_, err = c.CheckUserSubscription(&UserSubscriptionsParams{BroadcasterID: "23161357", UserID: "26301881"})
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("expected an AuthError, got %v", err)
+	}
+
+	// The client's own flows still see the statuses they handle, like the
+	// 409 Conflict of a subscription that already exists
+	c = newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token", ReturnAPIErrors: true}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":"Conflict","status":409,"message":"subscription already exists"}`))
+			return
+		}
+
+		w.Write([]byte(`{"data":[{"id":"existing","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"}}],"pagination":{}}`))
+	})
+
+	sub, err := c.EnsureEventSubSubscription(&EventSubSubscription{
+		Type:      EventSubTypeChannelFollow,
+		Condition: EventSubChannelFollowCondition("1337", "1337"),
+		Transport: EventSubTransport{
+			Method:   "webhook",
+			Callback: "https://example.com/eventsub",
+			Secret:   "s3cre7w0rd",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sub.ID != "existing" {
+		t.Errorf("expected subscription existing, got %s", sub.ID)
+	}
+}
@@ -8,20 +8,21 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 )
 
 // EventSub Types for Parsing Requests / Responses
 
 // Represents a subscription
 type EventSubSubscription struct {
-	ID        string            `json:"id"`
-	Type      string            `json:"type"`
-	Version   string            `json:"version"`
-	Status    string            `json:"status"`
-	Condition EventSubCondition `json:"condition"`
-	Transport EventSubTransport `json:"transport"`
-	CreatedAt Time              `json:"created_at"`
-	Cost      int               `json:"cost"`
+	ID        string                     `json:"id"`
+	Type      string                     `json:"type"`
+	Version   string                     `json:"version"`
+	Status    EventSubSubscriptionStatus `json:"status"`
+	Condition EventSubCondition          `json:"condition"`
+	Transport EventSubTransport          `json:"transport"`
+	CreatedAt Time                       `json:"created_at"`
+	Cost      int                        `json:"cost"`
 }
 
 // Conditions for a subscription, not all are necessary and some only apply to some subscription types, see https://dev.twitch.tv/docs/eventsub/eventsub-reference
@@ -34,6 +35,10 @@ type EventSubCondition struct {
 	ClientID              string `json:"client_id"`
 	ExtensionClientID     string `json:"extension_client_id"`
 	UserID                string `json:"user_id"`
+	OrganizationID        string `json:"organization_id,omitempty"`
+	CategoryID            string `json:"category_id,omitempty"`
+	CampaignID            string `json:"campaign_id,omitempty"`
+	ConduitID             string `json:"conduit_id,omitempty"`
 }
 
 // Transport for the subscription, currently the only supported Method is "webhook". Secret must be between 10 and 100 characters
@@ -42,6 +47,7 @@ type EventSubTransport struct {
 	Callback  string `json:"callback"`
 	Secret    string `json:"secret"`
 	SessionID string `json:"session_id"`
+	ConduitID string `json:"conduit_id,omitempty"`
 }
 
 // Twitch Response for getting all current subscriptions
@@ -54,17 +60,15 @@ type ManyEventSubSubscriptions struct {
 }
 
 // Response for getting all current subscriptions
-type EventSubSubscriptionsResponse struct {
-	ResponseCommon
-	Data ManyEventSubSubscriptions
-}
+type EventSubSubscriptionsResponse = ResponseOf[ManyEventSubSubscriptions]
 
 // Parameter for filtering subscriptions, currently only the status is filterable
 type EventSubSubscriptionsParams struct {
-	Status string `query:"status"`
-	Type   string `query:"type"`
-	UserID string `query:"user_id"`
-	After  string `query:"after"`
+	Status         EventSubSubscriptionStatus `query:"status"`
+	Type           string                     `query:"type"`
+	UserID         string                     `query:"user_id"`
+	SubscriptionID string                     `query:"subscription_id"`
+	After          string                     `query:"after"`
 }
 
 // Parameter for removing a subscription.
@@ -77,15 +81,61 @@ type RemoveEventSubSubscriptionParamsResponse struct {
 	ResponseCommon
 }
 
-// EventSub helper Variables for Types and Status
+// EventSubSubscriptionStatus is the status of a subscription, it is enabled
+// unless Twitch stopped sending its events.
+type EventSubSubscriptionStatus string
+
+// EventSub subscription statuses
 const (
-	EventSubStatusEnabled                      = "enabled"
-	EventSubStatusPending                      = "webhook_callback_verification_pending"
-	EventSubStatusFailed                       = "webhook_callback_verification_failed"
-	EventSubStatusNotificationFailuresExceeded = "notification_failures_exceeded"
-	EventSubStatusAuthorizationRevoked         = "authorization_revoked"
-	EventSubStatusUserRemoved                  = "user_removed"
+	EventSubStatusEnabled                         EventSubSubscriptionStatus = "enabled"
+	EventSubStatusPending                         EventSubSubscriptionStatus = "webhook_callback_verification_pending"
+	EventSubStatusFailed                          EventSubSubscriptionStatus = "webhook_callback_verification_failed"
+	EventSubStatusNotificationFailuresExceeded    EventSubSubscriptionStatus = "notification_failures_exceeded"
+	EventSubStatusAuthorizationRevoked            EventSubSubscriptionStatus = "authorization_revoked"
+	EventSubStatusModeratorRemoved                EventSubSubscriptionStatus = "moderator_removed"
+	EventSubStatusUserRemoved                     EventSubSubscriptionStatus = "user_removed"
+	EventSubStatusChatUserBanned                  EventSubSubscriptionStatus = "chat_user_banned"
+	EventSubStatusVersionRemoved                  EventSubSubscriptionStatus = "version_removed"
+	EventSubStatusBetaMaintenance                 EventSubSubscriptionStatus = "beta_maintenance"
+	EventSubStatusWebsocketDisconnected           EventSubSubscriptionStatus = "websocket_disconnected"
+	EventSubStatusWebsocketFailedPingPong         EventSubSubscriptionStatus = "websocket_failed_ping_pong"
+	EventSubStatusWebsocketReceivedInboundTraffic EventSubSubscriptionStatus = "websocket_received_inbound_traffic"
+	EventSubStatusWebsocketConnectionUnused       EventSubSubscriptionStatus = "websocket_connection_unused"
+	EventSubStatusWebsocketInternalError          EventSubSubscriptionStatus = "websocket_internal_error"
+	EventSubStatusWebsocketNetworkTimeout         EventSubSubscriptionStatus = "websocket_network_timeout"
+	EventSubStatusWebsocketNetworkError           EventSubSubscriptionStatus = "websocket_network_error"
+	EventSubStatusWebsocketFailedToReconnect      EventSubSubscriptionStatus = "websocket_failed_to_reconnect"
+)
 
+// IsValid reports whether the status is one Twitch documents.
+func (s EventSubSubscriptionStatus) IsValid() bool {
+	switch s {
+	case EventSubStatusEnabled,
+		EventSubStatusPending,
+		EventSubStatusFailed,
+		EventSubStatusNotificationFailuresExceeded,
+		EventSubStatusAuthorizationRevoked,
+		EventSubStatusModeratorRemoved,
+		EventSubStatusUserRemoved,
+		EventSubStatusChatUserBanned,
+		EventSubStatusVersionRemoved,
+		EventSubStatusBetaMaintenance,
+		EventSubStatusWebsocketDisconnected,
+		EventSubStatusWebsocketFailedPingPong,
+		EventSubStatusWebsocketReceivedInboundTraffic,
+		EventSubStatusWebsocketConnectionUnused,
+		EventSubStatusWebsocketInternalError,
+		EventSubStatusWebsocketNetworkTimeout,
+		EventSubStatusWebsocketNetworkError,
+		EventSubStatusWebsocketFailedToReconnect:
+		return true
+	}
+
+	return false
+}
+
+// EventSub subscription types
+const (
 	EventSubTypeChannelGoalBegin                          = "channel.goal.begin"
 	EventSubTypeChannelGoalProgress                       = "channel.goal.progress"
 	EventSubTypeChannelGoalEnd                            = "channel.goal.end"
@@ -132,6 +182,38 @@ const (
 	EventSubTypeUserUpdate                                = "user.update"
 	EventSubShoutoutCreate                                = "channel.shoutout.create"
 	EventSubShoutoutReceive                               = "channel.shoutout.receive"
+	EventSubTypeChannelSharedChatBegin                    = "channel.shared_chat.begin"
+	EventSubTypeChannelSharedChatUpdate                   = "channel.shared_chat.update"
+	EventSubTypeChannelSharedChatEnd                      = "channel.shared_chat.end"
+	EventSubTypeAutoModMessageHold                        = "automod.message.hold"
+	EventSubTypeAutoModMessageUpdate                      = "automod.message.update"
+	EventSubTypeAutoModSettingsUpdate                     = "automod.settings.update"
+	EventSubTypeAutoModTermsUpdate                        = "automod.terms.update"
+	EventSubTypeChannelAdBreakBegin                       = "channel.ad_break.begin"
+	EventSubTypeChannelBitsUse                            = "channel.bits.use"
+	EventSubTypeChannelChatSettingsUpdate                 = "channel.chat_settings.update"
+	EventSubTypeChannelChatUserMessageHold                = "channel.chat.user_message_hold"
+	EventSubTypeChannelChatUserMessageUpdate              = "channel.chat.user_message_update"
+	EventSubTypeChannelUnbanRequestCreate                 = "channel.unban_request.create"
+	EventSubTypeChannelUnbanRequestResolve                = "channel.unban_request.resolve"
+	EventSubTypeChannelModerate                           = "channel.moderate"
+	EventSubTypeChannelGuestStarSessionBegin              = "channel.guest_star_session.begin"
+	EventSubTypeChannelGuestStarSessionEnd                = "channel.guest_star_session.end"
+	EventSubTypeChannelGuestStarGuestUpdate               = "channel.guest_star_guest.update"
+	EventSubTypeChannelGuestStarSettingsUpdate            = "channel.guest_star_settings.update"
+	EventSubTypeChannelPointsAutomaticRewardRedemptionAdd = "channel.channel_points_automatic_reward_redemption.add"
+	EventSubTypeChannelSuspiciousUserMessage              = "channel.suspicious_user.message"
+	EventSubTypeChannelSuspiciousUserUpdate               = "channel.suspicious_user.update"
+	EventSubTypeChannelVIPAdd                             = "channel.vip.add"
+	EventSubTypeChannelVIPRemove                          = "channel.vip.remove"
+	EventSubTypeChannelWarningAcknowledge                 = "channel.warning.acknowledge"
+	EventSubTypeChannelWarningSend                        = "channel.warning.send"
+	EventSubTypeShieldModeBegin                           = "channel.shield_mode.begin"
+	EventSubTypeShieldModeEnd                             = "channel.shield_mode.end"
+	EventSubTypeConduitShardDisabled                      = "conduit.shard.disabled"
+	EventSubTypeDropEntitlementGrant                      = "drop.entitlement.grant"
+	EventSubTypeUserAuthorizationGrant                    = "user.authorization.grant"
+	EventSubTypeUserWhisperMessage                        = "user.whisper.message"
 )
 
 // Event Notification Responses
@@ -206,6 +288,281 @@ type EventSubChannelCheerEvent struct {
 	Bits                 int    `json:"bits"`
 }
 
+// Data for a channel bits use notification, sent for cheers, power-ups and
+// combos
+type EventSubChannelBitsUseEvent struct {
+	UserID               string                  `json:"user_id"`
+	UserLogin            string                  `json:"user_login"`
+	UserName             string                  `json:"user_name"`
+	BroadcasterUserID    string                  `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                  `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                  `json:"broadcaster_user_name"`
+	Bits                 int                     `json:"bits"`
+	Type                 EventSubBitsUseType     `json:"type"`
+	Message              *EventSubBitsUseMessage `json:"message"`  // nil if no message was sent with the bits
+	PowerUp              *EventSubBitsPowerUp    `json:"power_up"` // Only set for power-ups
+}
+
+type EventSubBitsUseType string
+
+const (
+	EventSubBitsUseTypeCheer   EventSubBitsUseType = "cheer"
+	EventSubBitsUseTypePowerUp EventSubBitsUseType = "power_up"
+	EventSubBitsUseTypeCombo   EventSubBitsUseType = "combo"
+)
+
+// EventSubBitsUseMessage is the chat message bits were used with, split into
+// fragments of text, cheermotes and emotes.
+type EventSubBitsUseMessage struct {
+	Text      string                        `json:"text"`
+	Fragments []EventSubChatMessageFragment `json:"fragments"`
+}
+
+// Emotes returns the emotes of the message with their positions in Text,
+// counted in characters with an inclusive end, so overlays can replace them
+// with images.
+func (m *EventSubBitsUseMessage) Emotes() []EventSubEmote {
+	return fragmentEmotes(m.Fragments)
+}
+
+func fragmentEmotes(fragments []EventSubChatMessageFragment) []EventSubEmote {
+	var emotes []EventSubEmote
+
+	position := 0
+	for _, fragment := range fragments {
+		length := utf8.RuneCountInString(fragment.Text)
+		if fragment.Type == EventSubChatMessageFragmentTypeEmote && length > 0 {
+			emotes = append(emotes, EventSubEmote{
+				Begin: position,
+				End:   position + length - 1,
+				ID:    fragment.Emote.ID,
+			})
+		}
+		position += length
+	}
+
+	return emotes
+}
+
+// EventSubBitsPowerUp is the power-up bits were used on.
+type EventSubBitsPowerUp struct {
+	Type            EventSubBitsPowerUpType   `json:"type"`
+	Emote           *EventSubBitsPowerUpEmote `json:"emote"`             // Only set for gigantified emotes
+	MessageEffectID string                    `json:"message_effect_id"` // Only set for message effects
+}
+
+type EventSubBitsPowerUpType string
+
+const (
+	EventSubBitsPowerUpTypeMessageEffect    EventSubBitsPowerUpType = "message_effect"
+	EventSubBitsPowerUpTypeCelebration      EventSubBitsPowerUpType = "celebration"
+	EventSubBitsPowerUpTypeGigantifyAnEmote EventSubBitsPowerUpType = "gigantify_an_emote"
+)
+
+type EventSubBitsPowerUpEmote struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Data for a channel suspicious user message notification, sent when a user
+// that is monitored or restricted as suspicious sends a chat message
+type EventSubChannelSuspiciousUserMessageEvent struct {
+	BroadcasterUserID    string                        `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                        `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                        `json:"broadcaster_user_name"`
+	UserID               string                        `json:"user_id"`
+	UserLogin            string                        `json:"user_login"`
+	UserName             string                        `json:"user_name"`
+	LowTrustStatus       EventSubLowTrustStatus        `json:"low_trust_status"`
+	SharedBanChannelIDs  []string                      `json:"shared_ban_channel_ids"` // The channels that share a ban of the user with the broadcaster
+	Types                []EventSubSuspiciousUserType  `json:"types"`
+	BanEvasionEvaluation EventSubBanEvasionEvaluation  `json:"ban_evasion_evaluation"`
+	Message              EventSubSuspiciousUserMessage `json:"message"`
+}
+
+// Data for a channel suspicious user update notification, sent when a
+// moderator changes how a suspicious user is treated
+type EventSubChannelSuspiciousUserUpdateEvent struct {
+	BroadcasterUserID    string                 `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                 `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                 `json:"broadcaster_user_name"`
+	ModeratorUserID      string                 `json:"moderator_user_id"`
+	ModeratorUserLogin   string                 `json:"moderator_user_login"`
+	ModeratorUserName    string                 `json:"moderator_user_name"`
+	UserID               string                 `json:"user_id"`
+	UserLogin            string                 `json:"user_login"`
+	UserName             string                 `json:"user_name"`
+	LowTrustStatus       EventSubLowTrustStatus `json:"low_trust_status"`
+}
+
+type EventSubSuspiciousUserMessage struct {
+	MessageID string                        `json:"message_id"`
+	Text      string                        `json:"text"`
+	Fragments []EventSubChatMessageFragment `json:"fragments"`
+}
+
+// EventSubLowTrustStatus is how a suspicious user is treated in chat.
+type EventSubLowTrustStatus string
+
+const (
+	EventSubLowTrustStatusNone             EventSubLowTrustStatus = "none"
+	EventSubLowTrustStatusActiveMonitoring EventSubLowTrustStatus = "active_monitoring"
+	EventSubLowTrustStatusRestricted       EventSubLowTrustStatus = "restricted"
+)
+
+// EventSubSuspiciousUserType is why a user is treated as suspicious.
+type EventSubSuspiciousUserType string
+
+const (
+	EventSubSuspiciousUserTypeManuallyAdded     EventSubSuspiciousUserType = "manually_added"
+	EventSubSuspiciousUserTypeBanEvaderDetector EventSubSuspiciousUserType = "ban_evader_detector"
+	EventSubSuspiciousUserTypeSharedChannelBan  EventSubSuspiciousUserType = "shared_channel_ban"
+)
+
+// EventSubBanEvasionEvaluation is how likely Twitch thinks it is that a
+// user evades a ban.
+type EventSubBanEvasionEvaluation string
+
+const (
+	EventSubBanEvasionEvaluationUnknown  EventSubBanEvasionEvaluation = "unknown"
+	EventSubBanEvasionEvaluationPossible EventSubBanEvasionEvaluation = "possible"
+	EventSubBanEvasionEvaluationLikely   EventSubBanEvasionEvaluation = "likely"
+)
+
+// Data for a channel moderate notification, sent for every moderator action.
+// Only the field of the action, see Action, is set, e.g. Ban for
+// EventSubModerateActionBan. Shared chat actions use the fields of their
+// counterparts, e.g. SharedChatBan has the shape of Ban.
+type EventSubChannelModerateEvent struct {
+	BroadcasterUserID          string                 `json:"broadcaster_user_id"`
+	BroadcasterUserLogin       string                 `json:"broadcaster_user_login"`
+	BroadcasterUserName        string                 `json:"broadcaster_user_name"`
+	SourceBroadcasterUserID    string                 `json:"source_broadcaster_user_id"` // The channel the action was taken in during a shared chat session
+	SourceBroadcasterUserLogin string                 `json:"source_broadcaster_user_login"`
+	SourceBroadcasterUserName  string                 `json:"source_broadcaster_user_name"`
+	ModeratorUserID            string                 `json:"moderator_user_id"`
+	ModeratorUserLogin         string                 `json:"moderator_user_login"`
+	ModeratorUserName          string                 `json:"moderator_user_name"`
+	Action                     EventSubModerateAction `json:"action"`
+
+	Followers           *EventSubModerateFollowers    `json:"followers"`
+	Slow                *EventSubModerateSlow         `json:"slow"`
+	VIP                 *EventSubModerateUser         `json:"vip"`
+	UnVIP               *EventSubModerateUser         `json:"unvip"`
+	Mod                 *EventSubModerateUser         `json:"mod"`
+	UnMod               *EventSubModerateUser         `json:"unmod"`
+	Ban                 *EventSubModerateBan          `json:"ban"`
+	Unban               *EventSubModerateUser         `json:"unban"`
+	Timeout             *EventSubModerateTimeout      `json:"timeout"`
+	Untimeout           *EventSubModerateUser         `json:"untimeout"`
+	Raid                *EventSubModerateRaid         `json:"raid"`
+	Unraid              *EventSubModerateUser         `json:"unraid"`
+	Delete              *EventSubModerateDelete       `json:"delete"`
+	AutomodTerms        *EventSubModerateAutomodTerms `json:"automod_terms"`
+	UnbanRequest        *EventSubModerateUnbanRequest `json:"unban_request"`
+	Warn                *EventSubModerateWarn         `json:"warn"`
+	SharedChatBan       *EventSubModerateBan          `json:"shared_chat_ban"`
+	SharedChatUnban     *EventSubModerateUser         `json:"shared_chat_unban"`
+	SharedChatTimeout   *EventSubModerateTimeout      `json:"shared_chat_timeout"`
+	SharedChatUntimeout *EventSubModerateUser         `json:"shared_chat_untimeout"`
+	SharedChatDelete    *EventSubModerateDelete       `json:"shared_chat_delete"`
+}
+
+type EventSubModerateAction string
+
+const (
+	EventSubModerateActionBan                 EventSubModerateAction = "ban"
+	EventSubModerateActionTimeout             EventSubModerateAction = "timeout"
+	EventSubModerateActionUnban               EventSubModerateAction = "unban"
+	EventSubModerateActionUntimeout           EventSubModerateAction = "untimeout"
+	EventSubModerateActionClear               EventSubModerateAction = "clear"
+	EventSubModerateActionEmoteOnly           EventSubModerateAction = "emoteonly"
+	EventSubModerateActionEmoteOnlyOff        EventSubModerateAction = "emoteonlyoff"
+	EventSubModerateActionFollowers           EventSubModerateAction = "followers"
+	EventSubModerateActionFollowersOff        EventSubModerateAction = "followersoff"
+	EventSubModerateActionUniqueChat          EventSubModerateAction = "uniquechat"
+	EventSubModerateActionUniqueChatOff       EventSubModerateAction = "uniquechatoff"
+	EventSubModerateActionSlow                EventSubModerateAction = "slow"
+	EventSubModerateActionSlowOff             EventSubModerateAction = "slowoff"
+	EventSubModerateActionSubscribers         EventSubModerateAction = "subscribers"
+	EventSubModerateActionSubscribersOff      EventSubModerateAction = "subscribersoff"
+	EventSubModerateActionRaid                EventSubModerateAction = "raid"
+	EventSubModerateActionUnraid              EventSubModerateAction = "unraid"
+	EventSubModerateActionDelete              EventSubModerateAction = "delete"
+	EventSubModerateActionVIP                 EventSubModerateAction = "vip"
+	EventSubModerateActionUnVIP               EventSubModerateAction = "unvip"
+	EventSubModerateActionMod                 EventSubModerateAction = "mod"
+	EventSubModerateActionUnMod               EventSubModerateAction = "unmod"
+	EventSubModerateActionAddBlockedTerm      EventSubModerateAction = "add_blocked_term"
+	EventSubModerateActionAddPermittedTerm    EventSubModerateAction = "add_permitted_term"
+	EventSubModerateActionRemoveBlockedTerm   EventSubModerateAction = "remove_blocked_term"
+	EventSubModerateActionRemovePermittedTerm EventSubModerateAction = "remove_permitted_term"
+	EventSubModerateActionApproveUnbanRequest EventSubModerateAction = "approve_unban_request"
+	EventSubModerateActionDenyUnbanRequest    EventSubModerateAction = "deny_unban_request"
+	EventSubModerateActionWarn                EventSubModerateAction = "warn"
+	EventSubModerateActionSharedChatBan       EventSubModerateAction = "shared_chat_ban"
+	EventSubModerateActionSharedChatTimeout   EventSubModerateAction = "shared_chat_timeout"
+	EventSubModerateActionSharedChatUnban     EventSubModerateAction = "shared_chat_unban"
+	EventSubModerateActionSharedChatUntimeout EventSubModerateAction = "shared_chat_untimeout"
+	EventSubModerateActionSharedChatDelete    EventSubModerateAction = "shared_chat_delete"
+)
+
+// EventSubModerateUser is the user a moderator action was taken against.
+type EventSubModerateUser struct {
+	UserID    string `json:"user_id"`
+	UserLogin string `json:"user_login"`
+	UserName  string `json:"user_name"`
+}
+
+type EventSubModerateFollowers struct {
+	FollowDurationMinutes int `json:"follow_duration_minutes"`
+}
+
+type EventSubModerateSlow struct {
+	WaitTimeSeconds int `json:"wait_time_seconds"`
+}
+
+type EventSubModerateBan struct {
+	EventSubModerateUser
+	Reason string `json:"reason"`
+}
+
+type EventSubModerateTimeout struct {
+	EventSubModerateUser
+	Reason    string `json:"reason"`
+	ExpiresAt Time   `json:"expires_at"`
+}
+
+type EventSubModerateRaid struct {
+	EventSubModerateUser
+	ViewerCount int `json:"viewer_count"`
+}
+
+type EventSubModerateDelete struct {
+	EventSubModerateUser
+	MessageID   string `json:"message_id"`
+	MessageBody string `json:"message_body"`
+}
+
+type EventSubModerateAutomodTerms struct {
+	Action      string   `json:"action"` // add or remove
+	List        string   `json:"list"`   // blocked or permitted
+	Terms       []string `json:"terms"`
+	FromAutomod bool     `json:"from_automod"` // Whether the terms were added because of an AutoMod message approval or denial
+}
+
+type EventSubModerateUnbanRequest struct {
+	EventSubModerateUser
+	IsApproved       bool   `json:"is_approved"`
+	ModeratorMessage string `json:"moderator_message"`
+}
+
+type EventSubModerateWarn struct {
+	EventSubModerateUser
+	Reason         string   `json:"reason"`
+	ChatRulesCited []string `json:"chat_rules_cited"`
+}
+
 // Data for a channel update notification
 type EventSubChannelUpdateEvent struct {
 	BroadcasterUserID    string `json:"broadcaster_user_id"`
@@ -288,15 +645,63 @@ type EventSubChannelChatMessageEvent struct {
 	Message                     EventSubChatMessage      `json:"message"`
 	MessageType                 EventSubChatMessageType  `json:"message_type"`
 	Badges                      []EventSubChatBadge      `json:"badges"`
-	Cheer                       EventSubChatMessageCheer `json:"cheer"`
+	Cheer                       EventSubChatMessageCheer `json:"cheer"` // Zero unless the message is a cheer
 	Color                       string                   `json:"color"`
-	Reply                       EventSubChatMessageReply `json:"reply"`
+	Reply                       EventSubChatMessageReply `json:"reply"` // Zero unless the message is a reply
 	ChannelPointsCustomRewardID string                   `json:"channel_points_custom_reward_id"`
+	ChannelPointsAnimationID    string                   `json:"channel_points_animation_id"`
+
+	// The channel the message was sent in during a shared chat session, empty
+	// if it was sent in the broadcaster's channel
+	SourceBroadcasterUserID    string              `json:"source_broadcaster_user_id"`
+	SourceBroadcasterUserLogin string              `json:"source_broadcaster_user_login"`
+	SourceBroadcasterUserName  string              `json:"source_broadcaster_user_name"`
+	SourceMessageID            string              `json:"source_message_id"`
+	SourceBadges               []EventSubChatBadge `json:"source_badges"`
+	IsSourceOnly               bool                `json:"is_source_only"`
+}
+
+// IsReply reports whether the message is a reply to another message.
+func (e *EventSubChannelChatMessageEvent) IsReply() bool {
+	return e.Reply.ParentMessageID != ""
 }
 
 type EventSubChatMessage struct {
 	Text      string                        `json:"text"`
-	Fragments []EventSubChatMessageFragment `json:"fragment"`
+	Fragments []EventSubChatMessageFragment `json:"fragments"`
+}
+
+// PlainText returns the text of the message without its emotes and
+// cheermotes, e.g. to match chat commands.
+func (m *EventSubChatMessage) PlainText() string {
+	var b strings.Builder
+	for _, fragment := range m.Fragments {
+		switch fragment.Type {
+		case EventSubChatMessageFragmentTypeEmote, EventSubChatMessageFragmentTypeCheermote:
+		default:
+			b.WriteString(fragment.Text)
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Emotes returns the emotes of the message with their positions in Text,
+// see EventSubBitsUseMessage.Emotes.
+func (m *EventSubChatMessage) Emotes() []EventSubEmote {
+	return fragmentEmotes(m.Fragments)
+}
+
+// Mentions returns the users mentioned in the message.
+func (m *EventSubChatMessage) Mentions() []EventSubChatMessageMention {
+	var mentions []EventSubChatMessageMention
+	for _, fragment := range m.Fragments {
+		if fragment.Type == EventSubChatMessageFragmentTypeMention {
+			mentions = append(mentions, fragment.Mention)
+		}
+	}
+
+	return mentions
 }
 
 type EventSubChatMessageReply struct {
@@ -328,6 +733,8 @@ const (
 	EventSubChatMessageTypeChannelPointsHighlighted EventSubChatMessageType = "channel_points_highlighted"
 	EventSubChatMessageTypeChannelPointsSubOnly     EventSubChatMessageType = "channel_points_sub_only"
 	EventSubChatMessageTypeUserIntro                EventSubChatMessageType = "user_intro"
+	EventSubChatMessageTypePowerUpsMessageEffect    EventSubChatMessageType = "power_ups_message_effect"
+	EventSubChatMessageTypePowerUpsGigantifiedEmote EventSubChatMessageType = "power_ups_gigantified_emote"
 )
 
 type EventSubChatMessageFragmentType string
@@ -354,10 +761,10 @@ type EventSubChatMessageCheermote struct {
 }
 
 type EventSubChatMessageEmote struct {
-	ID         string `json:"id"`
-	EmoteSetID string `json:"emote_set_id"`
-	OwnerID    string `json:"owner_id"`
-	Format     string `json:"format"`
+	ID         string   `json:"id"`
+	EmoteSetID string   `json:"emote_set_id"`
+	OwnerID    string   `json:"owner_id"`
+	Format     []string `json:"format"`
 }
 
 type EventSubChatMessageMention struct {
@@ -508,7 +915,7 @@ type EventSubChannelChatNotificationBitsBadgeTier struct {
 
 type EventSubChatNotificationMessage struct {
 	Text      string                        `json:"text"`
-	Fragments []EventSubChatMessageFragment `json:"fragment"`
+	Fragments []EventSubChatMessageFragment `json:"fragments"`
 }
 
 // Data for a channel poll begin event
@@ -576,19 +983,89 @@ type EventSubChannelPointsCustomRewardEvent struct {
 
 // Data for a channel points custom reward redemption notification
 type EventSubChannelPointsCustomRewardRedemptionEvent struct {
-	ID                   string         `json:"id"`
-	BroadcasterUserID    string         `json:"broadcaster_user_id"`
-	BroadcasterUserLogin string         `json:"broadcaster_user_login"`
-	BroadcasterUserName  string         `json:"broadcaster_user_name"`
-	UserID               string         `json:"user_id"`
-	UserLogin            string         `json:"user_login"`
-	UserName             string         `json:"user_name"`
-	UserInput            string         `json:"user_input"`
-	Status               string         `json:"status"`
-	Reward               EventSubReward `json:"reward"`
-	RedeemedAt           Time           `json:"redeemed_at"`
+	ID                   string                       `json:"id"`
+	BroadcasterUserID    string                       `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                       `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                       `json:"broadcaster_user_name"`
+	UserID               string                       `json:"user_id"`
+	UserLogin            string                       `json:"user_login"`
+	UserName             string                       `json:"user_name"`
+	UserInput            string                       `json:"user_input"`
+	Status               CustomRewardRedemptionStatus `json:"status"`
+	Reward               EventSubReward               `json:"reward"`
+	RedeemedAt           Time                         `json:"redeemed_at"`
+}
+
+// Data for a version 1 channel points automatic reward redemption
+// notification
+type EventSubChannelPointsAutomaticRewardRedemptionEvent struct {
+	ID                   string                  `json:"id"`
+	BroadcasterUserID    string                  `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                  `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                  `json:"broadcaster_user_name"`
+	UserID               string                  `json:"user_id"`
+	UserLogin            string                  `json:"user_login"`
+	UserName             string                  `json:"user_name"`
+	Reward               EventSubAutomaticReward `json:"reward"`
+	Message              EventSubMessage         `json:"message"`
+	UserInput            string                  `json:"user_input"`
+	RedeemedAt           Time                    `json:"redeemed_at"`
+}
+
+// Data for a version 2 channel points automatic reward redemption
+// notification, whose message is split into fragments
+type EventSubChannelPointsAutomaticRewardRedemptionV2Event struct {
+	ID                   string                          `json:"id"`
+	BroadcasterUserID    string                          `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                          `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                          `json:"broadcaster_user_name"`
+	UserID               string                          `json:"user_id"`
+	UserLogin            string                          `json:"user_login"`
+	UserName             string                          `json:"user_name"`
+	Reward               EventSubAutomaticRewardV2       `json:"reward"`
+	Message              *EventSubAutomaticRewardMessage `json:"message"` // nil if the reward has no message
+	RedeemedAt           Time                            `json:"redeemed_at"`
+}
+
+type EventSubAutomaticRewardType string
+
+const (
+	EventSubAutomaticRewardTypeSingleMessageBypassSubMode   EventSubAutomaticRewardType = "single_message_bypass_sub_mode"
+	EventSubAutomaticRewardTypeSendHighlightedMessage       EventSubAutomaticRewardType = "send_highlighted_message"
+	EventSubAutomaticRewardTypeRandomSubEmoteUnlock         EventSubAutomaticRewardType = "random_sub_emote_unlock"
+	EventSubAutomaticRewardTypeChosenSubEmoteUnlock         EventSubAutomaticRewardType = "chosen_sub_emote_unlock"
+	EventSubAutomaticRewardTypeChosenModifiedSubEmoteUnlock EventSubAutomaticRewardType = "chosen_modified_sub_emote_unlock"
+	EventSubAutomaticRewardTypeMessageEffect                EventSubAutomaticRewardType = "message_effect"
+	EventSubAutomaticRewardTypeGigantifyAnEmote             EventSubAutomaticRewardType = "gigantify_an_emote"
+	EventSubAutomaticRewardTypeCelebration                  EventSubAutomaticRewardType = "celebration"
+)
+
+// EventSubAutomaticReward is the reward of a version 1 automatic reward
+// redemption.
+type EventSubAutomaticReward struct {
+	Type          EventSubAutomaticRewardType   `json:"type"`
+	Cost          int                           `json:"cost"`
+	UnlockedEmote *EventSubAutomaticRewardEmote `json:"unlocked_emote"` // Only set for emote unlocks
+}
+
+// EventSubAutomaticRewardV2 is the reward of a version 2 automatic reward
+// redemption.
+type EventSubAutomaticRewardV2 struct {
+	Type          EventSubAutomaticRewardType   `json:"type"`
+	ChannelPoints int                           `json:"channel_points"`
+	Emote         *EventSubAutomaticRewardEmote `json:"emote"` // Only set for emote unlocks and gigantified emotes
+}
+
+type EventSubAutomaticRewardEmote struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
+// EventSubAutomaticRewardMessage is the message of a version 2 automatic
+// reward redemption, see EventSubBitsUseMessage.Emotes for the positions of
+// its emotes.
+type EventSubAutomaticRewardMessage = EventSubBitsUseMessage
+
 // Data for a channel prediction begin event
 type EventSubChannelPredictionBeginEvent struct {
 	ID                   string            `json:"id"`
@@ -647,9 +1124,11 @@ type EventSubExtensionBitsTransactionCreateEvent struct {
 
 // Data for a hype train begin notification
 type EventSubHypeTrainBeginEvent struct {
+	ID                   string                 `json:"id"`
 	BroadcasterUserID    string                 `json:"broadcaster_user_id"`
 	BroadcasterUserLogin string                 `json:"broadcaster_user_login"`
 	BroadcasterUserName  string                 `json:"broadcaster_user_name"`
+	Level                int                    `json:"level"`
 	Total                int                    `json:"total"`
 	Progress             int                    `json:"progress"`
 	Goal                 int                    `json:"goal"`
@@ -657,10 +1136,12 @@ type EventSubHypeTrainBeginEvent struct {
 	LastContribution     EventSubContribution   `json:"last_contribution"`
 	StartedAt            Time                   `json:"started_at"`
 	ExpiresAt            Time                   `json:"expires_at"`
+	IsGoldenKappaTrain   bool                   `json:"is_golden_kappa_train"`
 }
 
 // Data for a hype train progress notification
 type EventSubHypeTrainProgressEvent struct {
+	ID                   string                 `json:"id"`
 	BroadcasterUserID    string                 `json:"broadcaster_user_id"`
 	BroadcasterUserLogin string                 `json:"broadcaster_user_login"`
 	BroadcasterUserName  string                 `json:"broadcaster_user_name"`
@@ -672,6 +1153,7 @@ type EventSubHypeTrainProgressEvent struct {
 	LastContribution     EventSubContribution   `json:"last_contribution"`
 	StartedAt            Time                   `json:"started_at"`
 	ExpiresAt            Time                   `json:"expires_at"`
+	IsGoldenKappaTrain   bool                   `json:"is_golden_kappa_train"`
 }
 
 // Data for a hype train end notification
@@ -686,6 +1168,7 @@ type EventSubHypeTrainEndEvent struct {
 	StartedAt            Time                   `json:"started_at"`
 	EndedAt              Time                   `json:"ended_at"`
 	CooldownEndsAt       Time                   `json:"cooldown_ends_at"`
+	IsGoldenKappaTrain   bool                   `json:"is_golden_kappa_train"`
 }
 
 // Data for a stream online notification
@@ -735,13 +1218,20 @@ type EventSubImage struct {
 	Url4x string `json:"url_4x"`
 }
 
+// Contribution types of a hype train EventSubContribution
+const (
+	EventSubHypeTrainContributionTypeBits         = "bits"
+	EventSubHypeTrainContributionTypeSubscription = "subscription"
+	EventSubHypeTrainContributionTypeOther        = "other"
+)
+
 // This belongs to a hype train and defines a user contribution
 type EventSubContribution struct {
 	UserID    string `json:"user_id"`
 	UserLogin string `json:"user_login"`
 	UserName  string `json:"user_name"`
-	Type      string `json:"type"`
-	Total     int64  `json:"total"`
+	Type      string `json:"type"`  // "bits", "subscription" or "other"
+	Total     int64  `json:"total"` // Bits, or 500/1000/2500 points per tier 1/2/3 subscription
 }
 
 // This belong to an outcome and defines user reward
@@ -797,6 +1287,7 @@ type EventSubEmote struct {
 	ID    string `json:"id"`
 }
 
+// Data for a channel goal begin notification
 type EventSubChannelGoalStartEvent struct {
 	ID                   string `json:"id"`
 	BroadcasterUserID    string `json:"broadcaster_user_id"`
@@ -809,6 +1300,7 @@ type EventSubChannelGoalStartEvent struct {
 	StartedAt            Time   `json:"started_at"`
 }
 
+// Data for a channel goal progress notification
 type EventSubChannelGoalProgressEvent struct {
 	ID                   string `json:"id"`
 	BroadcasterUserID    string `json:"broadcaster_user_id"`
@@ -821,6 +1313,7 @@ type EventSubChannelGoalProgressEvent struct {
 	StartedAt            Time   `json:"started_at"`
 }
 
+// Data for a channel goal end notification
 type EventSubChannelGoalEndEvent struct {
 	ID                   string `json:"id"`
 	BroadcasterUserID    string `json:"broadcaster_user_id"`
@@ -835,11 +1328,7 @@ type EventSubChannelGoalEndEvent struct {
 	EndedAt              Time   `json:"ended_at"`
 }
 
-type EventSubCharityAmount struct {
-	Value         int64  `json:"value"`
-	DecimalPlaces int64  `json:"decimal_places"`
-	Currency      string `json:"currency"`
-}
+type EventSubCharityAmount = CharityCampaignAmount
 
 type EventSubCharityDonationEvent struct {
 	DonationID           string                `json:"id"`
@@ -933,22 +1422,49 @@ type EventSubShoutoutReceiveEvent struct {
 	StartedAt                Time   `json:"started_at"`
 }
 
+// Data for a channel participating in a shared chat session
+type EventSubSharedChatParticipant struct {
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+}
+
+// Data for a shared chat session begin notification
+type EventSubChannelSharedChatBeginEvent struct {
+	SessionID                string                          `json:"session_id"`
+	BroadcasterUserID        string                          `json:"broadcaster_user_id"`
+	BroadcasterUserLogin     string                          `json:"broadcaster_user_login"`
+	BroadcasterUserName      string                          `json:"broadcaster_user_name"`
+	HostBroadcasterUserID    string                          `json:"host_broadcaster_user_id"`
+	HostBroadcasterUserLogin string                          `json:"host_broadcaster_user_login"`
+	HostBroadcasterUserName  string                          `json:"host_broadcaster_user_name"`
+	Participants             []EventSubSharedChatParticipant `json:"participants"`
+}
+
+// Data for a shared chat session update notification
+type EventSubChannelSharedChatUpdateEvent = EventSubChannelSharedChatBeginEvent
+
+// Data for a shared chat session end notification
+type EventSubChannelSharedChatEndEvent struct {
+	SessionID                string `json:"session_id"`
+	BroadcasterUserID        string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin     string `json:"broadcaster_user_login"`
+	BroadcasterUserName      string `json:"broadcaster_user_name"`
+	HostBroadcasterUserID    string `json:"host_broadcaster_user_id"`
+	HostBroadcasterUserLogin string `json:"host_broadcaster_user_login"`
+	HostBroadcasterUserName  string `json:"host_broadcaster_user_name"`
+}
+
 // Get all EventSub Subscriptions
 func (c *Client) GetEventSubSubscriptions(params *EventSubSubscriptionsParams) (*EventSubSubscriptionsResponse, error) {
-	resp, err := c.get("/eventsub/subscriptions", &ManyEventSubSubscriptions{}, params)
+	resp, err := getResponse[ManyEventSubSubscriptions](c, "/eventsub/subscriptions", params)
 	if err != nil {
 		return nil, err
 	}
 
-	eventSubs := &EventSubSubscriptionsResponse{}
-	resp.HydrateResponseCommon(&eventSubs.ResponseCommon)
-	eventSubs.Data.Total = resp.Data.(*ManyEventSubSubscriptions).Total
-	eventSubs.Data.TotalCost = resp.Data.(*ManyEventSubSubscriptions).TotalCost
-	eventSubs.Data.MaxTotalCost = resp.Data.(*ManyEventSubSubscriptions).MaxTotalCost
-	eventSubs.Data.EventSubSubscriptions = resp.Data.(*ManyEventSubSubscriptions).EventSubSubscriptions
-	eventSubs.Data.Pagination = resp.Data.(*ManyEventSubSubscriptions).Pagination
+	c.recordEventSubCost(resp)
 
-	return eventSubs, nil
+	return resp, nil
 }
 
 // Remove an EventSub Subscription
@@ -964,8 +1480,14 @@ func (c *Client) RemoveEventSubSubscription(id string) (*RemoveEventSubSubscript
 	return eventsub, nil
 }
 
-// Creates an EventSub subscription
+// Creates an EventSub subscription. If Version is empty, it is set from EventSubTypeVersion.
+// With Options.LimitEventSubCost, an *EventSubCostError is returned instead of
+// sending a subscription that would exceed the maximum total cost.
 func (c *Client) CreateEventSubSubscription(payload *EventSubSubscription) (*EventSubSubscriptionsResponse, error) {
+	if payload.Version == "" {
+		payload.Version = EventSubTypeVersion(payload.Type)
+	}
+
 	switch payload.Transport.Method {
 	case "webhook":
 		if err := verifyWebhookSub(payload); err != nil {
@@ -975,8 +1497,16 @@ func (c *Client) CreateEventSubSubscription(payload *EventSubSubscription) (*Eve
 		if err := verifyWebsocketSub(payload); err != nil {
 			return nil, err
 		}
+	case "conduit":
+		if err := verifyConduitSub(payload); err != nil {
+			return nil, err
+		}
 	default:
-		return nil, fmt.Errorf("error: unsupported transport method: %s", payload.Transport.Method)
+		return nil, &ValidationError{Field: "transport.method", Message: "unsupported transport method: " + payload.Transport.Method}
+	}
+
+	if err := c.checkEventSubCost(); err != nil {
+		return nil, err
 	}
 
 	resp, err := c.postAsJSON("/eventsub/subscriptions", &ManyEventSubSubscriptions{}, payload)
@@ -987,25 +1517,51 @@ func (c *Client) CreateEventSubSubscription(payload *EventSubSubscription) (*Eve
 	eventsub := &EventSubSubscriptionsResponse{}
 	resp.HydrateResponseCommon(&eventsub.ResponseCommon)
 	eventsub.Data = *resp.Data.(*ManyEventSubSubscriptions)
+	c.recordEventSubCost(eventsub)
+
 	return eventsub, nil
 }
 
 // Verifys that a notification came from twitch using the a signature and the secret used when creating the subscription
 func VerifyEventSubNotification(secret string, header http.Header, message string) bool {
+	return VerifyEventSubNotificationWithSecrets([]string{secret}, header, message)
+}
+
+// VerifyEventSubNotificationWithSecrets verifies that a notification came from twitch using any of the given secrets.
+// Passing both the current and the previous secret allows rotating secrets without dropping notifications of
+// subscriptions that were created with the previous one.
+func VerifyEventSubNotificationWithSecrets(secrets []string, header http.Header, message string) bool {
 	hmacMessage := []byte(fmt.Sprintf("%s%s%s", header.Get("Twitch-Eventsub-Message-Id"), header.Get("Twitch-Eventsub-Message-Timestamp"), message))
-	mac := hmac.New(sha256.New, []byte(secret))
-	mac.Write(hmacMessage)
-	hmacsha256 := fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
-	return hmacsha256 == header.Get("Twitch-Eventsub-Message-Signature")
+	signature := []byte(header.Get("Twitch-Eventsub-Message-Signature"))
+
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(hmacMessage)
+		hmacsha256 := []byte(fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil))))
+		if hmac.Equal(hmacsha256, signature) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VerifyEventSubSignature verifies that a notification came from twitch using the client's EventSubSecrets.
+func (c *Client) VerifyEventSubSignature(header http.Header, message string) bool {
+	return VerifyEventSubNotificationWithSecrets(c.opts.EventSubSecrets, header, message)
 }
 
 func verifyWebhookSub(payload *EventSubSubscription) error {
 	if !strings.HasPrefix(payload.Transport.Callback, "https://") {
-		return fmt.Errorf("error: callback must use https")
+		return &ValidationError{Field: "transport.callback", Message: "callback must use https"}
 	}
 
 	if payload.Transport.Secret != "" && (len(payload.Transport.Secret) < 10 || len(payload.Transport.Secret) > 100) {
-		return fmt.Errorf("error: secret must be between 10 and 100 characters")
+		return &ValidationError{Field: "transport.secret", Message: "secret must be between 10 and 100 characters"}
 	}
 
 	callbackUrl, err := url.Parse(payload.Transport.Callback)
@@ -1013,7 +1569,7 @@ func verifyWebhookSub(payload *EventSubSubscription) error {
 		return err
 	}
 	if callbackUrl.Port() != "" && callbackUrl.Port() != "443" {
-		return fmt.Errorf("error: callback must use port 443")
+		return &ValidationError{Field: "transport.callback", Message: "callback must use port 443"}
 	}
 
 	return nil
@@ -1021,7 +1577,15 @@ func verifyWebhookSub(payload *EventSubSubscription) error {
 
 func verifyWebsocketSub(payload *EventSubSubscription) error {
 	if len(payload.Transport.SessionID) == 0 {
-		return fmt.Errorf("error: session ID must be set up")
+		return &ValidationError{Field: "transport.session_id", Message: "session ID must be set up"}
+	}
+
+	return nil
+}
+
+func verifyConduitSub(payload *EventSubSubscription) error {
+	if len(payload.Transport.ConduitID) == 0 {
+		return &ValidationError{Field: "transport.conduit_id", Message: "conduit ID must be set"}
 	}
 
 	return nil
@@ -0,0 +1,144 @@
+package helix
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// DefaultEventSubBulkConcurrency is the number of subscriptions
+// CreateEventSubSubscriptions creates at once if no concurrency is given.
+const DefaultEventSubBulkConcurrency = 4
+
+// maxEventSubBulkRateLimitRetries is how often a subscription is retried
+// after being rejected by the rate limit.
+const maxEventSubBulkRateLimitRetries = 3
+
+// BulkOptions configures CreateEventSubSubscriptions.
+type BulkOptions struct {
+	// Concurrency is the number of subscriptions created at once,
+	// DefaultEventSubBulkConcurrency if zero.
+	Concurrency int
+
+	// SkipConflicts reports subscriptions that already exist, which Twitch
+	// rejects with 409 Conflict, as skipped instead of failed.
+	SkipConflicts bool
+}
+
+// EventSubBulkResult is the outcome of creating one of the subscriptions
+// passed to CreateEventSubSubscriptions.
+type EventSubBulkResult struct {
+	Subscription *EventSubSubscription // The subscription as passed in
+	Created      *EventSubSubscription // The created subscription, nil unless it was created
+	Skipped      bool                  // The subscription already existed, see BulkOptions.SkipConflicts
+	Err          error                 // Why the subscription wasn't created
+}
+
+// CreateEventSubSubscriptions creates many subscriptions with a pool of
+// workers and returns a result for each of them, in the order they were
+// passed in. When the rate limit bucket of the client's token is empty, the
+// workers wait for it to be refilled instead of sending requests that Twitch
+// would reject.
+//
+// Every subscription is attempted, a failed one doesn't stop the others. If
+// any failed, the error counts them and wraps the error of the first.
+func (c *Client) CreateEventSubSubscriptions(subs []*EventSubSubscription, opts BulkOptions) ([]EventSubBulkResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultEventSubBulkConcurrency
+	}
+
+	results := make([]EventSubBulkResult, len(subs))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(subs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				results[index] = c.createEventSubBulkSubscription(subs[index], opts)
+			}
+		}()
+	}
+
+	for i := range subs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var firstErr error
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("error: could not create %d of %d eventsub subscriptions: %w", failed, len(subs), firstErr)
+	}
+
+	return results, nil
+}
+
+func (c *Client) createEventSubBulkSubscription(sub *EventSubSubscription, opts BulkOptions) EventSubBulkResult {
+	result := EventSubBulkResult{Subscription: sub}
+
+	for retries := 0; ; retries++ {
+		if err := c.waitForRateLimit(); err != nil {
+			result.Err = err
+			return result
+		}
+
+		resp, err := keepFailedResponse(c.CreateEventSubSubscription(sub))
+		if err != nil {
+			result.Err = err
+			return result
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusAccepted && len(resp.Data.EventSubSubscriptions) > 0:
+			result.Created = &resp.Data.EventSubSubscriptions[0]
+			return result
+		case resp.StatusCode == http.StatusConflict && opts.SkipConflicts:
+			result.Skipped = true
+			return result
+		case isRateLimited(&resp.ResponseCommon) && retries < maxEventSubBulkRateLimitRetries:
+			// The bucket is empty, wait for it to be refilled
+			continue
+		}
+
+		result.Err = fmt.Errorf("error: could not create %s eventsub subscription: %w", sub.Type, resp.Err())
+		return result
+	}
+}
+
+// waitForRateLimit sleeps until the rate limit bucket of the client's token
+// is refilled, if the last response emptied it.
+func (c *Client) waitForRateLimit() error {
+	rateLimit, ok := c.RateLimit()
+	if !ok || rateLimit.Remaining > 0 {
+		return nil
+	}
+
+	if wait := rateLimit.Reset.Sub(c.now()); wait > 0 {
+		return c.sleep(c.ctx, wait)
+	}
+
+	return nil
+}
+
+// isRateLimited reports whether a request was rejected because the rate
+// limit bucket was empty. EventSub also rejects subscriptions that exceed
+// the cost budget with 429 Too Many Requests.
+func isRateLimited(rc *ResponseCommon) bool {
+	return rc.StatusCode == http.StatusTooManyRequests &&
+		rc.Header.Get("Ratelimit-Limit") != "" &&
+		rc.GetRateLimitRemaining() == 0
+}
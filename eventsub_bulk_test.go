@@ -0,0 +1,144 @@
+package helix
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCreateEventSubSubscriptionsBulk(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	reset := strconv.FormatInt(clock.Now().Unix()+10, 10)
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	rateLimited := false
+	c := newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token", Clock: clock, Sleeper: clock}, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		var sub EventSubSubscription
+		json.NewDecoder(r.Body).Decode(&sub)
+		broadcasterID := sub.Condition.BroadcasterUserID
+
+		mu.Lock()
+		limited := broadcasterID == "3" && !rateLimited
+		if limited {
+			rateLimited = true
+		}
+		mu.Unlock()
+
+		w.Header().Set("Ratelimit-Limit", "800")
+		w.Header().Set("Ratelimit-Reset", reset)
+		switch {
+		case limited:
+			w.Header().Set("Ratelimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Too Many Requests","status":429,"message":""}`))
+		case broadcasterID == "4":
+			w.Header().Set("Ratelimit-Remaining", "700")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":"Conflict","status":409,"message":"subscription already exists"}`))
+		case broadcasterID == "5":
+			w.Header().Set("Ratelimit-Remaining", "700")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Bad Request","status":400,"message":"invalid condition"}`))
+		default:
+			w.Header().Set("Ratelimit-Remaining", "700")
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"data":[{"id":"sub-` + broadcasterID + `","status":"webhook_callback_verification_pending","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"` + broadcasterID + `"}}],"total":1,"total_cost":1,"max_total_cost":10000}`))
+		}
+	})
+
+	var subs []*EventSubSubscription
+	for i := 1; i <= 6; i++ {
+		subs = append(subs, &EventSubSubscription{
+			Type:      "channel.follow",
+			Condition: EventSubCondition{BroadcasterUserID: strconv.Itoa(i)},
+			Transport: EventSubTransport{
+				Method:   "webhook",
+				Callback: "https://example.com/eventsub",
+				Secret:   "s3cre7w0rd",
+			},
+		})
+	}
+
+	results, err := c.CreateEventSubSubscriptions(subs, BulkOptions{Concurrency: 2, SkipConflicts: true})
+	if err == nil || err.Error() != "error: could not create 1 of 6 eventsub subscriptions: error: could not create channel.follow eventsub subscription: 400 Bad Request: invalid condition" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest {
+		t.Errorf("expected the error to wrap the api error, got %v", err)
+	}
+
+	if len(results) != len(subs) {
+		t.Fatalf("expected %d results, got %d", len(subs), len(results))
+	}
+
+	for i, result := range results {
+		broadcasterID := strconv.Itoa(i + 1)
+		if result.Subscription != subs[i] {
+			t.Errorf("expected result %d to be of subscription %s", i, broadcasterID)
+		}
+
+		switch broadcasterID {
+		case "4":
+			if !result.Skipped || result.Created != nil || result.Err != nil {
+				t.Errorf("expected subscription 4 to be skipped, got %+v", result)
+			}
+		case "5":
+			if result.Err == nil || result.Created != nil {
+				t.Errorf("expected subscription 5 to fail, got %+v", result)
+			}
+		default:
+			if result.Err != nil || result.Created == nil || result.Created.ID != "sub-"+broadcasterID {
+				t.Errorf("expected subscription %s to be created, got %+v", broadcasterID, result)
+			}
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 requests at once, got %d", maxInFlight)
+	}
+
+	if len(clock.sleeps) == 0 {
+		t.Error("expected to wait for the rate limit to reset")
+	}
+}
+
+func TestCreateEventSubSubscriptionsBulkConflicts(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"}, newMockHandler(http.StatusConflict, `{"error":"Conflict","status":409,"message":"subscription already exists"}`, nil))
+
+	subs := []*EventSubSubscription{{
+		Type:      "channel.follow",
+		Condition: EventSubCondition{BroadcasterUserID: "1"},
+		Transport: EventSubTransport{Method: "websocket", SessionID: "session-1"},
+	}}
+
+	results, err := c.CreateEventSubSubscriptions(subs, BulkOptions{})
+	if err == nil {
+		t.Error("expected conflicts to fail without SkipConflicts")
+	}
+
+	if len(results) != 1 || results[0].Skipped || results[0].Err == nil {
+		t.Errorf("expected the subscription to fail, got %+v", results)
+	}
+}
@@ -0,0 +1,187 @@
+package helix
+
+// EventSub subscription versions
+const (
+	EventSubVersion1    = "1"
+	EventSubVersion2    = "2"
+	EventSubVersionBeta = "beta"
+)
+
+// eventSubTypeVersions maps each subscription type to the version whose
+// events are decoded by the EventSub event types of this package.
+var eventSubTypeVersions = map[string]string{
+	EventSubTypeAutoModMessageHold:                        EventSubVersion2,
+	EventSubTypeAutoModMessageUpdate:                      EventSubVersion2,
+	EventSubTypeAutoModSettingsUpdate:                     EventSubVersion1,
+	EventSubTypeAutoModTermsUpdate:                        EventSubVersion1,
+	EventSubTypeChannelAdBreakBegin:                       EventSubVersion1,
+	EventSubTypeChannelBan:                                EventSubVersion1,
+	EventSubTypeChannelBitsUse:                            EventSubVersion1,
+	EventSubTypeChannelChatClear:                          EventSubVersion1,
+	EventSubTypeChannelChatClearUserMessages:              EventSubVersion1,
+	EventSubTypeChannelChatMessage:                        EventSubVersion1,
+	EventSubTypeChannelChatMessageDelete:                  EventSubVersion1,
+	EventSubTypeChannelChatNotification:                   EventSubVersion1,
+	EventSubTypeChannelChatSettingsUpdate:                 EventSubVersion1,
+	EventSubTypeChannelChatUserMessageHold:                EventSubVersion1,
+	EventSubTypeChannelChatUserMessageUpdate:              EventSubVersion1,
+	EventSubTypeChannelCheer:                              EventSubVersion1,
+	EventSubTypeChannelFollow:                             EventSubVersion2,
+	EventSubTypeChannelGoalBegin:                          EventSubVersion1,
+	EventSubTypeChannelGoalEnd:                            EventSubVersion1,
+	EventSubTypeChannelGoalProgress:                       EventSubVersion1,
+	EventSubTypeChannelGuestStarGuestUpdate:               EventSubVersionBeta,
+	EventSubTypeChannelGuestStarSessionBegin:              EventSubVersionBeta,
+	EventSubTypeChannelGuestStarSessionEnd:                EventSubVersionBeta,
+	EventSubTypeChannelGuestStarSettingsUpdate:            EventSubVersionBeta,
+	EventSubTypeChannelModerate:                           EventSubVersion2,
+	EventSubTypeChannelPointsAutomaticRewardRedemptionAdd: EventSubVersion2,
+	EventSubTypeChannelPointsCustomRewardAdd:              EventSubVersion1,
+	EventSubTypeChannelPointsCustomRewardRedemptionAdd:    EventSubVersion1,
+	EventSubTypeChannelPointsCustomRewardRedemptionUpdate: EventSubVersion1,
+	EventSubTypeChannelPointsCustomRewardRemove:           EventSubVersion1,
+	EventSubTypeChannelPointsCustomRewardUpdate:           EventSubVersion1,
+	EventSubTypeChannelPollBegin:                          EventSubVersion1,
+	EventSubTypeChannelPollEnd:                            EventSubVersion1,
+	EventSubTypeChannelPollProgress:                       EventSubVersion1,
+	EventSubTypeChannelPredictionBegin:                    EventSubVersion1,
+	EventSubTypeChannelPredictionEnd:                      EventSubVersion1,
+	EventSubTypeChannelPredictionLock:                     EventSubVersion1,
+	EventSubTypeChannelPredictionProgress:                 EventSubVersion1,
+	EventSubTypeChannelRaid:                               EventSubVersion1,
+	EventSubTypeChannelSharedChatBegin:                    EventSubVersion1,
+	EventSubTypeChannelSharedChatEnd:                      EventSubVersion1,
+	EventSubTypeChannelSharedChatUpdate:                   EventSubVersion1,
+	EventSubTypeChannelSubscription:                       EventSubVersion1,
+	EventSubTypeChannelSubscriptionEnd:                    EventSubVersion1,
+	EventSubTypeChannelSubscriptionGift:                   EventSubVersion1,
+	EventSubTypeChannelSubscriptionMessage:                EventSubVersion1,
+	EventSubTypeChannelSuspiciousUserMessage:              EventSubVersion1,
+	EventSubTypeChannelSuspiciousUserUpdate:               EventSubVersion1,
+	EventSubTypeChannelUnban:                              EventSubVersion1,
+	EventSubTypeChannelUnbanRequestCreate:                 EventSubVersion1,
+	EventSubTypeChannelUnbanRequestResolve:                EventSubVersion1,
+	EventSubTypeChannelUpdate:                             EventSubVersion2,
+	EventSubTypeChannelVIPAdd:                             EventSubVersion1,
+	EventSubTypeChannelVIPRemove:                          EventSubVersion1,
+	EventSubTypeChannelWarningAcknowledge:                 EventSubVersion1,
+	EventSubTypeChannelWarningSend:                        EventSubVersion1,
+	EventSubTypeCharityDonation:                           EventSubVersion1,
+	EventSubTypeCharityProgress:                           EventSubVersion1,
+	EventSubTypeCharityStart:                              EventSubVersion1,
+	EventSubTypeCharityStop:                               EventSubVersion1,
+	EventSubTypeConduitShardDisabled:                      EventSubVersion1,
+	EventSubTypeDropEntitlementGrant:                      EventSubVersion1,
+	EventSubExtensionBitsTransactionCreate:                EventSubVersion1,
+	EventSubTypeHypeTrainBegin:                            EventSubVersion1,
+	EventSubTypeHypeTrainEnd:                              EventSubVersion1,
+	EventSubTypeHypeTrainProgress:                         EventSubVersion1,
+	EventSubTypeModeratorAdd:                              EventSubVersion1,
+	EventSubTypeModeratorRemove:                           EventSubVersion1,
+	EventSubTypeShieldModeBegin:                           EventSubVersion1,
+	EventSubTypeShieldModeEnd:                             EventSubVersion1,
+	EventSubShoutoutCreate:                                EventSubVersion1,
+	EventSubShoutoutReceive:                               EventSubVersion1,
+	EventSubTypeStreamOffline:                             EventSubVersion1,
+	EventSubTypeStreamOnline:                              EventSubVersion1,
+	EventSubTypeUserAuthorizationGrant:                    EventSubVersion1,
+	EventSubTypeUserAuthorizationRevoke:                   EventSubVersion1,
+	EventSubTypeUserUpdate:                                EventSubVersion1,
+	EventSubTypeUserWhisperMessage:                        EventSubVersion1,
+}
+
+// EventSubTypeVersion returns the version to subscribe to the given type
+// with, or an empty string for unknown types.
+func EventSubTypeVersion(subType string) string {
+	return eventSubTypeVersions[subType]
+}
+
+// NewEventSubSubscription returns a subscription to the given type, using the
+// version from EventSubTypeVersion. The condition is usually built with one of
+// the EventSub condition helpers, e.g. EventSubChannelFollowCondition.
+func NewEventSubSubscription(subType string, condition EventSubCondition, transport EventSubTransport) *EventSubSubscription {
+	return &EventSubSubscription{
+		Type:      subType,
+		Version:   EventSubTypeVersion(subType),
+		Condition: condition,
+		Transport: transport,
+	}
+}
+
+// EventSubBroadcasterCondition is the condition of the channel types that
+// only take the broadcaster, such as stream.online, channel.subscribe,
+// channel.cheer, channel.ban, channel.poll.begin or channel.hype_train.begin.
+func EventSubBroadcasterCondition(broadcasterID string) EventSubCondition {
+	return EventSubCondition{BroadcasterUserID: broadcasterID}
+}
+
+// EventSubModeratorCondition is the condition of the channel types that are
+// read by one of the broadcaster's moderators, such as channel.follow,
+// channel.shield_mode.begin, channel.shoutout.create, channel.moderate,
+// channel.suspicious_user.message, channel.warning.send or automod.message.hold.
+// The moderator may be the broadcaster.
+func EventSubModeratorCondition(broadcasterID, moderatorID string) EventSubCondition {
+	return EventSubCondition{BroadcasterUserID: broadcasterID, ModeratorUserID: moderatorID}
+}
+
+// EventSubChannelFollowCondition is the condition of channel.follow.
+func EventSubChannelFollowCondition(broadcasterID, moderatorID string) EventSubCondition {
+	return EventSubModeratorCondition(broadcasterID, moderatorID)
+}
+
+// EventSubChatCondition is the condition of the chat types, which are read as
+// the given user, such as channel.chat.message, channel.chat.notification,
+// channel.chat_settings.update or channel.chat.user_message_hold.
+func EventSubChatCondition(broadcasterID, userID string) EventSubCondition {
+	return EventSubCondition{BroadcasterUserID: broadcasterID, UserID: userID}
+}
+
+// EventSubRaidFromCondition is the condition of channel.raid for raids
+// started by the broadcaster.
+func EventSubRaidFromCondition(fromBroadcasterID string) EventSubCondition {
+	return EventSubCondition{FromBroadcasterUserID: fromBroadcasterID}
+}
+
+// EventSubRaidToCondition is the condition of channel.raid for raids
+// received by the broadcaster.
+func EventSubRaidToCondition(toBroadcasterID string) EventSubCondition {
+	return EventSubCondition{ToBroadcasterUserID: toBroadcasterID}
+}
+
+// EventSubRewardCondition is the condition of the custom reward types, such as
+// channel.channel_points_custom_reward_redemption.add. An empty rewardID
+// matches all of the broadcaster's rewards.
+func EventSubRewardCondition(broadcasterID, rewardID string) EventSubCondition {
+	return EventSubCondition{BroadcasterUserID: broadcasterID, RewardID: rewardID}
+}
+
+// EventSubUserCondition is the condition of user.update and
+// user.whisper.message.
+func EventSubUserCondition(userID string) EventSubCondition {
+	return EventSubCondition{UserID: userID}
+}
+
+// EventSubClientCondition is the condition of user.authorization.grant and
+// user.authorization.revoke.
+func EventSubClientCondition(clientID string) EventSubCondition {
+	return EventSubCondition{ClientID: clientID}
+}
+
+// EventSubConduitShardDisabledCondition is the condition of
+// conduit.shard.disabled. An empty conduitID matches all of the client's
+// conduits.
+func EventSubConduitShardDisabledCondition(clientID, conduitID string) EventSubCondition {
+	return EventSubCondition{ClientID: clientID, ConduitID: conduitID}
+}
+
+// EventSubExtensionCondition is the condition of
+// extension.bits_transaction.create.
+func EventSubExtensionCondition(extensionClientID string) EventSubCondition {
+	return EventSubCondition{ExtensionClientID: extensionClientID}
+}
+
+// EventSubDropEntitlementGrantCondition is the condition of
+// drop.entitlement.grant. categoryID and campaignID are optional.
+func EventSubDropEntitlementGrantCondition(organizationID, categoryID, campaignID string) EventSubCondition {
+	return EventSubCondition{OrganizationID: organizationID, CategoryID: categoryID, CampaignID: campaignID}
+}
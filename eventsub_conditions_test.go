@@ -0,0 +1,127 @@
+package helix
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestEventSubConditions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		condition EventSubCondition
+		expected  EventSubCondition
+	}{
+		{
+			EventSubBroadcasterCondition("1337"),
+			EventSubCondition{BroadcasterUserID: "1337"},
+		},
+		{
+			EventSubChannelFollowCondition("1337", "9001"),
+			EventSubCondition{BroadcasterUserID: "1337", ModeratorUserID: "9001"},
+		},
+		{
+			EventSubChatCondition("1337", "9001"),
+			EventSubCondition{BroadcasterUserID: "1337", UserID: "9001"},
+		},
+		{
+			EventSubRaidFromCondition("1337"),
+			EventSubCondition{FromBroadcasterUserID: "1337"},
+		},
+		{
+			EventSubRaidToCondition("1337"),
+			EventSubCondition{ToBroadcasterUserID: "1337"},
+		},
+		{
+			EventSubRewardCondition("1337", "reward-id"),
+			EventSubCondition{BroadcasterUserID: "1337", RewardID: "reward-id"},
+		},
+		{
+			EventSubUserCondition("1337"),
+			EventSubCondition{UserID: "1337"},
+		},
+		{
+			EventSubClientCondition("my-client-id"),
+			EventSubCondition{ClientID: "my-client-id"},
+		},
+		{
+			EventSubConduitShardDisabledCondition("my-client-id", "conduit-id"),
+			EventSubCondition{ClientID: "my-client-id", ConduitID: "conduit-id"},
+		},
+		{
+			EventSubExtensionCondition("extension-client-id"),
+			EventSubCondition{ExtensionClientID: "extension-client-id"},
+		},
+		{
+			EventSubDropEntitlementGrantCondition("organization-id", "", "campaign-id"),
+			EventSubCondition{OrganizationID: "organization-id", CampaignID: "campaign-id"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		if testCase.condition != testCase.expected {
+			t.Errorf("expected condition to be %+v, got %+v", testCase.expected, testCase.condition)
+		}
+	}
+}
+
+func TestEventSubTypeVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		subType  string
+		expected string
+	}{
+		{EventSubTypeChannelFollow, EventSubVersion2},
+		{EventSubTypeChannelUpdate, EventSubVersion2},
+		{EventSubTypeStreamOnline, EventSubVersion1},
+		{EventSubTypeChannelGuestStarSessionBegin, EventSubVersionBeta},
+		{"unknown.type", ""},
+	}
+
+	for _, testCase := range testCases {
+		if version := EventSubTypeVersion(testCase.subType); version != testCase.expected {
+			t.Errorf("expected version of %s to be %q, got %q", testCase.subType, testCase.expected, version)
+		}
+	}
+}
+
+func TestCreateEventSubSubscriptionDefaultVersion(t *testing.T) {
+	t.Parallel()
+
+	var sent EventSubSubscription
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Error(err)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[],"total":1,"total_cost":1,"max_total_cost":10000}`))
+	})
+
+	sub := NewEventSubSubscription(EventSubTypeChannelFollow, EventSubChannelFollowCondition("1337", "1337"), EventSubTransport{
+		Method:    "websocket",
+		SessionID: "session-id",
+	})
+	sub.Version = ""
+
+	_, err := c.CreateEventSubSubscription(sub)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if sent.Type != EventSubTypeChannelFollow || sent.Version != EventSubVersion2 {
+		t.Errorf("expected %s version %s to be sent, got %s version %s", EventSubTypeChannelFollow, EventSubVersion2, sent.Type, sent.Version)
+	}
+
+	if sent.Condition.BroadcasterUserID != "1337" || sent.Condition.ModeratorUserID != "1337" {
+		t.Errorf("unexpected condition %+v", sent.Condition)
+	}
+}
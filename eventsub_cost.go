@@ -0,0 +1,55 @@
+package helix
+
+// eventSubCost is the total and maximum cost of the EventSub subscriptions,
+// as reported by the last list or create response.
+type eventSubCost struct {
+	total int
+	max   int
+}
+
+// EventSubCostRemaining returns how much more the EventSub subscriptions may
+// cost, according to the total_cost and max_total_cost of the last response
+// of GetEventSubSubscriptions or CreateEventSubSubscription. Removed
+// subscriptions are only accounted for by the next such response. It returns
+// false if no response reported the cost yet.
+//
+// Subscriptions cost 1, unless the user in their condition authorized the
+// app, and websocket subscriptions are counted per user access token.
+func (c *Client) EventSubCostRemaining() (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.eventSubCost == nil {
+		return 0, false
+	}
+
+	return c.eventSubCost.max - c.eventSubCost.total, true
+}
+
+func (c *Client) recordEventSubCost(resp *EventSubSubscriptionsResponse) {
+	if resp.StatusCode >= 300 || resp.Data.MaxTotalCost == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.eventSubCost = &eventSubCost{total: resp.Data.TotalCost, max: resp.Data.MaxTotalCost}
+}
+
+// checkEventSubCost returns an *EventSubCostError if Options.LimitEventSubCost
+// is set and the subscriptions can't cost any more.
+func (c *Client) checkEventSubCost() error {
+	if !c.opts.LimitEventSubCost {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if cost := c.eventSubCost; cost != nil && cost.total >= cost.max {
+		return &EventSubCostError{TotalCost: cost.total, MaxTotalCost: cost.max}
+	}
+
+	return nil
+}
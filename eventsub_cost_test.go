@@ -0,0 +1,97 @@
+package helix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestEventSubCostRemaining(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		limit       bool
+		listBody    string
+		remaining   int
+		expectError bool
+	}{
+		{
+			false,
+			`{"total":1,"data":[],"total_cost":1,"max_total_cost":10,"pagination":{}}`,
+			9,
+			false,
+		},
+		{
+			false,
+			`{"total":10,"data":[],"total_cost":10,"max_total_cost":10,"pagination":{}}`,
+			0,
+			false,
+		},
+		{
+			true,
+			`{"total":1,"data":[],"total_cost":1,"max_total_cost":10,"pagination":{}}`,
+			9,
+			false,
+		},
+		{
+			true,
+			`{"total":10,"data":[],"total_cost":10,"max_total_cost":10,"pagination":{}}`,
+			0,
+			true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		created := false
+		c := newMockClient(&Options{ClientID: "my-client-id", LimitEventSubCost: testCase.limit}, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				created = true
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"data":[{"id":"1","status":"enabled","type":"channel.follow","cost":1}],"total":2,"total_cost":2,"max_total_cost":10}`))
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(testCase.listBody))
+		})
+
+		if _, ok := c.EventSubCostRemaining(); ok {
+			t.Error("expected no cost before the first response")
+		}
+
+		if _, err := c.GetEventSubSubscriptions(&EventSubSubscriptionsParams{}); err != nil {
+			t.Fatal(err)
+		}
+
+		remaining, ok := c.EventSubCostRemaining()
+		if !ok || remaining != testCase.remaining {
+			t.Errorf("expected %d remaining, got %d", testCase.remaining, remaining)
+		}
+
+		_, err := c.CreateEventSubSubscription(&EventSubSubscription{
+			Type:      EventSubTypeChannelFollow,
+			Condition: EventSubCondition{BroadcasterUserID: "12345678", ModeratorUserID: "12345678"},
+			Transport: EventSubTransport{Method: "webhook", Callback: "https://example.com/eventsub", Secret: "s3cre7w0rd"},
+		})
+
+		var costErr *EventSubCostError
+		if errors.As(err, &costErr) != testCase.expectError {
+			t.Errorf("expected cost error to be %t, got %v", testCase.expectError, err)
+		}
+
+		if created == testCase.expectError {
+			t.Errorf("expected subscription to be created to be %t", !testCase.expectError)
+		}
+
+		if testCase.expectError {
+			if costErr.TotalCost != 10 || costErr.MaxTotalCost != 10 {
+				t.Errorf("unexpected cost error %+v", costErr)
+			}
+			continue
+		}
+
+		if remaining, _ := c.EventSubCostRemaining(); remaining != 8 {
+			t.Errorf("expected 8 remaining after creating a subscription, got %d", remaining)
+		}
+	}
+}
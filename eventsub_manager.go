@@ -0,0 +1,280 @@
+package helix
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// EventSubManager converges the EventSub subscriptions of a transport on a
+// desired set of subscriptions, see Reconcile.
+type EventSubManager struct {
+	client    *Client
+	transport EventSubTransport
+
+	// MaxTotalCost caps the total cost of the client's subscriptions when
+	// creating new ones. Zero means the max total cost reported by Twitch.
+	MaxTotalCost int
+}
+
+// EventSubReconcileResult describes the changes made by Reconcile.
+type EventSubReconcileResult struct {
+	Created   []EventSubSubscription
+	Removed   []EventSubSubscription
+	Unchanged []EventSubSubscription
+	Skipped   []EventSubSubscription // Desired subscriptions that didn't fit into the cost budget
+	TotalCost int
+}
+
+// NewEventSubManager returns a manager for the subscriptions that use the
+// given transport. Webhook subscriptions are matched by their callback and
+// websocket subscriptions by their session ID. Subscriptions with any other
+// transport are left alone.
+func (c *Client) NewEventSubManager(transport EventSubTransport) *EventSubManager {
+	return &EventSubManager{
+		client:    c,
+		transport: transport,
+	}
+}
+
+// Reconcile lists the existing subscriptions with GetEventSubSubscriptions and
+// converges them on desired. Only the type, version and condition of the
+// desired subscriptions are used, an empty version defaults to
+// EventSubTypeVersion.
+//
+// Existing subscriptions that aren't desired are removed, as are subscriptions
+// that Twitch disabled, such as those that failed the webhook callback
+// verification. Desired subscriptions that don't exist, or were disabled, are
+// then created as long as the total cost stays within the budget. Those that
+// don't fit are reported as skipped.
+func (m *EventSubManager) Reconcile(desired []EventSubSubscription) (*EventSubReconcileResult, error) {
+	existing, totalCost, maxTotalCost, err := m.listSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.MaxTotalCost > 0 && (maxTotalCost == 0 || m.MaxTotalCost < maxTotalCost) {
+		maxTotalCost = m.MaxTotalCost
+	}
+
+	wanted := make(map[string]EventSubSubscription, len(desired))
+	var order []string
+	for _, sub := range desired {
+		sub = m.withTransport(sub)
+
+		key := eventSubSubscriptionKey(&sub)
+		if _, ok := wanted[key]; !ok {
+			order = append(order, key)
+		}
+		wanted[key] = sub
+	}
+
+	result := &EventSubReconcileResult{}
+	kept := make(map[string]bool, len(existing))
+	for _, sub := range existing {
+		key := eventSubSubscriptionKey(&sub)
+		if _, ok := wanted[key]; ok && !kept[key] && !isDisabledEventSubStatus(sub.Status) {
+			kept[key] = true
+			result.Unchanged = append(result.Unchanged, sub)
+			continue
+		}
+
+		resp, err := keepFailedResponse(m.client.RemoveEventSubSubscription(sub.ID))
+		if err != nil {
+			return result, err
+		}
+
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			return result, fmt.Errorf("error: could not remove eventsub subscription %s: %w", sub.ID, resp.Err())
+		}
+
+		totalCost -= sub.Cost
+		result.Removed = append(result.Removed, sub)
+	}
+
+	for _, key := range order {
+		if kept[key] {
+			continue
+		}
+
+		sub := wanted[key]
+		if maxTotalCost > 0 && totalCost >= maxTotalCost {
+			result.Skipped = append(result.Skipped, sub)
+			continue
+		}
+
+		resp, err := keepFailedResponse(m.client.CreateEventSubSubscription(&sub))
+		if err != nil {
+			return result, err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			// The cost budget is exhausted, subscriptions that cost
+			// nothing may still be created
+			result.Skipped = append(result.Skipped, sub)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusAccepted {
+			return result, fmt.Errorf("error: could not create %s eventsub subscription: %w", sub.Type, resp.Err())
+		}
+
+		totalCost = resp.Data.TotalCost
+		result.Created = append(result.Created, resp.Data.EventSubSubscriptions...)
+	}
+
+	result.TotalCost = totalCost
+
+	return result, nil
+}
+
+// listSubscriptions returns all subscriptions of the manager's transport,
+// along with the total cost of all of the client's subscriptions.
+func (m *EventSubManager) listSubscriptions() ([]EventSubSubscription, int, int, error) {
+	var subs []EventSubSubscription
+	var totalCost, maxTotalCost int
+
+	params := &EventSubSubscriptionsParams{}
+	for {
+		resp, err := m.client.GetEventSubSubscriptions(params)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, 0, 0, fmt.Errorf("error: could not get eventsub subscriptions: %w", resp.Err())
+		}
+
+		for _, sub := range resp.Data.EventSubSubscriptions {
+			if m.usesTransport(&sub) {
+				subs = append(subs, sub)
+			}
+		}
+
+		totalCost = resp.Data.TotalCost
+		maxTotalCost = resp.Data.MaxTotalCost
+
+		if resp.Data.Pagination.Cursor == "" {
+			break
+		}
+		params.After = resp.Data.Pagination.Cursor
+	}
+
+	return subs, totalCost, maxTotalCost, nil
+}
+
+func (m *EventSubManager) usesTransport(sub *EventSubSubscription) bool {
+	return isSameEventSubTransport(sub.Transport, m.transport)
+}
+
+// isSameEventSubTransport reports whether two transports deliver to the same
+// place, webhooks by their callback, websockets by their session ID and
+// conduits by their conduit ID.
+func isSameEventSubTransport(a, b EventSubTransport) bool {
+	if a.Method != b.Method {
+		return false
+	}
+
+	switch a.Method {
+	case "webhook":
+		return a.Callback == b.Callback
+	case "websocket":
+		return a.SessionID == b.SessionID
+	case "conduit":
+		return a.ConduitID == b.ConduitID
+	}
+
+	return false
+}
+
+func (m *EventSubManager) withTransport(sub EventSubSubscription) EventSubSubscription {
+	if sub.Version == "" {
+		sub.Version = EventSubTypeVersion(sub.Type)
+	}
+
+	return EventSubSubscription{
+		Type:      sub.Type,
+		Version:   sub.Version,
+		Condition: sub.Condition,
+		Transport: m.transport,
+	}
+}
+
+// EnsureEventSubSubscription creates a subscription unless it already
+// exists, and returns it either way. Twitch rejects a subscription with the
+// same type, version, condition and transport as an existing one with 409
+// Conflict, the existing one is then looked up and returned instead.
+func (c *Client) EnsureEventSubSubscription(sub *EventSubSubscription) (*EventSubSubscription, error) {
+	resp, err := keepFailedResponse(c.CreateEventSubSubscription(sub))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusAccepted && len(resp.Data.EventSubSubscriptions) > 0:
+		return &resp.Data.EventSubSubscriptions[0], nil
+	case resp.StatusCode != http.StatusConflict:
+		return nil, fmt.Errorf("error: could not create %s eventsub subscription: %w", sub.Type, resp.Err())
+	}
+
+	key := eventSubSubscriptionKey(sub)
+	params := &EventSubSubscriptionsParams{Type: sub.Type}
+	for {
+		resp, err := c.GetEventSubSubscriptions(params)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("error: could not get eventsub subscriptions: %w", resp.Err())
+		}
+
+		for i, existing := range resp.Data.EventSubSubscriptions {
+			if eventSubSubscriptionKey(&existing) == key && isSameEventSubTransport(existing.Transport, sub.Transport) {
+				return &resp.Data.EventSubSubscriptions[i], nil
+			}
+		}
+
+		if resp.Data.Pagination.Cursor == "" {
+			return nil, fmt.Errorf("error: %s eventsub subscription conflicts with one that could not be found", sub.Type)
+		}
+		params.After = resp.Data.Pagination.Cursor
+	}
+}
+
+// isDisabledEventSubStatus reports whether Twitch stopped sending events for
+// a subscription with the given status.
+func isDisabledEventSubStatus(status EventSubSubscriptionStatus) bool {
+	return status != EventSubStatusEnabled && status != EventSubStatusPending
+}
+
+// eventSubSubscriptionKey identifies a subscription by its type, version and
+// the condition fields that are set.
+func eventSubSubscriptionKey(sub *EventSubSubscription) string {
+	c := sub.Condition
+	fields := map[string]string{
+		"broadcaster_user_id":      c.BroadcasterUserID,
+		"from_broadcaster_user_id": c.FromBroadcasterUserID,
+		"moderator_user_id":        c.ModeratorUserID,
+		"to_broadcaster_user_id":   c.ToBroadcasterUserID,
+		"reward_id":                c.RewardID,
+		"client_id":                c.ClientID,
+		"extension_client_id":      c.ExtensionClientID,
+		"user_id":                  c.UserID,
+		"organization_id":          c.OrganizationID,
+		"category_id":              c.CategoryID,
+		"campaign_id":              c.CampaignID,
+		"conduit_id":               c.ConduitID,
+	}
+
+	parts := []string{sub.Type, sub.Version}
+	for name, value := range fields {
+		if value != "" {
+			parts = append(parts, name+"="+value)
+		}
+	}
+	sort.Strings(parts[2:])
+
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,299 @@
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestEventSubManagerReconcile(t *testing.T) {
+	t.Parallel()
+
+	transport := EventSubTransport{
+		Method:   "webhook",
+		Callback: "https://example.com/eventsub",
+		Secret:   "s3cr37w0rd",
+	}
+
+	var mu sync.Mutex
+	var removed []string
+	var created []string
+	totalCost := 4
+
+	c := newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "app-token"}, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("after") == "" {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"data":[
+					{"id":"follow","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"},"cost":1},
+					{"id":"online-failed","status":"webhook_callback_verification_failed","type":"stream.online","version":"1","condition":{"broadcaster_user_id":"1337"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"},"cost":1}
+				],"total":4,"total_cost":4,"max_total_cost":6,"pagination":{"cursor":"next"}}`))
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data":[
+				{"id":"offline","status":"enabled","type":"stream.offline","version":"1","condition":{"broadcaster_user_id":"1337"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"},"cost":1},
+				{"id":"other-callback","status":"enabled","type":"stream.offline","version":"1","condition":{"broadcaster_user_id":"42"},"transport":{"method":"webhook","callback":"https://example.com/other"},"cost":1}
+			],"total":4,"total_cost":4,"max_total_cost":6,"pagination":{}}`))
+		case http.MethodDelete:
+			removed = append(removed, r.URL.Query().Get("id"))
+			totalCost--
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+
+			var sub EventSubSubscription
+			if err := json.Unmarshal(body, &sub); err != nil {
+				t.Error(err)
+			}
+
+			if sub.Transport != transport {
+				t.Errorf("expected transport to be %+v, got %+v", transport, sub.Transport)
+			}
+
+			if totalCost >= 5 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error":"Too Many Requests","status":429,"message":"subscription cost exceeded"}`))
+				return
+			}
+
+			totalCost++
+			created = append(created, sub.Type)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(fmt.Sprintf(`{"data":[{"id":"new-%s","status":"webhook_callback_verification_pending","type":"%s","version":"%s","cost":1}],"total":%d,"total_cost":%d,"max_total_cost":6}`, sub.Type, sub.Type, sub.Version, totalCost, totalCost)))
+		}
+	})
+
+	manager := c.NewEventSubManager(transport)
+	manager.MaxTotalCost = 5
+
+	result, err := manager.Reconcile([]EventSubSubscription{
+		*NewEventSubSubscription(EventSubTypeChannelFollow, EventSubChannelFollowCondition("1337", "1337"), EventSubTransport{}),
+		{Type: EventSubTypeStreamOnline, Condition: EventSubBroadcasterCondition("1337")},
+		{Type: EventSubTypeChannelCheer, Condition: EventSubBroadcasterCondition("1337")},
+		{Type: EventSubTypeChannelRaid, Condition: EventSubRaidToCondition("1337")},
+		{Type: EventSubTypeChannelBan, Condition: EventSubBroadcasterCondition("1337")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(removed)
+	if fmt.Sprint(removed) != "[offline online-failed]" {
+		t.Errorf("expected offline and online-failed to be removed, got %v", removed)
+	}
+
+	if len(result.Unchanged) != 1 || result.Unchanged[0].ID != "follow" {
+		t.Errorf("expected follow to be unchanged, got %+v", result.Unchanged)
+	}
+
+	if fmt.Sprint(created) != "[stream.online channel.cheer channel.raid]" {
+		t.Errorf("expected stream.online, channel.cheer and channel.raid to be created, got %v", created)
+	}
+
+	if len(result.Created) != 3 || len(result.Removed) != 2 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// The manager's budget is used up before Twitch's is
+	if len(result.Skipped) != 1 || result.Skipped[0].Type != EventSubTypeChannelBan {
+		t.Errorf("expected channel.ban to be skipped, got %+v", result.Skipped)
+	}
+
+	if result.TotalCost != 5 {
+		t.Errorf("expected total cost to be %d, got %d", 5, result.TotalCost)
+	}
+
+	// Twitch rejects subscriptions that exceed its budget
+	result, err = c.NewEventSubManager(transport).Reconcile([]EventSubSubscription{
+		{Type: EventSubTypeChannelFollow, Condition: EventSubChannelFollowCondition("1337", "1337")},
+		{Type: EventSubTypeStreamOffline, Condition: EventSubBroadcasterCondition("1337")},
+		{Type: EventSubTypeStreamOnline, Condition: EventSubBroadcasterCondition("1337")},
+		{Type: EventSubTypeChannelBan, Condition: EventSubBroadcasterCondition("1337")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Skipped) != 1 || result.Skipped[0].Type != EventSubTypeChannelBan {
+		t.Errorf("expected channel.ban to be skipped, got %+v", result.Skipped)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.NewEventSubManager(transport).Reconcile(nil)
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestEventSubManagerReconcileConduit(t *testing.T) {
+	t.Parallel()
+
+	transport := EventSubTransport{
+		Method:    "conduit",
+		ConduitID: "bfcfc993-26b1-b876-44d9-afe75a379dac",
+	}
+
+	var removed []string
+	var created []string
+
+	c := newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "app-token"}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data":[
+				{"id":"follow","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"bfcfc993-26b1-b876-44d9-afe75a379dac"},"cost":0},
+				{"id":"offline","status":"enabled","type":"stream.offline","version":"1","condition":{"broadcaster_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"bfcfc993-26b1-b876-44d9-afe75a379dac"},"cost":0},
+				{"id":"other-conduit","status":"enabled","type":"stream.online","version":"1","condition":{"broadcaster_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"another-conduit"},"cost":0}
+			],"total":3,"total_cost":0,"max_total_cost":10000,"pagination":{}}`))
+		case http.MethodDelete:
+			removed = append(removed, r.URL.Query().Get("id"))
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			var sub EventSubSubscription
+			if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+				t.Error(err)
+			}
+
+			if sub.Transport != transport {
+				t.Errorf("expected transport to be %+v, got %+v", transport, sub.Transport)
+			}
+
+			created = append(created, sub.Type)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(fmt.Sprintf(`{"data":[{"id":"new-%s","status":"enabled","type":"%s","version":"%s","cost":0}],"total":3,"total_cost":0,"max_total_cost":10000}`, sub.Type, sub.Type, sub.Version)))
+		}
+	})
+
+	result, err := c.NewEventSubManager(transport).Reconcile([]EventSubSubscription{
+		{Type: EventSubTypeChannelFollow, Condition: EventSubChannelFollowCondition("1337", "1337")},
+		{Type: EventSubTypeStreamOnline, Condition: EventSubBroadcasterCondition("1337")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Subscriptions of the same conduit are kept, those of other conduits are
+	// left alone
+	if len(result.Unchanged) != 1 || result.Unchanged[0].ID != "follow" {
+		t.Errorf("expected follow to be unchanged, got %+v", result.Unchanged)
+	}
+
+	if fmt.Sprint(removed) != "[offline]" {
+		t.Errorf("expected offline to be removed, got %v", removed)
+	}
+
+	if fmt.Sprint(created) != "[stream.online]" {
+		t.Errorf("expected stream.online to be created, got %v", created)
+	}
+}
+
+func TestEnsureEventSubSubscription(t *testing.T) {
+	t.Parallel()
+
+	created := `{"data":[{"id":"new","status":"webhook_callback_verification_pending","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"}}],"total":1,"total_cost":1,"max_total_cost":10000}`
+	firstPage := `{"data":[{"id":"other-callback","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"webhook","callback":"https://example.com/other"}}],"pagination":{"cursor":"next"}}`
+	secondPage := `{"data":[{"id":"other-condition","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"42","moderator_user_id":"42"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"}},{"id":"existing","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"}}],"pagination":{}}`
+	conduitPage := `{"data":[{"id":"other-conduit","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"another-conduit"}},{"id":"existing-conduit","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"1337","moderator_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"bfcfc993-26b1-b876-44d9-afe75a379dac"}}],"pagination":{}}`
+	conflict := `{"error":"Conflict","status":409,"message":"subscription already exists"}`
+
+	webhook := EventSubTransport{
+		Method:   "webhook",
+		Callback: "https://example.com/eventsub",
+		Secret:   "s3cre7w0rd",
+	}
+	conduit := EventSubTransport{
+		Method:    "conduit",
+		ConduitID: "bfcfc993-26b1-b876-44d9-afe75a379dac",
+	}
+
+	testCases := []struct {
+		transport    EventSubTransport
+		createStatus int
+		createBody   string
+		pages        []string
+		expectedID   string
+		expectedErr  string
+	}{
+		{webhook, http.StatusAccepted, created, nil, "new", ""},
+		{webhook, http.StatusConflict, conflict, []string{firstPage, secondPage}, "existing", ""},
+		{webhook, http.StatusConflict, conflict, []string{firstPage, `{"data":[],"pagination":{}}`}, "", "error: channel.follow eventsub subscription conflicts with one that could not be found"},
+		{webhook, http.StatusBadRequest, `{"error":"Bad Request","status":400,"message":"invalid version"}`, nil, "", "error: could not create channel.follow eventsub subscription: 400 Bad Request: invalid version"},
+		{conduit, http.StatusConflict, conflict, []string{conduitPage}, "existing-conduit", ""},
+	}
+
+	for _, testCase := range testCases {
+		var listed []string
+		c := newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"}, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				w.WriteHeader(testCase.createStatus)
+				w.Write([]byte(testCase.createBody))
+				return
+			}
+
+			listed = append(listed, r.URL.Query().Get("type"))
+			if r.URL.Query().Get("after") == "next" {
+				w.Write([]byte(testCase.pages[1]))
+				return
+			}
+			w.Write([]byte(testCase.pages[0]))
+		})
+
+		sub, err := c.EnsureEventSubSubscription(&EventSubSubscription{
+			Type:      EventSubTypeChannelFollow,
+			Condition: EventSubChannelFollowCondition("1337", "1337"),
+			Transport: testCase.transport,
+		})
+
+		if testCase.expectedErr != "" {
+			if err == nil || err.Error() != testCase.expectedErr {
+				t.Errorf("expected error %q, got %v", testCase.expectedErr, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+			continue
+		}
+
+		if sub.ID != testCase.expectedID {
+			t.Errorf("expected subscription %s, got %s", testCase.expectedID, sub.ID)
+		}
+
+		if len(listed) != len(testCase.pages) {
+			t.Errorf("expected %d pages to be listed, got %d", len(testCase.pages), len(listed))
+		}
+
+		for _, subType := range listed {
+			if subType != EventSubTypeChannelFollow {
+				t.Errorf("expected subscriptions to be filtered by type, got %q", subType)
+			}
+		}
+	}
+}
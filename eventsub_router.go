@@ -0,0 +1,634 @@
+package helix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Values of the Twitch-Eventsub-Message-Type header of webhook requests
+const (
+	EventSubMessageTypeNotification = "notification"
+	EventSubMessageTypeVerification = "webhook_callback_verification"
+	EventSubMessageTypeRevocation   = "revocation"
+)
+
+// EventSubWebhookMaxMessageAge is how old a webhook message may be, going by
+// its Twitch-Eventsub-Message-Timestamp header, for WebhookHandler to accept
+// it. Twitch recommends rejecting older messages, as they may be replayed.
+const EventSubWebhookMaxMessageAge = 10 * time.Minute
+
+// EventSubWebhookMaxClockSkew is how far a webhook message's timestamp may be
+// ahead of the router's clock for WebhookHandler to accept it.
+const EventSubWebhookMaxClockSkew = time.Minute
+
+// EventSubNotification is the payload of a webhook request, or of a websocket
+// notification message. Challenge is only set on webhook verification
+// requests.
+type EventSubNotification struct {
+	Subscription EventSubSubscription `json:"subscription"`
+	Challenge    string               `json:"challenge,omitempty"`
+	Event        json.RawMessage      `json:"event,omitempty"`
+}
+
+// eventSubHandler decodes an event and passes it to a typed handler.
+type eventSubHandler func(event json.RawMessage, sub EventSubSubscription) error
+
+// EventSubRouter dispatches EventSub notifications to the handlers registered
+// for their subscription type, such as OnChannelFollow. Notifications of types
+// without a handler go to the handlers registered with OnUnhandled. The same
+// router can serve webhook requests, see WebhookHandler, and notifications
+// received over a websocket, see Dispatch. Handlers may be registered at any
+// time and are called in the order they were registered.
+type EventSubRouter struct {
+	// Clock tells the time to WebhookHandler when it checks the age of
+	// messages. The system clock is used if it is nil.
+	Clock Clock
+
+	mu            sync.RWMutex
+	handlers      map[string][]eventSubHandler
+	unhandled     []func(event json.RawMessage, sub EventSubSubscription)
+	revocations   []func(sub EventSubSubscription)
+	reconnects    []func(session EventSubWebsocketSession)
+	errorHandlers []func(err error)
+
+	messageIDs eventSubMessageIDs
+}
+
+// NewEventSubRouter returns a router without handlers.
+func NewEventSubRouter() *EventSubRouter {
+	return &EventSubRouter{handlers: map[string][]eventSubHandler{}}
+}
+
+func (r *EventSubRouter) now() time.Time {
+	if r.Clock != nil {
+		return r.Clock.Now()
+	}
+
+	return systemClock{}.Now()
+}
+
+// on registers a handler for the events of a subscription type, decoded as
+// T. A version is given for types whose events changed shape between
+// versions, an empty version matches every version.
+func on[T any](r *EventSubRouter, eventType, version string, handler func(event T, sub EventSubSubscription)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := eventSubHandlerKey(eventType, version)
+	r.handlers[key] = append(r.handlers[key], func(raw json.RawMessage, sub EventSubSubscription) error {
+		var event T
+		if err := decodeEventSubEvent(raw, &event); err != nil {
+			return err
+		}
+
+		handler(event, sub)
+		return nil
+	})
+}
+
+func eventSubHandlerKey(eventType, version string) string {
+	if version == "" {
+		return eventType
+	}
+
+	return eventType + "/" + version
+}
+
+// OnUnhandled registers a handler for notifications of subscription types
+// that have no handler, with the undecoded event.
+func (r *EventSubRouter) OnUnhandled(handler func(event json.RawMessage, sub EventSubSubscription)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.unhandled = append(r.unhandled, handler)
+}
+
+// OnRevocation registers a handler for subscriptions that Twitch revoked. The
+// subscription's Status tells why, for example EventSubStatusAuthorizationRevoked
+// means the user has to authorize the application again, and
+// EventSubStatusVersionRemoved that the subscription has to be created again
+// with a newer version.
+func (r *EventSubRouter) OnRevocation(handler func(sub EventSubSubscription)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revocations = append(r.revocations, handler)
+}
+
+// OnReconnect registers a handler for websocket reconnect messages. Twitch
+// closes the session soon after, so the handler should connect to
+// session.ReconnectURL, whose session keeps the existing subscriptions.
+// EventSubWebsocketClient reconnects by itself and doesn't call these
+// handlers, see its OnResubscribe.
+func (r *EventSubRouter) OnReconnect(handler func(session EventSubWebsocketSession)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reconnects = append(r.reconnects, handler)
+}
+
+// OnError registers a handler for webhook messages that WebhookHandler
+// acknowledged but couldn't pass to the router's handlers, because the
+// message or its event couldn't be decoded.
+func (r *EventSubRouter) OnError(handler func(err error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errorHandlers = append(r.errorHandlers, handler)
+}
+
+func (r *EventSubRouter) dispatchError(err error) {
+	r.mu.RLock()
+	handlers := r.errorHandlers
+	r.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(err)
+	}
+}
+
+// DispatchRevocation passes a revoked subscription to the revocation handlers.
+func (r *EventSubRouter) DispatchRevocation(sub EventSubSubscription) {
+	r.mu.RLock()
+	handlers := r.revocations
+	r.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(sub)
+	}
+}
+
+// DispatchReconnect passes the session of a reconnect message to the
+// reconnect handlers.
+func (r *EventSubRouter) DispatchReconnect(session EventSubWebsocketSession) {
+	r.mu.RLock()
+	handlers := r.reconnects
+	r.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(session)
+	}
+}
+
+// DispatchWebsocketMessage decodes a message received over an EventSub
+// websocket and passes notifications, revocations and reconnect requests to
+// the router's handlers. Welcome and keepalive messages are ignored.
+func (r *EventSubRouter) DispatchWebsocketMessage(message []byte) error {
+	var msg EventSubWebsocketMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return fmt.Errorf("error: could not decode eventsub websocket message: %w", err)
+	}
+
+	switch msg.Metadata.MessageType {
+	case EventSubWebsocketMessageTypeNotification:
+		return r.DispatchEvent(msg.Payload.Event, msg.Payload.Subscription)
+	case EventSubWebsocketMessageTypeRevocation:
+		r.DispatchRevocation(msg.Payload.Subscription)
+	case EventSubWebsocketMessageTypeReconnect:
+		r.DispatchReconnect(msg.Payload.Session)
+	}
+
+	return nil
+}
+
+// Dispatch decodes the payload of a webhook notification or websocket
+// notification message and passes its event to the handlers of its
+// subscription type.
+func (r *EventSubRouter) Dispatch(payload []byte) error {
+	var notification EventSubNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return fmt.Errorf("error: could not decode eventsub notification: %w", err)
+	}
+
+	return r.DispatchEvent(notification.Event, notification.Subscription)
+}
+
+// DispatchEvent passes an event of the given subscription to the handlers of
+// the subscription's type, and to those of its version.
+func (r *EventSubRouter) DispatchEvent(event json.RawMessage, sub EventSubSubscription) error {
+	r.mu.RLock()
+	var handlers []eventSubHandler
+	handlers = append(handlers, r.handlers[sub.Type]...)
+	handlers = append(handlers, r.handlers[eventSubHandlerKey(sub.Type, sub.Version)]...)
+	unhandled := r.unhandled
+	r.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		for _, handler := range unhandled {
+			handler(event, sub)
+		}
+		return nil
+	}
+
+	for _, handler := range handlers {
+		if err := handler(event, sub); err != nil {
+			return fmt.Errorf("error: could not decode %s event: %w", sub.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// WebhookHandler returns an http.Handler for the callback of webhook
+// subscriptions. Requests whose signature isn't accepted by verify are
+// rejected with 403, for example pass client.VerifyEventSubSignature, and so
+// are messages older than EventSubWebhookMaxMessageAge or dated more than
+// EventSubWebhookMaxClockSkew in the future. Verification requests
+// are answered with their challenge. Notifications are dispatched to the
+// router's handlers before they are acknowledged with 204, so handlers should
+// return quickly as Twitch expects a response within a few seconds.
+// Revocations are passed to the revocation handlers and acknowledged.
+//
+// Messages whose ID was already handled, such as retries, are acknowledged
+// without being dispatched again. Messages that can't be decoded are
+// acknowledged too, so Twitch doesn't retry them and eventually revoke the
+// subscription, and are passed to the OnError handlers.
+func (r *EventSubRouter) WebhookHandler(verify func(header http.Header, message string) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !verify(req.Header, string(body)) {
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+
+		timestamp, err := time.Parse(time.RFC3339Nano, req.Header.Get("Twitch-Eventsub-Message-Timestamp"))
+		if err != nil {
+			http.Error(w, "invalid message timestamp", http.StatusBadRequest)
+			return
+		}
+
+		now := r.now()
+		if now.Sub(timestamp) > EventSubWebhookMaxMessageAge {
+			http.Error(w, "message expired", http.StatusForbidden)
+			return
+		}
+
+		if timestamp.Sub(now) > EventSubWebhookMaxClockSkew {
+			http.Error(w, "message timestamp is in the future", http.StatusForbidden)
+			return
+		}
+
+		messageType := req.Header.Get("Twitch-Eventsub-Message-Type")
+		if messageType != EventSubMessageTypeVerification && !r.messageIDs.add(req.Header.Get("Twitch-Eventsub-Message-Id"), now) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var notification EventSubNotification
+		if err := json.Unmarshal(body, &notification); err != nil {
+			if messageType == EventSubMessageTypeVerification {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			r.dispatchError(fmt.Errorf("error: could not decode eventsub notification: %w", err))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch messageType {
+		case EventSubMessageTypeVerification:
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(notification.Challenge))
+			return
+		case EventSubMessageTypeNotification:
+			if err := r.DispatchEvent(notification.Event, notification.Subscription); err != nil {
+				r.dispatchError(err)
+			}
+		case EventSubMessageTypeRevocation:
+			r.DispatchRevocation(notification.Subscription)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// eventSubMessageIDs remembers the IDs of the webhook messages received in
+// the last EventSubWebhookMaxMessageAge. Older messages are rejected by their
+// timestamp, so their IDs are forgotten.
+type eventSubMessageIDs struct {
+	mu       sync.Mutex
+	received map[string]time.Time
+	order    []string
+}
+
+// add remembers a message ID and reports whether it is new.
+func (m *eventSubMessageIDs) add(id string, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for len(m.order) > 0 && now.Sub(m.received[m.order[0]]) > EventSubWebhookMaxMessageAge {
+		delete(m.received, m.order[0])
+		m.order = m.order[1:]
+	}
+
+	if _, ok := m.received[id]; ok {
+		return false
+	}
+
+	if m.received == nil {
+		m.received = map[string]time.Time{}
+	}
+	m.received[id] = now
+	m.order = append(m.order, id)
+
+	return true
+}
+
+// errEventSubEmptyEvent is returned when a notification lacks its event.
+var errEventSubEmptyEvent = errors.New("notification has no event")
+
+// OnChannelUpdate registers a handler for channel.update notifications.
+func (r *EventSubRouter) OnChannelUpdate(handler func(event EventSubChannelUpdateEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelUpdate, "", handler)
+}
+
+// OnChannelFollow registers a handler for channel.follow notifications.
+func (r *EventSubRouter) OnChannelFollow(handler func(event EventSubChannelFollowEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelFollow, "", handler)
+}
+
+// OnChannelSubscription registers a handler for channel.subscribe notifications.
+func (r *EventSubRouter) OnChannelSubscription(handler func(event EventSubChannelSubscribeEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSubscription, "", handler)
+}
+
+// OnChannelSubscriptionEnd registers a handler for channel.subscription.end notifications.
+func (r *EventSubRouter) OnChannelSubscriptionEnd(handler func(event EventSubChannelSubscribeEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSubscriptionEnd, "", handler)
+}
+
+// OnChannelSubscriptionGift registers a handler for channel.subscription.gift notifications.
+func (r *EventSubRouter) OnChannelSubscriptionGift(handler func(event EventSubChannelSubscriptionGiftEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSubscriptionGift, "", handler)
+}
+
+// OnChannelSubscriptionMessage registers a handler for channel.subscription.message notifications.
+func (r *EventSubRouter) OnChannelSubscriptionMessage(handler func(event EventSubChannelSubscriptionMessageEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSubscriptionMessage, "", handler)
+}
+
+// OnChannelCheer registers a handler for channel.cheer notifications.
+func (r *EventSubRouter) OnChannelCheer(handler func(event EventSubChannelCheerEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelCheer, "", handler)
+}
+
+// OnChannelBitsUse registers a handler for channel.bits.use notifications.
+func (r *EventSubRouter) OnChannelBitsUse(handler func(event EventSubChannelBitsUseEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelBitsUse, "", handler)
+}
+
+// OnChannelRaid registers a handler for channel.raid notifications.
+func (r *EventSubRouter) OnChannelRaid(handler func(event EventSubChannelRaidEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelRaid, "", handler)
+}
+
+// OnChannelBan registers a handler for channel.ban notifications.
+func (r *EventSubRouter) OnChannelBan(handler func(event EventSubChannelBanEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelBan, "", handler)
+}
+
+// OnChannelUnban registers a handler for channel.unban notifications.
+func (r *EventSubRouter) OnChannelUnban(handler func(event EventSubChannelUnbanEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelUnban, "", handler)
+}
+
+// OnModeratorAdd registers a handler for channel.moderator.add notifications.
+func (r *EventSubRouter) OnModeratorAdd(handler func(event EventSubModeratorAddEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeModeratorAdd, "", handler)
+}
+
+// OnModeratorRemove registers a handler for channel.moderator.remove notifications.
+func (r *EventSubRouter) OnModeratorRemove(handler func(event EventSubModeratorRemoveEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeModeratorRemove, "", handler)
+}
+
+// OnChannelPointsCustomRewardAdd registers a handler for channel.channel_points_custom_reward.add notifications.
+func (r *EventSubRouter) OnChannelPointsCustomRewardAdd(handler func(event EventSubChannelPointsCustomRewardEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPointsCustomRewardAdd, "", handler)
+}
+
+// OnChannelPointsCustomRewardUpdate registers a handler for channel.channel_points_custom_reward.update notifications.
+func (r *EventSubRouter) OnChannelPointsCustomRewardUpdate(handler func(event EventSubChannelPointsCustomRewardEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPointsCustomRewardUpdate, "", handler)
+}
+
+// OnChannelPointsCustomRewardRemove registers a handler for channel.channel_points_custom_reward.remove notifications.
+func (r *EventSubRouter) OnChannelPointsCustomRewardRemove(handler func(event EventSubChannelPointsCustomRewardEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPointsCustomRewardRemove, "", handler)
+}
+
+// OnChannelPointsCustomRewardRedemptionAdd registers a handler for channel.channel_points_custom_reward_redemption.add notifications.
+func (r *EventSubRouter) OnChannelPointsCustomRewardRedemptionAdd(handler func(event EventSubChannelPointsCustomRewardRedemptionEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPointsCustomRewardRedemptionAdd, "", handler)
+}
+
+// OnChannelPointsCustomRewardRedemptionUpdate registers a handler for channel.channel_points_custom_reward_redemption.update notifications.
+func (r *EventSubRouter) OnChannelPointsCustomRewardRedemptionUpdate(handler func(event EventSubChannelPointsCustomRewardRedemptionEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPointsCustomRewardRedemptionUpdate, "", handler)
+}
+
+// OnChannelPointsAutomaticRewardRedemptionAdd registers a handler for version 1
+// channel.channel_points_automatic_reward_redemption.add notifications.
+func (r *EventSubRouter) OnChannelPointsAutomaticRewardRedemptionAdd(handler func(event EventSubChannelPointsAutomaticRewardRedemptionEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPointsAutomaticRewardRedemptionAdd, EventSubVersion1, handler)
+}
+
+// OnChannelPointsAutomaticRewardRedemptionAddV2 registers a handler for version 2
+// channel.channel_points_automatic_reward_redemption.add notifications.
+func (r *EventSubRouter) OnChannelPointsAutomaticRewardRedemptionAddV2(handler func(event EventSubChannelPointsAutomaticRewardRedemptionV2Event, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPointsAutomaticRewardRedemptionAdd, EventSubVersion2, handler)
+}
+
+// OnChannelChatClear registers a handler for channel.chat.clear notifications.
+func (r *EventSubRouter) OnChannelChatClear(handler func(event EventSubChannelChatClearEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelChatClear, "", handler)
+}
+
+// OnChannelChatClearUserMessages registers a handler for channel.chat.clear_user_messages notifications.
+func (r *EventSubRouter) OnChannelChatClearUserMessages(handler func(event EventSubChannelChatClearUserMessagesEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelChatClearUserMessages, "", handler)
+}
+
+// OnChannelChatMessage registers a handler for channel.chat.message notifications.
+func (r *EventSubRouter) OnChannelChatMessage(handler func(event EventSubChannelChatMessageEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelChatMessage, "", handler)
+}
+
+// OnChannelChatMessageDelete registers a handler for channel.chat.message_delete notifications.
+func (r *EventSubRouter) OnChannelChatMessageDelete(handler func(event EventSubChannelChatMessageDeleteEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelChatMessageDelete, "", handler)
+}
+
+// OnChannelChatNotification registers a handler for channel.chat.notification notifications.
+func (r *EventSubRouter) OnChannelChatNotification(handler func(event EventSubChannelChatNotificationEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelChatNotification, "", handler)
+}
+
+// OnChannelPollBegin registers a handler for channel.poll.begin notifications.
+func (r *EventSubRouter) OnChannelPollBegin(handler func(event EventSubChannelPollBeginEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPollBegin, "", handler)
+}
+
+// OnChannelPollProgress registers a handler for channel.poll.progress notifications.
+func (r *EventSubRouter) OnChannelPollProgress(handler func(event EventSubChannelPollProgressEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPollProgress, "", handler)
+}
+
+// OnChannelPollEnd registers a handler for channel.poll.end notifications.
+func (r *EventSubRouter) OnChannelPollEnd(handler func(event EventSubChannelPollEndEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPollEnd, "", handler)
+}
+
+// OnChannelPredictionBegin registers a handler for channel.prediction.begin notifications.
+func (r *EventSubRouter) OnChannelPredictionBegin(handler func(event EventSubChannelPredictionBeginEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPredictionBegin, "", handler)
+}
+
+// OnChannelPredictionProgress registers a handler for channel.prediction.progress notifications.
+func (r *EventSubRouter) OnChannelPredictionProgress(handler func(event EventSubChannelPredictionProgressEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPredictionProgress, "", handler)
+}
+
+// OnChannelPredictionLock registers a handler for channel.prediction.lock notifications.
+func (r *EventSubRouter) OnChannelPredictionLock(handler func(event EventSubChannelPredictionLockEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPredictionLock, "", handler)
+}
+
+// OnChannelPredictionEnd registers a handler for channel.prediction.end notifications.
+func (r *EventSubRouter) OnChannelPredictionEnd(handler func(event EventSubChannelPredictionEndEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelPredictionEnd, "", handler)
+}
+
+// OnExtensionBitsTransactionCreate registers a handler for extension.bits_transaction.create notifications.
+func (r *EventSubRouter) OnExtensionBitsTransactionCreate(handler func(event EventSubExtensionBitsTransactionCreateEvent, sub EventSubSubscription)) {
+	on(r, EventSubExtensionBitsTransactionCreate, "", handler)
+}
+
+// OnHypeTrainBegin registers a handler for channel.hype_train.begin notifications.
+func (r *EventSubRouter) OnHypeTrainBegin(handler func(event EventSubHypeTrainBeginEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeHypeTrainBegin, "", handler)
+}
+
+// OnHypeTrainProgress registers a handler for channel.hype_train.progress notifications.
+func (r *EventSubRouter) OnHypeTrainProgress(handler func(event EventSubHypeTrainProgressEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeHypeTrainProgress, "", handler)
+}
+
+// OnHypeTrainEnd registers a handler for channel.hype_train.end notifications.
+func (r *EventSubRouter) OnHypeTrainEnd(handler func(event EventSubHypeTrainEndEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeHypeTrainEnd, "", handler)
+}
+
+// OnStreamOnline registers a handler for stream.online notifications.
+func (r *EventSubRouter) OnStreamOnline(handler func(event EventSubStreamOnlineEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeStreamOnline, "", handler)
+}
+
+// OnStreamOffline registers a handler for stream.offline notifications.
+func (r *EventSubRouter) OnStreamOffline(handler func(event EventSubStreamOfflineEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeStreamOffline, "", handler)
+}
+
+// OnUserAuthorizationRevoke registers a handler for user.authorization.revoke notifications.
+func (r *EventSubRouter) OnUserAuthorizationRevoke(handler func(event EventSubUserAuthenticationRevokeEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeUserAuthorizationRevoke, "", handler)
+}
+
+// OnUserUpdate registers a handler for user.update notifications.
+func (r *EventSubRouter) OnUserUpdate(handler func(event EventSubUserUpdateEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeUserUpdate, "", handler)
+}
+
+// OnChannelGoalBegin registers a handler for channel.goal.begin notifications.
+func (r *EventSubRouter) OnChannelGoalBegin(handler func(event EventSubChannelGoalStartEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelGoalBegin, "", handler)
+}
+
+// OnChannelGoalProgress registers a handler for channel.goal.progress notifications.
+func (r *EventSubRouter) OnChannelGoalProgress(handler func(event EventSubChannelGoalProgressEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelGoalProgress, "", handler)
+}
+
+// OnChannelGoalEnd registers a handler for channel.goal.end notifications.
+func (r *EventSubRouter) OnChannelGoalEnd(handler func(event EventSubChannelGoalEndEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelGoalEnd, "", handler)
+}
+
+// OnCharityDonation registers a handler for channel.charity_campaign.donate notifications.
+func (r *EventSubRouter) OnCharityDonation(handler func(event EventSubCharityDonationEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeCharityDonation, "", handler)
+}
+
+// OnCharityProgress registers a handler for channel.charity_campaign.progress notifications.
+func (r *EventSubRouter) OnCharityProgress(handler func(event EventSubCharityProgressEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeCharityProgress, "", handler)
+}
+
+// OnCharityStop registers a handler for channel.charity_campaign.stop notifications.
+func (r *EventSubRouter) OnCharityStop(handler func(event EventSubCharityStopEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeCharityStop, "", handler)
+}
+
+// OnCharityStart registers a handler for channel.charity_campaign.start notifications.
+func (r *EventSubRouter) OnCharityStart(handler func(event EventSubCharityStartEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeCharityStart, "", handler)
+}
+
+// OnShoutoutCreate registers a handler for channel.shoutout.create notifications.
+func (r *EventSubRouter) OnShoutoutCreate(handler func(event EventSubShoutoutCreateEvent, sub EventSubSubscription)) {
+	on(r, EventSubShoutoutCreate, "", handler)
+}
+
+// OnShoutoutReceive registers a handler for channel.shoutout.receive notifications.
+func (r *EventSubRouter) OnShoutoutReceive(handler func(event EventSubShoutoutReceiveEvent, sub EventSubSubscription)) {
+	on(r, EventSubShoutoutReceive, "", handler)
+}
+
+// OnChannelSuspiciousUserMessage registers a handler for channel.suspicious_user.message notifications.
+func (r *EventSubRouter) OnChannelSuspiciousUserMessage(handler func(event EventSubChannelSuspiciousUserMessageEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSuspiciousUserMessage, "", handler)
+}
+
+// OnChannelSuspiciousUserUpdate registers a handler for channel.suspicious_user.update notifications.
+func (r *EventSubRouter) OnChannelSuspiciousUserUpdate(handler func(event EventSubChannelSuspiciousUserUpdateEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSuspiciousUserUpdate, "", handler)
+}
+
+// OnChannelModerate registers a handler for channel.moderate notifications.
+func (r *EventSubRouter) OnChannelModerate(handler func(event EventSubChannelModerateEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelModerate, "", handler)
+}
+
+// OnChannelSharedChatBegin registers a handler for channel.shared_chat.begin notifications.
+func (r *EventSubRouter) OnChannelSharedChatBegin(handler func(event EventSubChannelSharedChatBeginEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSharedChatBegin, "", handler)
+}
+
+// OnChannelSharedChatUpdate registers a handler for channel.shared_chat.update notifications.
+func (r *EventSubRouter) OnChannelSharedChatUpdate(handler func(event EventSubChannelSharedChatUpdateEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSharedChatUpdate, "", handler)
+}
+
+// OnChannelSharedChatEnd registers a handler for channel.shared_chat.end notifications.
+func (r *EventSubRouter) OnChannelSharedChatEnd(handler func(event EventSubChannelSharedChatEndEvent, sub EventSubSubscription)) {
+	on(r, EventSubTypeChannelSharedChatEnd, "", handler)
+}
+
+func decodeEventSubEvent(raw json.RawMessage, event interface{}) error {
+	if len(raw) == 0 {
+		return errEventSubEmptyEvent
+	}
+
+	return json.Unmarshal(raw, event)
+}
@@ -0,0 +1,539 @@
+package helix
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventSubRouterDispatch(t *testing.T) {
+	t.Parallel()
+
+	var follows []EventSubChannelFollowEvent
+	var unhandled []string
+
+	router := NewEventSubRouter()
+	router.OnChannelFollow(func(event EventSubChannelFollowEvent, sub EventSubSubscription) {
+		if sub.ID != "f1c2a387-161a-49f9-a165-0f21d7a4e1c4" {
+			t.Errorf("expected the subscription to be passed along, got %q", sub.ID)
+		}
+		follows = append(follows, event)
+	})
+	router.OnUnhandled(func(event json.RawMessage, sub EventSubSubscription) {
+		unhandled = append(unhandled, sub.Type)
+	})
+
+	testCases := []struct {
+		payload string
+		err     string
+	}{
+		{
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":"1337","user_login":"awesome_user","broadcaster_user_id":"12826"}}`,
+			"",
+		},
+		{
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c5","type":"channel.unknown","version":"1"},"event":{}}`,
+			"",
+		},
+		{
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":1337}}`,
+			"error: could not decode channel.follow event: json: cannot unmarshal number into Go struct field EventSubChannelFollowEvent.user_id of type string",
+		},
+		{
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"}}`,
+			"error: could not decode channel.follow event: notification has no event",
+		},
+		{
+			`not json`,
+			"error: could not decode eventsub notification: invalid character 'o' in literal null (expecting 'u')",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := router.Dispatch([]byte(testCase.payload))
+		if testCase.err == "" && err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+
+		if testCase.err != "" && (err == nil || err.Error() != testCase.err) {
+			t.Errorf("expected error %q, got %v", testCase.err, err)
+		}
+	}
+
+	if len(follows) != 1 || follows[0].UserLogin != "awesome_user" {
+		t.Errorf("expected 1 follow by awesome_user, got %+v", follows)
+	}
+
+	if len(unhandled) != 1 || unhandled[0] != "channel.unknown" {
+		t.Errorf("expected 1 unhandled channel.unknown event, got %v", unhandled)
+	}
+}
+
+func TestEventSubRouterWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	now := clock.Now()
+
+	follows := 0
+	var revoked []EventSubSubscription
+	var errs []string
+	router := NewEventSubRouter()
+	router.Clock = clock
+	router.OnChannelFollow(func(event EventSubChannelFollowEvent, sub EventSubSubscription) {
+		follows++
+	})
+	router.OnRevocation(func(sub EventSubSubscription) {
+		revoked = append(revoked, sub)
+	})
+	router.OnError(func(err error) {
+		errs = append(errs, err.Error())
+	})
+
+	handler := router.WebhookHandler(func(header http.Header, message string) bool {
+		return header.Get("Twitch-Eventsub-Message-Signature") == "valid"
+	})
+
+	testCases := []struct {
+		messageType string
+		messageID   string
+		timestamp   time.Time
+		signature   string
+		body        string
+		status      int
+		response    string
+	}{
+		{
+			EventSubMessageTypeVerification,
+			"e76c6bd4-55c9-4987-8304-da1588d8988b",
+			now,
+			"valid",
+			`{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"}}`,
+			http.StatusOK,
+			"pogchamp-kappa-360noscope-vohiyo",
+		},
+		{
+			EventSubMessageTypeNotification,
+			"befa7b53-d79d-478f-86b9-120f112b044e",
+			now.Add(-time.Minute),
+			"valid",
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":"1337"}}`,
+			http.StatusNoContent,
+			"",
+		},
+		{
+			// A retry of the same message isn't dispatched again
+			EventSubMessageTypeNotification,
+			"befa7b53-d79d-478f-86b9-120f112b044e",
+			now.Add(-time.Minute),
+			"valid",
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":"1337"}}`,
+			http.StatusNoContent,
+			"",
+		},
+		{
+			EventSubMessageTypeNotification,
+			"6f2a1f5e-3e4b-4f39-9c61-1b6c36a3c1b2",
+			now.Add(-EventSubWebhookMaxMessageAge - time.Second),
+			"valid",
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":"1337"}}`,
+			http.StatusForbidden,
+			"message expired\n",
+		},
+		{
+			// Messages dated too far ahead of the clock may be replayed later
+			EventSubMessageTypeNotification,
+			"9d4e2c1b-6a7f-4e38-8b15-2f0c9a6d3e47",
+			now.Add(EventSubWebhookMaxClockSkew + time.Second),
+			"valid",
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":"1337"}}`,
+			http.StatusForbidden,
+			"message timestamp is in the future\n",
+		},
+		{
+			// A clock that is slightly behind Twitch's is tolerated
+			EventSubMessageTypeVerification,
+			"5b8f3a2d-1c6e-4f97-a0d4-7e2b9c1f8a63",
+			now.Add(EventSubWebhookMaxClockSkew - time.Second),
+			"valid",
+			`{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"}}`,
+			http.StatusOK,
+			"pogchamp-kappa-360noscope-vohiyo",
+		},
+		{
+			EventSubMessageTypeNotification,
+			"0b7a9e55-8f6c-4d53-9a57-0c2c1f1a8d34",
+			now,
+			"invalid",
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":"1337"}}`,
+			http.StatusForbidden,
+			"invalid signature\n",
+		},
+		{
+			// Events that can't be decoded are acknowledged and reported
+			EventSubMessageTypeNotification,
+			"3c9c0f5e-7f43-4a8e-b0a5-5d1f1c2d7e61",
+			now,
+			"valid",
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.follow","version":"2"},"event":{"user_id":1337}}`,
+			http.StatusNoContent,
+			"",
+		},
+		{
+			EventSubMessageTypeRevocation,
+			"84c1e79a-2a4b-4c13-ba0b-4312293e9308",
+			now,
+			"valid",
+			`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"authorization_revoked","type":"channel.follow","version":"2"}}`,
+			http.StatusNoContent,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/eventsub", strings.NewReader(testCase.body))
+		req.Header.Set("Twitch-Eventsub-Message-Id", testCase.messageID)
+		req.Header.Set("Twitch-Eventsub-Message-Timestamp", testCase.timestamp.Format(time.RFC3339Nano))
+		req.Header.Set("Twitch-Eventsub-Message-Type", testCase.messageType)
+		req.Header.Set("Twitch-Eventsub-Message-Signature", testCase.signature)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != testCase.status {
+			t.Errorf("expected status %d for %s message, got %d", testCase.status, testCase.messageType, rec.Code)
+		}
+
+		if rec.Body.String() != testCase.response {
+			t.Errorf("expected response %q for %s message, got %q", testCase.response, testCase.messageType, rec.Body.String())
+		}
+	}
+
+	if follows != 1 {
+		t.Errorf("expected 1 follow to be handled, got %d", follows)
+	}
+
+	if len(revoked) != 1 || revoked[0].Status != EventSubStatusAuthorizationRevoked {
+		t.Errorf("expected 1 subscription to be revoked, got %+v", revoked)
+	}
+
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "error: could not decode channel.follow event") {
+		t.Errorf("expected the undecodable event to be reported, got %v", errs)
+	}
+}
+
+func TestEventSubMessageIDs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var ids eventSubMessageIDs
+	if !ids.add("first", now) || ids.add("first", now.Add(time.Minute)) {
+		t.Error("expected only the first message to be new")
+	}
+
+	// IDs are forgotten once their messages would be rejected as expired
+	if !ids.add("second", now.Add(EventSubWebhookMaxMessageAge+time.Second)) {
+		t.Error("expected the second message to be new")
+	}
+
+	if len(ids.received) != 1 || len(ids.order) != 1 {
+		t.Errorf("expected the first id to be forgotten, got %v", ids.received)
+	}
+}
+
+func TestEventSubRouterWebsocketMessages(t *testing.T) {
+	t.Parallel()
+
+	var follows []string
+	var revoked []EventSubSubscriptionStatus
+	var reconnectURLs []string
+
+	router := NewEventSubRouter()
+	router.OnChannelFollow(func(event EventSubChannelFollowEvent, sub EventSubSubscription) {
+		follows = append(follows, event.UserID)
+	})
+	router.OnRevocation(func(sub EventSubSubscription) {
+		revoked = append(revoked, sub.Status)
+	})
+	router.OnReconnect(func(session EventSubWebsocketSession) {
+		reconnectURLs = append(reconnectURLs, session.ReconnectURL)
+	})
+
+	messages := []string{
+		`{"metadata":{"message_id":"96a3f3b5-5dec-4eed-908e-e11ee657416c","message_type":"session_welcome","message_timestamp":"2023-07-19T14:56:51.634234626Z"},"payload":{"session":{"id":"AQoQILE98gtqShGmLD7AM6yJThAB","status":"connected","connected_at":"2023-07-19T14:56:51.616329898Z","keepalive_timeout_seconds":10,"reconnect_url":null}}}`,
+		`{"metadata":{"message_id":"84c1e79a-2a4b-4c13-ba0b-4312293e9308","message_type":"session_keepalive","message_timestamp":"2023-07-19T10:11:12.634234626Z"},"payload":{}}`,
+		`{"metadata":{"message_id":"befa7b53-d79d-478f-86b9-120f112b044e","message_type":"notification","message_timestamp":"2022-11-16T10:11:12.464757833Z","subscription_type":"channel.follow","subscription_version":"2"},"payload":{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"enabled","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"12826"},"transport":{"method":"websocket","session_id":"AQoQILE98gtqShGmLD7AM6yJThAB"}},"event":{"user_id":"1337","user_login":"awesome_user"}}}`,
+		`{"metadata":{"message_id":"84c1e79a-2a4b-4c13-ba0b-4312293e9308","message_type":"session_reconnect","message_timestamp":"2022-11-18T09:10:11.634234626Z"},"payload":{"session":{"id":"AQoQexAWVYKSTIu4ec_2VAxyuhAB","status":"reconnecting","keepalive_timeout_seconds":null,"reconnect_url":"wss://eventsub.wss.twitch.tv?...","connected_at":"2022-11-16T10:11:12.634234626Z"}}}`,
+		`{"metadata":{"message_id":"84c1e79a-2a4b-4c13-ba0b-4312293e9308","message_type":"revocation","message_timestamp":"2022-11-16T10:11:12.464757833Z","subscription_type":"channel.follow","subscription_version":"2"},"payload":{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"authorization_revoked","type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"12826"},"transport":{"method":"websocket","session_id":"AQoQexAWVYKSTIu4ec_2VAxyuhAB"}}}}`,
+	}
+
+	for _, message := range messages {
+		if err := router.DispatchWebsocketMessage([]byte(message)); err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+
+	if len(follows) != 1 || follows[0] != "1337" {
+		t.Errorf("expected 1 follow by 1337, got %v", follows)
+	}
+
+	if len(revoked) != 1 || revoked[0] != EventSubStatusAuthorizationRevoked {
+		t.Errorf("expected 1 revocation, got %v", revoked)
+	}
+
+	if len(reconnectURLs) != 1 || reconnectURLs[0] != "wss://eventsub.wss.twitch.tv?..." {
+		t.Errorf("expected 1 reconnect, got %v", reconnectURLs)
+	}
+
+	err := router.DispatchWebsocketMessage([]byte("not json"))
+	if err == nil || !strings.HasPrefix(err.Error(), "error: could not decode eventsub websocket message") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestEventSubRouterChannelBitsUse(t *testing.T) {
+	t.Parallel()
+
+	var events []EventSubChannelBitsUseEvent
+	router := NewEventSubRouter()
+	router.OnChannelBitsUse(func(event EventSubChannelBitsUseEvent, sub EventSubSubscription) {
+		events = append(events, event)
+	})
+
+	payloads := []string{
+		`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.bits.use","version":"1"},"event":{"user_id":"1337","user_login":"cool_user","user_name":"Cool_User","broadcaster_user_id":"1337","broadcaster_user_login":"cooler_user","broadcaster_user_name":"Cooler_User","bits":2,"type":"cheer","message":{"text":"cheer1 hi 😀 Kappa cheer1","fragments":[{"text":"cheer1","type":"cheermote","emote":null,"cheermote":{"prefix":"cheer","bits":1,"tier":1}},{"text":" hi 😀 ","type":"text","emote":null,"cheermote":null},{"text":"Kappa","type":"emote","emote":{"id":"25","emote_set_id":"0","owner_id":"0","format":["static"]},"cheermote":null},{"text":" ","type":"text","emote":null,"cheermote":null},{"text":"cheer1","type":"cheermote","emote":null,"cheermote":{"prefix":"cheer","bits":1,"tier":1}}]},"power_up":null}}`,
+		`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.bits.use","version":"1"},"event":{"user_id":"1337","user_login":"cool_user","user_name":"Cool_User","broadcaster_user_id":"1337","broadcaster_user_login":"cooler_user","broadcaster_user_name":"Cooler_User","bits":50,"type":"power_up","message":{"text":"Kappa","fragments":[{"text":"Kappa","type":"emote","emote":{"id":"25","emote_set_id":"0","owner_id":"0","format":["static","animated"]},"cheermote":null}]},"power_up":{"type":"gigantify_an_emote","emote":{"id":"25","name":"Kappa"},"message_effect_id":null}}}`,
+	}
+
+	for _, payload := range payloads {
+		if err := router.Dispatch([]byte(payload)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	cheer := events[0]
+	if cheer.Type != EventSubBitsUseTypeCheer || cheer.Bits != 2 || cheer.PowerUp != nil {
+		t.Errorf("unexpected cheer %+v", cheer)
+	}
+	if fragment := cheer.Message.Fragments[0]; fragment.Type != EventSubChatMessageFragmentTypeCheermote || fragment.Cheermote.Prefix != "cheer" {
+		t.Errorf("unexpected cheermote fragment %+v", fragment)
+	}
+
+	emotes := cheer.Message.Emotes()
+	if len(emotes) != 1 || emotes[0] != (EventSubEmote{Begin: 12, End: 16, ID: "25"}) {
+		t.Errorf("expected Kappa at 12-16, got %+v", emotes)
+	}
+
+	powerUp := events[1]
+	if powerUp.Type != EventSubBitsUseTypePowerUp || powerUp.PowerUp == nil {
+		t.Fatalf("unexpected power-up %+v", powerUp)
+	}
+	if powerUp.PowerUp.Type != EventSubBitsPowerUpTypeGigantifyAnEmote || powerUp.PowerUp.Emote.Name != "Kappa" {
+		t.Errorf("unexpected power-up %+v", powerUp.PowerUp)
+	}
+	if format := powerUp.Message.Fragments[0].Emote.Format; len(format) != 2 || format[1] != "animated" {
+		t.Errorf("unexpected emote formats %v", format)
+	}
+}
+
+func TestEventSubRouterVersionedHandlers(t *testing.T) {
+	t.Parallel()
+
+	var v1 []EventSubChannelPointsAutomaticRewardRedemptionEvent
+	var v2 []EventSubChannelPointsAutomaticRewardRedemptionV2Event
+	var allVersions []string
+
+	router := NewEventSubRouter()
+	router.OnChannelPointsAutomaticRewardRedemptionAdd(func(event EventSubChannelPointsAutomaticRewardRedemptionEvent, sub EventSubSubscription) {
+		v1 = append(v1, event)
+	})
+	router.OnChannelPointsAutomaticRewardRedemptionAddV2(func(event EventSubChannelPointsAutomaticRewardRedemptionV2Event, sub EventSubSubscription) {
+		v2 = append(v2, event)
+	})
+	on(router, EventSubTypeChannelPointsAutomaticRewardRedemptionAdd, "", func(event json.RawMessage, sub EventSubSubscription) {
+		allVersions = append(allVersions, sub.Version)
+	})
+
+	payloads := []string{
+		`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.channel_points_automatic_reward_redemption.add","version":"1"},"event":{"broadcaster_user_id":"12826","broadcaster_user_name":"Twitch","broadcaster_user_login":"twitch","user_id":"141981764","user_name":"TwitchDev","user_login":"twitchdev","id":"f024099a-e0aa-4a3a-b7c0-e8f6bd2aa8ba","reward":{"type":"chosen_sub_emote_unlock","cost":300,"unlocked_emote":{"id":"emotesv2_9c9d3ee6e9c04e7c8d3c3a5fb4d2f3b6","name":"twitchdevHype"}},"message":{"text":"Hello Kappa","emotes":[{"id":"25","begin":6,"end":10}]},"user_input":"Hello Kappa","redeemed_at":"2024-02-23T21:14:34.260398045Z"}}`,
+		`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c5","type":"channel.channel_points_automatic_reward_redemption.add","version":"2"},"event":{"broadcaster_user_id":"12826","broadcaster_user_name":"Twitch","broadcaster_user_login":"twitch","user_id":"141981764","user_name":"TwitchDev","user_login":"twitchdev","id":"f024099a-e0aa-4a3a-b7c0-e8f6bd2aa8bb","reward":{"type":"send_highlighted_message","channel_points":100,"emote":null},"message":{"text":"Hello Kappa","fragments":[{"type":"text","text":"Hello "},{"type":"emote","text":"Kappa","emote":{"id":"25"}}]},"redeemed_at":"2024-08-12T21:14:34.260398045Z"}}`,
+	}
+
+	for _, payload := range payloads {
+		if err := router.Dispatch([]byte(payload)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if len(v1) != 1 || len(v2) != 1 {
+		t.Fatalf("expected 1 event of each version, got %d and %d", len(v1), len(v2))
+	}
+
+	if reward := v1[0].Reward; reward.Type != EventSubAutomaticRewardTypeChosenSubEmoteUnlock || reward.Cost != 300 || reward.UnlockedEmote.Name != "twitchdevHype" {
+		t.Errorf("unexpected version 1 reward %+v", reward)
+	}
+	if emotes := v1[0].Message.Emotes; len(emotes) != 1 || emotes[0].Begin != 6 {
+		t.Errorf("unexpected version 1 emotes %+v", emotes)
+	}
+
+	if reward := v2[0].Reward; reward.Type != EventSubAutomaticRewardTypeSendHighlightedMessage || reward.ChannelPoints != 100 || reward.Emote != nil {
+		t.Errorf("unexpected version 2 reward %+v", reward)
+	}
+	if emotes := v2[0].Message.Emotes(); len(emotes) != 1 || emotes[0] != (EventSubEmote{Begin: 6, End: 10, ID: "25"}) {
+		t.Errorf("unexpected version 2 emotes %+v", emotes)
+	}
+
+	if len(allVersions) != 2 {
+		t.Errorf("expected the handler of every version to get both events, got %v", allVersions)
+	}
+}
+
+func TestEventSubRouterSuspiciousUser(t *testing.T) {
+	t.Parallel()
+
+	var messages []EventSubChannelSuspiciousUserMessageEvent
+	var updates []EventSubChannelSuspiciousUserUpdateEvent
+
+	router := NewEventSubRouter()
+	router.OnChannelSuspiciousUserMessage(func(event EventSubChannelSuspiciousUserMessageEvent, sub EventSubSubscription) {
+		messages = append(messages, event)
+	})
+	router.OnChannelSuspiciousUserUpdate(func(event EventSubChannelSuspiciousUserUpdateEvent, sub EventSubSubscription) {
+		updates = append(updates, event)
+	})
+
+	payloads := []string{
+		`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","type":"channel.suspicious_user.message","version":"1"},"event":{"broadcaster_user_id":"1050263432","broadcaster_user_name":"dcf9d7fb9b3c4e6ea24d4c3d5f3a7d4e","broadcaster_user_login":"dcf9d7fb9b3c4e6ea24d4c3d5f3a7d4e","user_id":"1050263434","user_name":"4a46e2cbc9e54a2c8f5e2c5d3e2b1c2d","user_login":"4a46e2cbc9e54a2c8f5e2c5d3e2b1c2d","low_trust_status":"active_monitoring","shared_ban_channel_ids":["100","200"],"types":["ban_evader_detector","shared_channel_ban"],"ban_evasion_evaluation":"likely","message":{"message_id":"101010","text":"bad stuff pogchamp","fragments":[{"type":"emote","text":"bad stuff","cheermote":null,"emote":{"id":"899","emote_set_id":"1"}},{"type":"cheermote","text":"pogchamp","cheermote":{"prefix":"pogchamp","bits":100,"tier":1},"emote":null}]}}}`,
+		`{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c5","type":"channel.suspicious_user.update","version":"1"},"event":{"broadcaster_user_id":"1050263432","broadcaster_user_name":"dcf9d7fb9b3c4e6ea24d4c3d5f3a7d4e","broadcaster_user_login":"dcf9d7fb9b3c4e6ea24d4c3d5f3a7d4e","moderator_user_id":"1050263436","moderator_user_name":"4308a20e4d5c4f5c9c5b3e2c1d1a6e3f","moderator_user_login":"4308a20e4d5c4f5c9c5b3e2c1d1a6e3f","user_id":"1050263434","user_name":"4a46e2cbc9e54a2c8f5e2c5d3e2b1c2d","user_login":"4a46e2cbc9e54a2c8f5e2c5d3e2b1c2d","low_trust_status":"restricted"}}`,
+	}
+
+	for _, payload := range payloads {
+		if err := router.Dispatch([]byte(payload)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if len(messages) != 1 || len(updates) != 1 {
+		t.Fatalf("expected 1 message and 1 update, got %d and %d", len(messages), len(updates))
+	}
+
+	message := messages[0]
+	if message.LowTrustStatus != EventSubLowTrustStatusActiveMonitoring || message.BanEvasionEvaluation != EventSubBanEvasionEvaluationLikely {
+		t.Errorf("unexpected message %+v", message)
+	}
+	if len(message.Types) != 2 || message.Types[1] != EventSubSuspiciousUserTypeSharedChannelBan || len(message.SharedBanChannelIDs) != 2 {
+		t.Errorf("unexpected suspicious user types %v and shared bans %v", message.Types, message.SharedBanChannelIDs)
+	}
+	if message.Message.MessageID != "101010" || len(message.Message.Fragments) != 2 || message.Message.Fragments[1].Cheermote.Bits != 100 {
+		t.Errorf("unexpected chat message %+v", message.Message)
+	}
+
+	if update := updates[0]; update.LowTrustStatus != EventSubLowTrustStatusRestricted || update.ModeratorUserID != "1050263436" {
+		t.Errorf("unexpected update %+v", update)
+	}
+}
+
+func TestEventSubRouterChannelChatMessage(t *testing.T) {
+	t.Parallel()
+
+	var events []EventSubChannelChatMessageEvent
+	router := NewEventSubRouter()
+	router.OnChannelChatMessage(func(event EventSubChannelChatMessageEvent, sub EventSubSubscription) {
+		events = append(events, event)
+	})
+
+	payload := `{"subscription":{"id":"0b7f3361-672b-4d39-b307-dd5b576c9b27","type":"channel.chat.message","version":"1"},"event":{"broadcaster_user_id":"1971641","broadcaster_user_login":"streamer","broadcaster_user_name":"streamer","chatter_user_id":"4145994","chatter_user_login":"viewer32","chatter_user_name":"viewer32","message_id":"cc106a89-1814-919d-454c-f4f2f970aae7","message":{"text":"@streamer !so Kappa  cheer100 now","fragments":[{"type":"mention","text":"@streamer","cheermote":null,"emote":null,"mention":{"user_id":"1971641","user_name":"streamer","user_login":"streamer"}},{"type":"text","text":" !so ","cheermote":null,"emote":null,"mention":null},{"type":"emote","text":"Kappa","cheermote":null,"emote":{"id":"25","emote_set_id":"0","owner_id":"0","format":["static"]},"mention":null},{"type":"text","text":"  ","cheermote":null,"emote":null,"mention":null},{"type":"cheermote","text":"cheer100","cheermote":{"prefix":"cheer","bits":100,"tier":100},"emote":null,"mention":null},{"type":"text","text":" now","cheermote":null,"emote":null,"mention":null}]},"color":"#00FF7F","badges":[{"set_id":"moderator","id":"1","info":""},{"set_id":"subscriber","id":"12","info":"16"}],"message_type":"text","cheer":{"bits":100},"reply":{"parent_message_id":"c2ef1e7d-b0b2-4a3f-8f8b-a0b9c0cef0a3","parent_message_body":"hi","parent_user_id":"1971641","parent_user_name":"streamer","parent_user_login":"streamer","thread_message_id":"c2ef1e7d-b0b2-4a3f-8f8b-a0b9c0cef0a3","thread_user_id":"1971641","thread_user_name":"streamer","thread_user_login":"streamer"},"channel_points_custom_reward_id":null,"channel_points_animation_id":null,"source_broadcaster_user_id":"112233","source_broadcaster_user_login":"other","source_broadcaster_user_name":"Other","source_message_id":"e03f86c0-d6a4-4c52-8d2b-dd2b1b16f9f6","source_badges":[{"set_id":"subscriber","id":"3","info":"3"}],"is_source_only":false}}`
+
+	if err := router.Dispatch([]byte(payload)); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if !event.IsReply() || event.Cheer.Bits != 100 || len(event.Badges) != 2 || event.Badges[1].Info != "16" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if event.SourceBroadcasterUserID != "112233" || len(event.SourceBadges) != 1 {
+		t.Errorf("expected the shared chat source, got %q with badges %v", event.SourceBroadcasterUserID, event.SourceBadges)
+	}
+
+	if len(event.Message.Fragments) != 6 {
+		t.Fatalf("expected 6 fragments, got %d", len(event.Message.Fragments))
+	}
+
+	if text := event.Message.PlainText(); text != "@streamer !so now" {
+		t.Errorf("expected plain text %q, got %q", "@streamer !so now", text)
+	}
+
+	emotes := event.Message.Emotes()
+	if len(emotes) != 1 || emotes[0] != (EventSubEmote{Begin: 14, End: 18, ID: "25"}) {
+		t.Errorf("expected Kappa at 14-18, got %+v", emotes)
+	}
+
+	mentions := event.Message.Mentions()
+	if len(mentions) != 1 || mentions[0].UserLogin != "streamer" {
+		t.Errorf("expected streamer to be mentioned, got %+v", mentions)
+	}
+}
+
+func TestEventSubRouterChannelModerate(t *testing.T) {
+	t.Parallel()
+
+	var events []EventSubChannelModerateEvent
+	router := NewEventSubRouter()
+	router.OnChannelModerate(func(event EventSubChannelModerateEvent, sub EventSubSubscription) {
+		events = append(events, event)
+	})
+
+	prefix := `{"subscription":{"id":"7297f7eb-3bf5-461f-8ae6-7cd7781ebce3","type":"channel.moderate","version":"2"},"event":{"broadcaster_user_id":"1337","broadcaster_user_login":"glowillig","broadcaster_user_name":"glowillig","source_broadcaster_user_id":null,"source_broadcaster_user_login":null,"source_broadcaster_user_name":null,"moderator_user_id":"424596340","moderator_user_login":"quotrok","moderator_user_name":"quotrok",`
+	payloads := []string{
+		prefix + `"action":"timeout","followers":null,"slow":null,"vip":null,"unvip":null,"mod":null,"unmod":null,"ban":null,"unban":null,"timeout":{"user_id":"141981764","user_login":"twitchdev","user_name":"TwitchDev","reason":"spam","expires_at":"2024-08-12T21:14:34.260398045Z"},"untimeout":null,"raid":null,"unraid":null,"delete":null,"automod_terms":null,"unban_request":null,"warn":null,"shared_chat_ban":null,"shared_chat_unban":null,"shared_chat_timeout":null,"shared_chat_untimeout":null,"shared_chat_delete":null}}`,
+		prefix + `"action":"followers","followers":{"follow_duration_minutes":10}}}`,
+		prefix + `"action":"warn","warn":{"user_id":"141981764","user_login":"twitchdev","user_name":"TwitchDev","reason":"cut it out","chat_rules_cited":["Rule 1"]}}}`,
+		prefix + `"action":"add_blocked_term","automod_terms":{"action":"add","list":"blocked","terms":["bad"],"from_automod":true}}}`,
+	}
+
+	for _, payload := range payloads {
+		if err := router.Dispatch([]byte(payload)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(events))
+	}
+
+	timeout := events[0]
+	if timeout.Action != EventSubModerateActionTimeout || timeout.Timeout == nil || timeout.Ban != nil {
+		t.Fatalf("expected only the timeout to be set, got %+v", timeout)
+	}
+	if timeout.Timeout.UserLogin != "twitchdev" || timeout.Timeout.Reason != "spam" || timeout.Timeout.ExpiresAt.Year() != 2024 {
+		t.Errorf("unexpected timeout %+v", timeout.Timeout)
+	}
+	if timeout.ModeratorUserLogin != "quotrok" || timeout.SourceBroadcasterUserID != "" {
+		t.Errorf("unexpected moderator %q and source %q", timeout.ModeratorUserLogin, timeout.SourceBroadcasterUserID)
+	}
+
+	if followers := events[1]; followers.Action != EventSubModerateActionFollowers || followers.Followers.FollowDurationMinutes != 10 {
+		t.Errorf("unexpected followers event %+v", followers)
+	}
+
+	if warn := events[2]; warn.Action != EventSubModerateActionWarn || len(warn.Warn.ChatRulesCited) != 1 || warn.Warn.UserID != "141981764" {
+		t.Errorf("unexpected warn event %+v", warn.Warn)
+	}
+
+	if terms := events[3].AutomodTerms; terms == nil || terms.List != "blocked" || !terms.FromAutomod || terms.Terms[0] != "bad" {
+		t.Errorf("unexpected automod terms %+v", terms)
+	}
+}
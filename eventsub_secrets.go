@@ -0,0 +1,300 @@
+package helix
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// eventSubSecretBytes is the number of random bytes of a generated secret,
+// hex encoded to 64 characters.
+const eventSubSecretBytes = 32
+
+// GenerateEventSubSecret returns a cryptographically random webhook secret
+// that satisfies Twitch's length requirement of 10 to 100 characters.
+func GenerateEventSubSecret() (string, error) {
+	b := make([]byte, eventSubSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error: could not generate eventsub secret: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// EventSubSecretStore keeps the webhook secret of each EventSub subscription,
+// keyed by subscription ID. GetSecret returns an empty string if no secret is
+// stored for the subscription, and SetSecret with an empty secret removes it.
+// Implementations must be safe for concurrent use.
+type EventSubSecretStore interface {
+	GetSecret(subscriptionID string) (string, error)
+	SetSecret(subscriptionID, secret string) error
+}
+
+// MemoryEventSubSecretStore is an EventSubSecretStore that keeps secrets for
+// the lifetime of the process.
+type MemoryEventSubSecretStore struct {
+	mu      sync.RWMutex
+	secrets map[string]string
+}
+
+// NewMemoryEventSubSecretStore returns an empty in-memory secret store.
+func NewMemoryEventSubSecretStore() *MemoryEventSubSecretStore {
+	return &MemoryEventSubSecretStore{secrets: map[string]string{}}
+}
+
+func (s *MemoryEventSubSecretStore) GetSecret(subscriptionID string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.secrets[subscriptionID], nil
+}
+
+func (s *MemoryEventSubSecretStore) SetSecret(subscriptionID, secret string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if secret == "" {
+		delete(s.secrets, subscriptionID)
+		return nil
+	}
+	s.secrets[subscriptionID] = secret
+
+	return nil
+}
+
+// CreateEventSubSubscriptionWithSecret creates a webhook subscription with a
+// newly generated secret and stores the secret under the ID of the created
+// subscription. Any secret set on the payload's transport is replaced.
+//
+// Twitch may send the verification challenge before the subscription's ID is
+// known, so the secret is stored as pending until the subscription is
+// created, and VerifyEventSubNotificationWithStore checks challenges against
+// it. The pending secret is removed once the call returns.
+func (c *Client) CreateEventSubSubscriptionWithSecret(payload *EventSubSubscription, store EventSubSecretStore) (*EventSubSubscriptionsResponse, error) {
+	if payload.Transport.Method != "webhook" {
+		return nil, &ValidationError{Field: "transport.method", Message: "only webhook subscriptions have a secret"}
+	}
+
+	secret, err := GenerateEventSubSecret()
+	if err != nil {
+		return nil, err
+	}
+	payload.Transport.Secret = secret
+
+	pending := pendingEventSubSecretKey(payload)
+	if err := store.SetSecret(pending, secret); err != nil {
+		return nil, fmt.Errorf("error: could not store eventsub secret: %w", err)
+	}
+	defer store.SetSecret(pending, "")
+
+	resp, err := c.CreateEventSubSubscription(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return resp, nil
+	}
+
+	for _, sub := range resp.Data.EventSubSubscriptions {
+		if err := store.SetSecret(sub.ID, secret); err != nil {
+			return resp, fmt.Errorf("error: could not store eventsub secret: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// EventSubSecretRotationTimeout is how long RotateEventSubSecret waits for
+// Twitch to verify the callback of the new subscription.
+const EventSubSecretRotationTimeout = time.Minute
+
+// eventSubSecretRotationInterval is how often RotateEventSubSecret checks
+// whether the new subscription is enabled.
+const eventSubSecretRotationInterval = time.Second
+
+// eventSubRotationParam is the query parameter that tells the callbacks of
+// the old and the new subscription of a rotation apart.
+const eventSubRotationParam = "eventsub_rotation"
+
+// RotateEventSubSecret replaces a webhook subscription with one that has a
+// newly generated secret and returns the new subscription. Twitch rejects a
+// second subscription with the same type, condition and callback, so the new
+// one is created on the same callback with a query parameter added, or
+// removed if the callback has it from an earlier rotation. The old
+// subscription is only removed once Twitch has verified and enabled the new
+// one, so no events are lost. If the new subscription isn't enabled within
+// EventSubSecretRotationTimeout, it is removed and the old one is kept.
+//
+// Should removing the old subscription fail, the new subscription is returned
+// along with the error, and both deliver events until the old one is removed.
+func (c *Client) RotateEventSubSecret(sub *EventSubSubscription, store EventSubSecretStore) (*EventSubSubscription, error) {
+	if err := validateRequired("subscription_id", "subscription id", sub.ID); err != nil {
+		return nil, err
+	}
+
+	callback, err := rotatedEventSubCallback(sub.Transport.Callback)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &EventSubSubscription{
+		Type:      sub.Type,
+		Version:   sub.Version,
+		Condition: sub.Condition,
+		Transport: EventSubTransport{
+			Method:   "webhook",
+			Callback: callback,
+		},
+	}
+
+	resp, err := c.CreateEventSubSubscriptionWithSecret(payload, store)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusAccepted || len(resp.Data.EventSubSubscriptions) == 0 {
+		return nil, fmt.Errorf("error: could not create %s eventsub subscription: %w", sub.Type, resp.Err())
+	}
+	rotated := resp.Data.EventSubSubscriptions[0]
+
+	if err := c.waitForEventSubSubscription(rotated.ID); err != nil {
+		if removeErr := c.removeEventSubSubscriptionWithSecret(rotated.ID, store); removeErr != nil {
+			return nil, fmt.Errorf("%v, and the new subscription could not be removed: %w", err, removeErr)
+		}
+
+		return nil, err
+	}
+
+	if err := c.removeEventSubSubscriptionWithSecret(sub.ID, store); err != nil {
+		return &rotated, err
+	}
+
+	rotated.Status = EventSubStatusEnabled
+
+	return &rotated, nil
+}
+
+// rotatedEventSubCallback adds the rotation query parameter to a callback, or
+// removes it if the callback already has it.
+func rotatedEventSubCallback(callback string) (string, error) {
+	u, err := url.Parse(callback)
+	if err != nil {
+		return "", &ValidationError{Field: "transport.callback", Message: "callback must be a valid url"}
+	}
+
+	query := u.Query()
+	if query.Has(eventSubRotationParam) {
+		query.Del(eventSubRotationParam)
+	} else {
+		query.Set(eventSubRotationParam, "1")
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// waitForEventSubSubscription waits until Twitch has verified the callback of
+// a subscription and enabled it.
+func (c *Client) waitForEventSubSubscription(id string) error {
+	deadline := c.now().Add(EventSubSecretRotationTimeout)
+	for {
+		resp, err := c.GetEventSubSubscriptions(&EventSubSubscriptionsParams{SubscriptionID: id})
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("error: could not get eventsub subscription %s: %w", id, resp.Err())
+		}
+
+		status := EventSubStatusPending
+		for _, sub := range resp.Data.EventSubSubscriptions {
+			if sub.ID == id {
+				status = sub.Status
+			}
+		}
+
+		switch {
+		case status == EventSubStatusEnabled:
+			return nil
+		case status != EventSubStatusPending:
+			return fmt.Errorf("error: eventsub subscription %s was not enabled: %s", id, status)
+		case !c.now().Before(deadline):
+			return fmt.Errorf("error: timed out waiting for eventsub subscription %s to be enabled", id)
+		}
+
+		if err := c.sleep(c.ctx, eventSubSecretRotationInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// removeEventSubSubscriptionWithSecret removes a subscription and its secret.
+func (c *Client) removeEventSubSubscriptionWithSecret(id string, store EventSubSecretStore) error {
+	removed, err := keepFailedResponse(c.RemoveEventSubSubscription(id))
+	if err != nil {
+		return err
+	}
+
+	if removed.StatusCode != http.StatusNoContent && removed.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error: could not remove eventsub subscription %s: %w", id, removed.Err())
+	}
+
+	if err := store.SetSecret(id, ""); err != nil {
+		return fmt.Errorf("error: could not remove eventsub secret: %w", err)
+	}
+
+	return nil
+}
+
+// pendingEventSubSecretKey is the key the secret of a subscription is stored
+// under while it is being created and Twitch hasn't returned its ID yet. It
+// identifies the subscription by its type, condition and callback, which the
+// verification challenge carries as well.
+func pendingEventSubSecretKey(sub *EventSubSubscription) string {
+	condition, _ := json.Marshal(sub.Condition)
+	sum := sha256.Sum256([]byte(sub.Type + " " + sub.Transport.Callback + " " + string(condition)))
+
+	return "pending:" + hex.EncodeToString(sum[:])
+}
+
+// VerifyEventSubNotificationWithStore verifies that a notification came from
+// twitch using the secret stored for the subscription it was sent for.
+// Verification challenges of subscriptions that are still being created with
+// CreateEventSubSubscriptionWithSecret are checked against their pending
+// secret.
+func VerifyEventSubNotificationWithStore(store EventSubSecretStore, header http.Header, message string) bool {
+	var notification struct {
+		Challenge    string               `json:"challenge"`
+		Subscription EventSubSubscription `json:"subscription"`
+	}
+
+	if err := json.Unmarshal([]byte(message), &notification); err != nil || notification.Subscription.ID == "" {
+		return false
+	}
+
+	secret, err := store.GetSecret(notification.Subscription.ID)
+	if err != nil {
+		return false
+	}
+
+	if secret == "" && notification.Challenge != "" {
+		secret, err = store.GetSecret(pendingEventSubSecretKey(&notification.Subscription))
+		if err != nil {
+			return false
+		}
+	}
+
+	if secret == "" {
+		return false
+	}
+
+	return VerifyEventSubNotification(secret, header, message)
+}
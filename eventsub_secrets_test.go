@@ -0,0 +1,321 @@
+package helix
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGenerateEventSubSecret(t *testing.T) {
+	t.Parallel()
+
+	first, err := GenerateEventSubSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := GenerateEventSubSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first) < 10 || len(first) > 100 {
+		t.Errorf("expected a secret of 10 to 100 characters, got %d", len(first))
+	}
+
+	if first == second {
+		t.Error("expected generated secrets to differ")
+	}
+}
+
+// eventSubSecretServer fakes the subscription endpoints. Created
+// subscriptions get their IDs from ids and are enabled once their status was
+// checked verifyAfter times, or fail verification if verifyAfter is negative.
+// Every call is recorded in calls.
+type eventSubSecretServer struct {
+	ids         []string
+	verifyAfter int
+	calls       []string
+	secrets     []string
+	subs        map[string]EventSubSubscription
+	checks      map[string]int
+}
+
+func newEventSubSecretServer(verifyAfter int, ids ...string) *eventSubSecretServer {
+	return &eventSubSecretServer{
+		ids:         ids,
+		verifyAfter: verifyAfter,
+		subs:        map[string]EventSubSubscription{},
+		checks:      map[string]int{},
+	}
+}
+
+func (s *eventSubSecretServer) handle(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		s.calls = append(s.calls, "remove "+id)
+		delete(s.subs, id)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		id := r.URL.Query().Get("subscription_id")
+		s.calls = append(s.calls, "check "+id)
+
+		sub := s.subs[id]
+		s.checks[id]++
+		switch {
+		case s.verifyAfter < 0:
+			sub.Status = EventSubStatusFailed
+		case s.checks[id] > s.verifyAfter:
+			sub.Status = EventSubStatusEnabled
+		}
+
+		data, _ := json.Marshal(sub)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[` + string(data) + `],"total":1,"total_cost":1,"max_total_cost":10000,"pagination":{}}`))
+	default:
+		var sub EventSubSubscription
+		json.NewDecoder(r.Body).Decode(&sub)
+		s.calls = append(s.calls, "create "+sub.Transport.Callback)
+
+		if len(s.ids) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Bad Request","status":400,"message":"callback is unreachable"}`))
+			return
+		}
+
+		s.secrets = append(s.secrets, sub.Transport.Secret)
+		sub.ID, s.ids = s.ids[0], s.ids[1:]
+		sub.Status = EventSubStatusPending
+		sub.Transport.Secret = ""
+		s.subs[sub.ID] = sub
+
+		data, _ := json.Marshal(sub)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[` + string(data) + `],"total":1,"total_cost":1,"max_total_cost":10000}`))
+	}
+}
+
+// signEventSubMessage returns the headers Twitch sends a message with, signed
+// with secret.
+func signEventSubMessage(secret, message string) http.Header {
+	header := http.Header{}
+	header.Set("Twitch-Eventsub-Message-Id", "e76c6bd4-55c9-4987-8304-da1588d8988b")
+	header.Set("Twitch-Eventsub-Message-Timestamp", "2019-11-16T10:11:12.123Z")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header.Get("Twitch-Eventsub-Message-Id") + header.Get("Twitch-Eventsub-Message-Timestamp") + message))
+	header.Set("Twitch-Eventsub-Message-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	return header
+}
+
+func TestCreateEventSubSubscriptionWithSecretVerifiesEarlyChallenge(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryEventSubSecretStore()
+	payload := &EventSubSubscription{
+		Type:      EventSubTypeChannelFollow,
+		Condition: EventSubCondition{BroadcasterUserID: "12826", ModeratorUserID: "12826"},
+		Transport: EventSubTransport{Method: "webhook", Callback: "https://example.com/eventsub"},
+	}
+
+	verified := false
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		var sub EventSubSubscription
+		json.NewDecoder(r.Body).Decode(&sub)
+
+		// Twitch sends the challenge before it responds with the subscription's ID
+		secret := sub.Transport.Secret
+		sub.ID = "sub-1"
+		sub.Status = EventSubStatusPending
+		sub.Transport.Secret = ""
+		data, _ := json.Marshal(sub)
+		challenge := `{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":` + string(data) + `}`
+		verified = VerifyEventSubNotificationWithStore(store, signEventSubMessage(secret, challenge), challenge)
+
+		// Notifications aren't verified with pending secrets
+		notification := `{"subscription":` + string(data) + `,"event":{}}`
+		if VerifyEventSubNotificationWithStore(store, signEventSubMessage(secret, notification), notification) {
+			t.Error("expected a notification not to be verified with a pending secret")
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[` + string(data) + `],"total":1,"total_cost":1,"max_total_cost":10000}`))
+	})
+
+	if _, err := c.CreateEventSubSubscriptionWithSecret(payload, store); err != nil {
+		t.Fatal(err)
+	}
+
+	if !verified {
+		t.Error("expected the challenge sent before the subscription was created to be verified")
+	}
+
+	if len(store.secrets) != 1 || store.secrets["sub-1"] != payload.Transport.Secret {
+		t.Errorf("expected only the secret of sub-1 to be stored, got %v", store.secrets)
+	}
+
+	// The pending secret is removed if the subscription isn't created
+	store = NewMemoryEventSubSecretStore()
+	c = newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusBadRequest, `{"error":"Bad Request","status":400,"message":"callback is unreachable"}`, nil))
+	if _, err := c.CreateEventSubSubscriptionWithSecret(payload, store); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(store.secrets) != 0 {
+		t.Errorf("expected no secrets to be stored, got %v", store.secrets)
+	}
+}
+
+func TestRotateEventSubSecret(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	store := NewMemoryEventSubSecretStore()
+	server := newEventSubSecretServer(2, "sub-1", "sub-2", "sub-3")
+	c := newMockClient(&Options{ClientID: "my-client-id", Clock: clock, Sleeper: clock}, server.handle)
+
+	resp, err := c.CreateEventSubSubscriptionWithSecret(&EventSubSubscription{
+		Type:      EventSubTypeChannelFollow,
+		Condition: EventSubCondition{BroadcasterUserID: "12826", ModeratorUserID: "12826"},
+		Transport: EventSubTransport{Method: "webhook", Callback: "https://example.com/eventsub?channel=12826"},
+	}, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if secret, _ := store.GetSecret("sub-1"); secret == "" || secret != server.secrets[0] {
+		t.Errorf("expected the secret of sub-1 to be stored, got %q", secret)
+	}
+
+	server.calls = nil
+	sub, err := c.RotateEventSubSecret(&resp.Data.EventSubSubscriptions[0], store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The old subscription is only removed once the new one is enabled
+	expected := "[create https://example.com/eventsub?channel=12826&eventsub_rotation=1 check sub-2 check sub-2 check sub-2 remove sub-1]"
+	if fmt.Sprint(server.calls) != expected {
+		t.Errorf("expected the calls %s, got %v", expected, server.calls)
+	}
+
+	if sub.ID != "sub-2" || sub.Status != EventSubStatusEnabled {
+		t.Errorf("expected sub-1 to be replaced by the enabled sub-2, got %+v", sub)
+	}
+
+	if server.secrets[1] == server.secrets[0] {
+		t.Error("expected the new subscription to have a new secret")
+	}
+
+	if secret, _ := store.GetSecret("sub-1"); secret != "" {
+		t.Errorf("expected the secret of sub-1 to be removed, got %q", secret)
+	}
+
+	if secret, _ := store.GetSecret("sub-2"); secret != server.secrets[1] {
+		t.Errorf("expected the new secret of sub-2 to be stored, got %q", secret)
+	}
+
+	// Rotating again moves the subscription back to the original callback
+	server.calls = nil
+	sub, err = c.RotateEventSubSecret(sub, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = "[create https://example.com/eventsub?channel=12826 check sub-3 check sub-3 check sub-3 remove sub-2]"
+	if fmt.Sprint(server.calls) != expected {
+		t.Errorf("expected the calls %s, got %v", expected, server.calls)
+	}
+
+	// A new subscription that fails verification is removed and the old one is kept
+	server = newEventSubSecretServer(-1, "sub-4")
+	c = newMockClient(&Options{ClientID: "my-client-id", Clock: clock, Sleeper: clock}, server.handle)
+
+	_, err = c.RotateEventSubSecret(sub, store)
+	if err == nil || err.Error() != "error: eventsub subscription sub-4 was not enabled: webhook_callback_verification_failed" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	expected = "[create https://example.com/eventsub?channel=12826&eventsub_rotation=1 check sub-4 remove sub-4]"
+	if fmt.Sprint(server.calls) != expected {
+		t.Errorf("expected the calls %s, got %v", expected, server.calls)
+	}
+
+	if secret, _ := store.GetSecret("sub-3"); secret == "" {
+		t.Error("expected the secret of sub-3 to be kept")
+	}
+
+	if secret, _ := store.GetSecret("sub-4"); secret != "" {
+		t.Errorf("expected the secret of sub-4 to be removed, got %q", secret)
+	}
+
+	// A new subscription that isn't verified in time is removed as well
+	server = newEventSubSecretServer(1000, "sub-5")
+	c = newMockClient(&Options{ClientID: "my-client-id", Clock: clock, Sleeper: clock}, server.handle)
+
+	_, err = c.RotateEventSubSecret(sub, store)
+	if err == nil || err.Error() != "error: timed out waiting for eventsub subscription sub-5 to be enabled" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if last := server.calls[len(server.calls)-1]; last != "remove sub-5" {
+		t.Errorf("expected sub-5 to be removed, got %s", last)
+	}
+
+	// A subscription that can't be created leaves the old one alone
+	server = newEventSubSecretServer(0)
+	c = newMockClient(&Options{ClientID: "my-client-id"}, server.handle)
+
+	_, err = c.RotateEventSubSecret(sub, store)
+	if err == nil || err.Error() != "error: could not create channel.follow eventsub subscription: 400 Bad Request: callback is unreachable" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if len(server.calls) != 1 {
+		t.Errorf("expected only the failed create call, got %v", server.calls)
+	}
+
+	// Test with HTTP Failure
+	c = newMockClient(&Options{ClientID: "my-client-id"}, nil)
+	c.opts.HTTPClient = &badMockHTTPClient{newMockHandler(0, "", nil)}
+
+	_, err = c.RotateEventSubSecret(sub, store)
+	if err == nil || err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestVerifyEventSubNotificationWithStore(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+	header.Add("Twitch-Eventsub-Message-Id", "e76c6bd4-55c9-4987-8304-da1588d8988b")
+	header.Add("Twitch-Eventsub-Message-Signature", "sha256=7e5a96480c29cdf834b371e7a5b049638cba6e425ea51b9b2a9fabf69bc5d227")
+	header.Add("Twitch-Eventsub-Message-Timestamp", "2019-11-16T10:11:12.123Z")
+	body := `{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"webhook_callback_verification_pending","type":"channel.follow","version":"1","condition":{"broadcaster_user_id":"12826"},"transport":{"method":"webhook","callback":"https://example.com/webhooks/callback"},"created_at":"2019-11-16T10:11:12.123Z"}}`
+
+	store := NewMemoryEventSubSecretStore()
+	if VerifyEventSubNotificationWithStore(store, header, body) {
+		t.Error("expected verification without a stored secret to fail")
+	}
+
+	store.SetSecret("f1c2a387-161a-49f9-a165-0f21d7a4e1c4", "n3w-s3cRe7")
+	if VerifyEventSubNotificationWithStore(store, header, body) {
+		t.Error("expected verification with the wrong secret to fail")
+	}
+
+	store.SetSecret("f1c2a387-161a-49f9-a165-0f21d7a4e1c4", "s3cRe7")
+	if !VerifyEventSubNotificationWithStore(store, header, body) {
+		t.Error("expected verification with the stored secret to succeed")
+	}
+
+	if VerifyEventSubNotificationWithStore(store, header, "not json") {
+		t.Error("expected verification of a malformed message to fail")
+	}
+}
@@ -216,6 +216,23 @@ func TestCreateEventSubSubscriptions(t *testing.T) {
 			`{"error":"Unauthorized","status":401,"message":"OAuth token is missing"}`,
 			"error: unsupported transport method: custom",
 		},
+		{
+			http.StatusBadRequest,
+			&Options{ClientID: "my-client-id"},
+			&EventSubSubscription{
+				Type:    "channel.follow",
+				Version: "2",
+				Condition: EventSubCondition{
+					BroadcasterUserID: "12345678",
+					ModeratorUserID:   "12345678",
+				},
+				Transport: EventSubTransport{
+					Method: "conduit",
+				},
+			},
+			`{"error":"Bad Request","status":400,"message":"conduit_id must be set"}`,
+			"error: conduit ID must be set",
+		},
 		{
 			http.StatusBadRequest,
 			&Options{ClientID: "my-client-id"},
@@ -379,3 +396,55 @@ func TestVerifyEventSubNotification(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyEventSubNotificationWithSecrets(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+	header.Add("Twitch-Eventsub-Message-Id", "e76c6bd4-55c9-4987-8304-da1588d8988b")
+	header.Add("Twitch-Eventsub-Message-Signature", "sha256=7e5a96480c29cdf834b371e7a5b049638cba6e425ea51b9b2a9fabf69bc5d227")
+	header.Add("Twitch-Eventsub-Message-Timestamp", "2019-11-16T10:11:12.123Z")
+	body := `{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"webhook_callback_verification_pending","type":"channel.follow","version":"1","condition":{"broadcaster_user_id":"12826"},"transport":{"method":"webhook","callback":"https://example.com/webhooks/callback"},"created_at":"2019-11-16T10:11:12.123Z"}}`
+
+	testCases := []struct {
+		secrets  []string
+		expected bool
+	}{
+		{[]string{"s3cRe7"}, true},
+		{[]string{"n3w-s3cRe7", "s3cRe7"}, true},
+		{[]string{"n3w-s3cRe7"}, false},
+		{[]string{""}, false},
+		{nil, false},
+	}
+
+	for _, testCase := range testCases {
+		if ok := VerifyEventSubNotificationWithSecrets(testCase.secrets, header, body); ok != testCase.expected {
+			t.Errorf("expected signature verification with secrets %v to be %t, got %t", testCase.secrets, testCase.expected, ok)
+		}
+
+		c := newMockClient(&Options{ClientID: "my-client-id", EventSubSecrets: testCase.secrets}, nil)
+		if ok := c.VerifyEventSubSignature(header, body); ok != testCase.expected {
+			t.Errorf("expected client signature verification with secrets %v to be %t, got %t", testCase.secrets, testCase.expected, ok)
+		}
+	}
+}
+
+func TestEventSubSubscriptionStatusIsValid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		status EventSubSubscriptionStatus
+		valid  bool
+	}{
+		{EventSubStatusEnabled, true},
+		{EventSubStatusWebsocketDisconnected, true},
+		{"disabled", false},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		if valid := testCase.status.IsValid(); valid != testCase.valid {
+			t.Errorf("expected %q to be valid: %t, got %t", testCase.status, testCase.valid, valid)
+		}
+	}
+}
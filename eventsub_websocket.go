@@ -0,0 +1,447 @@
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultEventSubWebsocketURL is the URL of Twitch's EventSub websocket server.
+const DefaultEventSubWebsocketURL = "wss://eventsub.wss.twitch.tv/ws"
+
+// DefaultEventSubWebsocketMaxRetries is the number of consecutive failed
+// connection attempts after which EventSubWebsocketClient.Run gives up.
+const DefaultEventSubWebsocketMaxRetries = 5
+
+// eventSubWebsocketWelcomeTimeout is how long to wait for the welcome message
+// of a new connection.
+const eventSubWebsocketWelcomeTimeout = 10 * time.Second
+
+// Message types of the EventSub websocket transport
+const (
+	EventSubWebsocketMessageTypeWelcome      = "session_welcome"
+	EventSubWebsocketMessageTypeKeepalive    = "session_keepalive"
+	EventSubWebsocketMessageTypeNotification = "notification"
+	EventSubWebsocketMessageTypeReconnect    = "session_reconnect"
+	EventSubWebsocketMessageTypeRevocation   = "revocation"
+)
+
+// EventSubWebsocketMessage is a message received over an EventSub websocket.
+type EventSubWebsocketMessage struct {
+	Metadata EventSubWebsocketMetadata `json:"metadata"`
+	Payload  EventSubWebsocketPayload  `json:"payload"`
+}
+
+// EventSubWebsocketMetadata describes a websocket message, the subscription
+// fields are only set on notifications and revocations.
+type EventSubWebsocketMetadata struct {
+	MessageID           string `json:"message_id"`
+	MessageType         string `json:"message_type"`
+	MessageTimestamp    Time   `json:"message_timestamp"`
+	SubscriptionType    string `json:"subscription_type"`
+	SubscriptionVersion string `json:"subscription_version"`
+}
+
+// EventSubWebsocketPayload is the payload of a websocket message. Session is
+// set on welcome and reconnect messages, Subscription on notifications and
+// revocations, and Event only on notifications.
+type EventSubWebsocketPayload struct {
+	Session      EventSubWebsocketSession `json:"session"`
+	Subscription EventSubSubscription     `json:"subscription"`
+	Event        json.RawMessage          `json:"event"`
+}
+
+// EventSubWebsocketSession describes a websocket session. Use ID as the
+// SessionID of the websocket transport when creating subscriptions.
+// ReconnectURL is only set on reconnect messages.
+type EventSubWebsocketSession struct {
+	ID                      string `json:"id"`
+	Status                  string `json:"status"`
+	KeepaliveTimeoutSeconds int    `json:"keepalive_timeout_seconds"`
+	ReconnectURL            string `json:"reconnect_url"`
+	ConnectedAt             Time   `json:"connected_at"`
+}
+
+// EventSubWebsocketConn is a websocket connection that EventSub messages are
+// read from, see EventSubWebsocketClient.Dial.
+type EventSubWebsocketConn interface {
+	ReadMessage() ([]byte, error)
+	Close() error
+}
+
+// EventSubWebsocketClient receives EventSub notifications over a websocket
+// and passes them to a router. It keeps the subscriptions registered with
+// Subscribe alive across reconnects: when Twitch asks the client to
+// reconnect, or the connection drops, it connects to the new URL, waits for
+// the welcome message and creates the subscriptions again for the new
+// session. Handlers registered with OnResubscribe are then called once,
+// instead of the router's reconnect handlers.
+type EventSubWebsocketClient struct {
+	client *Client
+	router *EventSubRouter
+
+	// URL of the websocket server, DefaultEventSubWebsocketURL if empty.
+	URL string
+
+	// Dial opens a websocket connection, a minimal built-in client is used if
+	// nil. Set it to use another websocket library.
+	Dial func(ctx context.Context, url string) (EventSubWebsocketConn, error)
+
+	// MaxRetries is the number of consecutive failed connection attempts
+	// after which Run gives up, DefaultEventSubWebsocketMaxRetries if zero.
+	MaxRetries int
+
+	mu          sync.Mutex
+	subs        []EventSubSubscription
+	sessionID   string
+	resubscribe []func(session EventSubWebsocketSession)
+	errors      []func(err error)
+}
+
+// NewEventSubWebsocketClient returns a websocket client that passes
+// notifications to router. Subscriptions are created with the client's user
+// access token.
+func (c *Client) NewEventSubWebsocketClient(router *EventSubRouter) *EventSubWebsocketClient {
+	return &EventSubWebsocketClient{
+		client: c,
+		router: router,
+	}
+}
+
+// Subscribe registers a subscription, only its type, version and condition
+// are used. If the client is connected the subscription is created right
+// away, otherwise once Run connects.
+func (w *EventSubWebsocketClient) Subscribe(sub EventSubSubscription) error {
+	w.mu.Lock()
+	w.subs = append(w.subs, sub)
+	sessionID := w.sessionID
+	w.mu.Unlock()
+
+	if sessionID == "" {
+		return nil
+	}
+
+	return w.createSubscription(sub, sessionID)
+}
+
+// OnResubscribe registers a handler that is called after the client moved to
+// a new session and created the registered subscriptions again.
+func (w *EventSubWebsocketClient) OnResubscribe(handler func(session EventSubWebsocketSession)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.resubscribe = append(w.resubscribe, handler)
+}
+
+// OnError registers a handler for notifications that couldn't be decoded,
+// which are dropped.
+func (w *EventSubWebsocketClient) OnError(handler func(err error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.errors = append(w.errors, handler)
+}
+
+// Run connects, creates the registered subscriptions and passes notifications
+// to the router until ctx is done, one at a time and in the order they were
+// received. A lost connection doesn't make Run return: it connects again,
+// waiting a second longer after each failed attempt. Run only returns before
+// ctx is done if subscriptions can't be created or connecting fails
+// MaxRetries times in a row.
+func (w *EventSubWebsocketClient) Run(ctx context.Context) error {
+	url := w.URL
+	if url == "" {
+		url = DefaultEventSubWebsocketURL
+	}
+
+	maxRetries := w.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = DefaultEventSubWebsocketMaxRetries
+	}
+
+	connected := false
+	failures := 0
+	for {
+		conn, reads, session, err := w.connect(ctx, url)
+		if err == nil {
+			if err := w.subscribeAll(session); err != nil {
+				conn.Close()
+				return err
+			}
+
+			if connected {
+				w.dispatchResubscribe(session)
+			}
+			connected = true
+			failures = 0
+
+			err = w.serve(ctx, conn, reads, session)
+		}
+
+		w.setSessionID("")
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		failures++
+		if failures >= maxRetries {
+			return fmt.Errorf("error: could not connect to eventsub websocket: %w", err)
+		}
+
+		if err := w.client.sleep(ctx, time.Duration(failures)*time.Second); err != nil {
+			return err
+		}
+	}
+}
+
+// websocketRead is a message or error read from a connection.
+type websocketRead struct {
+	message []byte
+	err     error
+}
+
+// connect opens a connection and waits for its welcome message. Messages are
+// read in the background and sent on the returned channel until reading
+// fails.
+func (w *EventSubWebsocketClient) connect(ctx context.Context, url string) (EventSubWebsocketConn, <-chan websocketRead, EventSubWebsocketSession, error) {
+	dial := w.Dial
+	if dial == nil {
+		dial = dialWebsocket
+	}
+
+	conn, err := dial(ctx, url)
+	if err != nil {
+		return nil, nil, EventSubWebsocketSession{}, err
+	}
+
+	reads := make(chan websocketRead)
+	go func() {
+		defer close(reads)
+
+		for {
+			message, err := conn.ReadMessage()
+			reads <- websocketRead{message, err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	timeout, timer := w.client.after(eventSubWebsocketWelcomeTimeout)
+	defer timer.Stop()
+
+	select {
+	case read := <-reads:
+		if read.err != nil {
+			closeWebsocket(conn, reads)
+			return nil, nil, EventSubWebsocketSession{}, read.err
+		}
+
+		var msg EventSubWebsocketMessage
+		if err := json.Unmarshal(read.message, &msg); err != nil || msg.Metadata.MessageType != EventSubWebsocketMessageTypeWelcome {
+			closeWebsocket(conn, reads)
+			return nil, nil, EventSubWebsocketSession{}, errors.New("error: eventsub websocket didn't send a welcome message")
+		}
+
+		return conn, reads, msg.Payload.Session, nil
+	case <-timeout:
+		closeWebsocket(conn, reads)
+		return nil, nil, EventSubWebsocketSession{}, errors.New("error: timed out waiting for the eventsub websocket welcome message")
+	case <-ctx.Done():
+		closeWebsocket(conn, reads)
+		return nil, nil, EventSubWebsocketSession{}, ctx.Err()
+	}
+}
+
+// serve passes the messages of a connection to the router until it drops or
+// ctx is done. Reconnect requests are handled by moving to a new connection
+// once the old one has been drained.
+func (w *EventSubWebsocketClient) serve(ctx context.Context, conn EventSubWebsocketConn, reads <-chan websocketRead, session EventSubWebsocketSession) error {
+	timeout, timer := w.client.after(keepaliveTimeout(session))
+	defer func() {
+		timer.Stop()
+		closeWebsocket(conn, reads)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return errors.New("error: eventsub websocket keepalive timed out")
+		case read := <-reads:
+			if read.err != nil {
+				return read.err
+			}
+			timer.Stop()
+			timeout, timer = w.client.after(keepaliveTimeout(session))
+
+			reconnect := w.handleMessage(read.message)
+			if reconnect == nil {
+				continue
+			}
+
+			newConn, newReads, newSession, err := w.connect(ctx, reconnect.ReconnectURL)
+			if err != nil {
+				return err
+			}
+
+			// Twitch closes the old connection once the new one is
+			// welcomed, messages sent to it before that are passed on
+			// before those of the new connection
+			if err := w.finish(ctx, conn, reads, keepaliveTimeout(session)); err != nil {
+				closeWebsocket(newConn, newReads)
+				return err
+			}
+			conn, reads, session = newConn, newReads, newSession
+
+			if err := w.subscribeAll(session); err != nil {
+				return err
+			}
+			w.dispatchResubscribe(session)
+
+			timer.Stop()
+			timeout, timer = w.client.after(keepaliveTimeout(session))
+		}
+	}
+}
+
+// handleMessage passes a message to the router and returns the session of
+// reconnect messages.
+func (w *EventSubWebsocketClient) handleMessage(message []byte) *EventSubWebsocketSession {
+	var msg EventSubWebsocketMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		w.dispatchError(fmt.Errorf("error: could not decode eventsub websocket message: %w", err))
+		return nil
+	}
+
+	if msg.Metadata.MessageType == EventSubWebsocketMessageTypeReconnect {
+		return &msg.Payload.Session
+	}
+
+	if err := w.router.DispatchWebsocketMessage(message); err != nil {
+		w.dispatchError(err)
+	}
+
+	return nil
+}
+
+// finish passes the remaining messages of a connection that is being
+// replaced to the router, until the server closes it or timeout passes.
+// Messages of the new connection wait until it returns.
+func (w *EventSubWebsocketClient) finish(ctx context.Context, conn EventSubWebsocketConn, reads <-chan websocketRead, timeout time.Duration) error {
+	done, timer := w.client.after(timeout)
+	defer func() {
+		timer.Stop()
+		closeWebsocket(conn, reads)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+			return nil
+		case read, ok := <-reads:
+			if !ok || read.err != nil {
+				return nil
+			}
+			w.handleMessage(read.message)
+		}
+	}
+}
+
+// subscribeAll creates the registered subscriptions for a session.
+// Subscriptions that Twitch moved to the session already exist and are left
+// as they are.
+func (w *EventSubWebsocketClient) subscribeAll(session EventSubWebsocketSession) error {
+	w.mu.Lock()
+	w.sessionID = session.ID
+	subs := append([]EventSubSubscription(nil), w.subs...)
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		if err := w.createSubscription(sub, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *EventSubWebsocketClient) createSubscription(sub EventSubSubscription, sessionID string) error {
+	payload := &EventSubSubscription{
+		Type:      sub.Type,
+		Version:   sub.Version,
+		Condition: sub.Condition,
+		Transport: EventSubTransport{
+			Method:    "websocket",
+			SessionID: sessionID,
+		},
+	}
+
+	resp, err := keepFailedResponse(w.client.CreateEventSubSubscription(payload))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusConflict {
+		return fmt.Errorf("error: could not create %s eventsub subscription: %w", sub.Type, resp.Err())
+	}
+
+	return nil
+}
+
+func (w *EventSubWebsocketClient) setSessionID(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.sessionID = id
+}
+
+func (w *EventSubWebsocketClient) dispatchResubscribe(session EventSubWebsocketSession) {
+	w.mu.Lock()
+	handlers := w.resubscribe
+	w.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(session)
+	}
+}
+
+func (w *EventSubWebsocketClient) dispatchError(err error) {
+	w.mu.Lock()
+	handlers := w.errors
+	w.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(err)
+	}
+}
+
+// keepaliveTimeout is how long a session may go without a message before the
+// connection is considered dropped.
+func keepaliveTimeout(session EventSubWebsocketSession) time.Duration {
+	timeout := time.Duration(session.KeepaliveTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return timeout + timeout/2
+}
+
+// closeWebsocket closes a connection and drains its reads, so the reading
+// goroutine can exit.
+func closeWebsocket(conn EventSubWebsocketConn, reads <-chan websocketRead) {
+	conn.Close()
+
+	go func() {
+		for range reads {
+		}
+	}()
+}
@@ -0,0 +1,319 @@
+package helix
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeWebsocketConn returns its messages, then io.EOF, or blocks until it is
+// closed if block is set.
+type fakeWebsocketConn struct {
+	messages chan []byte
+	closed   chan struct{}
+	once     sync.Once
+	block    bool
+}
+
+func newFakeWebsocketConn(block bool, messages ...string) *fakeWebsocketConn {
+	conn := &fakeWebsocketConn{
+		messages: make(chan []byte, len(messages)),
+		closed:   make(chan struct{}),
+		block:    block,
+	}
+
+	for _, message := range messages {
+		conn.messages <- []byte(message)
+	}
+
+	return conn
+}
+
+func (c *fakeWebsocketConn) ReadMessage() ([]byte, error) {
+	select {
+	case message := <-c.messages:
+		return message, nil
+	default:
+	}
+
+	if !c.block {
+		return nil, io.EOF
+	}
+
+	<-c.closed
+	return nil, io.EOF
+}
+
+func (c *fakeWebsocketConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+func websocketWelcome(sessionID string) string {
+	return `{"metadata":{"message_id":"96a3f3b5-5dec-4eed-908e-e11ee657416c","message_type":"session_welcome","message_timestamp":"2023-07-19T14:56:51.634234626Z"},"payload":{"session":{"id":"` + sessionID + `","status":"connected","keepalive_timeout_seconds":10}}}`
+}
+
+func websocketFollow(userID string) string {
+	return `{"metadata":{"message_id":"befa7b53-d79d-478f-86b9-120f112b044e","message_type":"notification","message_timestamp":"2022-11-16T10:11:12.464757833Z","subscription_type":"channel.follow","subscription_version":"2"},"payload":{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"enabled","type":"channel.follow","version":"2"},"event":{"user_id":"` + userID + `"}}}`
+}
+
+func TestEventSubWebsocketClientResubscribes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var sessions []string
+	clock := newFakeClock()
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "my-user-access-token", Sleeper: clock}, func(w http.ResponseWriter, r *http.Request) {
+		var sub EventSubSubscription
+		json.NewDecoder(r.Body).Decode(&sub)
+
+		mu.Lock()
+		sessions = append(sessions, sub.Transport.SessionID)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"data":[{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"enabled","type":"channel.follow","version":"2"}]}`))
+	})
+
+	var follows []string
+	router := NewEventSubRouter()
+	router.OnChannelFollow(func(event EventSubChannelFollowEvent, sub EventSubSubscription) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		follows = append(follows, event.UserID)
+		if len(follows) == 4 {
+			cancel()
+		}
+	})
+	router.OnReconnect(func(session EventSubWebsocketSession) {
+		t.Error("expected the router's reconnect handlers not to be called")
+	})
+
+	conns := map[string][]*fakeWebsocketConn{
+		"wss://first": {
+			newFakeWebsocketConn(false,
+				websocketWelcome("session-1"),
+				websocketFollow("1"),
+				`{"metadata":{"message_type":"session_reconnect"},"payload":{"session":{"id":"session-1","status":"reconnecting","reconnect_url":"wss://second"}}}`,
+				websocketFollow("2"),
+			),
+			newFakeWebsocketConn(true, websocketWelcome("session-3"), websocketFollow("4")),
+		},
+		"wss://second": {
+			newFakeWebsocketConn(false, websocketWelcome("session-2"), websocketFollow("3")),
+		},
+	}
+
+	ws := c.NewEventSubWebsocketClient(router)
+	ws.URL = "wss://first"
+	ws.Dial = func(ctx context.Context, url string) (EventSubWebsocketConn, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(conns[url]) == 0 {
+			return nil, fmt.Errorf("unexpected dial to %s", url)
+		}
+
+		conn := conns[url][0]
+		conns[url] = conns[url][1:]
+		return conn, nil
+	}
+
+	var resubscribed []string
+	ws.OnResubscribe(func(session EventSubWebsocketSession) {
+		resubscribed = append(resubscribed, session.ID)
+	})
+
+	if err := ws.Subscribe(EventSubSubscription{
+		Type:      EventSubTypeChannelFollow,
+		Version:   "2",
+		Condition: EventSubCondition{BroadcasterUserID: "12826", ModeratorUserID: "12826"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ws.Run(ctx); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if fmt.Sprint(sessions) != "[session-1 session-2 session-3]" {
+		t.Errorf("expected the subscription to be created for each session, got %v", sessions)
+	}
+
+	if fmt.Sprint(resubscribed) != "[session-2 session-3]" {
+		t.Errorf("expected 2 resubscriptions, got %v", resubscribed)
+	}
+
+	if fmt.Sprint(follows) != "[1 2 3 4]" {
+		t.Errorf("expected 4 follows to be passed on in order, got %v", follows)
+	}
+}
+
+func TestEventSubWebsocketClientDrainsOldConnection(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "my-user-access-token"}, newMockHandler(http.StatusAccepted, `{"data":[]}`, nil))
+
+	var mu sync.Mutex
+	var follows []string
+	router := NewEventSubRouter()
+	router.OnChannelFollow(func(event EventSubChannelFollowEvent, sub EventSubSubscription) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		follows = append(follows, event.UserID)
+		if len(follows) == 2 {
+			cancel()
+		}
+	})
+
+	oldConn := newFakeWebsocketConn(true,
+		websocketWelcome("session-1"),
+		`{"metadata":{"message_type":"session_reconnect"},"payload":{"session":{"id":"session-1","status":"reconnecting","reconnect_url":"wss://second"}}}`,
+		websocketFollow("1"),
+	)
+	newConn := newFakeWebsocketConn(true, websocketWelcome("session-2"), websocketFollow("2"))
+
+	ws := c.NewEventSubWebsocketClient(router)
+	ws.URL = "wss://first"
+	ws.Dial = func(ctx context.Context, url string) (EventSubWebsocketConn, error) {
+		if url == "wss://second" {
+			return newConn, nil
+		}
+		return oldConn, nil
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- ws.Run(ctx)
+	}()
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(follows) == 1
+	})
+
+	// Messages of the new connection wait until the old one is closed
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	if fmt.Sprint(follows) != "[1]" {
+		t.Errorf("expected only the old connection's follow to be passed on, got %v", follows)
+	}
+	mu.Unlock()
+
+	oldConn.Close()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if fmt.Sprint(follows) != "[1 2]" {
+		t.Errorf("expected the follows in order, got %v", follows)
+	}
+}
+
+func TestEventSubWebsocketClientGivesUp(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	c := newMockClient(&Options{ClientID: "my-client-id", Sleeper: clock}, nil)
+
+	dials := 0
+	ws := c.NewEventSubWebsocketClient(NewEventSubRouter())
+	ws.MaxRetries = 3
+	ws.Dial = func(ctx context.Context, url string) (EventSubWebsocketConn, error) {
+		dials++
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	err := ws.Run(context.Background())
+	if err == nil || err.Error() != "error: could not connect to eventsub websocket: connection refused" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if dials != 3 {
+		t.Errorf("expected 3 connection attempts, got %d", dials)
+	}
+}
+
+func TestDialWebsocket(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	pongs := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return
+		}
+
+		accept := sha1.Sum([]byte(req.Header.Get("Sec-WebSocket-Key") + websocketGUID))
+		fmt.Fprintf(conn, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", base64.StdEncoding.EncodeToString(accept[:]))
+
+		// A ping, then a message in two fragments and a close frame
+		conn.Write([]byte{0x80 | websocketOpPing, 4, 'p', 'i', 'n', 'g'})
+		conn.Write(append([]byte{websocketOpText, 6}, "hello "...))
+		conn.Write(append([]byte{0x80 | websocketOpContinuation, 5}, "world"...))
+		conn.Write([]byte{0x80 | websocketOpClose, 2, 0x03, 0xe8})
+
+		ws := &websocketConn{conn: conn, reader: reader}
+		_, opcode, payload, err := ws.readFrame()
+		if err == nil && opcode == websocketOpPong {
+			pongs <- payload
+		}
+	}()
+
+	conn, err := dialWebsocket(context.Background(), "ws://"+listener.Addr().String()+"/ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	message, err := conn.ReadMessage()
+	if err != nil || string(message) != "hello world" {
+		t.Errorf("expected message %q, got %q (%v)", "hello world", message, err)
+	}
+
+	if pong := <-pongs; string(pong) != "ping" {
+		t.Errorf("expected the ping to be answered, got %q", pong)
+	}
+
+	if _, err := conn.ReadMessage(); err != errWebsocketClosed {
+		t.Errorf("expected %v, got %v", errWebsocketClosed, err)
+	}
+}
@@ -0,0 +1,73 @@
+package helix
+
+// ExtensionBitsProductCost describes what a Bits product costs
+type ExtensionBitsProductCost struct {
+	Amount int    `json:"amount"`
+	Type   string `json:"type"` // Always "bits"
+}
+
+// ExtensionBitsProduct is a Bits product belonging to an extension
+type ExtensionBitsProduct struct {
+	SKU           string                   `json:"sku"`
+	Cost          ExtensionBitsProductCost `json:"cost"`
+	InDevelopment bool                     `json:"in_development"`
+	DisplayName   string                   `json:"display_name"`
+	Expiration    Time                     `json:"expiration"`
+	IsBroadcast   bool                     `json:"is_broadcast"`
+}
+
+// ManyExtensionBitsProducts is the response data in ExtensionBitsProductsResponse
+type ManyExtensionBitsProducts struct {
+	ExtensionBitsProducts []ExtensionBitsProduct `json:"data"`
+}
+
+// ExtensionBitsProductsResponse is the response from GetExtensionBitsProducts and UpdateExtensionBitsProduct
+type ExtensionBitsProductsResponse = ResponseOf[ManyExtensionBitsProducts]
+
+// ExtensionBitsProductsParams are the parameters for GetExtensionBitsProducts
+type ExtensionBitsProductsParams struct {
+	ShouldIncludeAll bool `query:"should_include_all"` // Optional, include disabled and expired products
+}
+
+// GetExtensionBitsProducts gets the list of Bits products that belongs to the extension.
+// The client ID must be the extension's client ID and requires an app access token.
+//
+// See https://dev.twitch.tv/docs/api/reference/#get-extension-bits-products
+func (c *Client) GetExtensionBitsProducts(params *ExtensionBitsProductsParams) (*ExtensionBitsProductsResponse, error) {
+	return getResponse[ManyExtensionBitsProducts](c, "/bits/extensions", params)
+}
+
+// UpdateExtensionBitsProductParams are the parameters for UpdateExtensionBitsProduct
+type UpdateExtensionBitsProductParams struct {
+	SKU           string                   `json:"sku"`                      // Required, limit 255
+	Cost          ExtensionBitsProductCost `json:"cost"`                     // Required
+	DisplayName   string                   `json:"display_name"`             // Required, limit 255
+	InDevelopment bool                     `json:"in_development,omitempty"` // Optional
+	Expiration    *Time                    `json:"expiration,omitempty"`     // Optional, never expires if unset
+	IsBroadcast   bool                     `json:"is_broadcast,omitempty"`   // Optional
+}
+
+// UpdateExtensionBitsProduct adds or updates a Bits product that the extension created.
+// If the SKU doesn’t exist, the product is added. You may update all fields except the SKU.
+// The client ID must be the extension's client ID and requires an app access token.
+//
+// See https://dev.twitch.tv/docs/api/reference/#update-extension-bits-product
+func (c *Client) UpdateExtensionBitsProduct(params *UpdateExtensionBitsProductParams) (*ExtensionBitsProductsResponse, error) {
+	if params.SKU == "" || len(params.SKU) > 255 {
+		return nil, &ValidationError{Field: "sku", Message: "sku must be between 1 and 255 characters"}
+	}
+
+	if params.DisplayName == "" || len(params.DisplayName) > 255 {
+		return nil, &ValidationError{Field: "display_name", Message: "display name must be between 1 and 255 characters"}
+	}
+
+	if params.Cost.Amount < 1 {
+		return nil, &ValidationError{Field: "cost.amount", Message: "cost amount must be greater than zero"}
+	}
+
+	if params.Cost.Type == "" {
+		params.Cost.Type = "bits"
+	}
+
+	return putAsJSONResponse[ManyExtensionBitsProducts](c, "/bits/extensions", params)
+}
@@ -0,0 +1,188 @@
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGetExtensionBitsProducts(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		params     *ExtensionBitsProductsParams
+		respBody   string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			&ExtensionBitsProductsParams{ShouldIncludeAll: true},
+			`{"data":[{"sku":"1010","cost":{"amount":990,"type":"bits"},"in_development":true,"display_name":"Rusty Crate 2","expiration":"2021-05-18T09:10:13.397Z","is_broadcast":false}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("should_include_all") != "true" {
+				t.Errorf("expected should_include_all query param to be %s, got %s", "true", r.URL.Query().Get("should_include_all"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.GetExtensionBitsProducts(testCase.params)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.ExtensionBitsProducts) != 1 {
+			t.Errorf("expected number of products to be %d, got %d", 1, len(resp.Data.ExtensionBitsProducts))
+			continue
+		}
+
+		product := resp.Data.ExtensionBitsProducts[0]
+		if product.Cost.Amount != 990 {
+			t.Errorf("expected cost amount to be %d, got %d", 990, product.Cost.Amount)
+		}
+
+		if product.Expiration.IsZero() {
+			t.Error("expected expiration not to be zero")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetExtensionBitsProducts(&ExtensionBitsProductsParams{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestUpdateExtensionBitsProduct(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *UpdateExtensionBitsProductParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			&UpdateExtensionBitsProductParams{DisplayName: "Rusty Crate 2", Cost: ExtensionBitsProductCost{Amount: 990}},
+			``,
+			"error: sku must be between 1 and 255 characters",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			&UpdateExtensionBitsProductParams{SKU: "1010", Cost: ExtensionBitsProductCost{Amount: 990}},
+			``,
+			"error: display name must be between 1 and 255 characters",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			&UpdateExtensionBitsProductParams{SKU: "1010", DisplayName: "Rusty Crate 2"},
+			``,
+			"error: cost amount must be greater than zero",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token"},
+			&UpdateExtensionBitsProductParams{SKU: "1010", DisplayName: "Rusty Crate 2", Cost: ExtensionBitsProductCost{Amount: 990}, InDevelopment: true},
+			`{"data":[{"sku":"1010","cost":{"amount":990,"type":"bits"},"in_development":true,"display_name":"Rusty Crate 2","expiration":"","is_broadcast":false}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var product ExtensionBitsProduct
+			if err := json.Unmarshal(body, &product); err != nil {
+				t.Error(err)
+			}
+
+			if product.Cost.Type != "bits" {
+				t.Errorf("expected cost type to be %s, got %s", "bits", product.Cost.Type)
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.UpdateExtensionBitsProduct(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.ExtensionBitsProducts) != 1 {
+			t.Errorf("expected number of products to be %d, got %d", 1, len(resp.Data.ExtensionBitsProducts))
+			continue
+		}
+
+		if !resp.Data.ExtensionBitsProducts[0].InDevelopment {
+			t.Error("expected product to be in development")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.UpdateExtensionBitsProduct(&UpdateExtensionBitsProductParams{SKU: "1010", DisplayName: "Rusty Crate 2", Cost: ExtensionBitsProductCost{Amount: 990}})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
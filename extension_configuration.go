@@ -1,7 +1,5 @@
 package helix
 
-import "fmt"
-
 // SegmentType A segment configuration type
 type ExtensionSegmentType string
 
@@ -40,19 +38,18 @@ type ExtensionGetConfigurationParams struct {
 type ExtensionSetRequiredConfigurationParams struct {
 	BroadcasterID         string `query:"broadcaster_id" json:"-"`
 	ExtensionID           string `json:"extension_id"`
-	RequiredConfiguration string `json:"required_version"`
+	RequiredConfiguration string `json:"required_configuration"`
 	ExtensionVersion      string `json:"extension_version"`
-	ConfigurationVersion  string `json:"configuration_version"`
+
+	// Deprecated: not accepted by the endpoint and no longer sent.
+	ConfigurationVersion string `json:"-"`
 }
 
 type ExtensionSetRequiredConfigurationResponse struct {
 	ResponseCommon
 }
 
-type ExtensionGetConfigurationSegmentResponse struct {
-	ResponseCommon
-	Data ManyExtensionConfigurationSegments
-}
+type ExtensionGetConfigurationSegmentResponse = ResponseOf[ManyExtensionConfigurationSegments]
 
 type ManyExtensionConfigurationSegments struct {
 	Segments []ExtensionConfigurationSegment `json:"data"`
@@ -62,17 +59,20 @@ type ExtensionSetConfigurationResponse struct {
 	ResponseCommon
 }
 
-// https://dev.twitch.tv/docs/extensions/reference/#set-extension-configuration-segment
-func (c *Client) SetExtensionSegmentConfig(params *ExtensionSetConfigurationParams) (*ExtensionSetConfigurationResponse, error) {
+// SetExtensionConfigurationSegment updates a configuration segment.
+// Requires a signed JWT created by an Extension Backend Service (EBS), see ExtensionJWTSign.
+//
+// See https://dev.twitch.tv/docs/api/reference/#set-extension-configuration-segment
+func (c *Client) SetExtensionConfigurationSegment(params *ExtensionSetConfigurationParams) (*ExtensionSetConfigurationResponse, error) {
 	if params.BroadcasterID != "" {
 		switch params.Segment {
 		case ExtensionConfigurationDeveloperSegment, ExtensionConfigrationBroadcasterSegment:
 		default:
-			return nil, fmt.Errorf("error: developer or broadcaster extension configuration segment type must be provided for broadcasters")
+			return nil, &ValidationError{Field: "segment", Message: "developer or broadcaster extension configuration segment type must be provided for broadcasters"}
 		}
 	}
 
-	resp, err := c.putAsJSON("/extensions/configurations", &ManyPolls{}, params)
+	resp, err := c.putAsJSON("/extensions/configurations", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +83,17 @@ func (c *Client) SetExtensionSegmentConfig(params *ExtensionSetConfigurationPara
 	return setExtCnfgResp, nil
 }
 
+// SetExtensionSegmentConfig updates a configuration segment.
+//
+// Deprecated: use SetExtensionConfigurationSegment instead.
+func (c *Client) SetExtensionSegmentConfig(params *ExtensionSetConfigurationParams) (*ExtensionSetConfigurationResponse, error) {
+	return c.SetExtensionConfigurationSegment(params)
+}
+
+// GetExtensionConfigurationSegment gets the specified configuration segments.
+// Requires a signed JWT created by an Extension Backend Service (EBS), see ExtensionJWTSign.
+//
+// See https://dev.twitch.tv/docs/api/reference/#get-extension-configuration-segment
 func (c *Client) GetExtensionConfigurationSegment(params *ExtensionGetConfigurationParams) (*ExtensionGetConfigurationSegmentResponse, error) {
 
 	if params.BroadcasterID != "" {
@@ -90,26 +101,25 @@ func (c *Client) GetExtensionConfigurationSegment(params *ExtensionGetConfigurat
 			switch segment {
 			case ExtensionConfigurationDeveloperSegment, ExtensionConfigrationBroadcasterSegment:
 			default:
-				return nil, fmt.Errorf("error: only developer or broadcaster extension configuration segment type must be provided for broadcasters")
+				return nil, &ValidationError{Field: "segment", Message: "only developer or broadcaster extension configuration segment type must be provided for broadcasters"}
 			}
 		}
 	}
 
-	resp, err := c.get("/extensions/configurations", &ManyExtensionConfigurationSegments{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	extCfgSegResp := &ExtensionGetConfigurationSegmentResponse{}
-	resp.HydrateResponseCommon(&extCfgSegResp.ResponseCommon)
-	extCfgSegResp.Data.Segments = resp.Data.(*ManyExtensionConfigurationSegments).Segments
-
-	return extCfgSegResp, nil
+	return getResponse[ManyExtensionConfigurationSegments](c, "/extensions/configurations", params)
 }
 
+// SetExtensionRequiredConfiguration updates the extension’s required_configuration string.
+// Use it if your extension requires the broadcaster to configure the extension before activating it.
+// Requires a signed JWT created by an Extension Backend Service (EBS), see ExtensionJWTSign.
+//
+// See https://dev.twitch.tv/docs/api/reference/#set-extension-required-configuration
 func (c *Client) SetExtensionRequiredConfiguration(params *ExtensionSetRequiredConfigurationParams) (*ExtensionSetRequiredConfigurationResponse, error) {
+	if err := validateRequired("broadcaster_id", "broadcaster ID", params.BroadcasterID); err != nil {
+		return nil, err
+	}
 
-	resp, err := c.putAsJSON("/extensions/configurations/required_configuration", &ExtensionSetRequiredConfigurationResponse{}, params)
+	resp, err := c.putAsJSON("/extensions/required_configuration", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ type ExtensionCreateClaimsParams struct {
 	ChannelID string
 	// PubSub is the pubsub permission to attach to the claim
 	PubSub *PubSubPermissions
-	// Expiration is the epoch of jwt expiration, default 3 minutes from time.Now
+	// Expiration is the epoch of jwt expiration in seconds, default 3 minutes from time.Now
 	Expiration int64
 }
 
@@ -65,7 +65,7 @@ func (c *Client) ExtensionCreateClaims(
 
 	// default expiration to 3 minutes
 	if params.Expiration == 0 {
-		params.Expiration = time.Now().Add(time.Minute*3).UnixNano() / int64(time.Millisecond)
+		params.Expiration = c.now().Add(time.Minute * 3).Unix()
 	}
 
 	// default channelID to 'all'
@@ -60,20 +60,19 @@ func TestCreateClaims(t *testing.T) {
 	if claims.ChannelID != channelID {
 		t.Errorf("claims broadcasterId doesn't match got %s expected %s", claims.ChannelID, channelID)
 	}
-	if claims.ExpiresAt < time.Now().Add(4*time.Minute).UnixNano() && claims.ExpiresAt > time.Now().Add(-2*time.Minute).UnixNano() {
-		t.Errorf("claims expiry less than 3 minutes")
+	if claims.ExpiresAt > time.Now().Add(4*time.Minute).Unix() || claims.ExpiresAt < time.Now().Add(2*time.Minute).Unix() {
+		t.Errorf("claims expiry is not 3 minutes from now, got %d", claims.ExpiresAt)
 	}
 
-	expiration := time.Now().Add(10*time.Minute).UnixNano() / int64(time.Millisecond)
+	expiration := time.Now().Add(10 * time.Minute).Unix()
 	params.Expiration = expiration
 	claims, err = c.ExtensionCreateClaims(params)
 	if err != nil {
 		t.Errorf("unexpected error generating claims %s", err)
 	}
 
-	overTime := time.Now().Add(15 * time.Minute).Unix()
-	if claims.ExpiresAt < overTime {
-		t.Errorf("claims expiry does not confine to 10 minutes expiry")
+	if claims.ExpiresAt != expiration {
+		t.Errorf("claims expiry doesn't match got %d expected %d", claims.ExpiresAt, expiration)
 	}
 }
 
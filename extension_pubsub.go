@@ -61,8 +61,13 @@ type ExtensionSendPubSubMessageResponse struct {
 	ResponseCommon
 }
 
+// SendExtensionPubSubMessage sends a message to one or more viewers.
+// Requires a signed JWT created by an Extension Backend Service (EBS) with pubsub_perms
+// matching the target, see ExtensionCreateClaims and ExtensionJWTSign.
+//
+// See https://dev.twitch.tv/docs/api/reference/#send-extension-pubsub-message
 func (c *Client) SendExtensionPubSubMessage(params *ExtensionSendPubSubMessageParams) (*ExtensionSendPubSubMessageResponse, error) {
-	resp, err := c.postAsJSON("/extensions/pubsub", &ExtensionSendPubSubMessageResponse{}, params)
+	resp, err := c.postAsJSON("/extensions/pubsub", nil, params)
 	if err != nil {
 		return nil, err
 	}
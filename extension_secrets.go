@@ -39,6 +39,10 @@ type GetExtensionSecretParams struct {
 	ExtensionID string `query:"extension_id"`
 }
 
+// CreateExtensionSecret creates a shared secret used to sign and verify JWT tokens.
+// Requires a signed JWT created by an Extension Backend Service (EBS), see ExtensionJWTSign.
+//
+// See https://dev.twitch.tv/docs/api/reference/#create-extension-secret
 func (c *Client) CreateExtensionSecret(params *ExtensionSecretCreationParams) (*ExtensionSecretCreationResponse, error) {
 	resp, err := c.post("/extensions/jwt/secrets", &ManyExtensionSecrets{}, params)
 	if err != nil {
@@ -52,8 +56,12 @@ func (c *Client) CreateExtensionSecret(params *ExtensionSecretCreationParams) (*
 	return events, nil
 }
 
+// GetExtensionSecrets gets an extension’s list of shared secrets.
+// Requires a signed JWT created by an Extension Backend Service (EBS), see ExtensionJWTSign.
+//
+// See https://dev.twitch.tv/docs/api/reference/#get-extension-secrets
 func (c *Client) GetExtensionSecrets(params *GetExtensionSecretParams) (*GetExtensionSecretResponse, error) {
-	resp, err := c.postAsJSON("/extensions/jwt/secrets", &ManyExtensionSecrets{}, params)
+	resp, err := c.get("/extensions/jwt/secrets", &ManyExtensionSecrets{}, params)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,148 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCreateExtensionSecret(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		params     *ExtensionSecretCreationParams
+		respBody   string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&ExtensionSecretCreationParams{ExtensionID: "my-ext-id"},
+			`{"data":[{"format_version":1,"secrets":[{"content":"secret","active_at":"2021-03-29T06:58:40.858343036Z","expires_at":"2121-03-05T06:58:40.858343036Z"}]}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("expected method to be %s, got %s", http.MethodPost, r.Method)
+			}
+
+			if r.URL.Query().Get("delay") != "300" {
+				t.Errorf("expected delay query param to default to %s, got %s", "300", r.URL.Query().Get("delay"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.CreateExtensionSecret(testCase.params)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.SecretInfo) != 1 || len(resp.Data.SecretInfo[0].Secrets) != 1 {
+			t.Errorf("expected one secret, got %+v", resp.Data.SecretInfo)
+			continue
+		}
+
+		if resp.Data.SecretInfo[0].Secrets[0].Content != "secret" {
+			t.Errorf("expected secret content to be %s, got %s", "secret", resp.Data.SecretInfo[0].Secrets[0].Content)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.CreateExtensionSecret(&ExtensionSecretCreationParams{ExtensionID: "my-ext-id"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestGetExtensionSecrets(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		params     *GetExtensionSecretParams
+		respBody   string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetExtensionSecretParams{ExtensionID: "my-ext-id"},
+			`{"data":[{"format_version":1,"secrets":[{"content":"old-secret","active_at":"2021-03-29T06:58:40.858343036Z","expires_at":"2021-04-22T05:21:54.99261682Z"},{"content":"new-secret","active_at":"2021-04-22T04:16:54.996365329Z","expires_at":"2121-03-29T04:16:54.996365329Z"}]}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("expected method to be %s, got %s", http.MethodGet, r.Method)
+			}
+
+			if r.URL.Query().Get("extension_id") != testCase.params.ExtensionID {
+				t.Errorf("expected extension_id query param to be %s, got %s", testCase.params.ExtensionID, r.URL.Query().Get("extension_id"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.GetExtensionSecrets(testCase.params)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.SecretInfo) != 1 || len(resp.Data.SecretInfo[0].Secrets) != 2 {
+			t.Errorf("expected two secrets, got %+v", resp.Data.SecretInfo)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetExtensionSecrets(&GetExtensionSecretParams{ExtensionID: "my-ext-id"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
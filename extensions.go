@@ -1,7 +1,5 @@
 package helix
 
-import "fmt"
-
 type ExtensionTransaction struct {
 	ID               string `json:"id"`
 	Timestamp        Time   `json:"timestamp"`
@@ -31,10 +29,7 @@ type ManyExtensionTransactions struct {
 	Pagination            Pagination             `json:"pagination"`
 }
 
-type ExtensionTransactionsResponse struct {
-	ResponseCommon
-	Data ManyExtensionTransactions
-}
+type ExtensionTransactionsResponse = ResponseOf[ManyExtensionTransactions]
 
 type ExtensionTransactionsParams struct {
 	ExtensionID string   `query:"extension_id"` // Required
@@ -64,7 +59,7 @@ type ExtensionLiveChannel struct {
 
 type ManyExtensionLiveChannels struct {
 	LiveChannels []ExtensionLiveChannel `json:"data"`
-	Pagination   string                 `json:"pagination"`
+	Pagination   string                 `json:"pagination"` // Cursor for the next page, unlike most endpoints this isn't an object
 }
 
 type ExtensionLiveChannelsParams struct {
@@ -73,10 +68,7 @@ type ExtensionLiveChannelsParams struct {
 	First       int    `query:"first,20"`     // Optional, Limit 100
 }
 
-type ExtensionLiveChannelsResponse struct {
-	ResponseCommon
-	Data ManyExtensionLiveChannels
-}
+type ExtensionLiveChannelsResponse = ResponseOf[ManyExtensionLiveChannels]
 
 // GetExtensionTransactions allows extension back end servers to fetch a list of transactions that
 // have occurred for their extension across all of Twitch. A transaction is a record of a user
@@ -84,6 +76,10 @@ type ExtensionLiveChannelsResponse struct {
 //
 // See https://dev.twitch.tv/docs/api/reference/#get-extension-transactions
 func (c *Client) GetExtensionTransactions(params *ExtensionTransactionsParams) (*ExtensionTransactionsResponse, error) {
+	if err := validateMaxValues("id", "transaction ids", params.ID, 100); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.get("/extensions/transactions", &ManyExtensionTransactions{}, params)
 	if err != nil {
 		return nil, err
@@ -104,14 +100,14 @@ func (c *Client) GetExtensionTransactions(params *ExtensionTransactionsParams) (
 func (c *Client) SendExtensionChatMessage(params *ExtensionSendChatMessageParams) (*ExtensionSendChatMessageResponse, error) {
 
 	if len(params.Text) > 280 {
-		return nil, fmt.Errorf("error: chat message length exceeds 280 characters")
+		return nil, &ValidationError{Field: "text", Message: "chat message length exceeds 280 characters"}
 	}
 
-	if params.BroadcasterID == "" {
-		return nil, fmt.Errorf("error: broadcaster ID must be specified")
+	if err := validateRequired("broadcaster_id", "broadcaster ID", params.BroadcasterID); err != nil {
+		return nil, err
 	}
 
-	resp, err := c.postAsJSON("/extensions/chat", &ExtensionSendChatMessageResponse{}, params)
+	resp, err := c.postAsJSON("/extensions/chat", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -122,10 +118,18 @@ func (c *Client) SendExtensionChatMessage(params *ExtensionSendChatMessageParams
 	return sndExtMsgResp, nil
 }
 
+// GetExtensionLiveChannels gets a list of broadcasters that are streaming live and have installed or activated the extension.
+// To get the next page, set After to the Pagination cursor of the previous response.
+//
+// See https://dev.twitch.tv/docs/api/reference/#get-extension-live-channels
 func (c *Client) GetExtensionLiveChannels(params *ExtensionLiveChannelsParams) (*ExtensionLiveChannelsResponse, error) {
 
-	if params.ExtensionID == "" {
-		return nil, fmt.Errorf("error: extension ID must be specified")
+	if err := validateRequired("extension_id", "extension ID", params.ExtensionID); err != nil {
+		return nil, err
+	}
+
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
 	}
 
 	resp, err := c.get("/extensions/live", &ManyExtensionLiveChannels{}, params)
@@ -139,3 +143,98 @@ func (c *Client) GetExtensionLiveChannels(params *ExtensionLiveChannelsParams) (
 	liveChannels.Data.Pagination = resp.Data.(*ManyExtensionLiveChannels).Pagination
 	return liveChannels, nil
 }
+
+type ExtensionViewerURL struct {
+	ViewerURL              string `json:"viewer_url"`
+	CanLinkExternalContent bool   `json:"can_link_external_content"`
+}
+
+type ExtensionPanelView struct {
+	ViewerURL              string `json:"viewer_url"`
+	Height                 int    `json:"height"`
+	CanLinkExternalContent bool   `json:"can_link_external_content"`
+}
+
+type ExtensionComponentView struct {
+	ViewerURL              string `json:"viewer_url"`
+	AspectRatioX           int    `json:"aspect_ratio_x"`
+	AspectRatioY           int    `json:"aspect_ratio_y"`
+	Autoscale              bool   `json:"autoscale"`
+	ScalePixels            int    `json:"scale_pixels"`
+	TargetHeight           int    `json:"target_height"`
+	CanLinkExternalContent bool   `json:"can_link_external_content"`
+}
+
+type ExtensionViews struct {
+	Mobile       ExtensionViewerURL     `json:"mobile"`
+	Panel        ExtensionPanelView     `json:"panel"`
+	VideoOverlay ExtensionViewerURL     `json:"video_overlay"`
+	Component    ExtensionComponentView `json:"component"`
+	Config       ExtensionViewerURL     `json:"config"`
+}
+
+type Extension struct {
+	AuthorName                string            `json:"author_name"`
+	BitsEnabled               bool              `json:"bits_enabled"`
+	CanInstall                bool              `json:"can_install"`
+	ConfigurationLocation     string            `json:"configuration_location"` // hosted, custom or none
+	Description               string            `json:"description"`
+	EulaTosURL                string            `json:"eula_tos_url"`
+	HasChatSupport            bool              `json:"has_chat_support"`
+	IconURL                   string            `json:"icon_url"`
+	IconURLs                  map[string]string `json:"icon_urls"` // Keyed by size, e.g. "100x100"
+	ID                        string            `json:"id"`
+	Name                      string            `json:"name"`
+	PrivacyPolicyURL          string            `json:"privacy_policy_url"`
+	RequestIdentityLink       bool              `json:"request_identity_link"`
+	ScreenshotURLs            []string          `json:"screenshot_urls"`
+	State                     string            `json:"state"`
+	SubscriptionsSupportLevel string            `json:"subscriptions_support_level"` // none or optional
+	Summary                   string            `json:"summary"`
+	SupportEmail              string            `json:"support_email"`
+	Version                   string            `json:"version"`
+	ViewerSummary             string            `json:"viewer_summary"`
+	Views                     ExtensionViews    `json:"views"`
+	AllowlistedConfigURLs     []string          `json:"allowlisted_config_urls"`
+	AllowlistedPanelURLs      []string          `json:"allowlisted_panel_urls"`
+}
+
+type ManyExtensions struct {
+	Extensions []Extension `json:"data"`
+}
+
+type ExtensionsResponse = ResponseOf[ManyExtensions]
+
+type GetExtensionsParams struct {
+	ExtensionID      string `query:"extension_id"`      // Required
+	ExtensionVersion string `query:"extension_version"` // Optional, defaults to the latest version
+}
+
+// GetExtensions gets information about an extension, including versions that aren't released.
+// Requires a signed JWT created by an Extension Backend Service (EBS), see ExtensionJWTSign.
+//
+// See https://dev.twitch.tv/docs/api/reference/#get-extensions
+func (c *Client) GetExtensions(params *GetExtensionsParams) (*ExtensionsResponse, error) {
+	if err := validateRequired("extension_id", "extension ID", params.ExtensionID); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyExtensions](c, "/extensions", params)
+}
+
+type GetReleasedExtensionsParams struct {
+	ExtensionID      string `query:"extension_id"`      // Required
+	ExtensionVersion string `query:"extension_version"` // Optional, defaults to the latest released version
+}
+
+// GetReleasedExtensions gets information about a released extension.
+// Requires an app access token or user access token.
+//
+// See https://dev.twitch.tv/docs/api/reference/#get-released-extensions
+func (c *Client) GetReleasedExtensions(params *GetReleasedExtensionsParams) (*ExtensionsResponse, error) {
+	if err := validateRequired("extension_id", "extension ID", params.ExtensionID); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyExtensions](c, "/extensions/released", params)
+}
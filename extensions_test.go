@@ -250,3 +250,105 @@ func TestExtensionSendChatMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestGetExtensions(t *testing.T) {
+	t.Parallel()
+
+	respBody := `{"data":[{"author_name":"Twitch Developers","bits_enabled":true,"can_install":false,"configuration_location":"hosted","description":"An extension for testing all the features that we add to extensions","eula_tos_url":"","has_chat_support":true,"icon_url":"https://extensions-discovery-images.twitch.tv/uo6dggojyb8d6soh92zknwmi5ej1q2/0.0.1/logo6a447402-c83b-4a43-a5c6-d5f94cbdb41e","icon_urls":{"100x100":"https://extensions-discovery-images.twitch.tv/uo6dggojyb8d6soh92zknwmi5ej1q2/0.0.1/logo6a447402-c83b-4a43-a5c6-d5f94cbdb41e","24x24":"https://extensions-discovery-images.twitch.tv/uo6dggojyb8d6soh92zknwmi5ej1q2/0.0.1/taskbar905b19da-e7e2-4d1b-9c28-e1db7e3f4cd6"},"id":"pgn0bjv51epi7eaekt53tovjnc82qo","name":"Official Developers Demo","privacy_policy_url":"","request_identity_link":true,"screenshot_urls":["https://extensions-discovery-images.twitch.tv/uo6dggojyb8d6soh92zknwmi5ej1q2/0.0.1/screenshot7fcab35c-2b40-4a55-8b03-9f53b1c0de3c"],"state":"Testing","subscriptions_support_level":"optional","summary":"Test ALL the extensions features!","support_email":"dx-extensions-test-dev@justin.tv","version":"0.0.1","viewer_summary":"Test ALL the extensions features!","views":{"mobile":{"viewer_url":"https://pgn0bjv51epi7eaekt53tovjnc82qo.ext-twitch.tv/pgn0bjv51epi7eaekt53tovjnc82qo/0.0.1/974e5cd8ae5b6c2d2b5d9d3f3dc0ed4b/index.html?anchor=mobile"},"panel":{"viewer_url":"https://pgn0bjv51epi7eaekt53tovjnc82qo.ext-twitch.tv/pgn0bjv51epi7eaekt53tovjnc82qo/0.0.1/974e5cd8ae5b6c2d2b5d9d3f3dc0ed4b/index.html?anchor=panel","height":300,"can_link_external_content":false},"video_overlay":{"viewer_url":"","can_link_external_content":false},"component":{"viewer_url":"","aspect_ratio_x":1,"aspect_ratio_y":1,"autoscale":false,"scale_pixels":0,"target_height":0,"can_link_external_content":false},"config":{"viewer_url":"https://pgn0bjv51epi7eaekt53tovjnc82qo.ext-twitch.tv/pgn0bjv51epi7eaekt53tovjnc82qo/0.0.1/974e5cd8ae5b6c2d2b5d9d3f3dc0ed4b/config.html","can_link_external_content":false}},"allowlisted_config_urls":[],"allowlisted_panel_urls":[]}]}`
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		released      bool
+		extensionID   string
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			false,
+			"",
+			"",
+			"error: extension ID must be specified",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			true,
+			"",
+			"",
+			"error: extension ID must be specified",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			false,
+			"pgn0bjv51epi7eaekt53tovjnc82qo",
+			respBody,
+			"",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			true,
+			"pgn0bjv51epi7eaekt53tovjnc82qo",
+			respBody,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			expectedPath := "/extensions"
+			if testCase.released {
+				expectedPath += "/released"
+			}
+
+			if r.URL.Path != expectedPath {
+				t.Errorf("expected path to be %s, got %s", expectedPath, r.URL.Path)
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		var resp *ExtensionsResponse
+		var err error
+		if testCase.released {
+			resp, err = c.GetReleasedExtensions(&GetReleasedExtensionsParams{ExtensionID: testCase.extensionID})
+		} else {
+			resp, err = c.GetExtensions(&GetExtensionsParams{ExtensionID: testCase.extensionID})
+		}
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+
+			t.Error(err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Extensions) != 1 {
+			t.Errorf("expected 1 extension, got %d", len(resp.Data.Extensions))
+			continue
+		}
+
+		extension := resp.Data.Extensions[0]
+		if extension.ID != testCase.extensionID {
+			t.Errorf("expected extension id to be %s, got %s", testCase.extensionID, extension.ID)
+		}
+
+		if extension.Views.Panel.Height != 300 {
+			t.Errorf("expected panel height to be %d, got %d", 300, extension.Views.Panel.Height)
+		}
+
+		if len(extension.IconURLs) != 2 {
+			t.Errorf("expected 2 icon urls, got %d", len(extension.IconURLs))
+		}
+	}
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package helix
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an advisory lock shared by every process that locks the
+// same path, through a ".lock" file next to it. It blocks until the lock is
+// taken and returns a function that releases it.
+func lockFile(path string) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
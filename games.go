@@ -4,33 +4,31 @@ type Game struct {
 	ID        string `json:"id"`
 	Name      string `json:"name"`
 	BoxArtURL string `json:"box_art_url"`
+	IGDBID    string `json:"igdb_id"` // The ID IGDB uses to identify the game, empty if unknown
 }
 
 type ManyGames struct {
 	Games []Game `json:"data"`
 }
 
-type GamesResponse struct {
-	ResponseCommon
-	Data ManyGames
-}
+type GamesResponse = ResponseOf[ManyGames]
 
+// GamesParams is parameters for GetGames. IDs, Names and IGDBIDs can be
+// combined, with a combined limit of 100 values.
 type GamesParams struct {
-	IDs   []string `query:"id"`   // Limit 100
-	Names []string `query:"name"` // Limit 100
+	IDs     []string `query:"id"`      // Limit 100
+	Names   []string `query:"name"`    // Limit 100
+	IGDBIDs []string `query:"igdb_id"` // Limit 100
 }
 
+// GetGames gets information about the specified categories or games,
+// looked up by their Twitch ID, exact name or IGDB ID.
 func (c *Client) GetGames(params *GamesParams) (*GamesResponse, error) {
-	resp, err := c.get("/games", &ManyGames{}, params)
-	if err != nil {
-		return nil, err
+	if len(params.IDs)+len(params.Names)+len(params.IGDBIDs) > 100 {
+		return nil, &ValidationError{Field: "id", Message: "only 100 ids, names and igdb ids can be specified in total"}
 	}
 
-	games := &GamesResponse{}
-	resp.HydrateResponseCommon(&games.ResponseCommon)
-	games.Data.Games = resp.Data.(*ManyGames).Games
-
-	return games, nil
+	return getResponse[ManyGames](c, "/games", params)
 }
 
 type ManyGamesWithPagination struct {
@@ -44,21 +42,10 @@ type TopGamesParams struct {
 	First  int    `query:"first,20"` // Limit 100
 }
 
-type TopGamesResponse struct {
-	ResponseCommon
-	Data ManyGamesWithPagination
-}
+type TopGamesResponse = ResponseOf[ManyGamesWithPagination]
 
+// GetTopGames gets information about all broadcasts on Twitch, grouped by
+// category and sorted by the number of viewers, most viewers first.
 func (c *Client) GetTopGames(params *TopGamesParams) (*TopGamesResponse, error) {
-	resp, err := c.get("/games/top", &ManyGamesWithPagination{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	games := &TopGamesResponse{}
-	resp.HydrateResponseCommon(&games.ResponseCommon)
-	games.Data.Games = resp.Data.(*ManyGamesWithPagination).Games
-	games.Data.Pagination = resp.Data.(*ManyGamesWithPagination).Pagination
-
-	return games, nil
+	return getResponse[ManyGamesWithPagination](c, "/games/top", params)
 }
@@ -77,6 +77,39 @@ func TestGetGames(t *testing.T) {
 	}
 }
 
+func TestGetGamesByIGDBID(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		igdbIDs := r.URL.Query()["igdb_id"]
+		if len(igdbIDs) != 2 || igdbIDs[0] != "121" || igdbIDs[1] != "1905" {
+			t.Errorf("expected igdb_id query params to be [121 1905], got %v", igdbIDs)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"27471","name":"Minecraft","box_art_url":"https://static-cdn.jtvnw.net/ttv-boxart/Minecraft-{width}x{height}.jpg","igdb_id":"121"},{"id":"33214","name":"Fortnite","box_art_url":"https://static-cdn.jtvnw.net/ttv-boxart/Fortnite-{width}x{height}.jpg","igdb_id":"1905"}]}`))
+	})
+
+	resp, err := c.GetGames(&GamesParams{
+		IGDBIDs: []string{"121", "1905"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.Games) != 2 || resp.Data.Games[0].IGDBID != "121" || resp.Data.Games[1].IGDBID != "1905" {
+		t.Errorf("expected games with igdb ids 121 and 1905, got %+v", resp.Data.Games)
+	}
+
+	_, err = c.GetGames(&GamesParams{
+		IDs:     make([]string, 50),
+		IGDBIDs: make([]string, 51),
+	})
+	if err == nil || err.Error() != "error: only 100 ids, names and igdb ids can be specified in total" {
+		t.Errorf("expected too many ids error, got %v", err)
+	}
+}
+
 func TestGetTopGames(t *testing.T) {
 	t.Parallel()
 
@@ -1,5 +1,16 @@
 package helix
 
+// Types of creator goals
+const (
+	GoalTypeFollower             = "follower"
+	GoalTypeSubscription         = "subscription"           // Counts subscription points
+	GoalTypeSubscriptionCount    = "subscription_count"     // Counts subscriptions
+	GoalTypeNewSubscription      = "new_subscription"       // Counts new subscription points
+	GoalTypeNewSubscriptionCount = "new_subscription_count" // Counts new subscriptions
+	GoalTypeNewBit               = "new_bit"
+	GoalTypeNewCheerer           = "new_cheerer"
+)
+
 type Goal struct {
 	ID               string `json:"id"`
 	BroadcasterID    string `json:"broadcaster_id"`
@@ -17,26 +28,18 @@ type ManyGoals struct {
 	Pagination Pagination `json:"pagination"`
 }
 
-type CreatorGoalsResponse struct {
-	ResponseCommon
-	Data ManyGoals
-}
+type CreatorGoalsResponse = ResponseOf[ManyGoals]
 
 type GetCreatorGoalsParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
 }
 
+// GetCreatorGoals gets the broadcaster’s list of active goals.
 // Required scope: channel:read:goals
 func (c *Client) GetCreatorGoals(payload *GetCreatorGoalsParams) (*CreatorGoalsResponse, error) {
-	resp, err := c.get("/goals", &ManyGoals{}, payload)
-	if err != nil {
-		return nil, err
+	if payload.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided"}
 	}
 
-	goals := &CreatorGoalsResponse{}
-	resp.HydrateResponseCommon(&goals.ResponseCommon)
-	goals.Data.Goals = resp.Data.(*ManyGoals).Goals
-	goals.Data.Pagination = resp.Data.(*ManyGoals).Pagination
-
-	return goals, nil
+	return getResponse[ManyGoals](c, "/goals", payload)
 }
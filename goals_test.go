@@ -1,6 +1,8 @@
 package helix
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -38,6 +40,60 @@ func TestGetCreatorGoals(t *testing.T) {
 
 		if len(resp.Data.Goals) != 1 {
 			t.Errorf("expected %d goals got %d", 1, len(resp.Data.Goals))
+			continue
 		}
+
+		if resp.Data.Goals[0].Type != GoalTypeFollower {
+			t.Errorf("expected goal type to be \"%s\", got \"%s\"", GoalTypeFollower, resp.Data.Goals[0].Type)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetCreatorGoals(&GetCreatorGoalsParams{BroadcasterID: "123"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+
+	_, err = c.GetCreatorGoals(&GetCreatorGoalsParams{})
+	if err == nil || err.Error() != "error: broadcaster id must be provided" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: broadcaster id must be provided", err)
+	}
+}
+
+func TestEventSubChannelGoalEndEvent(t *testing.T) {
+	t.Parallel()
+
+	payload := `{"id":"12345-abc-678-defgh","broadcaster_user_id":"141981764","broadcaster_user_name":"TwitchDev","broadcaster_user_login":"twitchdev","type":"subscription","description":"Help me get partner!","is_achieved":false,"current_amount":180,"target_amount":220,"started_at":"2021-07-15T17:16:03.17106713Z","ended_at":"2020-07-16T17:16:03.17106713Z"}`
+
+	var event EventSubChannelGoalEndEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Type != GoalTypeSubscription {
+		t.Errorf("expected goal type to be \"%s\", got \"%s\"", GoalTypeSubscription, event.Type)
+	}
+
+	if event.IsAchieved || event.CurrentAmount != 180 || event.TargetAmount != 220 {
+		t.Errorf("expected unachieved goal at 180/220, got achieved=%t at %d/%d", event.IsAchieved, event.CurrentAmount, event.TargetAmount)
+	}
+
+	if event.EndedAt.IsZero() {
+		t.Error("expected ended at not to be zero")
 	}
 }
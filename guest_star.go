@@ -0,0 +1,404 @@
+package helix
+
+// Guest Star group layouts
+const (
+	GuestStarGroupLayoutTiled       = "TILED_LAYOUT"
+	GuestStarGroupLayoutScreenshare = "SCREENSHARE_LAYOUT"
+	GuestStarGroupLayoutHorizontal  = "HORIZONTAL_LAYOUT"
+	GuestStarGroupLayoutVertical    = "VERTICAL_LAYOUT"
+)
+
+// Guest Star invite statuses
+const (
+	GuestStarInviteStatusInvited  = "INVITED"
+	GuestStarInviteStatusAccepted = "ACCEPTED"
+	GuestStarInviteStatusReady    = "READY"
+)
+
+// GuestStarChannelSettings describes how a channel uses Guest Star
+type GuestStarChannelSettings struct {
+	IsModeratorSendLiveEnabled  bool   `json:"is_moderator_send_live_enabled"`
+	SlotCount                   int    `json:"slot_count"`
+	IsBrowserSourceAudioEnabled bool   `json:"is_browser_source_audio_enabled"`
+	GroupLayout                 string `json:"group_layout"`
+	BrowserSourceToken          string `json:"browser_source_token"`
+}
+
+// ManyGuestStarChannelSettings is the response data in GetChannelGuestStarSettingsResponse
+type ManyGuestStarChannelSettings struct {
+	Settings []GuestStarChannelSettings `json:"data"`
+}
+
+// GetChannelGuestStarSettingsParams are the parameters for GetChannelGuestStarSettings
+type GetChannelGuestStarSettingsParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+}
+
+// GetChannelGuestStarSettingsResponse is the response from GetChannelGuestStarSettings
+type GetChannelGuestStarSettingsResponse = ResponseOf[ManyGuestStarChannelSettings]
+
+// GetChannelGuestStarSettings gets the channel settings for configuration of the Guest Star feature.
+// Required scope: channel:read:guest_star, channel:manage:guest_star, moderator:read:guest_star or moderator:manage:guest_star
+func (c *Client) GetChannelGuestStarSettings(params *GetChannelGuestStarSettingsParams) (*GetChannelGuestStarSettingsResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+
+	return getResponse[ManyGuestStarChannelSettings](c, "/guest_star/channel_settings", params)
+}
+
+// UpdateChannelGuestStarSettingsParams are the parameters for UpdateChannelGuestStarSettings.
+// Settings left unset (i.e. nil) are not changed.
+type UpdateChannelGuestStarSettingsParams struct {
+	BroadcasterID               string `query:"broadcaster_id" json:"-"`
+	IsModeratorSendLiveEnabled  *bool  `json:"is_moderator_send_live_enabled,omitempty"`
+	SlotCount                   *int   `json:"slot_count,omitempty"` // Between 1 and 6
+	IsBrowserSourceAudioEnabled *bool  `json:"is_browser_source_audio_enabled,omitempty"`
+	GroupLayout                 string `json:"group_layout,omitempty"`
+	RegenerateBrowserSources    *bool  `json:"regenerate_browser_sources,omitempty"`
+}
+
+// UpdateChannelGuestStarSettingsResponse is the response from UpdateChannelGuestStarSettings
+type UpdateChannelGuestStarSettingsResponse struct {
+	ResponseCommon
+}
+
+// UpdateChannelGuestStarSettings mutates the channel settings for configuration of the Guest Star feature.
+// Required scope: channel:manage:guest_star
+func (c *Client) UpdateChannelGuestStarSettings(params *UpdateChannelGuestStarSettingsParams) (*UpdateChannelGuestStarSettingsResponse, error) {
+	if params.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided"}
+	}
+
+	if params.SlotCount != nil && (*params.SlotCount < 1 || *params.SlotCount > 6) {
+		return nil, &ValidationError{Field: "slot_count", Message: "slot count must be between 1 and 6"}
+	}
+
+	resp, err := c.putAsJSON("/guest_star/channel_settings", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &UpdateChannelGuestStarSettingsResponse{}
+	resp.HydrateResponseCommon(&settings.ResponseCommon)
+
+	return settings, nil
+}
+
+// GuestStarMediaSettings describes the audio or video settings of a guest
+type GuestStarMediaSettings struct {
+	IsHostEnabled  bool `json:"is_host_enabled"`
+	IsGuestEnabled bool `json:"is_guest_enabled"`
+	IsAvailable    bool `json:"is_available"`
+}
+
+// GuestStarGuest is a guest assigned to a slot in a Guest Star session.
+// The host is always in slot "0".
+type GuestStarGuest struct {
+	SlotID          string                 `json:"slot_id"`
+	IsLive          bool                   `json:"is_live"`
+	UserID          string                 `json:"user_id"`
+	UserDisplayName string                 `json:"user_display_name"`
+	UserLogin       string                 `json:"user_login"`
+	Volume          int                    `json:"volume"`
+	AssignedAt      Time                   `json:"assigned_at"`
+	AudioSettings   GuestStarMediaSettings `json:"audio_settings"`
+	VideoSettings   GuestStarMediaSettings `json:"video_settings"`
+}
+
+// GuestStarSession is a Guest Star session and its guests
+type GuestStarSession struct {
+	ID     string           `json:"id"`
+	Guests []GuestStarGuest `json:"guests"`
+}
+
+// ManyGuestStarSessions is the response data in GuestStarSessionResponse
+type ManyGuestStarSessions struct {
+	Sessions []GuestStarSession `json:"data"`
+}
+
+// GuestStarSessionResponse is the response from GetGuestStarSession,
+// CreateGuestStarSession and EndGuestStarSession
+type GuestStarSessionResponse = ResponseOf[ManyGuestStarSessions]
+
+// GetGuestStarSessionParams are the parameters for GetGuestStarSession
+type GetGuestStarSessionParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+}
+
+// GetGuestStarSession gets information about an ongoing Guest Star session for a particular channel.
+// Required scope: channel:read:guest_star, channel:manage:guest_star, moderator:read:guest_star or moderator:manage:guest_star
+func (c *Client) GetGuestStarSession(params *GetGuestStarSessionParams) (*GuestStarSessionResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+
+	return getResponse[ManyGuestStarSessions](c, "/guest_star/session", params)
+}
+
+// CreateGuestStarSessionParams are the parameters for CreateGuestStarSession
+type CreateGuestStarSessionParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+}
+
+// CreateGuestStarSession programmatically creates a Guest Star session on behalf of the broadcaster.
+// Required scope: channel:manage:guest_star
+func (c *Client) CreateGuestStarSession(params *CreateGuestStarSessionParams) (*GuestStarSessionResponse, error) {
+	if params.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided"}
+	}
+
+	return postResponse[ManyGuestStarSessions](c, "/guest_star/session", params)
+}
+
+// EndGuestStarSessionParams are the parameters for EndGuestStarSession
+type EndGuestStarSessionParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	SessionID     string `query:"session_id"`
+}
+
+// EndGuestStarSession programmatically ends a Guest Star session on behalf of the broadcaster.
+// The returned session describes its state at the time it ended.
+// Required scope: channel:manage:guest_star
+func (c *Client) EndGuestStarSession(params *EndGuestStarSessionParams) (*GuestStarSessionResponse, error) {
+	if params.BroadcasterID == "" || params.SessionID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and session id must be provided"}
+	}
+
+	return deleteResponse[ManyGuestStarSessions](c, "/guest_star/session", params)
+}
+
+// GuestStarInvite is a pending invite to a Guest Star session
+type GuestStarInvite struct {
+	UserID           string `json:"user_id"`
+	InvitedAt        Time   `json:"invited_at"`
+	Status           string `json:"status"`
+	IsVideoEnabled   bool   `json:"is_video_enabled"`
+	IsAudioEnabled   bool   `json:"is_audio_enabled"`
+	IsVideoAvailable bool   `json:"is_video_available"`
+	IsAudioAvailable bool   `json:"is_audio_available"`
+}
+
+// ManyGuestStarInvites is the response data in GetGuestStarInvitesResponse
+type ManyGuestStarInvites struct {
+	Invites []GuestStarInvite `json:"data"`
+}
+
+// GetGuestStarInvitesParams are the parameters for GetGuestStarInvites
+type GetGuestStarInvitesParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+	SessionID     string `query:"session_id"`
+}
+
+// GetGuestStarInvitesResponse is the response from GetGuestStarInvites
+type GetGuestStarInvitesResponse = ResponseOf[ManyGuestStarInvites]
+
+// GetGuestStarInvites provides the caller with a list of pending invites to a Guest Star session.
+// Required scope: channel:read:guest_star, channel:manage:guest_star, moderator:read:guest_star or moderator:manage:guest_star
+func (c *Client) GetGuestStarInvites(params *GetGuestStarInvitesParams) (*GetGuestStarInvitesResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" || params.SessionID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id, moderator id and session id must be provided"}
+	}
+
+	return getResponse[ManyGuestStarInvites](c, "/guest_star/invites", params)
+}
+
+// GuestStarInviteParams are the parameters for SendGuestStarInvite and DeleteGuestStarInvite
+type GuestStarInviteParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+	SessionID     string `query:"session_id"`
+	GuestID       string `query:"guest_id"`
+}
+
+// GuestStarInviteResponse is the response from SendGuestStarInvite and DeleteGuestStarInvite
+type GuestStarInviteResponse struct {
+	ResponseCommon
+}
+
+// SendGuestStarInvite sends an invite to a specified guest on behalf of the broadcaster for a Guest Star session in progress.
+// Required scope: channel:manage:guest_star or moderator:manage:guest_star
+func (c *Client) SendGuestStarInvite(params *GuestStarInviteParams) (*GuestStarInviteResponse, error) {
+	if err := validateGuestStarInviteParams(params); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post("/guest_star/invites", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &GuestStarInviteResponse{}
+	resp.HydrateResponseCommon(&invite.ResponseCommon)
+
+	return invite, nil
+}
+
+// DeleteGuestStarInvite revokes a previously sent invite for a Guest Star session.
+// Required scope: channel:manage:guest_star or moderator:manage:guest_star
+func (c *Client) DeleteGuestStarInvite(params *GuestStarInviteParams) (*GuestStarInviteResponse, error) {
+	if err := validateGuestStarInviteParams(params); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.delete("/guest_star/invites", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &GuestStarInviteResponse{}
+	resp.HydrateResponseCommon(&invite.ResponseCommon)
+
+	return invite, nil
+}
+
+func validateGuestStarInviteParams(params *GuestStarInviteParams) error {
+	if params.BroadcasterID == "" || params.ModeratorID == "" || params.SessionID == "" || params.GuestID == "" {
+		return &ValidationError{Field: "broadcaster_id", Message: "broadcaster id, moderator id, session id and guest id must be provided"}
+	}
+
+	return nil
+}
+
+// GuestStarSlotResponse is the response from the Guest Star slot management endpoints
+type GuestStarSlotResponse struct {
+	ResponseCommon
+}
+
+// AssignGuestStarSlotParams are the parameters for AssignGuestStarSlot
+type AssignGuestStarSlotParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+	SessionID     string `query:"session_id"`
+	GuestID       string `query:"guest_id"`
+	SlotID        string `query:"slot_id"`
+}
+
+// AssignGuestStarSlot allows a previously invited user to be assigned a slot within the active Guest Star session,
+// once that guest has indicated they are ready to join.
+// Required scope: channel:manage:guest_star or moderator:manage:guest_star
+func (c *Client) AssignGuestStarSlot(params *AssignGuestStarSlotParams) (*GuestStarSlotResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" || params.SessionID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id, moderator id and session id must be provided"}
+	}
+
+	if params.GuestID == "" || params.SlotID == "" {
+		return nil, &ValidationError{Field: "guest_id", Message: "guest id and slot id must be provided"}
+	}
+
+	resp, err := c.post("/guest_star/slot", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := &GuestStarSlotResponse{}
+	resp.HydrateResponseCommon(&slot.ResponseCommon)
+
+	return slot, nil
+}
+
+// UpdateGuestStarSlotParams are the parameters for UpdateGuestStarSlot.
+// If DestinationSlotID is occupied, the two guests swap slots.
+type UpdateGuestStarSlotParams struct {
+	BroadcasterID     string `query:"broadcaster_id"`
+	ModeratorID       string `query:"moderator_id"`
+	SessionID         string `query:"session_id"`
+	SourceSlotID      string `query:"source_slot_id"`
+	DestinationSlotID string `query:"destination_slot_id"`
+}
+
+// UpdateGuestStarSlot allows a user to update the assigned slot for a particular user within the active Guest Star session.
+// Required scope: channel:manage:guest_star or moderator:manage:guest_star
+func (c *Client) UpdateGuestStarSlot(params *UpdateGuestStarSlotParams) (*GuestStarSlotResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" || params.SessionID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id, moderator id and session id must be provided"}
+	}
+
+	if params.SourceSlotID == "" {
+		return nil, &ValidationError{Field: "source_slot_id", Message: "source slot id must be provided"}
+	}
+
+	resp, err := c.patch("/guest_star/slot", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := &GuestStarSlotResponse{}
+	resp.HydrateResponseCommon(&slot.ResponseCommon)
+
+	return slot, nil
+}
+
+// DeleteGuestStarSlotParams are the parameters for DeleteGuestStarSlot
+type DeleteGuestStarSlotParams struct {
+	BroadcasterID       string `query:"broadcaster_id"`
+	ModeratorID         string `query:"moderator_id"`
+	SessionID           string `query:"session_id"`
+	GuestID             string `query:"guest_id"`
+	SlotID              string `query:"slot_id"`
+	ShouldReinviteGuest *bool  `query:"should_reinvite_guest"`
+}
+
+// DeleteGuestStarSlot allows a caller to remove a slot assignment from a user participating in an active Guest Star session.
+// Required scope: channel:manage:guest_star or moderator:manage:guest_star
+func (c *Client) DeleteGuestStarSlot(params *DeleteGuestStarSlotParams) (*GuestStarSlotResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" || params.SessionID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id, moderator id and session id must be provided"}
+	}
+
+	if params.GuestID == "" || params.SlotID == "" {
+		return nil, &ValidationError{Field: "guest_id", Message: "guest id and slot id must be provided"}
+	}
+
+	resp, err := c.delete("/guest_star/slot", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := &GuestStarSlotResponse{}
+	resp.HydrateResponseCommon(&slot.ResponseCommon)
+
+	return slot, nil
+}
+
+// UpdateGuestStarSlotSettingsParams are the parameters for UpdateGuestStarSlotSettings.
+// Settings left unset (i.e. nil) are not changed.
+type UpdateGuestStarSlotSettingsParams struct {
+	BroadcasterID  string `query:"broadcaster_id"`
+	ModeratorID    string `query:"moderator_id"`
+	SessionID      string `query:"session_id"`
+	SlotID         string `query:"slot_id"`
+	IsAudioEnabled *bool  `query:"is_audio_enabled"`
+	IsVideoEnabled *bool  `query:"is_video_enabled"`
+	IsLive         *bool  `query:"is_live"`
+	Volume         *int   `query:"volume"` // Between 0 and 100
+}
+
+// UpdateGuestStarSlotSettings allows a user to update slot settings for a particular guest within a Guest Star session.
+// Required scope: channel:manage:guest_star or moderator:manage:guest_star
+func (c *Client) UpdateGuestStarSlotSettings(params *UpdateGuestStarSlotSettingsParams) (*GuestStarSlotResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" || params.SessionID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id, moderator id and session id must be provided"}
+	}
+
+	if params.SlotID == "" {
+		return nil, &ValidationError{Field: "slot_id", Message: "slot id must be provided"}
+	}
+
+	if params.Volume != nil && (*params.Volume < 0 || *params.Volume > 100) {
+		return nil, &ValidationError{Field: "volume", Message: "volume must be between 0 and 100"}
+	}
+
+	resp, err := c.patch("/guest_star/slot_settings", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := &GuestStarSlotResponse{}
+	resp.HydrateResponseCommon(&slot.ResponseCommon)
+
+	return slot, nil
+}
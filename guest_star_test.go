@@ -0,0 +1,489 @@
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGetChannelGuestStarSettings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetChannelGuestStarSettingsParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetChannelGuestStarSettingsParams{BroadcasterID: "9321049"},
+			``,
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetChannelGuestStarSettingsParams{BroadcasterID: "9321049", ModeratorID: "9321049"},
+			`{"data":[{"is_moderator_send_live_enabled":true,"slot_count":4,"is_browser_source_audio_enabled":true,"group_layout":"TILED_LAYOUT","browser_source_token":"eihq8rew7q3hgier8owzf"}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetChannelGuestStarSettings(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Settings) != 1 {
+			t.Errorf("expected number of settings to be %d, got %d", 1, len(resp.Data.Settings))
+			continue
+		}
+
+		settings := resp.Data.Settings[0]
+		if settings.SlotCount != 4 {
+			t.Errorf("expected slot count to be %d, got %d", 4, settings.SlotCount)
+		}
+
+		if settings.GroupLayout != GuestStarGroupLayoutTiled {
+			t.Errorf("expected group layout to be %s, got %s", GuestStarGroupLayoutTiled, settings.GroupLayout)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetChannelGuestStarSettings(&GetChannelGuestStarSettingsParams{BroadcasterID: "9321049", ModeratorID: "9321049"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestUpdateChannelGuestStarSettings(t *testing.T) {
+	t.Parallel()
+
+	slotCount := 6
+	invalidSlotCount := 7
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *UpdateChannelGuestStarSettingsParams
+		validationErr string
+	}{
+		{
+			http.StatusNoContent,
+			&Options{ClientID: "my-client-id", UserAccessToken: "broadcaster-access-token"},
+			&UpdateChannelGuestStarSettingsParams{SlotCount: &slotCount},
+			"error: broadcaster id must be provided",
+		},
+		{
+			http.StatusNoContent,
+			&Options{ClientID: "my-client-id", UserAccessToken: "broadcaster-access-token"},
+			&UpdateChannelGuestStarSettingsParams{BroadcasterID: "9321049", SlotCount: &invalidSlotCount},
+			"error: slot count must be between 1 and 6",
+		},
+		{
+			http.StatusNoContent,
+			&Options{ClientID: "my-client-id", UserAccessToken: "broadcaster-access-token"},
+			&UpdateChannelGuestStarSettingsParams{BroadcasterID: "9321049", SlotCount: &slotCount},
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("broadcaster_id") != testCase.params.BroadcasterID {
+				t.Errorf("expected broadcaster_id query param to be %s, got %s", testCase.params.BroadcasterID, r.URL.Query().Get("broadcaster_id"))
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var payload map[string]interface{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Error(err)
+			}
+
+			if len(payload) != 1 || payload["slot_count"] != float64(slotCount) {
+				t.Errorf("expected body to only contain slot_count, got %s", body)
+			}
+
+			w.WriteHeader(testCase.statusCode)
+		})
+
+		resp, err := c.UpdateChannelGuestStarSettings(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.UpdateChannelGuestStarSettings(&UpdateChannelGuestStarSettingsParams{BroadcasterID: "9321049"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestGuestStarSession(t *testing.T) {
+	t.Parallel()
+
+	respBody := `{"data":[{"id":"2KFRQbFtpmfyD3IevNRnCzOPRJI","guests":[{"slot_id":"0","id":"9321049","user_id":"9321049","user_display_name":"Cool_User","user_login":"cool_user","is_live":true,"volume":100,"assigned_at":"2023-01-02T04:16:53.325Z","audio_settings":{"is_available":true,"is_host_enabled":true,"is_guest_enabled":true},"video_settings":{"is_available":true,"is_host_enabled":true,"is_guest_enabled":true}}]}]}`
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "broadcaster-access-token"}, newMockHandler(http.StatusOK, respBody, nil))
+
+	_, err := c.GetGuestStarSession(&GetGuestStarSessionParams{BroadcasterID: "9321049"})
+	if err == nil || err.Error() != "error: broadcaster id and moderator id must be provided" {
+		t.Errorf("expected validation error, got %v", err)
+	}
+
+	_, err = c.CreateGuestStarSession(&CreateGuestStarSessionParams{})
+	if err == nil || err.Error() != "error: broadcaster id must be provided" {
+		t.Errorf("expected validation error, got %v", err)
+	}
+
+	_, err = c.EndGuestStarSession(&EndGuestStarSessionParams{BroadcasterID: "9321049"})
+	if err == nil || err.Error() != "error: broadcaster id and session id must be provided" {
+		t.Errorf("expected validation error, got %v", err)
+	}
+
+	responses := []*GuestStarSessionResponse{}
+
+	resp, err := c.GetGuestStarSession(&GetGuestStarSessionParams{BroadcasterID: "9321049", ModeratorID: "9321049"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	responses = append(responses, resp)
+
+	resp, err = c.CreateGuestStarSession(&CreateGuestStarSessionParams{BroadcasterID: "9321049"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	responses = append(responses, resp)
+
+	resp, err = c.EndGuestStarSession(&EndGuestStarSessionParams{BroadcasterID: "9321049", SessionID: "2KFRQbFtpmfyD3IevNRnCzOPRJI"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	responses = append(responses, resp)
+
+	for _, resp := range responses {
+		if len(resp.Data.Sessions) != 1 {
+			t.Errorf("expected number of sessions to be %d, got %d", 1, len(resp.Data.Sessions))
+			continue
+		}
+
+		session := resp.Data.Sessions[0]
+		if session.ID != "2KFRQbFtpmfyD3IevNRnCzOPRJI" {
+			t.Errorf("expected session id to be %s, got %s", "2KFRQbFtpmfyD3IevNRnCzOPRJI", session.ID)
+		}
+
+		if len(session.Guests) != 1 {
+			t.Errorf("expected number of guests to be %d, got %d", 1, len(session.Guests))
+			continue
+		}
+
+		if session.Guests[0].SlotID != "0" || !session.Guests[0].AudioSettings.IsHostEnabled {
+			t.Errorf("expected host in slot 0 with audio enabled, got %+v", session.Guests[0])
+		}
+	}
+}
+
+func TestGetGuestStarInvites(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetGuestStarInvitesParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetGuestStarInvitesParams{BroadcasterID: "9321049", ModeratorID: "9321049"},
+			``,
+			"error: broadcaster id, moderator id and session id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetGuestStarInvitesParams{BroadcasterID: "9321049", ModeratorID: "9321049", SessionID: "2KFRQbFtpmfyD3IevNRnCzOPRJI"},
+			`{"data":[{"user_id":"144601104","invited_at":"2023-01-02T04:16:53.325Z","status":"INVITED","is_audio_enabled":false,"is_video_enabled":true,"is_audio_available":true,"is_video_available":true}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetGuestStarInvites(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Invites) != 1 {
+			t.Errorf("expected number of invites to be %d, got %d", 1, len(resp.Data.Invites))
+			continue
+		}
+
+		if resp.Data.Invites[0].Status != GuestStarInviteStatusInvited {
+			t.Errorf("expected invite status to be %s, got %s", GuestStarInviteStatusInvited, resp.Data.Invites[0].Status)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetGuestStarInvites(&GetGuestStarInvitesParams{BroadcasterID: "9321049", ModeratorID: "9321049", SessionID: "2KFRQbFtpmfyD3IevNRnCzOPRJI"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestGuestStarInvite(t *testing.T) {
+	t.Parallel()
+
+	params := &GuestStarInviteParams{
+		BroadcasterID: "9321049",
+		ModeratorID:   "9321049",
+		SessionID:     "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+		GuestID:       "144601104",
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("guest_id") != params.GuestID {
+			t.Errorf("expected guest_id query param to be %s, got %s", params.GuestID, r.URL.Query().Get("guest_id"))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := c.SendGuestStarInvite(params)
+	if err != nil {
+		t.Error(err)
+	} else if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+
+	resp, err = c.DeleteGuestStarInvite(params)
+	if err != nil {
+		t.Error(err)
+	} else if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+
+	expectedErr := "error: broadcaster id, moderator id, session id and guest id must be provided"
+	_, err = c.SendGuestStarInvite(&GuestStarInviteParams{BroadcasterID: "9321049"})
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", expectedErr, err)
+	}
+
+	_, err = c.DeleteGuestStarInvite(&GuestStarInviteParams{BroadcasterID: "9321049"})
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", expectedErr, err)
+	}
+}
+
+func TestGuestStarSlots(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("session_id") != "2KFRQbFtpmfyD3IevNRnCzOPRJI" {
+			t.Errorf("expected session_id query param to be %s, got %s", "2KFRQbFtpmfyD3IevNRnCzOPRJI", r.URL.Query().Get("session_id"))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := c.AssignGuestStarSlot(&AssignGuestStarSlotParams{
+		BroadcasterID: "9321049",
+		ModeratorID:   "9321049",
+		SessionID:     "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+		GuestID:       "144601104",
+		SlotID:        "1",
+	})
+	if err != nil {
+		t.Error(err)
+	} else if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+
+	resp, err = c.UpdateGuestStarSlot(&UpdateGuestStarSlotParams{
+		BroadcasterID:     "9321049",
+		ModeratorID:       "9321049",
+		SessionID:         "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+		SourceSlotID:      "1",
+		DestinationSlotID: "2",
+	})
+	if err != nil {
+		t.Error(err)
+	} else if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+
+	resp, err = c.DeleteGuestStarSlot(&DeleteGuestStarSlotParams{
+		BroadcasterID: "9321049",
+		ModeratorID:   "9321049",
+		SessionID:     "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+		GuestID:       "144601104",
+		SlotID:        "2",
+	})
+	if err != nil {
+		t.Error(err)
+	} else if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+
+	_, err = c.AssignGuestStarSlot(&AssignGuestStarSlotParams{BroadcasterID: "9321049", ModeratorID: "9321049", SessionID: "2KFRQbFtpmfyD3IevNRnCzOPRJI"})
+	if err == nil || err.Error() != "error: guest id and slot id must be provided" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: guest id and slot id must be provided", err)
+	}
+
+	_, err = c.UpdateGuestStarSlot(&UpdateGuestStarSlotParams{BroadcasterID: "9321049", ModeratorID: "9321049", SessionID: "2KFRQbFtpmfyD3IevNRnCzOPRJI"})
+	if err == nil || err.Error() != "error: source slot id must be provided" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: source slot id must be provided", err)
+	}
+
+	_, err = c.DeleteGuestStarSlot(&DeleteGuestStarSlotParams{BroadcasterID: "9321049"})
+	if err == nil || err.Error() != "error: broadcaster id, moderator id and session id must be provided" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: broadcaster id, moderator id and session id must be provided", err)
+	}
+}
+
+func TestUpdateGuestStarSlotSettings(t *testing.T) {
+	t.Parallel()
+
+	isLive := false
+	volume := 101
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"}, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("is_live") != "false" {
+			t.Errorf("expected is_live query param to be %s, got %s", "false", query.Get("is_live"))
+		}
+
+		if _, ok := query["is_audio_enabled"]; ok {
+			t.Error("expected is_audio_enabled query param not to be set")
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	params := &UpdateGuestStarSlotSettingsParams{
+		BroadcasterID: "9321049",
+		ModeratorID:   "9321049",
+		SessionID:     "2KFRQbFtpmfyD3IevNRnCzOPRJI",
+		SlotID:        "1",
+		IsLive:        &isLive,
+	}
+
+	resp, err := c.UpdateGuestStarSlotSettings(params)
+	if err != nil {
+		t.Error(err)
+	} else if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+
+	params.Volume = &volume
+	_, err = c.UpdateGuestStarSlotSettings(params)
+	if err == nil || err.Error() != "error: volume must be between 0 and 100" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: volume must be between 0 and 100", err)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	params.Volume = nil
+	_, err = c.UpdateGuestStarSlotSettings(params)
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
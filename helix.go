@@ -22,6 +22,11 @@ const (
 
 	// AuthBaseURL is the base URL for composing authentication requests.
 	AuthBaseURL = "https://id.twitch.tv/oauth2"
+
+	// TwitchCLIMockAPIBaseURL and TwitchCLIMockAuthBaseURL point the client at
+	// the mock server started by the Twitch CLI with "twitch mock-api start".
+	TwitchCLIMockAPIBaseURL  = "http://localhost:8080/mock"
+	TwitchCLIMockAuthBaseURL = "http://localhost:8080/auth"
 )
 
 type HTTPClient interface {
@@ -29,11 +34,14 @@ type HTTPClient interface {
 }
 
 type Client struct {
-	mu           sync.RWMutex
-	ctx          context.Context
-	opts         *Options
-	lastResponse *Response
-	callbacks    struct {
+	mu            sync.RWMutex
+	ctx           context.Context
+	opts          *Options
+	lastResponse  *Response
+	rateLimits    map[string]RateLimit     // Keyed by a hash of the bearer token
+	tokenMetadata map[string]tokenMetadata // Keyed by a hash of the access token
+	eventSubCost  *eventSubCost
+	callbacks     struct {
 		onUserAccessTokenRefreshed func(newAccessToken, newRefreshToken string)
 	}
 }
@@ -49,7 +57,78 @@ type Options struct {
 	HTTPClient      HTTPClient
 	RateLimitFunc   RateLimitFunc
 	APIBaseURL      string
+	AuthBaseURL     string
 	ExtensionOpts   ExtensionOptions
+
+	// (Optional) Scopes granted to UserAccessToken. When set, requests to
+	// endpoints that require other scopes fail before they are sent.
+	UserAccessTokenScopes []string
+
+	// (Optional) Secrets accepted when verifying EventSub notifications, see
+	// VerifyEventSubSignature. List the new secret along with the previous
+	// one while rotating secrets.
+	EventSubSecrets []string
+
+	// (Optional) Middleware run around every request, including retries
+	// after a token refresh or a rate limit. The first middleware is the
+	// outermost one and the HTTPClient is called last. Responses served from
+	// the Cache don't pass through it.
+	Middleware []Middleware
+
+	// (Optional) Logger that receives a debug entry for every request,
+	// with its method, endpoint, status, remaining rate limit and latency.
+	Logger Logger
+
+	// (Optional) Cache for the responses of GET requests to the endpoints in
+	// CacheTTLs or DefaultCacheTTLs, keyed by URL and token. NewLRUCache
+	// returns an in-memory cache. Responses served from the cache bypass
+	// Middleware, which only sees the requests that are sent.
+	Cache Cache
+
+	// (Optional) How long the responses of endpoints are cached, e.g.
+	// "/users". Overrides DefaultCacheTTLs, a zero TTL disables caching.
+	CacheTTLs map[string]time.Duration
+
+	// (Optional) Store the app and user access tokens are read from before
+	// every request and saved to whenever they change, e.g. after a refresh.
+	// Clients sharing a store share their tokens.
+	TokenStore TokenStore
+
+	// (Optional) Clock and Sleeper used for token and cache expiry, rate
+	// limit resets and for the waits between polls, so tests can control
+	// time. A Clock that implements TimerClock also runs the client's
+	// timeouts. Default to the system clock and time.Sleep.
+	Clock   Clock
+	Sleeper Sleeper
+
+	// (Optional) Transport of the HTTP client, ignored if HTTPClient is set.
+	// Clients without either share a transport built by NewTransport, pass
+	// the same transport to clients to tune their shared connection pool.
+	Transport *http.Transport
+
+	// (Optional) Refuse to create EventSub subscriptions with an
+	// *EventSubCostError once the total cost last reported by Twitch reached
+	// the maximum, see EventSubCostRemaining. This also refuses subscriptions
+	// that would cost nothing because their user authorized the app.
+	LimitEventSubCost bool
+
+	// (Optional) Don't ask for compressed responses. By default requests
+	// are sent with "Accept-Encoding: gzip, deflate" and compressed
+	// responses are decompressed before middleware sees them, whichever
+	// HTTPClient is used.
+	DisableCompression bool
+
+	// (Optional) Replace broadcaster and moderator ids set to SelfID with
+	// the id of the user the user access token belongs to, which is looked
+	// up by validating the token once.
+	ResolveSelfIDs bool
+
+	// (Optional) Return the error of responses with a 4xx or 5xx status,
+	// see ResponseCommon.Err, as the error of the call instead of a response.
+	// By default these responses are returned with a nil error and their
+	// StatusCode and error fields set. ValidateToken still reports invalid
+	// tokens through its result.
+	ReturnAPIErrors bool
 }
 
 type ExtensionOptions struct {
@@ -66,12 +145,24 @@ type DateRange struct {
 
 type RateLimitFunc func(*Response) error
 
+// RoundTripFunc sends a request and returns its response, like
+// HTTPClient.Do.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every request made by the client, e.g. to
+// log or time requests, mutate their headers or serve them from a cache. It
+// returns a RoundTripFunc that usually calls next.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
 type ResponseCommon struct {
 	StatusCode   int
 	Header       http.Header
+	RequestID    string `json:"-"` // The X-Request-Id header, quote it when reporting issues to Twitch
 	Error        string `json:"error"`
 	ErrorStatus  int    `json:"status"`
 	ErrorMessage string `json:"message"`
+
+	receivedAt time.Time // When the client received the response, see Err
 }
 
 func (rc *ResponseCommon) convertHeaderToInt(str string) int {
@@ -104,9 +195,25 @@ type Response struct {
 func (r *Response) HydrateResponseCommon(rc *ResponseCommon) {
 	rc.StatusCode = r.ResponseCommon.StatusCode
 	rc.Header = r.ResponseCommon.Header
+	rc.RequestID = r.ResponseCommon.RequestID
 	rc.Error = r.ResponseCommon.Error
 	rc.ErrorStatus = r.ResponseCommon.ErrorStatus
 	rc.ErrorMessage = r.ResponseCommon.ErrorMessage
+	rc.receivedAt = r.ResponseCommon.receivedAt
+}
+
+// ResponseOf is the response of an endpoint whose data is a T, such as
+// ManyOf of the endpoint's items. Most response types are aliases of it,
+// e.g. GamesResponse is a ResponseOf[ManyGames].
+type ResponseOf[T any] struct {
+	ResponseCommon
+	Data T
+}
+
+// ManyOf is the data of endpoints that return a page of items.
+type ManyOf[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination Pagination `json:"pagination"`
 }
 
 type Pagination struct {
@@ -125,18 +232,29 @@ func NewClientWithContext(ctx context.Context, options *Options) (*Client, error
 	}
 
 	if options.HTTPClient == nil {
-		options.HTTPClient = http.DefaultClient
+		options.HTTPClient = defaultHTTPClient
+		if options.Transport != nil {
+			options.HTTPClient = &http.Client{Transport: options.Transport}
+		}
 	}
 
 	if options.APIBaseURL == "" {
 		options.APIBaseURL = DefaultAPIBaseURL
 	}
 
+	if options.AuthBaseURL == "" {
+		options.AuthBaseURL = AuthBaseURL
+	}
+
 	client := &Client{
 		ctx:  ctx,
 		opts: options,
 	}
 
+	if err := client.seedTokenStore(); err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
 
@@ -156,6 +274,10 @@ func (c *Client) delete(path string, respData, reqData interface{}) (*Response,
 	return c.sendRequest(http.MethodDelete, path, respData, reqData, false)
 }
 
+func (c *Client) patch(path string, respData, reqData interface{}) (*Response, error) {
+	return c.sendRequest(http.MethodPatch, path, respData, reqData, false)
+}
+
 func (c *Client) patchAsJSON(path string, respData, reqData interface{}) (*Response, error) {
 	return c.sendRequest(http.MethodPatch, path, respData, reqData, true)
 }
@@ -168,12 +290,85 @@ func (c *Client) putAsJSON(path string, respData, reqData interface{}) (*Respons
 	return c.sendRequest(http.MethodPut, path, respData, reqData, true)
 }
 
+// getResponse and the functions below send a request like the client method
+// of the same name and decode the response data into a T, so an endpoint only
+// needs its params and data types. Methods can't have type parameters, so
+// these take the client as an argument.
+func getResponse[T any](c *Client, path string, reqData interface{}) (*ResponseOf[T], error) {
+	return sendRequestOf[T](c, http.MethodGet, path, reqData, false)
+}
+
+func postResponse[T any](c *Client, path string, reqData interface{}) (*ResponseOf[T], error) {
+	return sendRequestOf[T](c, http.MethodPost, path, reqData, false)
+}
+
+func putResponse[T any](c *Client, path string, reqData interface{}) (*ResponseOf[T], error) {
+	return sendRequestOf[T](c, http.MethodPut, path, reqData, false)
+}
+
+func deleteResponse[T any](c *Client, path string, reqData interface{}) (*ResponseOf[T], error) {
+	return sendRequestOf[T](c, http.MethodDelete, path, reqData, false)
+}
+
+func patchAsJSONResponse[T any](c *Client, path string, reqData interface{}) (*ResponseOf[T], error) {
+	return sendRequestOf[T](c, http.MethodPatch, path, reqData, true)
+}
+
+func postAsJSONResponse[T any](c *Client, path string, reqData interface{}) (*ResponseOf[T], error) {
+	return sendRequestOf[T](c, http.MethodPost, path, reqData, true)
+}
+
+func putAsJSONResponse[T any](c *Client, path string, reqData interface{}) (*ResponseOf[T], error) {
+	return sendRequestOf[T](c, http.MethodPut, path, reqData, true)
+}
+
+func sendRequestOf[T any](c *Client, method, path string, reqData interface{}, hasJSONBody bool) (*ResponseOf[T], error) {
+	resp, err := c.sendRequest(method, path, new(T), reqData, hasJSONBody)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := &ResponseOf[T]{Data: *resp.Data.(*T)}
+	resp.HydrateResponseCommon(&typed.ResponseCommon)
+
+	return typed, nil
+}
+
 func (c *Client) sendRequest(method, path string, respData, reqData interface{}, hasJSONBody bool) (*Response, error) {
+	resp, err := c.sendRawRequest(method, path, respData, reqData, hasJSONBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.responseError(&resp.ResponseCommon); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// sendRawRequest sends a request like sendRequest, but returns failed
+// responses whether or not Options.ReturnAPIErrors is set, for endpoints
+// that give some error statuses a meaning of their own.
+func (c *Client) sendRawRequest(method, path string, respData, reqData interface{}, hasJSONBody bool) (*Response, error) {
 	resp := &Response{}
 	if respData != nil {
 		resp.Data = respData
 	}
 
+	if err := c.loadStoredTokens(); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkUserAccessTokenScopes(method, path); err != nil {
+		return nil, err
+	}
+
+	reqData, err := c.resolveSelfIDs(reqData)
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := c.newRequest(method, path, reqData, hasJSONBody)
 	if err != nil {
 		return nil, err
@@ -223,7 +418,15 @@ func buildQueryString(req *http.Request, v interface{}) (string, error) {
 			}
 		}
 
-		if field.Type.Kind() == reflect.Slice {
+		if field.Type.Kind() == reflect.Ptr {
+			// Attach pointer values only when they have been set
+			fieldVal := vValue.Field(i)
+			if fieldVal.IsNil() {
+				continue
+			}
+
+			query.Add(tag, fmt.Sprintf("%v", fieldVal.Elem()))
+		} else if field.Type.Kind() == reflect.Slice {
 			// Attach any slices as query params
 			fieldVal := vValue.Field(i)
 			for j := 0; j < fieldVal.Len(); j++ {
@@ -334,13 +537,21 @@ func (c *Client) newJSONRequest(method, url string, data interface{}) (*http.Req
 func (c *Client) getBaseURL(path string) string {
 	for _, authPath := range authPaths {
 		if strings.Contains(path, authPath) {
-			return AuthBaseURL
+			return c.getAuthBaseURL()
 		}
 	}
 
 	return c.opts.APIBaseURL
 }
 
+func (c *Client) getAuthBaseURL() string {
+	if c.opts.AuthBaseURL != "" {
+		return c.opts.AuthBaseURL
+	}
+
+	return AuthBaseURL
+}
+
 func (c *Client) doRequest(req *http.Request, resp *Response) error {
 	c.setRequestHeaders(req)
 
@@ -354,30 +565,43 @@ func (c *Client) doRequest(req *http.Request, resp *Response) error {
 			}
 		}
 
-		response, err := c.opts.HTTPClient.Do(req)
+		start := c.now()
+		response, err := c.cachedRoundTrip(req)
+		c.logRequest(req, response, err, c.now().Sub(start))
 		if err != nil {
-			return fmt.Errorf("Failed to execute API request: %s", err.Error())
+			return fmt.Errorf("Failed to execute API request: %w", err)
 		}
 		defer response.Body.Close()
 
 		resp.Header = response.Header
+		resp.RequestID = response.Header.Get(RequestIDHeader)
+		resp.receivedAt = c.now()
 
 		setResponseStatusCode(resp, "StatusCode", response.StatusCode)
 
-		bodyBytes, err := ioutil.ReadAll(response.Body)
-		if err != nil {
+		var bodyBytes []byte
+		if stream, ok := resp.Data.(streamDecoder); ok && resp.StatusCode < http.StatusBadRequest {
+			// Successful request whose data is decoded as it is read
+			if err := stream.decodeStream(response.Body); err != nil {
+				return fmt.Errorf("Failed to decode API response: %s", err.Error())
+			}
+		} else if bodyBytes, err = ioutil.ReadAll(response.Body); err != nil {
 			return err
 		}
 
 		// Only attempt to decode the response if we have a response we can handle
 		if len(bodyBytes) > 0 && resp.StatusCode < http.StatusInternalServerError {
-			if resp.Data != nil && resp.StatusCode < http.StatusBadRequest {
+			if raw, ok := resp.Data.(*[]byte); ok && resp.StatusCode < http.StatusBadRequest {
+				// Successful request for a non-JSON resource
+				*raw = bodyBytes
+			} else if resp.Data != nil && resp.StatusCode < http.StatusBadRequest {
 				// Successful request
 				err = json.Unmarshal(bodyBytes, &resp.Data)
 			} else {
 				// A 401 means Twitch wants us to refresh our token:
 				// https://dev.twitch.tv/docs/authentication/refresh-tokens/
-				if resp.StatusCode == http.StatusUnauthorized && c.canRefreshToken() {
+				_, hasRequestToken := requestToken(req.Context())
+				if resp.StatusCode == http.StatusUnauthorized && !hasRequestToken && c.canRefreshToken() {
 					if refreshErr := c.refreshToken(); refreshErr != nil {
 						log.Printf("Failed to refresh helix auth token: %v", refreshErr)
 						return err
@@ -417,6 +641,21 @@ func (c *Client) doRequest(req *http.Request, resp *Response) error {
 	return nil
 }
 
+// roundTrip sends the request through the middleware chain.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := c.decompress(c.opts.HTTPClient.Do)
+	for i := len(c.opts.Middleware) - 1; i >= 0; i-- {
+		next = c.opts.Middleware[i](next)
+	}
+
+	response, err := next(req)
+	if err == nil {
+		c.recordRateLimit(req, response.Header)
+	}
+
+	return response, err
+}
+
 func (c *Client) canRefreshToken() bool {
 	return c.opts.ClientID != "" &&
 		c.opts.ClientSecret != "" &&
@@ -437,10 +676,15 @@ func (c *Client) refreshToken() error {
 	}
 
 	c.mu.Lock()
+	if c.opts.UserAccessToken != resp.Data.AccessToken {
+		c.forgetToken(c.opts.UserAccessToken)
+	}
 	c.opts.UserAccessToken = resp.Data.AccessToken
 	c.opts.RefreshToken = resp.Data.RefreshToken
 	c.mu.Unlock()
 
+	c.storeUserToken(c.now().Add(time.Duration(resp.Data.ExpiresIn) * time.Second))
+
 	if cb := c.callbacks.onUserAccessTokenRefreshed; cb != nil {
 		go cb(resp.Data.AccessToken, resp.Data.RefreshToken)
 	}
@@ -457,20 +701,18 @@ func (c *Client) setRequestHeaders(req *http.Request) {
 		req.Header.Set("User-Agent", opts.UserAgent)
 	}
 
-	var bearerToken string
-	if opts.AppAccessToken != "" {
-		bearerToken = opts.AppAccessToken
-	}
-	if opts.UserAccessToken != "" {
-		bearerToken = opts.UserAccessToken
+	if correlationID := CorrelationID(req.Context()); correlationID != "" {
+		req.Header.Set(CorrelationIDHeader, correlationID)
 	}
-	if opts.ExtensionOpts.SignedJWTToken != "" {
-		bearerToken = opts.ExtensionOpts.SignedJWTToken
+
+	bearerToken, ok := requestToken(req.Context())
+	if !ok {
+		bearerToken = c.bearerToken()
 	}
 
 	authType := "Bearer"
 	// Token validation requires different type of Auth
-	if req.URL.String() == AuthBaseURL+authPaths["validate"] {
+	if req.URL.String() == c.getAuthBaseURL()+authPaths["validate"] {
 		authType = "OAuth"
 	}
 
@@ -479,6 +721,38 @@ func (c *Client) setRequestHeaders(req *http.Request) {
 	}
 }
 
+// requestTokenKey is the context key of the token a single request is
+// authorized with instead of the client's tokens.
+type requestTokenKey struct{}
+
+func withRequestToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, requestTokenKey{}, token)
+}
+
+func requestToken(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(requestTokenKey{}).(string)
+	return token, ok
+}
+
+// bearerToken returns the token requests are authorized with: the signed
+// extension JWT, else the user access token, else the app access token.
+func (c *Client) bearerToken() string {
+	opts := c.opts
+
+	var bearerToken string
+	if opts.AppAccessToken != "" {
+		bearerToken = opts.AppAccessToken
+	}
+	if opts.UserAccessToken != "" {
+		bearerToken = opts.UserAccessToken
+	}
+	if opts.ExtensionOpts.SignedJWTToken != "" {
+		bearerToken = opts.ExtensionOpts.SignedJWTToken
+	}
+
+	return bearerToken
+}
+
 func setResponseStatusCode(v interface{}, fieldName string, code int) {
 	s := reflect.ValueOf(v).Elem()
 	field := s.FieldByName(fieldName)
@@ -492,8 +766,13 @@ func (c *Client) GetAppAccessToken() string {
 
 func (c *Client) SetAppAccessToken(accessToken string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	if c.opts.AppAccessToken != accessToken {
+		c.forgetToken(c.opts.AppAccessToken)
+	}
 	c.opts.AppAccessToken = accessToken
+	c.mu.Unlock()
+
+	c.storeAppToken(time.Time{})
 }
 
 // GetUserAccessToken returns the current user access token.
@@ -501,10 +780,34 @@ func (c *Client) GetUserAccessToken() string {
 	return c.opts.UserAccessToken
 }
 
+// SetUserAccessToken sets the user access token. The scopes of the previous
+// token are forgotten when the token changes, see SetUserAccessTokenScopes.
 func (c *Client) SetUserAccessToken(accessToken string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	if c.opts.UserAccessToken != accessToken {
+		c.opts.UserAccessTokenScopes = nil
+		c.forgetToken(c.opts.UserAccessToken)
+	}
 	c.opts.UserAccessToken = accessToken
+	c.mu.Unlock()
+
+	c.storeUserToken(time.Time{})
+}
+
+// GetUserAccessTokenScopes returns the scopes known to be granted to the
+// current user access token, or nil if they are unknown.
+func (c *Client) GetUserAccessTokenScopes() []string {
+	return c.opts.UserAccessTokenScopes
+}
+
+// SetUserAccessTokenScopes sets the scopes granted to the current user access
+// token, which lets requests to endpoints that require other scopes fail
+// before they are sent. Validating the current token with ValidateToken sets
+// them too.
+func (c *Client) SetUserAccessTokenScopes(scopes []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opts.UserAccessTokenScopes = scopes
 }
 
 // GetRefreshToken returns the current refresh token.
@@ -514,8 +817,10 @@ func (c *Client) GetRefreshToken() string {
 
 func (c *Client) SetRefreshToken(refreshToken string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	c.opts.RefreshToken = refreshToken
+	c.mu.Unlock()
+
+	c.storeUserToken(time.Time{})
 }
 
 // GetAppAccessToken returns the current app access token.
@@ -546,3 +851,26 @@ func (c *Client) OnUserAccessTokenRefreshed(f func(newAccessToken, newRefreshTok
 	defer c.mu.Unlock()
 	c.callbacks.onUserAccessTokenRefreshed = f
 }
+
+// AsUser returns a client that makes its requests with the given user access
+// token, so a single client can act on behalf of many users without calling
+// SetUserAccessToken, which would change the token of every request in
+// flight. The returned client shares the options of c, such as its
+// HTTPClient, Middleware and Cache, but not its refresh token, token store or
+// refresh callback. Set those on the returned client to have the user's token
+// refreshed automatically.
+func (c *Client) AsUser(accessToken string) *Client {
+	c.mu.RLock()
+	opts := *c.opts
+	c.mu.RUnlock()
+
+	opts.UserAccessToken = accessToken
+	opts.UserAccessTokenScopes = nil
+	opts.RefreshToken = ""
+	opts.TokenStore = nil
+
+	return &Client{
+		ctx:  c.ctx,
+		opts: &opts,
+	}
+}
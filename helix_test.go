@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -156,8 +157,8 @@ func TestNewClientDefault(t *testing.T) {
 		t.Errorf("expected accesstoken to be \"\", got \"%s\"", opts.UserAccessToken)
 	}
 
-	if opts.HTTPClient != http.DefaultClient {
-		t.Errorf("expected httpClient to be \"%v\", got \"%v\"", http.DefaultClient, opts.HTTPClient)
+	if opts.HTTPClient != defaultHTTPClient {
+		t.Errorf("expected httpClient to be \"%v\", got \"%v\"", defaultHTTPClient, opts.HTTPClient)
 	}
 
 	if opts.RateLimitFunc != nil {
@@ -341,6 +342,70 @@ func TestRatelimitCallbackFailsOnError(t *testing.T) {
 	}
 }
 
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				req.Header.Set("X-Middleware", name)
+				return next(req)
+			}
+		}
+	}
+
+	options := &Options{
+		ClientID:   "my-client-id",
+		Middleware: []Middleware{middleware("first"), middleware("second")},
+	}
+
+	c := newMockClient(options, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Middleware", r.Header.Get("X-Middleware"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	resp, err := c.GetStreams(&StreamsParams{})
+	if err != nil {
+		t.Errorf("Did not expect error, got \"%s\"", err.Error())
+	}
+
+	if !reflect.DeepEqual(calls, []string{"first", "second"}) {
+		t.Errorf("expected middleware to be called in order, got %v", calls)
+	}
+
+	if resp.Header.Get("X-Middleware") != "second" {
+		t.Errorf("expected the last middleware to run closest to the request, got %q", resp.Header.Get("X-Middleware"))
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	errMsg := "served from cache"
+	options := &Options{
+		ClientID: "my-client-id",
+		Middleware: []Middleware{
+			func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New(errMsg)
+				}
+			},
+		},
+	}
+
+	c := newMockClient(options, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the request not to reach the HTTP client")
+	})
+
+	_, err := c.GetStreams(&StreamsParams{})
+	if err == nil || err.Error() != "Failed to execute API request: "+errMsg {
+		t.Errorf("expected middleware error, got %v", err)
+	}
+}
+
 func TestAutomaticUserTokenRefresh(t *testing.T) {
 	t.Parallel()
 
@@ -789,6 +854,63 @@ func TestHydrateRequestCommon(t *testing.T) {
 	}
 }
 
+func TestGetResponse(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID string `json:"id"`
+	}
+
+	testCases := []struct {
+		statusCode int
+		respBody   string
+		ids        []string
+		cursor     string
+	}{
+		{
+			http.StatusOK,
+			`{"data":[{"id":"1"},{"id":"2"}],"pagination":{"cursor":"abc"}}`,
+			[]string{"1", "2"},
+			"abc",
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":"Bad Request","status":400,"message":"Invalid first"}`,
+			nil,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := getResponse[ManyOf[item]](c, "/items", nil)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Data) != len(testCase.ids) {
+			t.Errorf("expected %d items, got %d", len(testCase.ids), len(resp.Data.Data))
+			continue
+		}
+
+		for i, id := range testCase.ids {
+			if resp.Data.Data[i].ID != id {
+				t.Errorf("expected item id to be %s, got %s", id, resp.Data.Data[i].ID)
+			}
+		}
+
+		if resp.Data.Pagination.Cursor != testCase.cursor {
+			t.Errorf("expected cursor to be %s, got %s", testCase.cursor, resp.Data.Pagination.Cursor)
+		}
+	}
+}
+
 func TestQueryStringBuilderAllQuery(t *testing.T) {
 	t.Parallel()
 
@@ -839,6 +961,32 @@ func TestQueryStringBuilderPartialQuery(t *testing.T) {
 	}
 }
 
+func TestQueryStringBuilderPointerQuery(t *testing.T) {
+	t.Parallel()
+
+	// For structs with optional pointer members
+	type Struct struct {
+		Foo *bool `query:"foo"`
+		Bar *int  `query:"bar"`
+	}
+	foo := false
+	v := &Struct{Foo: &foo}
+	expectedQueryString := `foo=false`
+
+	req, err := http.NewRequest("GET", "https://example.com", nil)
+	if err != nil {
+		t.Error("request creation failed")
+	}
+
+	q, err := buildQueryString(req, v)
+	if err != nil {
+		t.Errorf("expected buildQueryString to not error, got \"%s\"", err)
+	}
+	if q != expectedQueryString {
+		t.Errorf(`expected q to be "%s", got "%s"`, expectedQueryString, q)
+	}
+}
+
 func TestQueryStringBuilderNoQuery(t *testing.T) {
 	t.Parallel()
 
@@ -863,3 +1011,47 @@ func TestQueryStringBuilderNoQuery(t *testing.T) {
 		t.Errorf(`expected q to be "%s", got "%s"`, expectedQueryString, q)
 	}
 }
+
+func TestAsUser(t *testing.T) {
+	t.Parallel()
+
+	options := &Options{
+		ClientID:        "my-client-id",
+		AppAccessToken:  "my-app-access-token",
+		UserAccessToken: "my-user-access-token",
+		RefreshToken:    "my-refresh-token",
+	}
+	c := newMockClient(options, func(w http.ResponseWriter, r *http.Request) {
+		// Echo the token as the user's login
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		w.Write([]byte(`{"data":[{"id":"1","login":"` + token + `"}]}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(token string) {
+			defer wg.Done()
+
+			user := c.AsUser(token)
+			if user.GetRefreshToken() != "" {
+				t.Errorf("expected the refresh token not to be shared, got %q", user.GetRefreshToken())
+			}
+
+			resp, err := user.GetUsers(&UsersParams{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if len(resp.Data.Users) != 1 || resp.Data.Users[0].Login != token {
+				t.Errorf("expected the request to be sent with %q, got %+v", token, resp.Data.Users)
+			}
+		}("user-token-" + strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	if c.GetUserAccessToken() != "my-user-access-token" || c.GetRefreshToken() != "my-refresh-token" {
+		t.Errorf("expected the client's tokens to be unchanged, got %q and %q", c.GetUserAccessToken(), c.GetRefreshToken())
+	}
+}
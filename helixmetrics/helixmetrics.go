@@ -0,0 +1,282 @@
+// Package helixmetrics instruments the requests of a helix client. A
+// Collector is installed as client middleware and records per-endpoint call
+// counts, latencies, errors and rate limit utilization, which it serves in the
+// Prometheus text exposition format. It can also start a span for every
+// request through a Tracer, such as an adapter for an OpenTelemetry tracer.
+//
+// The package has no dependencies outside of the standard library, so it can
+// be scraped by Prometheus or adapted to other metrics libraries without
+// pulling them into every user of the helix package.
+package helixmetrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the request latency
+// histogram.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Tracer starts a span for every request, see Collector.Tracer.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// EndpointStats are the metrics recorded for one endpoint.
+type EndpointStats struct {
+	Requests int           // Requests sent, including failed ones
+	Errors   int           // Requests that failed to send or were answered with a 4xx or 5xx status
+	Latency  time.Duration // Total latency of the requests
+
+	statuses map[int]int
+	buckets  []int
+}
+
+// Collector records the metrics of the requests sent through its Middleware.
+// Create one with NewCollector.
+type Collector struct {
+	// Tracer, if set, starts a span named "helix <method> <endpoint>" for
+	// every request.
+	Tracer Tracer
+
+	buckets []float64
+
+	mu                 sync.Mutex
+	endpoints          map[endpointKey]*EndpointStats
+	rateLimit          int
+	rateLimitRemaining int
+}
+
+type endpointKey struct {
+	method   string
+	endpoint string
+}
+
+// NewCollector returns a collector with the given latency histogram buckets,
+// DefaultBuckets if none are given.
+func NewCollector(buckets ...float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	return &Collector{
+		buckets:   sorted,
+		endpoints: map[endpointKey]*EndpointStats{},
+	}
+}
+
+// Middleware records the request sent by next. Add it to
+// helix.Options.Middleware:
+//
+//	collector := helixmetrics.NewCollector()
+//	client, err := helix.NewClient(&helix.Options{
+//		ClientID:   "your-client-id",
+//		Middleware: []helix.Middleware{collector.Middleware},
+//	})
+func (c *Collector) Middleware(next helix.RoundTripFunc) helix.RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		var span Span
+		if c.Tracer != nil {
+			var ctx context.Context
+			ctx, span = c.Tracer.Start(req.Context(), "helix "+req.Method+" "+req.URL.Path)
+			req = req.WithContext(ctx)
+
+			span.SetAttribute("http.method", req.Method)
+			span.SetAttribute("http.route", req.URL.Path)
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		c.record(req, resp, err, time.Since(start))
+
+		if span != nil {
+			if err != nil {
+				span.RecordError(err)
+			} else {
+				span.SetAttribute("http.status_code", resp.StatusCode)
+				if remaining := resp.Header.Get("Ratelimit-Remaining"); remaining != "" {
+					span.SetAttribute("helix.ratelimit.remaining", remaining)
+				}
+			}
+			span.End()
+		}
+
+		return resp, err
+	}
+}
+
+func (c *Collector) record(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := endpointKey{method: req.Method, endpoint: req.URL.Path}
+	stats, ok := c.endpoints[key]
+	if !ok {
+		stats = &EndpointStats{
+			statuses: map[int]int{},
+			buckets:  make([]int, len(c.buckets)),
+		}
+		c.endpoints[key] = stats
+	}
+
+	stats.Requests++
+	stats.Latency += latency
+	for i, bound := range c.buckets {
+		if latency.Seconds() <= bound {
+			stats.buckets[i]++
+		}
+	}
+
+	if err != nil {
+		stats.Errors++
+		return
+	}
+
+	stats.statuses[resp.StatusCode]++
+	if resp.StatusCode >= http.StatusBadRequest {
+		stats.Errors++
+	}
+
+	if limit, err := strconv.Atoi(resp.Header.Get("Ratelimit-Limit")); err == nil {
+		c.rateLimit = limit
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("Ratelimit-Remaining")); err == nil {
+		c.rateLimitRemaining = remaining
+	}
+}
+
+// Stats returns the metrics recorded for the endpoint with the given method
+// and path, e.g. "GET" and "/helix/users".
+func (c *Collector) Stats(method, endpoint string) EndpointStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.endpoints[endpointKey{method: method, endpoint: endpoint}]
+	if !ok {
+		return EndpointStats{}
+	}
+
+	return EndpointStats{
+		Requests: stats.Requests,
+		Errors:   stats.Errors,
+		Latency:  stats.Latency,
+	}
+}
+
+// RateLimitUtilization returns the share of the rate limit bucket used, from
+// 0 to 1, according to the most recent response that reported it.
+func (c *Collector) RateLimitUtilization() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rateLimitUtilization()
+}
+
+func (c *Collector) rateLimitUtilization() float64 {
+	if c.rateLimit <= 0 {
+		return 0
+	}
+
+	return float64(c.rateLimit-c.rateLimitRemaining) / float64(c.rateLimit)
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format, so
+// the collector can be registered as the /metrics handler of a server scraped
+// by Prometheus.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]endpointKey, 0, len(c.endpoints))
+	for key := range c.endpoints {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	var b strings.Builder
+
+	b.WriteString("# HELP helix_requests_total Requests sent to the Twitch API by status code.\n")
+	b.WriteString("# TYPE helix_requests_total counter\n")
+	for _, key := range keys {
+		statuses := c.endpoints[key].statuses
+		codes := make([]int, 0, len(statuses))
+		for code := range statuses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+
+		for _, code := range codes {
+			fmt.Fprintf(&b, "helix_requests_total{%s,status=\"%d\"} %d\n", key.labels(), code, statuses[code])
+		}
+	}
+
+	b.WriteString("# HELP helix_request_errors_total Requests that failed to send or were answered with a 4xx or 5xx status.\n")
+	b.WriteString("# TYPE helix_request_errors_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "helix_request_errors_total{%s} %d\n", key.labels(), c.endpoints[key].Errors)
+	}
+
+	b.WriteString("# HELP helix_request_duration_seconds Latency of requests sent to the Twitch API.\n")
+	b.WriteString("# TYPE helix_request_duration_seconds histogram\n")
+	for _, key := range keys {
+		stats := c.endpoints[key]
+		for i, bound := range c.buckets {
+			fmt.Fprintf(&b, "helix_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", key.labels(), formatFloat(bound), stats.buckets[i])
+		}
+		fmt.Fprintf(&b, "helix_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key.labels(), stats.Requests)
+		fmt.Fprintf(&b, "helix_request_duration_seconds_sum{%s} %s\n", key.labels(), formatFloat(stats.Latency.Seconds()))
+		fmt.Fprintf(&b, "helix_request_duration_seconds_count{%s} %d\n", key.labels(), stats.Requests)
+	}
+
+	b.WriteString("# HELP helix_ratelimit_limit Size of the rate limit bucket.\n")
+	b.WriteString("# TYPE helix_ratelimit_limit gauge\n")
+	fmt.Fprintf(&b, "helix_ratelimit_limit %d\n", c.rateLimit)
+	b.WriteString("# HELP helix_ratelimit_remaining Points left in the rate limit bucket.\n")
+	b.WriteString("# TYPE helix_ratelimit_remaining gauge\n")
+	fmt.Fprintf(&b, "helix_ratelimit_remaining %d\n", c.rateLimitRemaining)
+	b.WriteString("# HELP helix_ratelimit_utilization Share of the rate limit bucket used.\n")
+	b.WriteString("# TYPE helix_ratelimit_utilization gauge\n")
+	fmt.Fprintf(&b, "helix_ratelimit_utilization %s\n", formatFloat(c.rateLimitUtilization()))
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (k endpointKey) labels() string {
+	return fmt.Sprintf("method=%q,endpoint=%q", k.method, k.endpoint)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,146 @@
+package helixmetrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nicklaw5/helix/v2"
+	"github.com/nicklaw5/helix/v2/helixmock"
+)
+
+type mockSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *mockSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *mockSpan) RecordError(err error)                      { s.err = err }
+func (s *mockSpan) End()                                       { s.ended = true }
+
+type mockTracer struct {
+	spans []*mockSpan
+}
+
+func (t *mockTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	span := &mockSpan{name: spanName, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+// rateLimitHeaders adds the rate limit headers Twitch sends, which the mock
+// server doesn't.
+func rateLimitHeaders(next helix.RoundTripFunc) helix.RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		resp, err := next(req)
+		if err == nil {
+			resp.Header.Set("Ratelimit-Limit", "800")
+			resp.Header.Set("Ratelimit-Remaining", "600")
+		}
+		return resp, err
+	}
+}
+
+func newTestClient(t *testing.T) (*helixmock.Server, *Collector, *helix.Client) {
+	s := helixmock.NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddUsers(helix.User{ID: "1337", Login: "twitchdev", DisplayName: "TwitchDev"})
+	s.AddAppAccessToken("app-token")
+
+	collector := NewCollector()
+
+	opts := s.Options()
+	opts.AppAccessToken = "app-token"
+	opts.Middleware = []helix.Middleware{collector.Middleware, rateLimitHeaders}
+
+	c, err := helix.NewClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, collector, c
+}
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	s, collector, c := newTestClient(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetUsers(&helix.UsersParams{Logins: []string{"twitchdev"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.InjectFault(helixmock.Fault{Path: "/helix/users", StatusCode: http.StatusInternalServerError, Times: 1})
+	if _, err := c.GetUsers(&helix.UsersParams{Logins: []string{"twitchdev"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := collector.Stats(http.MethodGet, "/helix/users")
+	if stats.Requests != 3 || stats.Errors != 1 {
+		t.Errorf("expected 3 requests and 1 error, got %+v", stats)
+	}
+
+	if stats.Latency <= 0 {
+		t.Errorf("expected latency to be recorded, got %v", stats.Latency)
+	}
+
+	if utilization := collector.RateLimitUtilization(); utilization != 0.25 {
+		t.Errorf("expected rate limit utilization to be 0.25, got %v", utilization)
+	}
+
+	rec := httptest.NewRecorder()
+	collector.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	for _, expected := range []string{
+		`helix_requests_total{method="GET",endpoint="/helix/users",status="200"} 2`,
+		`helix_requests_total{method="GET",endpoint="/helix/users",status="500"} 1`,
+		`helix_request_errors_total{method="GET",endpoint="/helix/users"} 1`,
+		`helix_request_duration_seconds_bucket{method="GET",endpoint="/helix/users",le="+Inf"} 3`,
+		`helix_request_duration_seconds_count{method="GET",endpoint="/helix/users"} 3`,
+		"helix_ratelimit_limit 800",
+		"helix_ratelimit_remaining 600",
+		"helix_ratelimit_utilization 0.25",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", expected, body)
+		}
+	}
+}
+
+func TestCollectorTracer(t *testing.T) {
+	t.Parallel()
+
+	_, collector, c := newTestClient(t)
+
+	tracer := &mockTracer{}
+	collector.Tracer = tracer
+
+	if _, err := c.GetUsers(&helix.UsersParams{Logins: []string{"twitchdev"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+
+	span := tracer.spans[0]
+	if span.name != "helix GET /helix/users" {
+		t.Errorf("expected span name to be %q, got %q", "helix GET /helix/users", span.name)
+	}
+
+	if !span.ended || span.err != nil {
+		t.Errorf("expected span to end without error, got %+v", span)
+	}
+
+	if span.attributes["http.status_code"] != http.StatusOK || span.attributes["helix.ratelimit.remaining"] != "600" {
+		t.Errorf("unexpected span attributes %v", span.attributes)
+	}
+}
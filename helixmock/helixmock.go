@@ -0,0 +1,523 @@
+// Package helixmock provides an in-memory Twitch API server for testing code
+// that uses the helix client, without hitting Twitch or the Twitch CLI.
+//
+// The server emulates the users, streams and EventSub subscription endpoints
+// along with the token endpoints, serves fixtures added by the test and can
+// be told to fail requests with InjectFault.
+package helixmock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+)
+
+// Default credentials of the server's client
+const (
+	DefaultClientID     = "helixmock-client-id"
+	DefaultClientSecret = "helixmock-client-secret"
+)
+
+// DefaultMaxTotalCost is the EventSub cost budget of the server's client.
+const DefaultMaxTotalCost = 10000
+
+// Fault makes the server fail matching requests.
+type Fault struct {
+	Method     string // Empty matches any method
+	Path       string // e.g. "/helix/users" or "/oauth2/token", empty matches any path
+	StatusCode int
+	Message    string
+	Times      int           // Number of requests to fail, zero fails every matching request
+	Delay      time.Duration // Delay before responding
+}
+
+type token struct {
+	userID string // Empty for app access tokens
+	scopes []string
+}
+
+// Server is a mock Twitch API server. Create one with NewServer and close it
+// when done.
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+	MaxTotalCost int
+
+	mu             sync.Mutex
+	users          []helix.User
+	streams        []helix.Stream
+	subscriptions  []helix.EventSubSubscription
+	tokens         map[string]*token
+	refreshTokens  map[string]*token
+	codes          map[string]*token
+	faults         []*Fault
+	nextID         int
+	requestsByPath map[string]int
+}
+
+// NewServer starts a mock server with no fixtures.
+func NewServer() *Server {
+	s := &Server{
+		ClientID:       DefaultClientID,
+		ClientSecret:   DefaultClientSecret,
+		MaxTotalCost:   DefaultMaxTotalCost,
+		tokens:         map[string]*token{},
+		refreshTokens:  map[string]*token{},
+		codes:          map[string]*token{},
+		requestsByPath: map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// HTTPClient returns a client that sends every request to the server,
+// regardless of its host, so both API and authentication requests of a helix
+// client are served by the mock.
+func (s *Server) HTTPClient() *http.Client {
+	target, _ := url.Parse(s.URL)
+
+	return &http.Client{
+		Transport: &rewriteTransport{target: target, base: s.Client().Transport},
+	}
+}
+
+// Options returns helix client options that use the server.
+func (s *Server) Options() *helix.Options {
+	return &helix.Options{
+		ClientID:     s.ClientID,
+		ClientSecret: s.ClientSecret,
+		HTTPClient:   s.HTTPClient(),
+	}
+}
+
+// AddUsers adds users served by the users endpoint.
+func (s *Server) AddUsers(users ...helix.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = append(s.users, users...)
+}
+
+// AddStreams adds live streams served by the streams endpoint.
+func (s *Server) AddStreams(streams ...helix.Stream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams = append(s.streams, streams...)
+}
+
+// AddAppAccessToken adds an app access token accepted by the server.
+func (s *Server) AddAppAccessToken(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[accessToken] = &token{}
+}
+
+// AddUserAccessToken adds a user access token accepted by the server.
+func (s *Server) AddUserAccessToken(accessToken, userID string, scopes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[accessToken] = &token{userID: userID, scopes: scopes}
+}
+
+// AddAuthorizationCode adds an authorization code that can be exchanged for
+// a user access token of the given user.
+func (s *Server) AddAuthorizationCode(code, userID string, scopes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.codes[code] = &token{userID: userID, scopes: scopes}
+}
+
+// EventSubSubscriptions returns the EventSub subscriptions created on the server.
+func (s *Server) EventSubSubscriptions() []helix.EventSubSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]helix.EventSubSubscription(nil), s.subscriptions...)
+}
+
+// InjectFault makes the server fail the requests matching the fault.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests served for the given path, e.g.
+// "/helix/users".
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requestsByPath[path]
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+
+	return t.base.RoundTrip(r)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requestsByPath[r.URL.Path]++
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Delay > 0 {
+			time.Sleep(fault.Delay)
+		}
+
+		if fault.StatusCode != 0 {
+			writeError(w, fault.StatusCode, fault.Message)
+			return
+		}
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/oauth2/token":
+		s.serveToken(w, r)
+	case "/oauth2/validate":
+		s.serveValidate(w, r)
+	case "/oauth2/revoke":
+		s.serveRevoke(w, r)
+	case "/helix/users", "/helix/streams", "/helix/eventsub/subscriptions":
+		tkn, ok := s.authorize(w, r)
+		if !ok {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/helix/users":
+			s.serveUsers(w, r, tkn)
+		case "/helix/streams":
+			s.serveStreams(w, r)
+		default:
+			s.serveEventSubSubscriptions(w, r, tkn)
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if (fault.Method != "" && fault.Method != r.Method) || (fault.Path != "" && fault.Path != r.URL.Path) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) (*token, bool) {
+	if r.Header.Get("Client-Id") != s.ClientID {
+		writeError(w, http.StatusUnauthorized, "Client ID and OAuth token do not match")
+		return nil, false
+	}
+
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	tkn, ok := s.tokens[accessToken]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Invalid OAuth token")
+		return nil, false
+	}
+
+	return tkn, true
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func (s *Server) issueToken(w http.ResponseWriter, tkn *token, refreshable bool) {
+	accessToken := "helixmock-access-token-" + s.newID()
+	s.tokens[accessToken] = tkn
+
+	credentials := helix.AccessCredentials{
+		AccessToken: accessToken,
+		ExpiresIn:   14400,
+		Scopes:      tkn.scopes,
+	}
+
+	if refreshable {
+		credentials.RefreshToken = "helixmock-refresh-token-" + s.newID()
+		s.refreshTokens[credentials.RefreshToken] = tkn
+	}
+
+	writeJSON(w, http.StatusOK, credentials)
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+
+	if r.Form.Get("client_id") != s.ClientID {
+		writeError(w, http.StatusBadRequest, "invalid client")
+		return
+	}
+
+	if r.Form.Get("client_secret") != s.ClientSecret && r.Form.Get("code_verifier") == "" {
+		writeError(w, http.StatusForbidden, "invalid client secret")
+		return
+	}
+
+	switch r.Form.Get("grant_type") {
+	case "client_credentials":
+		s.issueToken(w, &token{}, false)
+	case "authorization_code":
+		tkn, ok := s.codes[r.Form.Get("code")]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid authorization code")
+			return
+		}
+		delete(s.codes, r.Form.Get("code"))
+		s.issueToken(w, tkn, true)
+	case "refresh_token":
+		tkn, ok := s.refreshTokens[r.Form.Get("refresh_token")]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid refresh token")
+			return
+		}
+		delete(s.refreshTokens, r.Form.Get("refresh_token"))
+		s.issueToken(w, tkn, true)
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid grant type")
+	}
+}
+
+func (s *Server) serveValidate(w http.ResponseWriter, r *http.Request) {
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "OAuth ")
+	tkn, ok := s.tokens[accessToken]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid access token")
+		return
+	}
+
+	details := helix.ValidateTokenDetails{
+		ClientID:  s.ClientID,
+		Scopes:    tkn.scopes,
+		UserID:    tkn.userID,
+		ExpiresIn: 14400,
+	}
+	if user := s.findUser(tkn.userID); user != nil {
+		details.Login = user.Login
+	}
+
+	writeJSON(w, http.StatusOK, details)
+}
+
+func (s *Server) serveRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Form.Get("client_id") != s.ClientID {
+		writeError(w, http.StatusBadRequest, "Invalid client_id: "+r.Form.Get("client_id"))
+		return
+	}
+
+	if _, ok := s.tokens[r.Form.Get("token")]; !ok {
+		writeError(w, http.StatusBadRequest, "Invalid token")
+		return
+	}
+
+	delete(s.tokens, r.Form.Get("token"))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) findUser(id string) *helix.User {
+	for i := range s.users {
+		if s.users[i].ID == id {
+			return &s.users[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) serveUsers(w http.ResponseWriter, r *http.Request, tkn *token) {
+	ids, logins := r.Form["id"], r.Form["login"]
+	if len(ids) == 0 && len(logins) == 0 {
+		if tkn.userID == "" {
+			writeError(w, http.StatusBadRequest, "The ID or login query parameter is required if you specify an app access token")
+			return
+		}
+		ids = []string{tkn.userID}
+	}
+
+	users := []helix.User{}
+	for _, user := range s.users {
+		if contains(ids, user.ID) || contains(logins, user.Login) {
+			users = append(users, user)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, helix.ManyUsers{Users: users})
+}
+
+func (s *Server) serveStreams(w http.ResponseWriter, r *http.Request) {
+	first := 20
+	if r.Form.Get("first") != "" {
+		first, _ = strconv.Atoi(r.Form.Get("first"))
+	}
+
+	streams := []helix.Stream{}
+	for _, stream := range s.streams {
+		if len(r.Form["user_id"]) > 0 && !contains(r.Form["user_id"], stream.UserID) {
+			continue
+		}
+		if len(r.Form["user_login"]) > 0 && !contains(r.Form["user_login"], stream.UserLogin) {
+			continue
+		}
+		if len(r.Form["game_id"]) > 0 && !contains(r.Form["game_id"], stream.GameID) {
+			continue
+		}
+		if len(streams) == first {
+			break
+		}
+		streams = append(streams, stream)
+	}
+
+	writeJSON(w, http.StatusOK, helix.ManyStreams{Streams: streams})
+}
+
+func (s *Server) serveEventSubSubscriptions(w http.ResponseWriter, r *http.Request, tkn *token) {
+	switch r.Method {
+	case http.MethodGet:
+		subs := []helix.EventSubSubscription{}
+		for _, sub := range s.subscriptions {
+			if status := r.Form.Get("status"); status != "" && sub.Status != helix.EventSubSubscriptionStatus(status) {
+				continue
+			}
+			if subType := r.Form.Get("type"); subType != "" && sub.Type != subType {
+				continue
+			}
+			subs = append(subs, sub)
+		}
+
+		writeJSON(w, http.StatusOK, s.eventSubResponse(subs))
+	case http.MethodPost:
+		var sub helix.EventSubSubscription
+		if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if sub.Type == "" || sub.Version == "" {
+			writeError(w, http.StatusBadRequest, "type and version are required")
+			return
+		}
+
+		// Subscriptions authorized by a user cost nothing
+		sub.Cost = 1
+		if tkn.userID != "" {
+			sub.Cost = 0
+		}
+
+		if s.totalCost()+sub.Cost > s.MaxTotalCost {
+			writeError(w, http.StatusTooManyRequests, "subscription cost exceeded")
+			return
+		}
+
+		sub.ID = "helixmock-subscription-" + s.newID()
+		sub.Status = helix.EventSubStatusEnabled
+		sub.CreatedAt = helix.Time{Time: time.Now().UTC()}
+		sub.Transport.Secret = ""
+		s.subscriptions = append(s.subscriptions, sub)
+
+		writeJSON(w, http.StatusAccepted, s.eventSubResponse([]helix.EventSubSubscription{sub}))
+	case http.MethodDelete:
+		for i, sub := range s.subscriptions {
+			if sub.ID == r.Form.Get("id") {
+				s.subscriptions = append(s.subscriptions[:i], s.subscriptions[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		writeError(w, http.StatusNotFound, "subscription not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) totalCost() int {
+	total := 0
+	for _, sub := range s.subscriptions {
+		total += sub.Cost
+	}
+
+	return total
+}
+
+func (s *Server) eventSubResponse(subs []helix.EventSubSubscription) helix.ManyEventSubSubscriptions {
+	return helix.ManyEventSubSubscriptions{
+		Total:                 len(s.subscriptions),
+		TotalCost:             s.totalCost(),
+		MaxTotalCost:          s.MaxTotalCost,
+		EventSubSubscriptions: subs,
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error":   http.StatusText(statusCode),
+		"status":  statusCode,
+		"message": message,
+	})
+}
@@ -0,0 +1,217 @@
+package helixmock
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nicklaw5/helix/v2"
+)
+
+func newTestServer(t *testing.T) (*Server, *helix.Client) {
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddUsers(
+		helix.User{ID: "1337", Login: "twitchdev", DisplayName: "TwitchDev"},
+		helix.User{ID: "9001", Login: "helixuser", DisplayName: "HelixUser"},
+	)
+	s.AddStreams(helix.Stream{ID: "stream-id", UserID: "1337", UserLogin: "twitchdev", GameID: "509658", Type: "live"})
+
+	c, err := helix.NewClient(s.Options())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, c
+}
+
+func TestAppAccessToken(t *testing.T) {
+	t.Parallel()
+
+	_, c := newTestServer(t)
+
+	// Requests without a token are rejected
+	resp, err := c.GetUsers(&helix.UsersParams{Logins: []string{"twitchdev"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected status code to be %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	token, err := c.RequestAppAccessToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.StatusCode != http.StatusOK || token.Data.AccessToken == "" {
+		t.Fatalf("expected an app access token, got %+v", token)
+	}
+	c.SetAppAccessToken(token.Data.AccessToken)
+
+	resp, err = c.GetUsers(&helix.UsersParams{Logins: []string{"twitchdev"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.Users) != 1 || resp.Data.Users[0].ID != "1337" {
+		t.Errorf("expected user 1337, got %+v", resp.Data.Users)
+	}
+
+	streams, err := c.GetStreams(&helix.StreamsParams{GameIDs: []string{"509658"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(streams.Data.Streams) != 1 || streams.Data.Streams[0].UserLogin != "twitchdev" {
+		t.Errorf("expected stream of twitchdev, got %+v", streams.Data.Streams)
+	}
+}
+
+func TestUserAccessToken(t *testing.T) {
+	t.Parallel()
+
+	s, c := newTestServer(t)
+	s.AddAuthorizationCode("some-code", "9001", helix.ScopeUserReadEmail)
+
+	token, err := c.RequestUserAccessToken("some-code")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.StatusCode != http.StatusOK || token.Data.RefreshToken == "" {
+		t.Fatalf("expected a user access token, got %+v", token)
+	}
+	accessToken := token.Data.AccessToken
+	c.SetUserAccessToken(accessToken)
+
+	isValid, validation, err := c.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !isValid || validation.Data.Login != "helixuser" || !validation.Data.HasScopes(helix.ScopeUserReadEmail) {
+		t.Errorf("unexpected token details %+v", validation.Data)
+	}
+
+	// Without ids or logins, the token's user is returned
+	resp, err := c.GetUsers(&helix.UsersParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data.Users) != 1 || resp.Data.Users[0].ID != "9001" {
+		t.Errorf("expected user 9001, got %+v", resp.Data.Users)
+	}
+
+	// Codes can only be exchanged once
+	token, err = c.RequestUserAccessToken("some-code")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status code to be %d, got %d", http.StatusBadRequest, token.StatusCode)
+	}
+
+	if _, err := c.RevokeUserAccessToken(accessToken); err != nil {
+		t.Fatal(err)
+	}
+
+	isValid, _, err = c.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if isValid {
+		t.Error("expected revoked token to be invalid")
+	}
+}
+
+func TestEventSubSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	s, c := newTestServer(t)
+	s.AddAppAccessToken("app-token")
+	s.MaxTotalCost = 1
+	c.SetAppAccessToken("app-token")
+
+	transport := helix.EventSubTransport{Method: "webhook", Callback: "https://example.com/eventsub", Secret: "s3cr37w0rd"}
+
+	created, err := c.CreateEventSubSubscription(helix.NewEventSubSubscription(helix.EventSubTypeStreamOnline, helix.EventSubBroadcasterCondition("1337"), transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.StatusCode != http.StatusAccepted || len(created.Data.EventSubSubscriptions) != 1 || created.Data.TotalCost != 1 {
+		t.Fatalf("unexpected response %+v", created)
+	}
+
+	// The budget is used up
+	exceeded, err := c.CreateEventSubSubscription(helix.NewEventSubSubscription(helix.EventSubTypeStreamOffline, helix.EventSubBroadcasterCondition("1337"), transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exceeded.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status code to be %d, got %d", http.StatusTooManyRequests, exceeded.StatusCode)
+	}
+
+	list, err := c.GetEventSubSubscriptions(&helix.EventSubSubscriptionsParams{Type: helix.EventSubTypeStreamOnline})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Data.EventSubSubscriptions) != 1 {
+		t.Fatalf("expected 1 subscription, got %+v", list.Data.EventSubSubscriptions)
+	}
+
+	removed, err := c.RemoveEventSubSubscription(list.Data.EventSubSubscriptions[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removed.StatusCode != http.StatusNoContent || len(s.EventSubSubscriptions()) != 0 {
+		t.Errorf("expected subscription to be removed, got status code %d and %+v", removed.StatusCode, s.EventSubSubscriptions())
+	}
+}
+
+func TestInjectFault(t *testing.T) {
+	t.Parallel()
+
+	s, c := newTestServer(t)
+	s.AddAppAccessToken("app-token")
+	c.SetAppAccessToken("app-token")
+
+	s.InjectFault(Fault{
+		Method:     http.MethodGet,
+		Path:       "/helix/users",
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "Service Unavailable",
+		Times:      1,
+	})
+
+	resp, err := c.GetUsers(&helix.UsersParams{IDs: []string{"1337"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status code to be %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	// The fault only applied once
+	resp, err = c.GetUsers(&helix.UsersParams{IDs: []string{"1337"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK || len(resp.Data.Users) != 1 {
+		t.Errorf("expected user 1337, got status code %d and %+v", resp.StatusCode, resp.Data.Users)
+	}
+
+	if s.Requests("/helix/users") != 2 {
+		t.Errorf("expected %d requests, got %d", 2, s.Requests("/helix/users"))
+	}
+}
@@ -0,0 +1,221 @@
+// Package helixtest generates fake EventSub traffic for testing handlers
+// end-to-end without Twitch: webhook requests with correct headers and
+// signatures, websocket messages, and a websocket server that sends them.
+//
+// Events can be of any type, they are encoded as JSON as is, so both the
+// helix event structs and hand-written maps can be used.
+package helixtest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+)
+
+// DefaultCallback is the callback of subscriptions whose transport isn't set.
+const DefaultCallback = "https://example.com/eventsub"
+
+// Now returns the time of generated messages, it can be replaced to make
+// messages deterministic.
+var Now = func() time.Time {
+	return time.Now().UTC()
+}
+
+// Subscription fills in the fields of sub that Twitch would set: a random ID,
+// the version from helix.EventSubTypeVersion, the enabled status, the creation
+// time and a webhook transport to DefaultCallback.
+func Subscription(sub helix.EventSubSubscription) helix.EventSubSubscription {
+	if sub.ID == "" {
+		sub.ID = newID()
+	}
+
+	if sub.Version == "" {
+		sub.Version = helix.EventSubTypeVersion(sub.Type)
+	}
+
+	if sub.Status == "" {
+		sub.Status = helix.EventSubStatusEnabled
+	}
+
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = helix.Time{Time: Now()}
+	}
+
+	if sub.Transport.Method == "" {
+		sub.Transport = helix.EventSubTransport{Method: "webhook", Callback: DefaultCallback}
+	}
+	sub.Transport.Secret = ""
+
+	return sub
+}
+
+// NewNotificationRequest returns a webhook notification request for an
+// event of the subscription, signed with secret.
+func NewNotificationRequest(secret string, sub helix.EventSubSubscription, event interface{}) (*http.Request, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWebhookRequest(secret, helix.EventSubMessageTypeNotification, helix.EventSubNotification{
+		Subscription: Subscription(sub),
+		Event:        data,
+	})
+}
+
+// NewVerificationRequest returns a webhook callback verification request for
+// the subscription, signed with secret.
+func NewVerificationRequest(secret string, sub helix.EventSubSubscription, challenge string) (*http.Request, error) {
+	sub.Status = helix.EventSubStatusPending
+
+	return newWebhookRequest(secret, helix.EventSubMessageTypeVerification, helix.EventSubNotification{
+		Subscription: Subscription(sub),
+		Challenge:    challenge,
+	})
+}
+
+// NewRevocationRequest returns a webhook revocation request for the
+// subscription, signed with secret. The reason is the subscription's new
+// status, such as helix.EventSubStatusAuthorizationRevoked.
+func NewRevocationRequest(secret string, sub helix.EventSubSubscription, reason helix.EventSubSubscriptionStatus) (*http.Request, error) {
+	sub.Status = reason
+
+	return newWebhookRequest(secret, helix.EventSubMessageTypeRevocation, helix.EventSubNotification{
+		Subscription: Subscription(sub),
+	})
+}
+
+func newWebhookRequest(secret, messageType string, notification helix.EventSubNotification) (*http.Request, error) {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := notification.Subscription
+
+	req := httptest.NewRequest(http.MethodPost, sub.Transport.Callback, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Twitch-Eventsub-Message-Id", newID())
+	req.Header.Set("Twitch-Eventsub-Message-Retry", "0")
+	req.Header.Set("Twitch-Eventsub-Message-Type", messageType)
+	req.Header.Set("Twitch-Eventsub-Message-Timestamp", Now().Format(time.RFC3339Nano))
+	req.Header.Set("Twitch-Eventsub-Subscription-Type", sub.Type)
+	req.Header.Set("Twitch-Eventsub-Subscription-Version", sub.Version)
+	req.Header.Set("Twitch-Eventsub-Message-Signature", Sign(secret, req.Header, body))
+
+	return req, nil
+}
+
+// Sign returns the signature header value of a webhook message, computed
+// from the message ID and timestamp in header and the body.
+func Sign(secret string, header http.Header, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header.Get("Twitch-Eventsub-Message-Id")))
+	mac.Write([]byte(header.Get("Twitch-Eventsub-Message-Timestamp")))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WelcomeMessage returns the websocket welcome message of a session.
+func WelcomeMessage(sessionID string) []byte {
+	return websocketMessage(helix.EventSubWebsocketMessageTypeWelcome, helix.EventSubWebsocketPayload{
+		Session: helix.EventSubWebsocketSession{
+			ID:                      sessionID,
+			Status:                  "connected",
+			KeepaliveTimeoutSeconds: 10,
+			ConnectedAt:             helix.Time{Time: Now()},
+		},
+	}, nil)
+}
+
+// KeepaliveMessage returns a websocket keepalive message.
+func KeepaliveMessage() []byte {
+	return websocketMessage(helix.EventSubWebsocketMessageTypeKeepalive, helix.EventSubWebsocketPayload{}, nil)
+}
+
+// ReconnectMessage returns a websocket message asking the client of a
+// session to reconnect to reconnectURL.
+func ReconnectMessage(sessionID, reconnectURL string) []byte {
+	return websocketMessage(helix.EventSubWebsocketMessageTypeReconnect, helix.EventSubWebsocketPayload{
+		Session: helix.EventSubWebsocketSession{
+			ID:           sessionID,
+			Status:       "reconnecting",
+			ReconnectURL: reconnectURL,
+			ConnectedAt:  helix.Time{Time: Now()},
+		},
+	}, nil)
+}
+
+// NotificationMessage returns a websocket notification message for an event
+// of the subscription.
+func NotificationMessage(sub helix.EventSubSubscription, event interface{}) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	sub = Subscription(sub)
+
+	return websocketMessage(helix.EventSubWebsocketMessageTypeNotification, helix.EventSubWebsocketPayload{
+		Subscription: sub,
+		Event:        data,
+	}, &sub), nil
+}
+
+// RevocationMessage returns a websocket revocation message for the
+// subscription, the reason is the subscription's new status.
+func RevocationMessage(sub helix.EventSubSubscription, reason helix.EventSubSubscriptionStatus) []byte {
+	sub.Status = reason
+	sub = Subscription(sub)
+
+	return websocketMessage(helix.EventSubWebsocketMessageTypeRevocation, helix.EventSubWebsocketPayload{
+		Subscription: sub,
+	}, &sub)
+}
+
+func websocketMessage(messageType string, payload helix.EventSubWebsocketPayload, sub *helix.EventSubSubscription) []byte {
+	metadata := helix.EventSubWebsocketMetadata{
+		MessageID:        newID(),
+		MessageType:      messageType,
+		MessageTimestamp: helix.Time{Time: Now()},
+	}
+
+	if sub != nil {
+		metadata.SubscriptionType = sub.Type
+		metadata.SubscriptionVersion = sub.Version
+	}
+
+	// Only the fields of the message type are sent
+	message := map[string]interface{}{"metadata": metadata}
+	fields := map[string]interface{}{}
+	switch messageType {
+	case helix.EventSubWebsocketMessageTypeWelcome, helix.EventSubWebsocketMessageTypeReconnect:
+		fields["session"] = payload.Session
+	case helix.EventSubWebsocketMessageTypeNotification:
+		fields["subscription"] = payload.Subscription
+		fields["event"] = payload.Event
+	case helix.EventSubWebsocketMessageTypeRevocation:
+		fields["subscription"] = payload.Subscription
+	}
+	message["payload"] = fields
+
+	data, _ := json.Marshal(message)
+	return data
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
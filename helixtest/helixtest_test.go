@@ -0,0 +1,156 @@
+package helixtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+	"github.com/nicklaw5/helix/v2/helixmock"
+)
+
+func TestWebhookRequests(t *testing.T) {
+	t.Parallel()
+
+	secret := "s3cre7w0rd"
+	sub := Subscription(helix.EventSubSubscription{
+		Type:      helix.EventSubTypeChannelFollow,
+		Condition: helix.EventSubChannelFollowCondition("1337", "1337"),
+	})
+
+	var follows []helix.EventSubChannelFollowEvent
+	var revoked []helix.EventSubSubscriptionStatus
+
+	router := helix.NewEventSubRouter()
+	router.OnChannelFollow(func(event helix.EventSubChannelFollowEvent, got helix.EventSubSubscription) {
+		if got.ID != sub.ID || got.Version != "2" {
+			t.Errorf("expected subscription %s version 2, got %s version %s", sub.ID, got.ID, got.Version)
+		}
+		follows = append(follows, event)
+	})
+	router.OnRevocation(func(sub helix.EventSubSubscription) {
+		revoked = append(revoked, sub.Status)
+	})
+
+	handler := router.WebhookHandler(func(header http.Header, message string) bool {
+		return helix.VerifyEventSubNotification(secret, header, message)
+	})
+
+	verification, err := NewVerificationRequest(secret, sub, "pogchamp-kappa-360noscope-vohiyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notification, err := NewNotificationRequest(secret, sub, helix.EventSubChannelFollowEvent{UserID: "9001", UserLogin: "helixuser"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forged, err := NewNotificationRequest("wrong-secret", sub, helix.EventSubChannelFollowEvent{UserID: "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revocation, err := NewRevocationRequest(secret, sub, helix.EventSubStatusAuthorizationRevoked)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		req      *http.Request
+		status   int
+		response string
+	}{
+		{verification, http.StatusOK, "pogchamp-kappa-360noscope-vohiyo"},
+		{notification, http.StatusNoContent, ""},
+		{forged, http.StatusForbidden, "invalid signature\n"},
+		{revocation, http.StatusNoContent, ""},
+	}
+
+	for _, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, testCase.req)
+
+		if rec.Code != testCase.status || rec.Body.String() != testCase.response {
+			t.Errorf("expected %d %q for %s request, got %d %q", testCase.status, testCase.response, testCase.req.Header.Get("Twitch-Eventsub-Message-Type"), rec.Code, rec.Body.String())
+		}
+	}
+
+	if len(follows) != 1 || follows[0].UserLogin != "helixuser" {
+		t.Errorf("expected 1 follow by helixuser, got %+v", follows)
+	}
+
+	if len(revoked) != 1 || revoked[0] != helix.EventSubStatusAuthorizationRevoked {
+		t.Errorf("expected 1 revocation, got %v", revoked)
+	}
+}
+
+func TestWebsocketServer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	api := helixmock.NewServer()
+	defer api.Close()
+	api.AddUserAccessToken("my-user-access-token", "1337")
+
+	ws := NewWebsocketServer()
+	defer ws.Close()
+
+	options := api.Options()
+	options.UserAccessToken = "my-user-access-token"
+	client, err := helix.NewClient(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var follows []string
+	router := helix.NewEventSubRouter()
+	router.OnChannelFollow(func(event helix.EventSubChannelFollowEvent, sub helix.EventSubSubscription) {
+		follows = append(follows, event.UserID)
+		cancel()
+	})
+
+	wsClient := client.NewEventSubWebsocketClient(router)
+	wsClient.URL = ws.WebsocketURL()
+	wsClient.Subscribe(helix.EventSubSubscription{
+		Type:      helix.EventSubTypeChannelFollow,
+		Condition: helix.EventSubChannelFollowCondition("1337", "1337"),
+	})
+
+	go func() {
+		conn, err := ws.Accept(ctx)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.Send(WelcomeMessage("session-1"))
+		conn.Send(KeepaliveMessage())
+
+		notification, _ := NotificationMessage(helix.EventSubSubscription{
+			Type:      helix.EventSubTypeChannelFollow,
+			Condition: helix.EventSubChannelFollowCondition("1337", "1337"),
+			Transport: helix.EventSubTransport{Method: "websocket", SessionID: "session-1"},
+		}, helix.EventSubChannelFollowEvent{UserID: "9001"})
+		conn.Send(notification)
+
+		<-ctx.Done()
+	}()
+
+	if err := wsClient.Run(ctx); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	if len(follows) != 1 || follows[0] != "9001" {
+		t.Errorf("expected 1 follow by 9001, got %v", follows)
+	}
+
+	subs := api.EventSubSubscriptions()
+	if len(subs) != 1 || subs[0].Transport.SessionID != "session-1" {
+		t.Errorf("expected a subscription for session-1, got %+v", subs)
+	}
+}
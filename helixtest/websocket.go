@@ -0,0 +1,116 @@
+package helixtest
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// websocketGUID is appended to the handshake key to derive the accept key.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebsocketServer is a websocket server that test code sends EventSub
+// messages through, for example to a helix.EventSubWebsocketClient whose URL
+// is set to WebsocketURL.
+type WebsocketServer struct {
+	*httptest.Server
+
+	conns chan *WebsocketConn
+}
+
+// NewWebsocketServer starts a websocket server.
+func NewWebsocketServer() *WebsocketServer {
+	s := &WebsocketServer{conns: make(chan *WebsocketConn, 16)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.upgrade))
+
+	return s
+}
+
+// WebsocketURL returns the ws:// URL of the server.
+func (s *WebsocketServer) WebsocketURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// Accept waits for the next client to connect.
+func (s *WebsocketServer) Accept(ctx context.Context) (*WebsocketConn, error) {
+	select {
+	case conn := <-s.conns:
+		return conn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *WebsocketServer) upgrade(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(w, "expected a websocket handshake", http.StatusBadRequest)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection can't be hijacked", http.StatusInternalServerError)
+		return
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+
+	accept := sha1.Sum([]byte(key + websocketGUID))
+	buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	buf.WriteString("Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n\r\n")
+	if err := buf.Flush(); err != nil {
+		conn.Close()
+		return
+	}
+
+	s.conns <- &WebsocketConn{conn: conn, reader: buf.Reader}
+}
+
+// WebsocketConn is the server side of a websocket connection.
+type WebsocketConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+// Send sends a text message, such as one returned by NotificationMessage.
+func (c *WebsocketConn) Send(message []byte) error {
+	return c.writeFrame(0x1, message)
+}
+
+// Close sends a close frame and closes the connection.
+func (c *WebsocketConn) Close() error {
+	c.writeFrame(0x8, []byte{0x03, 0xe8}) // 1000, normal closure
+	return c.conn.Close()
+}
+
+// writeFrame writes a single unmasked frame, servers must not mask frames.
+func (c *WebsocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frame := []byte{0x80 | opcode}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, 126, byte(len(payload)>>8), byte(len(payload)))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+
+	_, err := c.conn.Write(append(frame, payload...))
+	return err
+}
@@ -1,5 +1,12 @@
 package helix
 
+// Contribution types reported by GetHypeTrainEvents
+const (
+	HypeTrainContributionTypeBits         = "BITS"
+	HypeTrainContributionTypeSubscription = "SUBS"
+	HypeTrainContributionTypeOther        = "OTHER"
+)
+
 type HypeTrainContribuition struct {
 	Total int64  `json:"total"`
 	Type  string `json:"type"`
@@ -32,10 +39,7 @@ type ManyHypeTrainEvents struct {
 	Pagination Pagination       `json:"pagination"`
 }
 
-type HypeTrainEventsResponse struct {
-	ResponseCommon
-	Data ManyHypeTrainEvents
-}
+type HypeTrainEventsResponse = ResponseOf[ManyHypeTrainEvents]
 
 type HypeTrainEventsParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
@@ -44,17 +48,8 @@ type HypeTrainEventsParams struct {
 	ID            string `query:"id"`
 }
 
+// GetHypeTrainEvents gets information about the broadcaster’s current or most recent Hype Train event.
 // Required scope: channel:read:hype_train
 func (c *Client) GetHypeTrainEvents(params *HypeTrainEventsParams) (*HypeTrainEventsResponse, error) {
-	resp, err := c.get("/hypetrain/events", &ManyHypeTrainEvents{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	events := &HypeTrainEventsResponse{}
-	resp.HydrateResponseCommon(&events.ResponseCommon)
-	events.Data.Events = resp.Data.(*ManyHypeTrainEvents).Events
-	events.Data.Pagination = resp.Data.(*ManyHypeTrainEvents).Pagination
-
-	return events, nil
+	return getResponse[ManyHypeTrainEvents](c, "/hypetrain/events", params)
 }
@@ -2,6 +2,7 @@ package helix
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -65,6 +66,10 @@ func TestGetHypeTrainEvents(t *testing.T) {
 		if len(resp.Data.Events[0].Event.TopContributions) != 2 {
 			t.Errorf("expected hype train event top contributors len to be 2, got %d", len(resp.Data.Events[0].Event.TopContributions))
 		}
+
+		if resp.Data.Events[0].Event.LastContribution.Type != HypeTrainContributionTypeBits {
+			t.Errorf("expected last contribution type to be %s, got %s", HypeTrainContributionTypeBits, resp.Data.Events[0].Event.LastContribution.Type)
+		}
 	}
 
 	// Test with HTTP Failure
@@ -88,3 +93,34 @@ func TestGetHypeTrainEvents(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestEventSubHypeTrainProgressEvent(t *testing.T) {
+	t.Parallel()
+
+	payload := `{"id":"1b0AsbInCHZW2SQFQkCzqN07Ib2","broadcaster_user_id":"1337","broadcaster_user_login":"cool_user","broadcaster_user_name":"Cool_User","level":2,"total":700,"progress":200,"goal":1000,"top_contributions":[{"user_id":"123","user_login":"pogchamp","user_name":"PogChamp","type":"bits","total":50},{"user_id":"456","user_login":"kappa","user_name":"Kappa","type":"subscription","total":45}],"last_contribution":{"user_id":"123","user_login":"pogchamp","user_name":"PogChamp","type":"bits","total":50},"started_at":"2020-07-15T17:16:03.17106713Z","expires_at":"2020-07-15T17:16:11.17106713Z","is_golden_kappa_train":false}`
+
+	var event EventSubHypeTrainProgressEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatal(err)
+	}
+
+	if event.ID != "1b0AsbInCHZW2SQFQkCzqN07Ib2" {
+		t.Errorf("expected hype train id to be %s, got %s", "1b0AsbInCHZW2SQFQkCzqN07Ib2", event.ID)
+	}
+
+	if event.Level != 2 || event.Progress != 200 || event.Goal != 1000 {
+		t.Errorf("expected level 2 with progress 200/1000, got level %d with progress %d/%d", event.Level, event.Progress, event.Goal)
+	}
+
+	if len(event.TopContributions) != 2 {
+		t.Errorf("expected top contributions len to be 2, got %d", len(event.TopContributions))
+	}
+
+	if event.LastContribution.Type != EventSubHypeTrainContributionTypeBits {
+		t.Errorf("expected last contribution type to be %s, got %s", EventSubHypeTrainContributionTypeBits, event.LastContribution.Type)
+	}
+
+	if event.ExpiresAt.IsZero() {
+		t.Error("expected expires at not to be zero")
+	}
+}
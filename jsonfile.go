@@ -0,0 +1,108 @@
+package helix
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// jsonFile is a map kept as a JSON object in a file, for the stores that
+// persist their values to disk. The file is read on every get and replaced
+// atomically on every set. set holds an advisory lock on the file while it
+// reads and writes it, see lockFile, so processes sharing the file don't lose
+// each other's updates.
+type jsonFile[V any] struct {
+	path string
+
+	mu sync.Mutex
+}
+
+func (f *jsonFile[V]) get(key string) (V, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var value V
+	values, err := f.read()
+	if err != nil {
+		return value, false, err
+	}
+
+	value, ok := values[key]
+	return value, ok, nil
+}
+
+// set stores value under key, or removes the key if value is nil.
+func (f *jsonFile[V]) set(key string, value *V) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	unlock, err := lockFile(f.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	values, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		delete(values, key)
+	} else {
+		values[key] = *value
+	}
+
+	b, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(f.path, b)
+}
+
+func (f *jsonFile[V]) read() (map[string]V, error) {
+	values := map[string]V{}
+
+	b, err := ioutil.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) == 0 {
+		return values, nil
+	}
+
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// writeFileAtomic replaces the file at path with b, through a temporary file
+// so readers never see a partial file.
+func writeFileAtomic(path string, b []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,89 @@
+package helix
+
+import (
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// Logger receives a debug entry for every request the client sends. The
+// arguments are alternating keys and values, so a *slog.Logger can be used
+// as is.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// ErrorLogger is a Logger that also receives the errors the client can't
+// return to a caller, such as tokens that couldn't be saved to the
+// TokenStore. A *slog.Logger implements it.
+type ErrorLogger interface {
+	Logger
+	Error(msg string, args ...interface{})
+}
+
+// redactedQueryParams are query parameters that carry secrets, such as those
+// sent to the token endpoints.
+var redactedQueryParams = []string{
+	"client_secret",
+	"code",
+	"code_verifier",
+	"device_code",
+	"refresh_token",
+	"token",
+}
+
+var lastRequestID uint64
+
+// logRequest logs a request sent with roundTrip. Tokens are never logged, the
+// Authorization header isn't included and secret query parameters are
+// redacted.
+func (c *Client) logRequest(req *http.Request, response *http.Response, err error, latency time.Duration) {
+	logger := c.opts.Logger
+	if logger == nil {
+		return
+	}
+
+	args := []interface{}{
+		"request_id", atomic.AddUint64(&lastRequestID, 1),
+		"method", req.Method,
+		"endpoint", req.URL.Path,
+	}
+
+	if query := redactQuery(req.URL.Query()); query != "" {
+		args = append(args, "query", query)
+	}
+
+	args = append(args, "latency", latency)
+
+	if err != nil {
+		logger.Debug("helix request failed", append(args, "error", err.Error())...)
+		return
+	}
+
+	args = append(args, "status", response.StatusCode)
+
+	if twitchRequestID := response.Header.Get(RequestIDHeader); twitchRequestID != "" {
+		args = append(args, "twitch_request_id", twitchRequestID)
+	}
+
+	if correlationID := req.Header.Get(CorrelationIDHeader); correlationID != "" {
+		args = append(args, "correlation_id", correlationID)
+	}
+
+	if remaining := response.Header.Get("Ratelimit-Remaining"); remaining != "" {
+		args = append(args, "ratelimit_remaining", remaining)
+	}
+
+	logger.Debug("helix request", args...)
+}
+
+func redactQuery(query url.Values) string {
+	for _, param := range redactedQueryParams {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+		}
+	}
+
+	return query.Encode()
+}
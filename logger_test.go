@@ -0,0 +1,151 @@
+package helix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type mockLogger struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *mockLogger) Debug(msg string, args ...interface{}) {
+	l.log(msg, args)
+}
+
+func (l *mockLogger) Error(msg string, args ...interface{}) {
+	l.log("ERROR "+msg, args)
+}
+
+func (l *mockLogger) log(msg string, args []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := msg
+	for i := 0; i+1 < len(args); i += 2 {
+		entry += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func TestLoggerRequest(t *testing.T) {
+	t.Parallel()
+
+	logger := &mockLogger{}
+	options := &Options{
+		ClientID:        "my-client-id",
+		UserAccessToken: "my-user-access-token",
+		Logger:          logger,
+	}
+
+	c := newMockClient(options, newMockHandler(http.StatusOK, `{"data":[]}`, map[string]string{"Ratelimit-Remaining": "799"}))
+
+	_, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(logger.entries))
+	}
+
+	entry := logger.entries[0]
+	for _, expected := range []string{"method=GET", "endpoint=/users", "query=login=summit1g", "status=200", "ratelimit_remaining=799", "request_id=", "latency="} {
+		if !strings.Contains(entry, expected) {
+			t.Errorf("expected log entry to contain %q, got %q", expected, entry)
+		}
+	}
+
+	if strings.Contains(entry, "my-user-access-token") {
+		t.Errorf("expected log entry not to contain the access token, got %q", entry)
+	}
+}
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	logger := &mockLogger{}
+	options := &Options{
+		ClientID:     "my-client-id",
+		ClientSecret: "my-client-secret",
+		Logger:       logger,
+	}
+
+	c := newMockClient(options, newMockHandler(http.StatusBadRequest, `{"status":400,"message":"Invalid refresh token"}`, nil))
+
+	_, err := c.RefreshUserAccessToken("my-refresh-token")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(logger.entries))
+	}
+
+	entry := logger.entries[0]
+	if strings.Contains(entry, "my-client-secret") || strings.Contains(entry, "my-refresh-token") {
+		t.Errorf("expected secrets to be redacted, got %q", entry)
+	}
+
+	if !strings.Contains(entry, "client_secret=REDACTED") || !strings.Contains(entry, "status=400") {
+		t.Errorf("unexpected log entry %q", entry)
+	}
+
+	// Test with HTTP Failure
+	logger = &mockLogger{}
+	options = &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+		Logger: logger,
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if len(logger.entries) != 1 || !strings.Contains(logger.entries[0], "error=Oops, that's bad :(") {
+		t.Errorf("expected failed request to be logged, got %v", logger.entries)
+	}
+}
+
+func TestLoggerRedactsDeviceCode(t *testing.T) {
+	t.Parallel()
+
+	logger := &mockLogger{}
+	options := &Options{
+		ClientID: "my-client-id",
+		Logger:   logger,
+	}
+
+	c := newMockClient(options, newMockHandler(http.StatusBadRequest, `{"status":400,"message":"authorization_pending"}`, nil))
+
+	_, err := c.RequestDeviceAccessToken(&DeviceCode{DeviceCode: "my-device-code", Scopes: []string{"chat:read"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(logger.entries))
+	}
+
+	entry := logger.entries[0]
+	if strings.Contains(entry, "my-device-code") {
+		t.Errorf("expected the device code to be redacted, got %q", entry)
+	}
+
+	if !strings.Contains(entry, "device_code=REDACTED") || !strings.Contains(entry, "status=400") {
+		t.Errorf("unexpected log entry %q", entry)
+	}
+}
@@ -1,6 +1,8 @@
 package helix
 
-import "errors"
+import (
+	"fmt"
+)
 
 // ExpiresAt must be parsed manually since an empty string means perma ban
 type Ban struct {
@@ -15,10 +17,7 @@ type ManyBans struct {
 	Pagination Pagination `json:"pagination"`
 }
 
-type BannedUsersResponse struct {
-	ResponseCommon
-	Data ManyBans
-}
+type BannedUsersResponse = ResponseOf[ManyBans]
 
 // BroadcasterID must match the auth tokens user_id
 type BannedUsersParams struct {
@@ -32,17 +31,7 @@ type BannedUsersParams struct {
 //
 // Required scope: moderation:read
 func (c *Client) GetBannedUsers(params *BannedUsersParams) (*BannedUsersResponse, error) {
-	resp, err := c.get("/moderation/banned", &ManyBans{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	bans := &BannedUsersResponse{}
-	resp.HydrateResponseCommon(&bans.ResponseCommon)
-	bans.Data.Bans = resp.Data.(*ManyBans).Bans
-	bans.Data.Pagination = resp.Data.(*ManyBans).Pagination
-
-	return bans, nil
+	return getResponse[ManyBans](c, "/moderation/banned", params)
 }
 
 type BanUserParams struct {
@@ -51,16 +40,20 @@ type BanUserParams struct {
 	Body          BanUserRequestBody `json:"data"`
 }
 
+// Bounds for BanUserRequestBody.Duration, in seconds. A duration of 0
+// results in a permanent ban rather than a timeout.
+const (
+	MinTimeoutDuration = 1
+	MaxTimeoutDuration = 1209600 // 2 weeks
+)
+
 type BanUserRequestBody struct {
 	Duration int    `json:"duration,omitempty"` // optional
-	Reason   string `json:"reason"`             // required
+	Reason   string `json:"reason"`             // optional, max 500 chars
 	UserId   string `json:"user_id"`            // required
 }
 
-type BanUserResponse struct {
-	ResponseCommon
-	Data ManyBanUser
-}
+type BanUserResponse = ResponseOf[ManyBanUser]
 
 type ManyBanUser struct {
 	Bans []BanUser `json:"data"`
@@ -77,16 +70,20 @@ type BanUser struct {
 // BanUser Bans a user from participating in a broadcaster’s chat room, or puts them in a timeout.
 // Required scope: moderator:manage:banned_users
 func (c *Client) BanUser(params *BanUserParams) (*BanUserResponse, error) {
-	resp, err := c.postAsJSON("/moderation/bans", &ManyBanUser{}, params)
-	if err != nil {
-		return nil, err
+	if params.BroadcasterID == "" || params.ModeratorId == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+	if params.Body.UserId == "" {
+		return nil, &ValidationError{Field: "user_id", Message: "user id must be provided"}
+	}
+	if params.Body.Duration < 0 || params.Body.Duration > MaxTimeoutDuration {
+		return nil, &ValidationError{Field: "duration", Message: fmt.Sprintf("duration must be between %d and %d seconds", MinTimeoutDuration, MaxTimeoutDuration)}
+	}
+	if len(params.Body.Reason) > 500 {
+		return nil, &ValidationError{Field: "reason", Message: "reason must not exceed 500 characters"}
 	}
 
-	banResp := &BanUserResponse{}
-	resp.HydrateResponseCommon(&banResp.ResponseCommon)
-	banResp.Data.Bans = resp.Data.(*ManyBanUser).Bans
-
-	return banResp, nil
+	return postAsJSONResponse[ManyBanUser](c, "/moderation/bans", params)
 }
 
 type UnbanUserParams struct {
@@ -102,6 +99,13 @@ type UnbanUserResponse struct {
 // UnbanUser Removes the ban or timeout that was placed on the specified user
 // Required scope: moderator:manage:banned_users
 func (c *Client) UnbanUser(params *UnbanUserParams) (*UnbanUserResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+	if params.UserID == "" {
+		return nil, &ValidationError{Field: "user_id", Message: "user id must be provided"}
+	}
+
 	resp, err := c.delete("/moderation/bans", nil, params)
 	if err != nil {
 		return nil, err
@@ -112,6 +116,50 @@ func (c *Client) UnbanUser(params *UnbanUserParams) (*UnbanUserResponse, error)
 	return unbanResp, nil
 }
 
+type WarnChatUserParams struct {
+	BroadcasterID string                  `query:"broadcaster_id"`
+	ModeratorID   string                  `query:"moderator_id"`
+	Body          WarnChatUserRequestBody `json:"data"`
+}
+
+type WarnChatUserRequestBody struct {
+	UserID string `json:"user_id"` // required
+	Reason string `json:"reason"`  // required, max 500 chars
+}
+
+type WarnChatUserResponse = ResponseOf[ManyChatWarnings]
+
+type ManyChatWarnings struct {
+	Warnings []ChatWarning `json:"data"`
+}
+
+type ChatWarning struct {
+	BroadcasterID string `json:"broadcaster_id"`
+	UserID        string `json:"user_id"`
+	ModeratorID   string `json:"moderator_id"`
+	Reason        string `json:"reason"`
+}
+
+// WarnChatUser Warns a user in the broadcaster’s chat room, preventing them from chatting
+// until the warning is acknowledged.
+// Required scope: moderator:manage:warnings
+func (c *Client) WarnChatUser(params *WarnChatUserParams) (*WarnChatUserResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+	if params.Body.UserID == "" {
+		return nil, &ValidationError{Field: "user_id", Message: "user id must be provided"}
+	}
+	if params.Body.Reason == "" {
+		return nil, &ValidationError{Field: "reason", Message: "reason must be provided"}
+	}
+	if len(params.Body.Reason) > 500 {
+		return nil, &ValidationError{Field: "reason", Message: "reason must not exceed 500 characters"}
+	}
+
+	return postAsJSONResponse[ManyChatWarnings](c, "/moderation/warnings", params)
+}
+
 type BlockedTermsParams struct {
 	// Required
 	BroadcasterID string `query:"broadcaster_id"`
@@ -119,13 +167,10 @@ type BlockedTermsParams struct {
 
 	// Optional
 	After string `query:"after"`
-	First int    `query:"first"`
+	First int    `query:"first"` // Limit 100
 }
 
-type BlockedTermsResponse struct {
-	ResponseCommon
-	Data ManyBlockedTerms
-}
+type BlockedTermsResponse = ResponseOf[ManyBlockedTerms]
 
 type ManyBlockedTerms struct {
 	Terms      []BlockedTerm `json:"data"`
@@ -147,20 +192,14 @@ type BlockedTerm struct {
 // Required scope: moderator:read:blocked_terms
 func (c *Client) GetBlockedTerms(params *BlockedTermsParams) (*BlockedTermsResponse, error) {
 	if params.BroadcasterID == "" || params.ModeratorID == "" {
-		return nil, errors.New("broadcaster id and moderator id must be provided")
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided", bare: true}
 	}
 
-	resp, err := c.get("/moderation/blocked_terms", &ManyBlockedTerms{}, params)
-	if err != nil {
+	if err := validateFirst(params.First, 100); err != nil {
 		return nil, err
 	}
 
-	blockedTermsResp := &BlockedTermsResponse{}
-	resp.HydrateResponseCommon(&blockedTermsResp.ResponseCommon)
-	blockedTermsResp.Data.Terms = resp.Data.(*ManyBlockedTerms).Terms
-	blockedTermsResp.Data.Pagination = resp.Data.(*ManyBlockedTerms).Pagination
-
-	return blockedTermsResp, nil
+	return getResponse[ManyBlockedTerms](c, "/moderation/blocked_terms", params)
 }
 
 type AddBlockedTermParams struct {
@@ -169,10 +208,7 @@ type AddBlockedTermParams struct {
 	Text          string `json:"text"`
 }
 
-type AddBlockedTermResponse struct {
-	ResponseCommon
-	Data ManyAddBlockedTerms
-}
+type AddBlockedTermResponse = ResponseOf[ManyAddBlockedTerms]
 
 type ManyAddBlockedTerms struct {
 	Terms []BlockedTerm `json:"data"`
@@ -183,28 +219,19 @@ type ManyAddBlockedTerms struct {
 // Required scope: moderator:manage:blocked_terms
 func (c *Client) AddBlockedTerm(params *AddBlockedTermParams) (*AddBlockedTermResponse, error) {
 	if params.BroadcasterID == "" || params.ModeratorID == "" {
-		return nil, errors.New("broadcaster id and moderator id must be provided")
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided", bare: true}
 	}
 	if len(params.Text) < 2 || len(params.Text) > 500 {
-		return nil, errors.New("the term len must be between 2 and 500")
+		return nil, &ValidationError{Field: "text", Message: "the term len must be between 2 and 500", bare: true}
 	}
 
-	resp, err := c.postAsJSON("/moderation/blocked_terms", &ManyAddBlockedTerms{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	addTermResp := &AddBlockedTermResponse{}
-	resp.HydrateResponseCommon(&addTermResp.ResponseCommon)
-	addTermResp.Data.Terms = resp.Data.(*ManyAddBlockedTerms).Terms
-
-	return addTermResp, nil
+	return postAsJSONResponse[ManyAddBlockedTerms](c, "/moderation/blocked_terms", params)
 }
 
 type RemoveBlockedTermParams struct {
-	BroadcasterID string `json:"broadcaster_id"`
-	ModeratorID   string `json:"moderator_id"`
-	ID            string `json:"id"`
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+	ID            string `query:"id"`
 }
 
 type RemoveBlockedTermResponse struct {
@@ -215,11 +242,11 @@ type RemoveBlockedTermResponse struct {
 // Required scope: moderator:manage:blocked_terms
 func (c *Client) RemoveBlockedTerm(params *RemoveBlockedTermParams) (*RemoveBlockedTermResponse, error) {
 	if params.BroadcasterID == "" || params.ModeratorID == "" {
-		return nil, errors.New("broadcaster id and moderator id must be provided")
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided", bare: true}
 	}
 
 	if params.ID == "" {
-		return nil, errors.New("id must be provided")
+		return nil, &ValidationError{Field: "id", Message: "id must be provided", bare: true}
 	}
 
 	resp, err := c.delete("/moderation/blocked_terms", nil, params)
@@ -247,11 +274,11 @@ type DeleteChatMessageResponse struct {
 // Required scope: moderator:manage:chat_messages
 func (c *Client) DeleteChatMessage(params *DeleteChatMessageParams) (*DeleteChatMessageResponse, error) {
 	if params.BroadcasterID == "" || params.ModeratorID == "" {
-		return nil, errors.New("broadcaster id and moderator id must be provided")
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided", bare: true}
 	}
 
 	if params.MessageID == "" {
-		return nil, errors.New("message id must be provided")
+		return nil, &ValidationError{Field: "message_id", Message: "message id must be provided", bare: true}
 	}
 
 	resp, err := c.delete("/moderation/chat", nil, params)
@@ -278,7 +305,7 @@ type DeleteAllChatMessagesResponse struct {
 // Required scope: moderator:manage:chat_messages
 func (c *Client) DeleteAllChatMessages(params *DeleteAllChatMessagesParams) (*DeleteAllChatMessagesResponse, error) {
 	if params.BroadcasterID == "" || params.ModeratorID == "" {
-		return nil, errors.New("broadcaster id and moderator id must be provided")
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided", bare: true}
 	}
 
 	resp, err := c.delete("/moderation/chat", nil, params)
@@ -313,10 +340,7 @@ type ManyModerators struct {
 	Pagination Pagination  `json:"pagination"`
 }
 
-type ModeratorsResponse struct {
-	ResponseCommon
-	Data ManyModerators
-}
+type ModeratorsResponse = ResponseOf[ManyModerators]
 
 type AddChannelModeratorParams struct {
 	UserID        string `query:"user_id"`
@@ -339,23 +363,20 @@ type RemoveChannelModeratorResponse struct {
 // GetModerators Gets all users allowed to moderate the broadcaster’s chat room.
 // Required scope: moderation:read
 func (c *Client) GetModerators(params *GetModeratorsParams) (*ModeratorsResponse, error) {
-	if params.BroadcasterID == "" {
-		return nil, errors.New("broadcaster id must be provided")
-	}
-
-	resp, err := c.get("/moderation/moderators", &ManyModerators{}, params)
-	if err != nil {
+	if err := validateMaxValues("user_id", "user ids", params.UserIDs, 100); err != nil {
 		return nil, err
 	}
 
-	moderators := &ModeratorsResponse{}
-	resp.HydrateResponseCommon(&moderators.ResponseCommon)
-	moderators.Data.Moderators = resp.Data.(*ManyModerators).Moderators
-	moderators.Data.Pagination = resp.Data.(*ManyModerators).Pagination
+	if params.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided", bare: true}
+	}
 
-	return moderators, nil
+	return getResponse[ManyModerators](c, "/moderation/moderators", params)
 }
 
+// AddChannelModerator Adds a moderator to the broadcaster’s chat room.
+// Required scope: channel:manage:moderators
+// Rate Limits: The broadcaster may add a maximum of 10 moderators within a 10-second window.
 func (c *Client) AddChannelModerator(params *AddChannelModeratorParams) (*AddChannelModeratorResponse, error) {
 	resp, err := c.post("/moderation/moderators", nil, params)
 	if err != nil {
@@ -368,6 +389,9 @@ func (c *Client) AddChannelModerator(params *AddChannelModeratorParams) (*AddCha
 	return moderators, nil
 }
 
+// RemoveChannelModerator Removes a moderator from the broadcaster’s chat room.
+// Required scope: channel:manage:moderators
+// Rate Limits: The broadcaster may remove a maximum of 10 moderators within a 10-second window.
 func (c *Client) RemoveChannelModerator(params *RemoveChannelModeratorParams) (*RemoveChannelModeratorResponse, error) {
 	resp, err := c.delete("/moderation/moderators", nil, params)
 	if err != nil {
@@ -379,3 +403,39 @@ func (c *Client) RemoveChannelModerator(params *RemoveChannelModeratorParams) (*
 
 	return moderators, nil
 }
+
+type GetModeratedChannelsParams struct {
+	// Required, must match the user id of the user access token
+	UserID string `query:"user_id"`
+
+	// Optional
+	After string `query:"after"`
+	First int    `query:"first"` // Limit 100
+}
+
+type ModeratedChannel struct {
+	BroadcasterID    string `json:"broadcaster_id"`
+	BroadcasterLogin string `json:"broadcaster_login"`
+	BroadcasterName  string `json:"broadcaster_name"`
+}
+
+type ManyModeratedChannels struct {
+	ModeratedChannels []ModeratedChannel `json:"data"`
+	Pagination        Pagination         `json:"pagination"`
+}
+
+type ModeratedChannelsResponse = ResponseOf[ManyModeratedChannels]
+
+// GetModeratedChannels Gets a list of channels that the specified user has moderator privileges in.
+// Required scope: user:read:moderated_channels
+func (c *Client) GetModeratedChannels(params *GetModeratedChannelsParams) (*ModeratedChannelsResponse, error) {
+	if params.UserID == "" {
+		return nil, &ValidationError{Field: "user_id", Message: "user id must be provided"}
+	}
+
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyModeratedChannels](c, "/moderation/channels", params)
+}
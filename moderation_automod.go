@@ -1,17 +1,24 @@
 package helix
 
+// Actions accepted by ManageHeldAutoModMessages
+const (
+	AutoModActionAllow = "ALLOW"
+	AutoModActionDeny  = "DENY"
+)
+
 type HeldMessageModerationResponse struct {
 	ResponseCommon
 }
 
 type HeldMessageModerationParams struct {
-	UserID string `query:"user_id"`
-	MsgID  string `query:"msg_id"`
-	Action string `query:"action"` // Must be "ALLOW" or "DENY".
+	UserID string `json:"user_id"`
+	MsgID  string `json:"msg_id"`
+	Action string `json:"action"` // Must be "ALLOW" or "DENY".
 }
 
+// ManageHeldAutoModMessages allows or denies a message that was held for review by AutoMod.
 // Required scope: moderator:manage:automod
-func (c *Client) ModerateHeldMessage(params *HeldMessageModerationParams) (*HeldMessageModerationResponse, error) {
+func (c *Client) ManageHeldAutoModMessages(params *HeldMessageModerationParams) (*HeldMessageModerationResponse, error) {
 	resp, err := c.postAsJSON("/moderation/automod/message", nil, params)
 	if err != nil {
 		return nil, err
@@ -22,3 +29,127 @@ func (c *Client) ModerateHeldMessage(params *HeldMessageModerationParams) (*Held
 
 	return moderation, nil
 }
+
+// Deprecated: use ManageHeldAutoModMessages instead.
+func (c *Client) ModerateHeldMessage(params *HeldMessageModerationParams) (*HeldMessageModerationResponse, error) {
+	return c.ManageHeldAutoModMessages(params)
+}
+
+type AutoModSettings struct {
+	BroadcasterID string `json:"broadcaster_id"`
+	ModeratorID   string `json:"moderator_id"`
+
+	// Null if the individual settings have been set to differing levels
+	OverallLevel *int `json:"overall_level"`
+
+	Disability              int `json:"disability"`
+	Aggression              int `json:"aggression"`
+	SexualitySexOrGender    int `json:"sexuality_sex_or_gender"`
+	Misogyny                int `json:"misogyny"`
+	Bullying                int `json:"bullying"`
+	Swearing                int `json:"swearing"`
+	RaceEthnicityOrReligion int `json:"race_ethnicity_or_religion"`
+	SexBasedTerms           int `json:"sex_based_terms"`
+}
+
+type ManyAutoModSettings struct {
+	Settings []AutoModSettings `json:"data"`
+}
+
+type GetAutoModSettingsParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+}
+
+type GetAutoModSettingsResponse = ResponseOf[ManyAutoModSettings]
+
+// GetAutoModSettings gets the broadcaster’s AutoMod settings.
+// Required scope: moderator:read:automod_settings
+func (c *Client) GetAutoModSettings(params *GetAutoModSettingsParams) (*GetAutoModSettingsResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+
+	return getResponse[ManyAutoModSettings](c, "/moderation/automod/settings", params)
+}
+
+type UpdateAutoModSettingsParams struct {
+	// Required
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+
+	// Either set OverallLevel, or any of the individual settings. Levels range from 0 (no filtering) to 4 (most aggressive).
+	// Any individual setting left unset (i.e. nil) is set to 0 by Twitch, since this overwrites all settings.
+	OverallLevel *int `json:"overall_level,omitempty"`
+
+	Disability              *int `json:"disability,omitempty"`
+	Aggression              *int `json:"aggression,omitempty"`
+	SexualitySexOrGender    *int `json:"sexuality_sex_or_gender,omitempty"`
+	Misogyny                *int `json:"misogyny,omitempty"`
+	Bullying                *int `json:"bullying,omitempty"`
+	Swearing                *int `json:"swearing,omitempty"`
+	RaceEthnicityOrReligion *int `json:"race_ethnicity_or_religion,omitempty"`
+	SexBasedTerms           *int `json:"sex_based_terms,omitempty"`
+}
+
+type UpdateAutoModSettingsResponse = ResponseOf[ManyAutoModSettings]
+
+func (p *UpdateAutoModSettingsParams) hasIndividualSettings() bool {
+	for _, level := range []*int{
+		p.Disability, p.Aggression, p.SexualitySexOrGender, p.Misogyny,
+		p.Bullying, p.Swearing, p.RaceEthnicityOrReligion, p.SexBasedTerms,
+	} {
+		if level != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdateAutoModSettings updates the broadcaster’s AutoMod settings.
+// Required scope: moderator:manage:automod_settings
+func (c *Client) UpdateAutoModSettings(params *UpdateAutoModSettingsParams) (*UpdateAutoModSettingsResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+	if params.OverallLevel != nil && params.hasIndividualSettings() {
+		return nil, &ValidationError{Field: "overall_level", Message: "overall level and individual settings are mutually exclusive"}
+	}
+
+	return putAsJSONResponse[ManyAutoModSettings](c, "/moderation/automod/settings", params)
+}
+
+type AutoModMessage struct {
+	MsgID   string `json:"msg_id"`
+	MsgText string `json:"msg_text"`
+}
+
+type CheckAutoModStatusParams struct {
+	BroadcasterID string           `query:"broadcaster_id"`
+	Messages      []AutoModMessage `json:"data"` // Limit 100
+}
+
+type AutoModStatus struct {
+	MsgID       string `json:"msg_id"`
+	IsPermitted bool   `json:"is_permitted"`
+}
+
+type ManyAutoModStatuses struct {
+	Statuses []AutoModStatus `json:"data"`
+}
+
+type CheckAutoModStatusResponse = ResponseOf[ManyAutoModStatuses]
+
+// CheckAutoModStatus checks whether AutoMod would flag the specified messages for review.
+// Required scope: moderation:read
+func (c *Client) CheckAutoModStatus(params *CheckAutoModStatusParams) (*CheckAutoModStatusResponse, error) {
+	if params.BroadcasterID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id must be provided"}
+	}
+	if len(params.Messages) == 0 || len(params.Messages) > 100 {
+		return nil, &ValidationError{Field: "data", Message: "between 1 and 100 messages must be provided"}
+	}
+
+	return postAsJSONResponse[ManyAutoModStatuses](c, "/moderation/enforcements/status", params)
+}
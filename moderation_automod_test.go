@@ -84,3 +84,231 @@ func TestModerateHeldMessage(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestGetAutoModSettings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetAutoModSettingsParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetAutoModSettingsParams{BroadcasterID: "1234"},
+			``,
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetAutoModSettingsParams{BroadcasterID: "1234", ModeratorID: "5678"},
+			`{"data":[{"broadcaster_id":"1234","moderator_id":"5678","overall_level":null,"disability":0,"aggression":0,"sexuality_sex_or_gender":0,"misogyny":0,"bullying":0,"swearing":0,"race_ethnicity_or_religion":0,"sex_based_terms":0}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetAutoModSettings(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Settings) != 1 {
+			t.Errorf("expected number of settings to be %d, got %d", 1, len(resp.Data.Settings))
+			continue
+		}
+
+		if resp.Data.Settings[0].OverallLevel != nil {
+			t.Errorf("expected overall level to be nil, got %d", *resp.Data.Settings[0].OverallLevel)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetAutoModSettings(&GetAutoModSettingsParams{BroadcasterID: "1234", ModeratorID: "5678"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestUpdateAutoModSettings(t *testing.T) {
+	t.Parallel()
+
+	overallLevel := 3
+	swearing := 4
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *UpdateAutoModSettingsParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&UpdateAutoModSettingsParams{BroadcasterID: "1234", ModeratorID: "5678", OverallLevel: &overallLevel, Swearing: &swearing},
+			``,
+			"error: overall level and individual settings are mutually exclusive",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&UpdateAutoModSettingsParams{BroadcasterID: "1234", ModeratorID: "5678", OverallLevel: &overallLevel},
+			`{"data":[{"broadcaster_id":"1234","moderator_id":"5678","overall_level":3,"disability":3,"aggression":3,"sexuality_sex_or_gender":3,"misogyny":3,"bullying":2,"swearing":0,"race_ethnicity_or_religion":3,"sex_based_terms":3}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.UpdateAutoModSettings(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Settings) != 1 {
+			t.Errorf("expected number of settings to be %d, got %d", 1, len(resp.Data.Settings))
+			continue
+		}
+
+		if level := resp.Data.Settings[0].OverallLevel; level == nil || *level != overallLevel {
+			t.Errorf("expected overall level to be %d, got %v", overallLevel, level)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.UpdateAutoModSettings(&UpdateAutoModSettingsParams{BroadcasterID: "1234", ModeratorID: "5678"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestCheckAutoModStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *CheckAutoModStatusParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "broadcaster-access-token"},
+			&CheckAutoModStatusParams{BroadcasterID: "1234"},
+			``,
+			"error: between 1 and 100 messages must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "broadcaster-access-token"},
+			&CheckAutoModStatusParams{BroadcasterID: "1234", Messages: []AutoModMessage{
+				{MsgID: "123", MsgText: "Hello World!"},
+				{MsgID: "393", MsgText: "Boooooo!"},
+			}},
+			`{"data":[{"msg_id":"123","is_permitted":true},{"msg_id":"393","is_permitted":false}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.CheckAutoModStatus(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Statuses) != len(testCase.params.Messages) {
+			t.Errorf("expected number of statuses to be %d, got %d", len(testCase.params.Messages), len(resp.Data.Statuses))
+			continue
+		}
+
+		if !resp.Data.Statuses[0].IsPermitted || resp.Data.Statuses[1].IsPermitted {
+			t.Errorf("expected only the first message to be permitted, got %+v", resp.Data.Statuses)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.CheckAutoModStatus(&CheckAutoModStatusParams{BroadcasterID: "1234", Messages: []AutoModMessage{{MsgID: "123", MsgText: "Hello World!"}}})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
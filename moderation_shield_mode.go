@@ -0,0 +1,48 @@
+package helix
+
+type ShieldModeStatus struct {
+	IsActive        bool   `json:"is_active"`
+	ModeratorID     string `json:"moderator_id"`
+	ModeratorLogin  string `json:"moderator_login"`
+	ModeratorName   string `json:"moderator_name"`
+	LastActivatedAt Time   `json:"last_activated_at"`
+}
+
+type ManyShieldModeStatuses struct {
+	Statuses []ShieldModeStatus `json:"data"`
+}
+
+type GetShieldModeStatusParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+}
+
+type GetShieldModeStatusResponse = ResponseOf[ManyShieldModeStatuses]
+
+// GetShieldModeStatus gets the broadcaster’s Shield Mode activation status.
+// Required scope: moderator:read:shield_mode or moderator:manage:shield_mode
+func (c *Client) GetShieldModeStatus(params *GetShieldModeStatusParams) (*GetShieldModeStatusResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+
+	return getResponse[ManyShieldModeStatuses](c, "/moderation/shield_mode", params)
+}
+
+type UpdateShieldModeStatusParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+	IsActive      bool   `json:"is_active"`
+}
+
+type UpdateShieldModeStatusResponse = ResponseOf[ManyShieldModeStatuses]
+
+// UpdateShieldModeStatus activates or deactivates the broadcaster’s Shield Mode.
+// Required scope: moderator:manage:shield_mode
+func (c *Client) UpdateShieldModeStatus(params *UpdateShieldModeStatusParams) (*UpdateShieldModeStatusResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+
+	return putAsJSONResponse[ManyShieldModeStatuses](c, "/moderation/shield_mode", params)
+}
@@ -0,0 +1,175 @@
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetShieldModeStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetShieldModeStatusParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetShieldModeStatusParams{ModeratorID: "98765"},
+			``,
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetShieldModeStatusParams{BroadcasterID: "12345", ModeratorID: "98765"},
+			`{"data":[{"is_active":true,"moderator_id":"98765","moderator_name":"SimplySimple","moderator_login":"simplysimple","last_activated_at":"2022-07-26T17:16:03.123Z"}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetShieldModeStatus(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Statuses) != 1 {
+			t.Errorf("expected number of statuses to be %d, got %d", 1, len(resp.Data.Statuses))
+			continue
+		}
+
+		status := resp.Data.Statuses[0]
+		if !status.IsActive {
+			t.Errorf("expected shield mode to be active")
+		}
+
+		if status.ModeratorLogin != "simplysimple" {
+			t.Errorf("expected moderator login to be %s, got %s", "simplysimple", status.ModeratorLogin)
+		}
+
+		if status.LastActivatedAt.IsZero() {
+			t.Errorf("expected last activated at not to be zero")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetShieldModeStatus(&GetShieldModeStatusParams{BroadcasterID: "12345", ModeratorID: "98765"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestUpdateShieldModeStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *UpdateShieldModeStatusParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&UpdateShieldModeStatusParams{BroadcasterID: "12345", IsActive: true},
+			``,
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&UpdateShieldModeStatusParams{BroadcasterID: "12345", ModeratorID: "98765", IsActive: false},
+			`{"data":[{"is_active":false,"moderator_id":"98765","moderator_name":"SimplySimple","moderator_login":"simplysimple","last_activated_at":"2022-07-26T17:16:03.123Z"}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				IsActive *bool `json:"is_active"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.IsActive == nil {
+				t.Errorf("expected is_active to be sent in the request body")
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.UpdateShieldModeStatus(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.Statuses) != 1 {
+			t.Errorf("expected number of statuses to be %d, got %d", 1, len(resp.Data.Statuses))
+			continue
+		}
+
+		if resp.Data.Statuses[0].IsActive != testCase.params.IsActive {
+			t.Errorf("expected is_active to be %t, got %t", testCase.params.IsActive, resp.Data.Statuses[0].IsActive)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.UpdateShieldModeStatus(&UpdateShieldModeStatusParams{BroadcasterID: "12345", ModeratorID: "98765"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
@@ -3,6 +3,7 @@ package helix
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 )
@@ -197,7 +198,7 @@ func TestBanUser(t *testing.T) {
 		ctx:  context.Background(),
 	}
 
-	_, err := c.BanUser(&BanUserParams{})
+	_, err := c.BanUser(&BanUserParams{BroadcasterID: "1234", ModeratorId: "5678", Body: BanUserRequestBody{UserId: "9876"}})
 	if err == nil {
 		t.Error("expected error but got nil")
 	}
@@ -205,6 +206,36 @@ func TestBanUser(t *testing.T) {
 	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
 		t.Error("expected error does match return error")
 	}
+
+	// Test validation errors
+	validationCases := []struct {
+		params *BanUserParams
+		err    string
+	}{
+		{
+			&BanUserParams{ModeratorId: "5678", Body: BanUserRequestBody{UserId: "9876"}},
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			&BanUserParams{BroadcasterID: "1234", ModeratorId: "5678"},
+			"error: user id must be provided",
+		},
+		{
+			&BanUserParams{BroadcasterID: "1234", ModeratorId: "5678", Body: BanUserRequestBody{UserId: "9876", Duration: MaxTimeoutDuration + 1}},
+			"error: duration must be between 1 and 1209600 seconds",
+		},
+		{
+			&BanUserParams{BroadcasterID: "1234", ModeratorId: "5678", Body: BanUserRequestBody{UserId: "9876", Reason: strings.Repeat("a", 501)}},
+			"error: reason must not exceed 500 characters",
+		},
+	}
+
+	for _, validationCase := range validationCases {
+		_, err := c.BanUser(validationCase.params)
+		if err == nil || err.Error() != validationCase.err {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", validationCase.err, err)
+		}
+	}
 }
 
 func TestUnbanUser(t *testing.T) {
@@ -268,7 +299,7 @@ func TestUnbanUser(t *testing.T) {
 		ctx:  context.Background(),
 	}
 
-	_, err := c.UnbanUser(&UnbanUserParams{})
+	_, err := c.UnbanUser(&UnbanUserParams{BroadcasterID: "1234", ModeratorID: "5678", UserID: "9876"})
 	if err == nil {
 		t.Error("expected error but got nil")
 	}
@@ -276,6 +307,133 @@ func TestUnbanUser(t *testing.T) {
 	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
 		t.Error("expected error does match return error")
 	}
+
+	_, err = c.UnbanUser(&UnbanUserParams{BroadcasterID: "1234", ModeratorID: "5678"})
+	if err == nil || err.Error() != "error: user id must be provided" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: user id must be provided", err)
+	}
+}
+
+func TestWarnChatUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode     int
+		options        *Options
+		params         *WarnChatUserParams
+		respBody       string
+		expectedErrMsg string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&WarnChatUserParams{BroadcasterID: "1234", ModeratorID: "5678", Body: WarnChatUserRequestBody{
+				UserID: "9876",
+				Reason: "stop doing that!",
+			}},
+			`{"data": [{"broadcaster_id": "1234","user_id": "9876","moderator_id": "5678","reason": "stop doing that!"}]}`,
+			"",
+		},
+		{
+			http.StatusBadRequest,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&WarnChatUserParams{BroadcasterID: "1234", ModeratorID: "5678", Body: WarnChatUserRequestBody{
+				UserID: "1234",
+				Reason: "stop doing that!",
+			}},
+			`{"error":"Bad Request","status": 400,"message":"The user specified in the user_id field may not be warned."}`,
+			"The user specified in the user_id field may not be warned.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.WarnChatUser(testCase.params)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			if resp.ErrorStatus != testCase.statusCode {
+				t.Errorf("expected error status to be \"%d\", got \"%d\"", testCase.statusCode, resp.ErrorStatus)
+			}
+
+			if resp.ErrorMessage != testCase.expectedErrMsg {
+				t.Errorf("expected error message to be \"%s\", got \"%s\"", testCase.expectedErrMsg, resp.ErrorMessage)
+			}
+			continue
+		}
+
+		if len(resp.Data.Warnings) != 1 {
+			t.Errorf("expected 1 warning, got %d", len(resp.Data.Warnings))
+			continue
+		}
+
+		warning := resp.Data.Warnings[0]
+		if warning.UserID != testCase.params.Body.UserID {
+			t.Errorf("expected user id to be \"%s\", got \"%s\"", testCase.params.Body.UserID, warning.UserID)
+		}
+
+		if warning.Reason != testCase.params.Body.Reason {
+			t.Errorf("expected reason to be \"%s\", got \"%s\"", testCase.params.Body.Reason, warning.Reason)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.WarnChatUser(&WarnChatUserParams{BroadcasterID: "1234", ModeratorID: "5678", Body: WarnChatUserRequestBody{UserID: "9876", Reason: "no reason"}})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+
+	// Test validation errors
+	validationCases := []struct {
+		params *WarnChatUserParams
+		err    string
+	}{
+		{
+			&WarnChatUserParams{BroadcasterID: "1234", Body: WarnChatUserRequestBody{UserID: "9876", Reason: "no reason"}},
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			&WarnChatUserParams{BroadcasterID: "1234", ModeratorID: "5678", Body: WarnChatUserRequestBody{Reason: "no reason"}},
+			"error: user id must be provided",
+		},
+		{
+			&WarnChatUserParams{BroadcasterID: "1234", ModeratorID: "5678", Body: WarnChatUserRequestBody{UserID: "9876"}},
+			"error: reason must be provided",
+		},
+		{
+			&WarnChatUserParams{BroadcasterID: "1234", ModeratorID: "5678", Body: WarnChatUserRequestBody{UserID: "9876", Reason: strings.Repeat("a", 501)}},
+			"error: reason must not exceed 500 characters",
+		},
+	}
+
+	for _, validationCase := range validationCases {
+		_, err := c.WarnChatUser(validationCase.params)
+		if err == nil || err.Error() != validationCase.err {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", validationCase.err, err)
+		}
+	}
 }
 
 func TestGetBlockedTerms(t *testing.T) {
@@ -326,6 +484,13 @@ func TestGetBlockedTerms(t *testing.T) {
 			``,
 			"broadcaster id and moderator id must be provided",
 		},
+		{
+			http.StatusBadRequest,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&BlockedTermsParams{BroadcasterID: "1234", ModeratorID: "5678", First: 101},
+			``,
+			"error: first must not be greater than 100",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -510,7 +675,15 @@ func TestRemoveBlockedTerm(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if query.Get("broadcaster_id") != testCase.params.BroadcasterID || query.Get("moderator_id") != testCase.params.ModeratorID || query.Get("id") != testCase.params.ID {
+				t.Errorf("expected query to contain the blocked term identifiers, got \"%s\"", r.URL.RawQuery)
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
 
 		resp, err := c.RemoveBlockedTerm(testCase.params)
 		if err != nil {
@@ -949,3 +1122,88 @@ func TestRemoveChannelModerator(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestGetModeratedChannels(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetModeratedChannelsParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "user-access-token"},
+			&GetModeratedChannelsParams{},
+			``,
+			"error: user id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "user-access-token"},
+			&GetModeratedChannelsParams{UserID: "931931", First: 101},
+			``,
+			"error: first must not be greater than 100",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "user-access-token"},
+			&GetModeratedChannelsParams{UserID: "931931"},
+			`{"data":[{"broadcaster_id":"12345","broadcaster_login":"grateful_broadcaster","broadcaster_name":"Grateful_Broadcaster"},{"broadcaster_id":"98765","broadcaster_login":"bashfulgamer","broadcaster_name":"BashfulGamer"}],"pagination":{"cursor":"eyJiIjpudWxsLCJhIjp7IkN1cnNvciI6IjEwMDQ3MzA2NDo4NjQwNjU3MToxSVZCVDFKMnY5M1BTOXh3d1E0dUdXMkJOMFcifX0"}}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetModeratedChannels(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.ModeratedChannels) != 2 {
+			t.Errorf("expected number of moderated channels to be %d, got %d", 2, len(resp.Data.ModeratedChannels))
+			continue
+		}
+
+		if resp.Data.ModeratedChannels[1].BroadcasterLogin != "bashfulgamer" {
+			t.Errorf("expected broadcaster login to be %s, got %s", "bashfulgamer", resp.Data.ModeratedChannels[1].BroadcasterLogin)
+		}
+
+		if resp.Data.Pagination.Cursor == "" {
+			t.Error("expected pagination cursor not to be empty")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetModeratedChannels(&GetModeratedChannelsParams{UserID: "931931"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
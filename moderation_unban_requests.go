@@ -0,0 +1,101 @@
+package helix
+
+// Unban request statuses
+const (
+	UnbanRequestStatusPending      = "pending"
+	UnbanRequestStatusApproved     = "approved"
+	UnbanRequestStatusDenied       = "denied"
+	UnbanRequestStatusAcknowledged = "acknowledged"
+	UnbanRequestStatusCanceled     = "canceled"
+)
+
+type UnbanRequest struct {
+	ID               string `json:"id"`
+	BroadcasterID    string `json:"broadcaster_id"`
+	BroadcasterLogin string `json:"broadcaster_login"`
+	BroadcasterName  string `json:"broadcaster_name"`
+	ModeratorID      string `json:"moderator_id"`
+	ModeratorLogin   string `json:"moderator_login"`
+	ModeratorName    string `json:"moderator_name"`
+	UserID           string `json:"user_id"`
+	UserLogin        string `json:"user_login"`
+	UserName         string `json:"user_name"`
+	Text             string `json:"text"`
+	Status           string `json:"status"`
+	CreatedAt        Time   `json:"created_at"`
+	ResolvedAt       Time   `json:"resolved_at"`
+	ResolutionText   string `json:"resolution_text"`
+}
+
+type ManyUnbanRequests struct {
+	UnbanRequests []UnbanRequest `json:"data"`
+	Pagination    Pagination     `json:"pagination"`
+}
+
+type GetUnbanRequestsParams struct {
+	// Required
+	BroadcasterID string `query:"broadcaster_id"`
+	ModeratorID   string `query:"moderator_id"`
+	Status        string `query:"status"` // One of the UnbanRequestStatus* values
+
+	// Optional
+	UserID string `query:"user_id"`
+	After  string `query:"after"`
+	First  int    `query:"first"` // Limit 100
+}
+
+type GetUnbanRequestsResponse = ResponseOf[ManyUnbanRequests]
+
+// GetUnbanRequests gets a list of unban requests for a broadcaster’s channel.
+// Required scope: moderator:read:unban_requests
+func (c *Client) GetUnbanRequests(params *GetUnbanRequestsParams) (*GetUnbanRequestsResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+	if params.Status == "" {
+		return nil, &ValidationError{Field: "status", Message: "status must be provided"}
+	}
+
+	return getResponse[ManyUnbanRequests](c, "/moderation/unban_requests", params)
+}
+
+type ResolveUnbanRequestParams struct {
+	// Required
+	BroadcasterID  string `query:"broadcaster_id"`
+	ModeratorID    string `query:"moderator_id"`
+	UnbanRequestID string `query:"unban_request_id"`
+	Status         string `query:"status"` // Must be "approved" or "denied"
+
+	// Optional
+	ResolutionText string `query:"resolution_text"` // Max 500 chars
+}
+
+type ResolveUnbanRequestResponse = ResponseOf[ManyUnbanRequests]
+
+// ResolveUnbanRequest approves or denies an unban request.
+// Required scope: moderator:manage:unban_requests
+func (c *Client) ResolveUnbanRequest(params *ResolveUnbanRequestParams) (*ResolveUnbanRequestResponse, error) {
+	if params.BroadcasterID == "" || params.ModeratorID == "" {
+		return nil, &ValidationError{Field: "broadcaster_id", Message: "broadcaster id and moderator id must be provided"}
+	}
+	if params.UnbanRequestID == "" {
+		return nil, &ValidationError{Field: "unban_request_id", Message: "unban request id must be provided"}
+	}
+	if params.Status != UnbanRequestStatusApproved && params.Status != UnbanRequestStatusDenied {
+		return nil, &ValidationError{Field: "status", Message: "status must be either approved or denied"}
+	}
+	if len(params.ResolutionText) > 500 {
+		return nil, &ValidationError{Field: "resolution_text", Message: "resolution text must not exceed 500 characters"}
+	}
+
+	resp, err := c.patch("/moderation/unban_requests", &ManyUnbanRequests{}, params)
+	if err != nil {
+		return nil, err
+	}
+
+	unbanRequests := &ResolveUnbanRequestResponse{}
+	resp.HydrateResponseCommon(&unbanRequests.ResponseCommon)
+	unbanRequests.Data.UnbanRequests = resp.Data.(*ManyUnbanRequests).UnbanRequests
+
+	return unbanRequests, nil
+}
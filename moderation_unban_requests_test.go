@@ -0,0 +1,204 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetUnbanRequests(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetUnbanRequestsParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetUnbanRequestsParams{ModeratorID: "274637212", Status: UnbanRequestStatusPending},
+			``,
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetUnbanRequestsParams{BroadcasterID: "274637212", ModeratorID: "274637212"},
+			``,
+			"error: status must be provided",
+		},
+		{
+			http.StatusUnauthorized,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetUnbanRequestsParams{BroadcasterID: "274637212", ModeratorID: "274637212", Status: UnbanRequestStatusPending},
+			`{"error":"Unauthorized","status":401,"message":"Missing scope: moderator:read:unban_requests"}`,
+			"",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&GetUnbanRequestsParams{BroadcasterID: "274637212", ModeratorID: "274637212", Status: UnbanRequestStatusPending},
+			`{"data":[{"id":"92af127c-7326-4483-a52b-b0da0be61c01","broadcaster_name":"torpedo09","broadcaster_login":"torpedo09","broadcaster_id":"274637212","moderator_id":"141981764","moderator_login":"twitchdev","moderator_name":"TwitchDev","user_id":"955811734","user_login":"kite","user_name":"kite","text":"Please unban me from the channel?","status":"pending","created_at":"2022-08-07T02:07:55Z","resolved_at":null,"resolution_text":null}],"pagination":{"cursor":"eyJiIjpudWxsLCJhIjp7IkN1cnNvciI6IjEwMDQ3MzA2NDo4NjQwNjU3MToxSVZCVDFKMnY5M1BTOXh3d1E0dUdXMkJOMFcifX0"}}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetUnbanRequests(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized {
+			if resp.ErrorMessage != "Missing scope: moderator:read:unban_requests" {
+				t.Errorf("expected error message to be %s, got %s", "Missing scope: moderator:read:unban_requests", resp.ErrorMessage)
+			}
+
+			continue
+		}
+
+		if len(resp.Data.UnbanRequests) != 1 {
+			t.Errorf("expected number of unban requests to be %d, got %d", 1, len(resp.Data.UnbanRequests))
+			continue
+		}
+
+		unbanRequest := resp.Data.UnbanRequests[0]
+		if unbanRequest.Status != UnbanRequestStatusPending {
+			t.Errorf("expected status to be %s, got %s", UnbanRequestStatusPending, unbanRequest.Status)
+		}
+
+		if !unbanRequest.ResolvedAt.IsZero() {
+			t.Errorf("expected resolved_at to be zero, got %s", unbanRequest.ResolvedAt)
+		}
+
+		if resp.Data.Pagination.Cursor == "" {
+			t.Errorf("expected pagination cursor not to be empty")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetUnbanRequests(&GetUnbanRequestsParams{BroadcasterID: "274637212", ModeratorID: "274637212", Status: UnbanRequestStatusPending})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestResolveUnbanRequest(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *ResolveUnbanRequestParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&ResolveUnbanRequestParams{BroadcasterID: "274637212", ModeratorID: "274637212", Status: UnbanRequestStatusApproved},
+			``,
+			"error: unban request id must be provided",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&ResolveUnbanRequestParams{BroadcasterID: "274637212", ModeratorID: "274637212", UnbanRequestID: "92af127c-7326-4483-a52b-b0da0be61c01", Status: UnbanRequestStatusPending},
+			``,
+			"error: status must be either approved or denied",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "moderator-access-token"},
+			&ResolveUnbanRequestParams{BroadcasterID: "274637212", ModeratorID: "274637212", UnbanRequestID: "92af127c-7326-4483-a52b-b0da0be61c01", Status: UnbanRequestStatusApproved, ResolutionText: "Sure thing"},
+			`{"data":[{"id":"92af127c-7326-4483-a52b-b0da0be61c01","broadcaster_name":"torpedo09","broadcaster_login":"torpedo09","broadcaster_id":"274637212","moderator_id":"141981764","moderator_login":"twitchdev","moderator_name":"TwitchDev","user_id":"955811734","user_login":"kite","user_name":"kite","text":"Please unban me from the channel?","status":"approved","created_at":"2022-08-07T02:07:55Z","resolved_at":"2022-08-09T02:07:55Z","resolution_text":"Sure thing"}]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch {
+				t.Errorf("expected method to be %s, got %s", http.MethodPatch, r.Method)
+			}
+
+			if r.URL.Query().Get("status") != testCase.params.Status {
+				t.Errorf("expected status query to be %s, got %s", testCase.params.Status, r.URL.Query().Get("status"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.ResolveUnbanRequest(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if len(resp.Data.UnbanRequests) != 1 {
+			t.Errorf("expected number of unban requests to be %d, got %d", 1, len(resp.Data.UnbanRequests))
+			continue
+		}
+
+		if resp.Data.UnbanRequests[0].ResolutionText != testCase.params.ResolutionText {
+			t.Errorf("expected resolution text to be %s, got %s", testCase.params.ResolutionText, resp.Data.UnbanRequests[0].ResolutionText)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.ResolveUnbanRequest(&ResolveUnbanRequestParams{BroadcasterID: "274637212", ModeratorID: "274637212", UnbanRequestID: "92af127c-7326-4483-a52b-b0da0be61c01", Status: UnbanRequestStatusDenied})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
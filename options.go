@@ -0,0 +1,187 @@
+package helix
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option sets a field of the Options of a client created with
+// NewClientWithOptions.
+type Option func(opts *Options)
+
+// NewClientWithOptions creates a client from functional options, as an
+// alternative to passing an Options struct to NewClient:
+//
+//	client, err := helix.NewClientWithOptions(
+//		helix.WithClientID("your-client-id"),
+//		helix.WithAppAccessToken("your-app-access-token"),
+//		helix.WithLogger(logger),
+//	)
+//
+// Options are applied in order, a later option overrides an earlier one.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return NewClient(options)
+}
+
+// WithClientID sets Options.ClientID, which is required.
+func WithClientID(clientID string) Option {
+	return func(opts *Options) {
+		opts.ClientID = clientID
+	}
+}
+
+// WithClientSecret sets Options.ClientSecret.
+func WithClientSecret(clientSecret string) Option {
+	return func(opts *Options) {
+		opts.ClientSecret = clientSecret
+	}
+}
+
+// WithAppAccessToken sets Options.AppAccessToken.
+func WithAppAccessToken(token string) Option {
+	return func(opts *Options) {
+		opts.AppAccessToken = token
+	}
+}
+
+// WithUserAccessToken sets Options.UserAccessToken and, if given, the
+// scopes it was granted, see Options.UserAccessTokenScopes.
+func WithUserAccessToken(token string, scopes ...string) Option {
+	return func(opts *Options) {
+		opts.UserAccessToken = token
+		if len(scopes) > 0 {
+			opts.UserAccessTokenScopes = scopes
+		}
+	}
+}
+
+// WithRefreshToken sets Options.RefreshToken.
+func WithRefreshToken(token string) Option {
+	return func(opts *Options) {
+		opts.RefreshToken = token
+	}
+}
+
+// WithUserAgent sets Options.UserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(opts *Options) {
+		opts.UserAgent = userAgent
+	}
+}
+
+// WithRedirectURI sets Options.RedirectURI.
+func WithRedirectURI(redirectURI string) Option {
+	return func(opts *Options) {
+		opts.RedirectURI = redirectURI
+	}
+}
+
+// WithHTTPClient sets Options.HTTPClient.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(opts *Options) {
+		opts.HTTPClient = client
+	}
+}
+
+// WithTransport sets Options.Transport.
+func WithTransport(transport *http.Transport) Option {
+	return func(opts *Options) {
+		opts.Transport = transport
+	}
+}
+
+// WithRateLimiter sets Options.RateLimitFunc.
+func WithRateLimiter(rateLimitFunc RateLimitFunc) Option {
+	return func(opts *Options) {
+		opts.RateLimitFunc = rateLimitFunc
+	}
+}
+
+// WithBaseURLs sets Options.APIBaseURL and Options.AuthBaseURL, e.g. to
+// those of a mock server. An empty URL keeps the default.
+func WithBaseURLs(apiBaseURL, authBaseURL string) Option {
+	return func(opts *Options) {
+		opts.APIBaseURL = apiBaseURL
+		opts.AuthBaseURL = authBaseURL
+	}
+}
+
+// WithExtensionOptions sets Options.ExtensionOpts.
+func WithExtensionOptions(extensionOpts ExtensionOptions) Option {
+	return func(opts *Options) {
+		opts.ExtensionOpts = extensionOpts
+	}
+}
+
+// WithEventSubSecrets sets Options.EventSubSecrets.
+func WithEventSubSecrets(secrets ...string) Option {
+	return func(opts *Options) {
+		opts.EventSubSecrets = secrets
+	}
+}
+
+// WithEventSubCostLimit sets Options.LimitEventSubCost.
+func WithEventSubCostLimit() Option {
+	return func(opts *Options) {
+		opts.LimitEventSubCost = true
+	}
+}
+
+// WithMiddleware appends to Options.Middleware, so it can be given more than
+// once.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(opts *Options) {
+		opts.Middleware = append(opts.Middleware, middleware...)
+	}
+}
+
+// WithLogger sets Options.Logger.
+func WithLogger(logger Logger) Option {
+	return func(opts *Options) {
+		opts.Logger = logger
+	}
+}
+
+// WithCache sets Options.Cache and, if given, Options.CacheTTLs.
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	return func(opts *Options) {
+		opts.Cache = cache
+		if ttls != nil {
+			opts.CacheTTLs = ttls
+		}
+	}
+}
+
+// WithTokenStore sets Options.TokenStore.
+func WithTokenStore(store TokenStore) Option {
+	return func(opts *Options) {
+		opts.TokenStore = store
+	}
+}
+
+// WithClock sets Options.Clock and Options.Sleeper, either may be nil.
+func WithClock(clock Clock, sleeper Sleeper) Option {
+	return func(opts *Options) {
+		opts.Clock = clock
+		opts.Sleeper = sleeper
+	}
+}
+
+// WithSelfIDs sets Options.ResolveSelfIDs.
+func WithSelfIDs() Option {
+	return func(opts *Options) {
+		opts.ResolveSelfIDs = true
+	}
+}
+
+// WithoutCompression sets Options.DisableCompression.
+func WithoutCompression() Option {
+	return func(opts *Options) {
+		opts.DisableCompression = true
+	}
+}
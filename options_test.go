@@ -0,0 +1,85 @@
+package helix
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &mockHTTPClient{newMockHandler(http.StatusOK, `{"data":[]}`, nil)}
+	middleware := func(next RoundTripFunc) RoundTripFunc { return next }
+	clock := newFakeClock()
+	cache := NewLRUCache(10)
+
+	client, err := NewClientWithOptions(
+		WithClientID("my-client-id"),
+		WithClientSecret("my-client-secret"),
+		WithAppAccessToken("my-app-access-token"),
+		WithUserAccessToken("my-user-access-token", "user:read:email"),
+		WithRefreshToken("my-refresh-token"),
+		WithUserAgent("my-user-agent"),
+		WithRedirectURI("http://localhost/callback"),
+		WithHTTPClient(httpClient),
+		WithBaseURLs("http://localhost:8080/mock", ""),
+		WithEventSubSecrets("new-secret", "old-secret"),
+		WithEventSubCostLimit(),
+		WithMiddleware(middleware),
+		WithMiddleware(middleware),
+		WithCache(cache, map[string]time.Duration{"/users": time.Minute}),
+		WithClock(clock, clock),
+		WithSelfIDs(),
+		WithoutCompression(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := client.opts
+	if opts.ClientID != "my-client-id" || opts.ClientSecret != "my-client-secret" {
+		t.Errorf("expected the client id and secret to be set, got %q and %q", opts.ClientID, opts.ClientSecret)
+	}
+
+	if opts.AppAccessToken != "my-app-access-token" || opts.UserAccessToken != "my-user-access-token" || opts.RefreshToken != "my-refresh-token" {
+		t.Errorf("expected the tokens to be set, got %+v", opts)
+	}
+
+	if len(opts.UserAccessTokenScopes) != 1 || opts.UserAccessTokenScopes[0] != "user:read:email" {
+		t.Errorf("expected the scopes of the user access token to be set, got %v", opts.UserAccessTokenScopes)
+	}
+
+	if opts.UserAgent != "my-user-agent" || opts.RedirectURI != "http://localhost/callback" {
+		t.Errorf("expected the user agent and redirect uri to be set, got %q and %q", opts.UserAgent, opts.RedirectURI)
+	}
+
+	if opts.HTTPClient != httpClient {
+		t.Error("expected the http client to be set")
+	}
+
+	if opts.APIBaseURL != "http://localhost:8080/mock" || client.getAuthBaseURL() != AuthBaseURL {
+		t.Errorf("expected the api base url to be set and the auth base url to default, got %q and %q", opts.APIBaseURL, client.getAuthBaseURL())
+	}
+
+	if len(opts.EventSubSecrets) != 2 || !opts.LimitEventSubCost {
+		t.Errorf("expected the eventsub options to be set, got %v and %t", opts.EventSubSecrets, opts.LimitEventSubCost)
+	}
+
+	if len(opts.Middleware) != 2 {
+		t.Errorf("expected both middleware to be kept, got %d", len(opts.Middleware))
+	}
+
+	if opts.Cache != cache || opts.CacheTTLs["/users"] != time.Minute {
+		t.Errorf("expected the cache to be set, got %v", opts.CacheTTLs)
+	}
+
+	if opts.Clock != clock || opts.Sleeper != clock || !opts.DisableCompression || !opts.ResolveSelfIDs {
+		t.Error("expected the clock, compression and self id options to be set")
+	}
+
+	_, err = NewClientWithOptions(WithAppAccessToken("my-app-access-token"))
+	if err == nil || err.Error() != "A client ID was not provided but is required" {
+		t.Errorf("expected the missing client id to be reported, got %v", err)
+	}
+}
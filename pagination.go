@@ -0,0 +1,183 @@
+package helix
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CursorStore keeps the pagination cursors of long-running jobs, so that a
+// Paginator can resume where a previous process stopped. GetCursor returns
+// an empty string if no cursor is stored with the key, and SetCursor with an
+// empty cursor removes it. Implementations must be safe for concurrent use.
+type CursorStore interface {
+	GetCursor(key string) (string, error)
+	SetCursor(key, cursor string) error
+}
+
+// MemoryCursorStore is a CursorStore that keeps cursors for the lifetime of
+// the process.
+type MemoryCursorStore struct {
+	mu      sync.RWMutex
+	cursors map[string]string
+}
+
+// NewMemoryCursorStore returns an empty in-memory cursor store.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: map[string]string{}}
+}
+
+func (s *MemoryCursorStore) GetCursor(key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cursors[key], nil
+}
+
+func (s *MemoryCursorStore) SetCursor(key, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cursor == "" {
+		delete(s.cursors, key)
+		return nil
+	}
+	s.cursors[key] = cursor
+
+	return nil
+}
+
+// FileCursorStore is a CursorStore that keeps cursors in a JSON file, so
+// they survive restarts of the process. The file is replaced atomically on
+// every SetCursor.
+type FileCursorStore struct {
+	file jsonFile[string]
+}
+
+// NewFileCursorStore returns a store that keeps cursors in the file at path.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{file: jsonFile[string]{path: path}}
+}
+
+func (s *FileCursorStore) GetCursor(key string) (string, error) {
+	cursor, _, err := s.file.get(key)
+	return cursor, err
+}
+
+func (s *FileCursorStore) SetCursor(key, cursor string) error {
+	if cursor == "" {
+		return s.file.set(key, nil)
+	}
+
+	return s.file.set(key, &cursor)
+}
+
+// PageFunc fetches the page of a list endpoint that starts at cursor, the
+// first page if cursor is empty. It returns the items of the page and the
+// cursor of the next one, which is empty on the last page.
+type PageFunc[T any] func(cursor string) ([]T, string, error)
+
+// Paginator pages through a list endpoint, one page per call to Next:
+//
+//	for paginator.Next() {
+//		for _, item := range paginator.Page() {
+//			// ...
+//		}
+//	}
+//	if err := paginator.Err(); err != nil {
+//		// handle error
+//	}
+//
+// With a CursorStore, the cursor of the next page is saved under CursorKey
+// once the current page was handled, that is when Next is called again. A
+// Paginator with the same store and key then starts at that page, so a job
+// that was interrupted doesn't start over, though the page it was handling
+// is fetched again. The cursor is removed once the last page was handled.
+type Paginator[T any] struct {
+	fetch PageFunc[T]
+
+	// CursorStore checkpoints the cursor under CursorKey, optional.
+	CursorStore CursorStore
+	CursorKey   string
+
+	page    []T
+	cursor  string
+	started bool
+	done    bool
+	err     error
+}
+
+// NewPaginator returns a paginator that fetches pages with fetch.
+func NewPaginator[T any](fetch PageFunc[T]) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch}
+}
+
+// Next fetches the next page. It returns false after the last page, or when
+// fetching the page or saving the cursor failed, see Err.
+func (p *Paginator[T]) Next() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	if !p.started {
+		p.started = true
+		if p.CursorStore != nil {
+			cursor, err := p.CursorStore.GetCursor(p.CursorKey)
+			if err != nil {
+				p.err = fmt.Errorf("error: could not get cursor %s: %w", p.CursorKey, err)
+				return false
+			}
+			p.cursor = cursor
+		}
+	} else {
+		// The previous page was handled
+		if err := p.checkpoint(p.cursor); err != nil {
+			p.err = err
+			return false
+		}
+
+		if p.cursor == "" {
+			p.done = true
+			p.page = nil
+			return false
+		}
+	}
+
+	page, cursor, err := p.fetch(p.cursor)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.page = page
+	p.cursor = cursor
+
+	return true
+}
+
+// Page returns the items of the page fetched by the last call to Next.
+func (p *Paginator[T]) Page() []T {
+	return p.page
+}
+
+// Cursor returns the cursor of the page after the current one, which is
+// empty on the last page.
+func (p *Paginator[T]) Cursor() string {
+	return p.cursor
+}
+
+// Err returns the error that stopped the paginator, if any.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+func (p *Paginator[T]) checkpoint(cursor string) error {
+	if p.CursorStore == nil {
+		return nil
+	}
+
+	if err := p.CursorStore.SetCursor(p.CursorKey, cursor); err != nil {
+		return fmt.Errorf("error: could not save cursor %s: %w", p.CursorKey, err)
+	}
+
+	return nil
+}
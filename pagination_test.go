@@ -0,0 +1,139 @@
+package helix
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// fakePages serves pages of numbers, page n has cursor "n" and the first
+// page has the empty cursor.
+type fakePages struct {
+	pages   [][]int
+	fetched []string
+	failAt  string // cursor of a page that fails to be fetched
+}
+
+func (f *fakePages) fetch(cursor string) ([]int, string, error) {
+	f.fetched = append(f.fetched, cursor)
+	if f.failAt != "" && cursor == f.failAt {
+		return nil, "", errors.New("error: page unavailable")
+	}
+
+	index := 0
+	if cursor != "" {
+		index = int(cursor[0] - '0')
+	}
+
+	next := ""
+	if index+1 < len(f.pages) {
+		next = string(rune('0' + index + 1))
+	}
+
+	return f.pages[index], next, nil
+}
+
+func TestPaginator(t *testing.T) {
+	t.Parallel()
+
+	pages := &fakePages{pages: [][]int{{1, 2}, {3, 4}, {5}}}
+
+	var items []int
+	paginator := NewPaginator(pages.fetch)
+	for paginator.Next() {
+		items = append(items, paginator.Page()...)
+	}
+
+	if err := paginator.Err(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if len(items) != 5 || items[0] != 1 || items[4] != 5 {
+		t.Errorf("expected items 1 to 5, got %v", items)
+	}
+
+	if paginator.Next() {
+		t.Error("expected a finished paginator to stay finished")
+	}
+
+	if len(pages.fetched) != 3 {
+		t.Errorf("expected 3 pages to be fetched, got %v", pages.fetched)
+	}
+}
+
+func TestPaginatorCursorStore(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]CursorStore{
+		"memory": NewMemoryCursorStore(),
+		"file":   NewFileCursorStore(filepath.Join(t.TempDir(), "cursors.json")),
+	}
+
+	for name, store := range stores {
+		pages := &fakePages{pages: [][]int{{1, 2}, {3, 4}, {5}}, failAt: "2"}
+
+		// The first run fails on the last page
+		var items []int
+		paginator := NewPaginator(pages.fetch)
+		paginator.CursorStore = store
+		paginator.CursorKey = "followers:1337"
+		for paginator.Next() {
+			items = append(items, paginator.Page()...)
+		}
+
+		if err := paginator.Err(); err == nil || err.Error() != "error: page unavailable" {
+			t.Errorf("%s: expected the page error, got %v", name, err)
+		}
+
+		if len(items) != 4 {
+			t.Errorf("%s: expected 4 items before the failure, got %v", name, items)
+		}
+
+		if cursor, err := store.GetCursor("followers:1337"); err != nil || cursor != "2" {
+			t.Errorf("%s: expected the cursor of the failed page to be stored, got %q (%v)", name, cursor, err)
+		}
+
+		// The second run resumes at the failed page
+		pages.failAt = ""
+		pages.fetched = nil
+		items = nil
+		paginator = NewPaginator(pages.fetch)
+		paginator.CursorStore = store
+		paginator.CursorKey = "followers:1337"
+		for paginator.Next() {
+			items = append(items, paginator.Page()...)
+		}
+
+		if err := paginator.Err(); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+
+		if len(pages.fetched) != 1 || pages.fetched[0] != "2" || len(items) != 1 || items[0] != 5 {
+			t.Errorf("%s: expected only the last page to be fetched, got %v with %v", name, pages.fetched, items)
+		}
+
+		if cursor, err := store.GetCursor("followers:1337"); err != nil || cursor != "" {
+			t.Errorf("%s: expected the cursor to be removed, got %q (%v)", name, cursor, err)
+		}
+	}
+}
+
+func TestFileCursorStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cursors.json")
+
+	if err := NewFileCursorStore(path).SetCursor("a", "eyJiIjpudWxsfQ"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cursors are read by another store of the same file
+	store := NewFileCursorStore(path)
+	if cursor, err := store.GetCursor("a"); err != nil || cursor != "eyJiIjpudWxsfQ" {
+		t.Errorf("expected the stored cursor, got %q (%v)", cursor, err)
+	}
+
+	if cursor, err := store.GetCursor("b"); err != nil || cursor != "" {
+		t.Errorf("expected no cursor, got %q (%v)", cursor, err)
+	}
+}
@@ -1,5 +1,15 @@
 package helix
 
+// Statuses of a poll. EndPoll only accepts PollStatusTerminated and PollStatusArchived.
+const (
+	PollStatusActive     = "ACTIVE"
+	PollStatusCompleted  = "COMPLETED"
+	PollStatusTerminated = "TERMINATED"
+	PollStatusArchived   = "ARCHIVED"
+	PollStatusModerated  = "MODERATED"
+	PollStatusInvalid    = "INVALID"
+)
+
 type Poll struct {
 	ID                         string       `json:"id"`
 	BroadcasterID              string       `json:"broadcaster_id"`
@@ -17,12 +27,22 @@ type Poll struct {
 	EndedAt                    Time         `json:"ended_at"`
 }
 
+// TotalVotes returns the number of votes cast across all of the poll's choices.
+func (p *Poll) TotalVotes() int {
+	total := 0
+	for _, choice := range p.Choices {
+		total += choice.Votes
+	}
+
+	return total
+}
+
 type PollChoice struct {
 	ID                 string `json:"id"`
 	Title              string `json:"title"`
 	BitsVotes          int    `json:"bits_votes"`
 	ChannelPointsVotes int    `json:"channel_points_votes"`
-	Votes              int    `json:"votes"`
+	Votes              int    `json:"votes"` // Includes votes cast with channel points
 }
 
 type ManyPolls struct {
@@ -30,10 +50,7 @@ type ManyPolls struct {
 	Pagination Pagination `json:"pagination"`
 }
 
-type PollsResponse struct {
-	ResponseCommon
-	Data ManyPolls
-}
+type PollsResponse = ResponseOf[ManyPolls]
 
 type PollsParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
@@ -42,24 +59,12 @@ type PollsParams struct {
 	First         string `query:"first"`
 }
 
-type GetPollsResponse struct {
-	ResponseCommon
-	Data ManyPolls
-}
+type GetPollsResponse = ResponseOf[ManyPolls]
 
+// GetPolls gets a list of polls that the broadcaster created. Polls are available for 90 days.
 // Required scope: channel:read:polls
 func (c *Client) GetPolls(params *PollsParams) (*PollsResponse, error) {
-	resp, err := c.get("/polls", &ManyPolls{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	polls := &PollsResponse{}
-	resp.HydrateResponseCommon(&polls.ResponseCommon)
-	polls.Data.Polls = resp.Data.(*ManyPolls).Polls
-	polls.Data.Pagination = resp.Data.(*ManyPolls).Pagination
-
-	return polls, nil
+	return getResponse[ManyPolls](c, "/polls", params)
 }
 
 type CreatePollParams struct {
@@ -77,38 +82,20 @@ type PollChoiceParam struct {
 	Title string `json:"title"` // Maximum: 25 characters.
 }
 
+// CreatePoll creates a poll that viewers in the broadcaster’s channel can vote on.
 // Required scope: channel:manage:polls
 func (c *Client) CreatePoll(params *CreatePollParams) (*PollsResponse, error) {
-	resp, err := c.postAsJSON("/polls", &ManyPolls{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	polls := &PollsResponse{}
-	resp.HydrateResponseCommon(&polls.ResponseCommon)
-	polls.Data.Polls = resp.Data.(*ManyPolls).Polls
-	polls.Data.Pagination = resp.Data.(*ManyPolls).Pagination
-
-	return polls, nil
+	return postAsJSONResponse[ManyPolls](c, "/polls", params)
 }
 
 type EndPollParams struct {
 	BroadcasterID string `json:"broadcaster_id"`
 	ID            string `json:"id"`
-	Status        string `json:"status"`
+	Status        string `json:"status"` // Must be "TERMINATED" or "ARCHIVED"
 }
 
+// EndPoll ends an active poll. A terminated poll stays visible in the channel, an archived one doesn't.
 // Required scope: channel:manage:polls
 func (c *Client) EndPoll(params *EndPollParams) (*PollsResponse, error) {
-	resp, err := c.patchAsJSON("/polls", &ManyPolls{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	polls := &PollsResponse{}
-	resp.HydrateResponseCommon(&polls.ResponseCommon)
-	polls.Data.Polls = resp.Data.(*ManyPolls).Polls
-	polls.Data.Pagination = resp.Data.(*ManyPolls).Pagination
-
-	return polls, nil
+	return patchAsJSONResponse[ManyPolls](c, "/polls", params)
 }
@@ -1,5 +1,20 @@
 package helix
 
+// Statuses of a prediction. EndPrediction accepts PredictionStatusResolved,
+// PredictionStatusCanceled and PredictionStatusLocked.
+const (
+	PredictionStatusActive   = "ACTIVE"
+	PredictionStatusLocked   = "LOCKED"
+	PredictionStatusResolved = "RESOLVED"
+	PredictionStatusCanceled = "CANCELED"
+)
+
+// Colors of a prediction outcome
+const (
+	PredictionOutcomeColorBlue = "BLUE"
+	PredictionOutcomeColorPink = "PINK"
+)
+
 // Prediction ... same struct as Poll
 type Prediction struct {
 	ID                   string     `json:"id"`
@@ -38,10 +53,7 @@ type ManyPredictions struct {
 	Pagination  Pagination   `json:"pagination"`
 }
 
-type PredictionsResponse struct {
-	ResponseCommon
-	Data ManyPredictions
-}
+type PredictionsResponse = ResponseOf[ManyPredictions]
 
 type PredictionsParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
@@ -50,30 +62,18 @@ type PredictionsParams struct {
 	First         string `query:"first"`
 }
 
-type GetPredictionsResponse struct {
-	ResponseCommon
-	Data ManyPredictions
-}
+type GetPredictionsResponse = ResponseOf[ManyPredictions]
 
+// GetPredictions gets a list of Channel Points Predictions that the broadcaster created.
 // Required scope: channel:read:predictions
 func (c *Client) GetPredictions(params *PredictionsParams) (*PredictionsResponse, error) {
-	resp, err := c.get("/predictions", &ManyPredictions{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	predictions := &PredictionsResponse{}
-	resp.HydrateResponseCommon(&predictions.ResponseCommon)
-	predictions.Data.Predictions = resp.Data.(*ManyPredictions).Predictions
-	predictions.Data.Pagination = resp.Data.(*ManyPredictions).Pagination
-
-	return predictions, nil
+	return getResponse[ManyPredictions](c, "/predictions", params)
 }
 
 type CreatePredictionParams struct {
 	BroadcasterID    string                  `json:"broadcaster_id"`
 	Title            string                  `json:"title"`             // Maximum: 45 characters.
-	Outcomes         []PredictionChoiceParam `json:"outcomes"`          // 2 choices mandatory
+	Outcomes         []PredictionChoiceParam `json:"outcomes"`          // Minimum: 2 choices. Maximum: 10 choices.
 	PredictionWindow int                     `json:"prediction_window"` // Minimum: 1. Maximum: 1800.
 }
 
@@ -81,39 +81,27 @@ type PredictionChoiceParam struct {
 	Title string `json:"title"` // Maximum: 25 characters.
 }
 
+// CreatePrediction creates a Channel Points Prediction.
 // Required scope: channel:manage:predictions
 func (c *Client) CreatePrediction(params *CreatePredictionParams) (*PredictionsResponse, error) {
-	resp, err := c.postAsJSON("/predictions", &ManyPredictions{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	predictions := &PredictionsResponse{}
-	resp.HydrateResponseCommon(&predictions.ResponseCommon)
-	predictions.Data.Predictions = resp.Data.(*ManyPredictions).Predictions
-	predictions.Data.Pagination = resp.Data.(*ManyPredictions).Pagination
-
-	return predictions, nil
+	return postAsJSONResponse[ManyPredictions](c, "/predictions", params)
 }
 
 type EndPredictionParams struct {
 	BroadcasterID    string `json:"broadcaster_id"`
 	ID               string `json:"id"`
-	Status           string `json:"status"`
-	WinningOutcomeID string `json:"winning_outcome_id"`
+	Status           string `json:"status"`                       // Must be "RESOLVED", "CANCELED" or "LOCKED"
+	WinningOutcomeID string `json:"winning_outcome_id,omitempty"` // Required if status is "RESOLVED"
 }
 
+// EndPrediction locks, resolves, or cancels a Channel Points Prediction.
+// Locking stops viewers from making predictions; resolving pays out the points to the
+// viewers who picked the winning outcome; canceling refunds the points to all participants.
 // Required scope: channel:manage:predictions
 func (c *Client) EndPrediction(params *EndPredictionParams) (*PredictionsResponse, error) {
-	resp, err := c.patchAsJSON("/predictions", &ManyPredictions{}, params)
-	if err != nil {
-		return nil, err
+	if params.Status == PredictionStatusResolved && params.WinningOutcomeID == "" {
+		return nil, &ValidationError{Field: "winning_outcome_id", Message: "winning outcome id must be provided when resolving a prediction"}
 	}
 
-	predictions := &PredictionsResponse{}
-	resp.HydrateResponseCommon(&predictions.ResponseCommon)
-	predictions.Data.Predictions = resp.Data.(*ManyPredictions).Predictions
-	predictions.Data.Pagination = resp.Data.(*ManyPredictions).Pagination
-
-	return predictions, nil
+	return patchAsJSONResponse[ManyPredictions](c, "/predictions", params)
 }
@@ -185,7 +185,7 @@ func TestEndPrediction(t *testing.T) {
 			&EndPredictionParams{
 				BroadcasterID:    "145328278",
 				ID:               "92bdcb5c-6d83-4c75-95d6-fdd34f128d43",
-				Status:           "RESOLVED",
+				Status:           PredictionStatusResolved,
 				WinningOutcomeID: "6afe5daf-e54c-48d7-9c57-d07e791c496b",
 			},
 			`{"data":[{"id":"92bdcb5c-6d83-4c75-95d6-fdd34f128d43","broadcaster_id":"145328278","broadcaster_name":"Scorfly","broadcaster_login":"scorfly","title":"Test","winning_outcome_id":"6afe5daf-e54c-48d7-9c57-d07e791c496b","outcomes":[{"id":"6afe5daf-e54c-48d7-9c57-d07e791c496b","title":"choix 1","users":0,"channel_points":0,"top_predictors":null,"color":"BLUE"},{"id":"d8ffec60-f87f-44ac-b7b1-c53001bf2e4b","title":"choix 2","users":0,"channel_points":0,"top_predictors":null,"color":"PINK"}],"prediction_window":300,"status":"RESOLVED","created_at":"2021-05-07T22:15:34.457301028Z","ended_at":"2021-05-07T22:18:14.015776526Z","locked_at":null}]}`,
@@ -242,4 +242,12 @@ func TestEndPrediction(t *testing.T) {
 	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
 		t.Error("expected error does match return error")
 	}
+	_, err = c.EndPrediction(&EndPredictionParams{
+		BroadcasterID: "145328278",
+		ID:            "92bdcb5c-6d83-4c75-95d6-fdd34f128d43",
+		Status:        PredictionStatusResolved,
+	})
+	if err == nil || err.Error() != "error: winning outcome id must be provided when resolving a prediction" {
+		t.Errorf("expected error to be \"%s\", got \"%v\"", "error: winning outcome id must be provided when resolving a prediction", err)
+	}
 }
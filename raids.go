@@ -1,10 +1,7 @@
 package helix
 
 // RaidResponse is the response from StartRaid
-type RaidResponse struct {
-	ResponseCommon
-	Data StartRaidResponse
-}
+type RaidResponse = ResponseOf[StartRaidResponse]
 
 // StartRaidParams are the parameters for StartRaid
 type StartRaidParams struct {
@@ -29,18 +26,10 @@ type RaidDetails struct {
 // Required scope: channel:manage:raids
 // Rate limit: 10 requests within a 10-minute window.
 func (c *Client) StartRaid(params *StartRaidParams) (*RaidResponse, error) {
-	resp, err := c.post("/raids", &StartRaidResponse{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	raid := &RaidResponse{}
-	resp.HydrateResponseCommon(&raid.ResponseCommon)
-
-	return raid, nil
+	return postResponse[StartRaidResponse](c, "/raids", params)
 }
 
-// CancelRaidResponse is the response from StartRaid
+// CancelRaidResponse is the response from CancelRaid
 type CancelRaidResponse struct {
 	ResponseCommon
 }
@@ -63,6 +63,19 @@ func TestStartRaid(t *testing.T) {
 
 			continue
 		}
+
+		if len(resp.Data.Data) != 1 {
+			t.Errorf("expected 1 raid, got %d", len(resp.Data.Data))
+			continue
+		}
+
+		if resp.Data.Data[0].CreatedAt.IsZero() {
+			t.Error("expected raid created at not to be zero")
+		}
+
+		if resp.Data.Data[0].IsMature {
+			t.Error("expected raid not to be mature")
+		}
 	}
 
 	// Test with HTTP Failure
@@ -0,0 +1,76 @@
+package helix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RateLimit is the state of a rate limit bucket, as reported by the last
+// response to a request made with the bucket's token. Twitch gives the app
+// access token and every user access token a bucket of their own.
+type RateLimit struct {
+	Limit     int       // The number of points the bucket holds when full
+	Remaining int       // The number of points left in the bucket
+	Reset     time.Time // When the bucket is refilled
+	UpdatedAt time.Time // When the response was received
+}
+
+// RateLimit returns the rate limit of the bucket of the token the client
+// currently sends, e.g. the user access token if one is set. It returns false
+// if no response reported it yet.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rateLimit, ok := c.rateLimits[rateLimitKey(c.bearerToken())]
+
+	return rateLimit, ok
+}
+
+// recordRateLimit stores the rate limit headers of a response under a hash
+// of the token of its request. Responses without them, such as those of the
+// authentication endpoints, are ignored.
+func (c *Client) recordRateLimit(req *http.Request, header http.Header) {
+	if header.Get("Ratelimit-Limit") == "" {
+		return
+	}
+
+	rc := &ResponseCommon{Header: header}
+	rateLimit := RateLimit{
+		Limit:     rc.GetRateLimit(),
+		Remaining: rc.GetRateLimitRemaining(),
+		Reset:     time.Unix(int64(rc.GetRateLimitReset()), 0),
+		UpdatedAt: c.now(),
+	}
+
+	authorization := req.Header.Get("Authorization")
+	token := authorization[strings.Index(authorization, " ")+1:]
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rateLimits == nil {
+		c.rateLimits = map[string]RateLimit{}
+	}
+	c.rateLimits[rateLimitKey(token)] = rateLimit
+}
+
+// forgetToken drops the rate limit and the metadata of a token that was
+// replaced or revoked. c.mu must be held.
+func (c *Client) forgetToken(token string) {
+	if token != "" {
+		delete(c.rateLimits, rateLimitKey(token))
+		delete(c.tokenMetadata, rateLimitKey(token))
+	}
+}
+
+// rateLimitKey hashes a token, so tokens aren't kept in memory after the
+// client stops using them. Token metadata is keyed by it as well.
+func rateLimitKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
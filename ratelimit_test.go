@@ -0,0 +1,123 @@
+package helix
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	reset := clock.Now().Add(time.Minute).Unix()
+
+	c := newMockClient(&Options{
+		ClientID:        "my-client-id",
+		AppAccessToken:  "app-access-token",
+		UserAccessToken: "user-access-token",
+		Clock:           clock,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		remaining := "799"
+		if r.Header.Get("Authorization") == "Bearer app-access-token" {
+			remaining = "750"
+		}
+
+		w.Header().Set("Ratelimit-Limit", "800")
+		w.Header().Set("Ratelimit-Remaining", remaining)
+		w.Header().Set("Ratelimit-Reset", strconv.FormatInt(reset, 10))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	if _, ok := c.RateLimit(); ok {
+		t.Error("expected no rate limit before the first request")
+	}
+
+	if _, err := c.GetUsers(&UsersParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rateLimit, ok := c.RateLimit()
+	if !ok {
+		t.Fatal("expected the rate limit of the user access token")
+	}
+
+	if rateLimit.Limit != 800 || rateLimit.Remaining != 799 || rateLimit.Reset.Unix() != reset || !rateLimit.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("unexpected rate limit %+v", rateLimit)
+	}
+
+	// The app access token has a bucket of its own
+	c.SetUserAccessToken("")
+	if _, ok := c.RateLimit(); ok {
+		t.Error("expected no rate limit for the app access token")
+	}
+
+	if _, err := c.GetUsers(&UsersParams{IDs: []string{"1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	rateLimit, ok = c.RateLimit()
+	if !ok || rateLimit.Remaining != 750 {
+		t.Errorf("expected 750 points remaining for the app access token, got %+v", rateLimit)
+	}
+}
+
+func TestRateLimitForgottenWithToken(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{
+		ClientID:        "my-client-id",
+		AppAccessToken:  "app-access-token",
+		UserAccessToken: "user-access-token",
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == authPaths["revoke"] {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.Header().Set("Ratelimit-Limit", "800")
+		w.Header().Set("Ratelimit-Remaining", "799")
+		w.Header().Set("Ratelimit-Reset", "0")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	if _, err := c.GetUsers(&UsersParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := c.rateLimits["user-access-token"]; ok {
+		t.Error("expected rate limits not to be keyed by the raw token")
+	}
+	if _, ok := c.rateLimits[rateLimitKey("user-access-token")]; !ok {
+		t.Fatal("expected the rate limit of the user access token")
+	}
+
+	// Replacing a token drops its rate limit
+	c.SetUserAccessToken("other-user-access-token")
+	if _, ok := c.rateLimits[rateLimitKey("user-access-token")]; ok {
+		t.Error("expected the rate limit of the replaced token to be dropped")
+	}
+
+	if _, err := c.GetUsers(&UsersParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Revoking a token drops its rate limit
+	if _, err := c.RevokeUserAccessToken("other-user-access-token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.rateLimits[rateLimitKey("other-user-access-token")]; ok {
+		t.Error("expected the rate limit of the revoked token to be dropped")
+	}
+
+	// Requests made with the app access token from now on keep their own bucket
+	if _, err := c.GetUsers(&UsersParams{IDs: []string{"1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.rateLimits) != 1 {
+		t.Errorf("expected only the app access token's rate limit, got %d", len(c.rateLimits))
+	}
+}
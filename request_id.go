@@ -0,0 +1,28 @@
+package helix
+
+import "context"
+
+// RequestIDHeader is the header Twitch identifies every response with, see
+// ResponseCommon.RequestID.
+const RequestIDHeader = "X-Request-Id"
+
+// CorrelationIDHeader is the header requests carry the correlation ID of
+// their context in, see WithCorrelationID.
+const CorrelationIDHeader = "X-Correlation-Id"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context whose requests are sent with the given
+// ID in the CorrelationIDHeader, so that they can be matched with the logs of
+// the caller. Requests use the context of their client, see
+// NewClientWithContext.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID set with WithCorrelationID, or an
+// empty string.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
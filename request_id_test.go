@@ -0,0 +1,72 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestResponseRequestID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		headers   map[string]string
+		requestID string
+	}{
+		{map[string]string{"X-Request-Id": "01HZ5V2Q9K"}, "01HZ5V2Q9K"},
+		{nil, ""},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[]}`, testCase.headers))
+
+		resp, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if resp.RequestID != testCase.requestID {
+			t.Errorf("expected request id to be %q, got %q", testCase.requestID, resp.RequestID)
+		}
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ctx           context.Context
+		correlationID string
+	}{
+		{WithCorrelationID(context.Background(), "ticket-1234"), "ticket-1234"},
+		{context.Background(), ""},
+	}
+
+	for _, testCase := range testCases {
+		var header http.Header
+		c, err := NewClientWithContext(testCase.ctx, &Options{
+			ClientID: "my-client-id",
+			HTTPClient: &mockHTTPClient{func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header
+			}},
+		})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if _, err := c.GetUsers(&UsersParams{Logins: []string{"summit1g"}}); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if CorrelationID(testCase.ctx) != testCase.correlationID {
+			t.Errorf("expected correlation id of context to be %q, got %q", testCase.correlationID, CorrelationID(testCase.ctx))
+		}
+
+		if header.Get(CorrelationIDHeader) != testCase.correlationID {
+			t.Errorf("expected %s header to be %q, got %q", CorrelationIDHeader, testCase.correlationID, header.Get(CorrelationIDHeader))
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package helix
 
+import "encoding/json"
+
 // GetScheduleParams are the parameters for GetSchedule
 type GetScheduleParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
@@ -56,6 +58,7 @@ type GetSchedulePagination struct {
 
 // Gets the broadcaster’s streaming schedule.
 // You can get the entire schedule or specific segments of the schedule
+// Requires an app access token or user access token
 func (c *Client) GetSchedule(params *GetScheduleParams) (*GetScheduleResponse, error) {
 	resp, err := c.get("/schedule", &GetScheduleData{}, params)
 	if err != nil {
@@ -71,11 +74,11 @@ func (c *Client) GetSchedule(params *GetScheduleParams) (*GetScheduleResponse, e
 }
 
 type UpdateScheduleParams struct {
-	BroadcasterID     string `json:"broadcaster_id"`
-	IsVacationEnabled bool   `json:"is_vacation_enabled"`
-	VacationStartTime Time   `json:"vacation_start_time"`
-	VacationEndTime   Time   `json:"vacation_end_time"`
-	Timezone          string `json:"timezone"`
+	BroadcasterID     string `query:"broadcaster_id"`
+	IsVacationEnabled bool   `query:"is_vacation_enabled"`
+	VacationStartTime Time   `query:"vacation_start_time"` // Required if IsVacationEnabled is true
+	VacationEndTime   Time   `query:"vacation_end_time"`   // Required if IsVacationEnabled is true
+	Timezone          string `query:"timezone"`            // IANA time zone, required if IsVacationEnabled is true
 }
 
 type UpdateScheduleResponse struct {
@@ -83,8 +86,9 @@ type UpdateScheduleResponse struct {
 }
 
 // Updates the broadcaster’s schedule settings, such as scheduling a vacation
+// Required scope: channel:manage:schedule
 func (c *Client) UpdateSchedule(params *UpdateScheduleParams) (*UpdateScheduleResponse, error) {
-	resp, err := c.get("/schedule/settings", nil, params)
+	resp, err := c.patch("/schedule/settings", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -96,13 +100,13 @@ func (c *Client) UpdateSchedule(params *UpdateScheduleParams) (*UpdateScheduleRe
 }
 
 type CreateScheduleSegmentParams struct {
-	BroadcasterID string `json:"broadcaster_id"`
+	BroadcasterID string `query:"broadcaster_id"`
 	StartTime     Time   `json:"start_time"`
-	Timezone      string `json:"timezone"`
-	Duration      string `json:"duration"`
-	IsRecurring   bool   `json:"is_recurring"`
-	CategoryID    string `json:"category_id"`
-	Title         string `json:"title"`
+	Timezone      string `json:"timezone"`              // IANA time zone, e.g. America/New_York
+	Duration      string `json:"duration"`              // In minutes, between 30 and 1380
+	IsRecurring   bool   `json:"is_recurring"`          // Recurring segments repeat weekly
+	CategoryID    string `json:"category_id,omitempty"` // optional
+	Title         string `json:"title,omitempty"`       // optional, max 140 chars
 }
 
 type CreateScheduleSegmentResponse struct {
@@ -115,9 +119,10 @@ type CreateScheduleSegmentData struct {
 	Schedule ScheduleData `json:"data"`
 }
 
-// Updates the broadcaster’s schedule settings, such as scheduling a vacation
+// Adds a single or recurring broadcast to the broadcaster’s streaming schedule
+// Required scope: channel:manage:schedule
 func (c *Client) CreateScheduleSegment(params *CreateScheduleSegmentParams) (*CreateScheduleSegmentResponse, error) {
-	resp, err := c.post("/schedule/segment", &CreateScheduleSegmentData{}, params)
+	resp, err := c.postAsJSON("/schedule/segment", &CreateScheduleSegmentData{}, params)
 	if err != nil {
 		return nil, err
 	}
@@ -129,15 +134,31 @@ func (c *Client) CreateScheduleSegment(params *CreateScheduleSegmentParams) (*Cr
 	return schedule, nil
 }
 
+// UpdateScheduleSegmentParams only sends the fields that are set, all others are left unchanged.
 type UpdateScheduleSegmentParams struct {
-	BroadcasterID string `json:"broadcaster_id"`
-	ID            string `json:"id"`
+	BroadcasterID string `query:"broadcaster_id"`
+	ID            string `query:"id"`
 	StartTime     Time   `json:"start_time"`
-	Duration      string `json:"duration"`
-	CategoryID    string `json:"category_id"`
-	Title         string `json:"title"`
-	IsCanceled    bool   `json:"is_canceled"`
-	Timezone      string `json:"timezone"`
+	Duration      string `json:"duration,omitempty"`
+	CategoryID    string `json:"category_id,omitempty"`
+	Title         string `json:"title,omitempty"`
+	IsCanceled    bool   `json:"is_canceled,omitempty"`
+	Timezone      string `json:"timezone,omitempty"`
+}
+
+// MarshalJSON leaves start_time out of the request body when StartTime is not set.
+func (p UpdateScheduleSegmentParams) MarshalJSON() ([]byte, error) {
+	type params UpdateScheduleSegmentParams
+
+	var startTime *Time
+	if !p.StartTime.IsZero() {
+		startTime = &p.StartTime
+	}
+
+	return json.Marshal(&struct {
+		params
+		StartTime *Time `json:"start_time,omitempty"`
+	}{params(p), startTime})
 }
 
 type UpdateScheduleSegmentResponse struct {
@@ -150,7 +171,8 @@ type UpdateScheduleSegmentData struct {
 	Schedule ScheduleData `json:"data"`
 }
 
-// Updates the broadcaster’s schedule settings, such as scheduling a vacation
+// Updates a scheduled broadcast segment, or cancels a single occurrence of it
+// Required scope: channel:manage:schedule
 func (c *Client) UpdateScheduleSegment(params *UpdateScheduleSegmentParams) (*UpdateScheduleSegmentResponse, error) {
 	resp, err := c.patchAsJSON("/schedule/segment", &UpdateScheduleSegmentData{}, params)
 	if err != nil {
@@ -165,8 +187,8 @@ func (c *Client) UpdateScheduleSegment(params *UpdateScheduleSegmentParams) (*Up
 }
 
 type DeleteScheduleSegmentParams struct {
-	BroadcasterID string `json:"broadcaster_id"`
-	ID            string `json:"id"`
+	BroadcasterID string `query:"broadcaster_id"`
+	ID            string `query:"id"`
 }
 
 type DeleteScheduleSegmentResponse struct {
@@ -174,6 +196,7 @@ type DeleteScheduleSegmentResponse struct {
 }
 
 // Removes a broadcast segment from the broadcaster’s streaming schedule
+// Required scope: channel:manage:schedule
 func (c *Client) DeleteScheduleSegment(params *DeleteScheduleSegmentParams) (*DeleteScheduleSegmentResponse, error) {
 	resp, err := c.delete("/schedule/segment", nil, params)
 	if err != nil {
@@ -185,3 +208,31 @@ func (c *Client) DeleteScheduleSegment(params *DeleteScheduleSegmentParams) (*De
 
 	return schedule, nil
 }
+
+type GetChanneliCalendarParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+}
+
+type GetChanneliCalendarResponse struct {
+	ResponseCommon
+
+	// The raw iCalendar (RFC 5545) data of the broadcaster's schedule
+	Data []byte
+}
+
+// Gets the broadcaster’s streaming schedule as an iCalendar.
+// Doesn't require authentication.
+func (c *Client) GetChanneliCalendar(params *GetChanneliCalendarParams) (*GetChanneliCalendarResponse, error) {
+	var calendar []byte
+
+	resp, err := c.get("/schedule/icalendar", &calendar, params)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &GetChanneliCalendarResponse{}
+	resp.HydrateResponseCommon(&schedule.ResponseCommon)
+	schedule.Data = *resp.Data.(*[]byte)
+
+	return schedule, nil
+}
@@ -2,6 +2,7 @@ package helix
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -108,7 +109,22 @@ func TestUpdateSchedule(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, ``, nil))
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch {
+				t.Errorf("expected method to be %s, got %s", http.MethodPatch, r.Method)
+			}
+
+			query := r.URL.Query()
+			if query.Get("broadcaster_id") != testCase.params.BroadcasterID {
+				t.Errorf("expected broadcaster_id query param to be %s, got %s", testCase.params.BroadcasterID, query.Get("broadcaster_id"))
+			}
+
+			if query.Get("timezone") != testCase.params.Timezone {
+				t.Errorf("expected timezone query param to be %s, got %s", testCase.params.Timezone, query.Get("timezone"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+		})
 
 		resp, err := c.UpdateSchedule(testCase.params)
 		if err != nil {
@@ -271,7 +287,27 @@ func TestUpdateScheduleSegment(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("id") != testCase.params.ID {
+				t.Errorf("expected id query param to be %s, got %s", testCase.params.ID, r.URL.Query().Get("id"))
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+
+			if _, ok := body["start_time"]; ok && testCase.params.StartTime.IsZero() {
+				t.Error("expected start_time not to be sent when unset")
+			}
+
+			if body["duration"] != testCase.params.Duration {
+				t.Errorf("expected duration to be %s, got %v", testCase.params.Duration, body["duration"])
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
 
 		resp, err := c.UpdateScheduleSegment(testCase.params)
 		if err != nil {
@@ -335,7 +371,13 @@ func TestDeleteScheduleSegment(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, ``, nil))
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("id") != testCase.params.ID {
+				t.Errorf("expected id query param to be %s, got %s", testCase.params.ID, r.URL.Query().Get("id"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+		})
 
 		resp, err := c.DeleteScheduleSegment(testCase.params)
 		if err != nil {
@@ -368,3 +410,74 @@ func TestDeleteScheduleSegment(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestGetChanneliCalendar(t *testing.T) {
+	t.Parallel()
+
+	calendar := "BEGIN:VCALENDAR\r\nPRODID:-//twitch.tv//StreamSchedule//1.0\r\nVERSION:2.0\r\nCALSCALE:GREGORIAN\r\nREFRESH-INTERVAL;VALUE=DURATION:PT1H\r\nNAME:TwitchDev\r\nEND:VCALENDAR\r\n"
+
+	testCases := []struct {
+		statusCode int
+		options    *Options
+		params     *GetChanneliCalendarParams
+		respBody   string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetChanneliCalendarParams{BroadcasterID: "141981764"},
+			calendar,
+		},
+		{
+			http.StatusBadRequest,
+			&Options{ClientID: "my-client-id"},
+			&GetChanneliCalendarParams{},
+			`{"error":"Bad Request","status":400,"message":"Missing required parameter \"broadcaster_id\""}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetChanneliCalendar(testCase.params)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		if resp.StatusCode == http.StatusBadRequest {
+			if resp.ErrorMessage != "Missing required parameter \"broadcaster_id\"" {
+				t.Errorf("expected error message to be \"%s\", got \"%s\"", "Missing required parameter \"broadcaster_id\"", resp.ErrorMessage)
+			}
+			continue
+		}
+
+		if string(resp.Data) != calendar {
+			t.Errorf("expected calendar to be %q, got %q", calendar, string(resp.Data))
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetChanneliCalendar(&GetChanneliCalendarParams{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
@@ -0,0 +1,225 @@
+package helix
+
+// OAuth scopes of the Twitch API
+const (
+	ScopeAnalyticsReadExtensions        = "analytics:read:extensions"
+	ScopeAnalyticsReadGames             = "analytics:read:games"
+	ScopeBitsRead                       = "bits:read"
+	ScopeChannelBot                     = "channel:bot"
+	ScopeChannelEditCommercial          = "channel:edit:commercial"
+	ScopeChannelManageAds               = "channel:manage:ads"
+	ScopeChannelManageBroadcast         = "channel:manage:broadcast"
+	ScopeChannelManageClips             = "channel:manage:clips"
+	ScopeChannelManageExtensions        = "channel:manage:extensions"
+	ScopeChannelManageGuestStar         = "channel:manage:guest_star"
+	ScopeChannelManageModerators        = "channel:manage:moderators"
+	ScopeChannelManagePolls             = "channel:manage:polls"
+	ScopeChannelManagePredictions       = "channel:manage:predictions"
+	ScopeChannelManageRaids             = "channel:manage:raids"
+	ScopeChannelManageRedemptions       = "channel:manage:redemptions"
+	ScopeChannelManageSchedule          = "channel:manage:schedule"
+	ScopeChannelManageVideos            = "channel:manage:videos"
+	ScopeChannelManageVIPs              = "channel:manage:vips"
+	ScopeChannelModerate                = "channel:moderate"
+	ScopeChannelReadAds                 = "channel:read:ads"
+	ScopeChannelReadCharity             = "channel:read:charity"
+	ScopeChannelReadEditors             = "channel:read:editors"
+	ScopeChannelReadGoals               = "channel:read:goals"
+	ScopeChannelReadGuestStar           = "channel:read:guest_star"
+	ScopeChannelReadHypeTrain           = "channel:read:hype_train"
+	ScopeChannelReadPolls               = "channel:read:polls"
+	ScopeChannelReadPredictions         = "channel:read:predictions"
+	ScopeChannelReadRedemptions         = "channel:read:redemptions"
+	ScopeChannelReadStreamKey           = "channel:read:stream_key"
+	ScopeChannelReadSubscriptions       = "channel:read:subscriptions"
+	ScopeChannelReadVIPs                = "channel:read:vips"
+	ScopeClipsEdit                      = "clips:edit"
+	ScopeEditorManageClips              = "editor:manage:clips"
+	ScopeModerationRead                 = "moderation:read"
+	ScopeModeratorManageAnnouncements   = "moderator:manage:announcements"
+	ScopeModeratorManageAutoMod         = "moderator:manage:automod"
+	ScopeModeratorManageAutoModSettings = "moderator:manage:automod_settings"
+	ScopeModeratorManageBannedUsers     = "moderator:manage:banned_users"
+	ScopeModeratorManageBlockedTerms    = "moderator:manage:blocked_terms"
+	ScopeModeratorManageChatMessages    = "moderator:manage:chat_messages"
+	ScopeModeratorManageChatSettings    = "moderator:manage:chat_settings"
+	ScopeModeratorManageGuestStar       = "moderator:manage:guest_star"
+	ScopeModeratorManageShieldMode      = "moderator:manage:shield_mode"
+	ScopeModeratorManageShoutouts       = "moderator:manage:shoutouts"
+	ScopeModeratorManageUnbanRequests   = "moderator:manage:unban_requests"
+	ScopeModeratorManageWarnings        = "moderator:manage:warnings"
+	ScopeModeratorReadAutoModSettings   = "moderator:read:automod_settings"
+	ScopeModeratorReadBannedUsers       = "moderator:read:banned_users"
+	ScopeModeratorReadBlockedTerms      = "moderator:read:blocked_terms"
+	ScopeModeratorReadChatMessages      = "moderator:read:chat_messages"
+	ScopeModeratorReadChatSettings      = "moderator:read:chat_settings"
+	ScopeModeratorReadChatters          = "moderator:read:chatters"
+	ScopeModeratorReadFollowers         = "moderator:read:followers"
+	ScopeModeratorReadGuestStar         = "moderator:read:guest_star"
+	ScopeModeratorReadModerators        = "moderator:read:moderators"
+	ScopeModeratorReadShieldMode        = "moderator:read:shield_mode"
+	ScopeModeratorReadShoutouts         = "moderator:read:shoutouts"
+	ScopeModeratorReadSuspiciousUsers   = "moderator:read:suspicious_users"
+	ScopeModeratorReadUnbanRequests     = "moderator:read:unban_requests"
+	ScopeModeratorReadVIPs              = "moderator:read:vips"
+	ScopeModeratorReadWarnings          = "moderator:read:warnings"
+	ScopeUserBot                        = "user:bot"
+	ScopeUserEdit                       = "user:edit"
+	ScopeUserEditBroadcast              = "user:edit:broadcast"
+	ScopeUserManageBlockedUsers         = "user:manage:blocked_users"
+	ScopeUserManageChatColor            = "user:manage:chat_color"
+	ScopeUserManageWhispers             = "user:manage:whispers"
+	ScopeUserReadBlockedUsers           = "user:read:blocked_users"
+	ScopeUserReadBroadcast              = "user:read:broadcast"
+	ScopeUserReadChat                   = "user:read:chat"
+	ScopeUserReadEmail                  = "user:read:email"
+	ScopeUserReadEmotes                 = "user:read:emotes"
+	ScopeUserReadFollows                = "user:read:follows"
+	ScopeUserReadModeratedChannels      = "user:read:moderated_channels"
+	ScopeUserReadSubscriptions          = "user:read:subscriptions"
+	ScopeUserReadWhispers               = "user:read:whispers"
+	ScopeUserWriteChat                  = "user:write:chat"
+)
+
+// OAuth scopes of Twitch chat over IRC
+const (
+	ScopeChatEdit     = "chat:edit"
+	ScopeChatRead     = "chat:read"
+	ScopeWhispersRead = "whispers:read"
+)
+
+// ScopeOpenID requests an ID token along with the access token, see IDTokenVerifier
+const ScopeOpenID = "openid"
+
+// endpointScopes maps endpoints, keyed by method and path, to the scopes a
+// user access token needs to call them. Any one of the listed scopes is enough.
+// Endpoints that only use scopes to include optional data are left out.
+var endpointScopes = map[string][]string{
+	"GET /analytics/extensions":                        {ScopeAnalyticsReadExtensions},
+	"GET /analytics/games":                             {ScopeAnalyticsReadGames},
+	"GET /bits/leaderboard":                            {ScopeBitsRead},
+	"GET /channel_points/custom_rewards":               {ScopeChannelReadRedemptions, ScopeChannelManageRedemptions},
+	"POST /channel_points/custom_rewards":              {ScopeChannelManageRedemptions},
+	"PATCH /channel_points/custom_rewards":             {ScopeChannelManageRedemptions},
+	"DELETE /channel_points/custom_rewards":            {ScopeChannelManageRedemptions},
+	"GET /channel_points/custom_rewards/redemptions":   {ScopeChannelReadRedemptions, ScopeChannelManageRedemptions},
+	"PATCH /channel_points/custom_rewards/redemptions": {ScopeChannelManageRedemptions},
+	"PATCH /channels":                                  {ScopeChannelManageBroadcast},
+	"GET /channels/ads":                                {ScopeChannelReadAds},
+	"POST /channels/ads/schedule/snooze":               {ScopeChannelManageAds},
+	"POST /channels/commercial":                        {ScopeChannelEditCommercial},
+	"GET /channels/editors":                            {ScopeChannelReadEditors},
+	"GET /channels/followed":                           {ScopeUserReadFollows},
+	"GET /channels/vips":                               {ScopeChannelReadVIPs, ScopeChannelManageVIPs},
+	"POST /channels/vips":                              {ScopeChannelManageVIPs},
+	"DELETE /channels/vips":                            {ScopeChannelManageVIPs},
+	"GET /charity/campaigns":                           {ScopeChannelReadCharity},
+	"GET /charity/donations":                           {ScopeChannelReadCharity},
+	"POST /chat/announcements":                         {ScopeModeratorManageAnnouncements},
+	"GET /chat/chatters":                               {ScopeModeratorReadChatters},
+	"PUT /chat/color":                                  {ScopeUserManageChatColor},
+	"POST /chat/messages":                              {ScopeUserWriteChat},
+	"PATCH /chat/settings":                             {ScopeModeratorManageChatSettings},
+	"POST /chat/shoutouts":                             {ScopeModeratorManageShoutouts},
+	"POST /clips":                                      {ScopeClipsEdit},
+	"GET /clips/downloads":                             {ScopeEditorManageClips, ScopeChannelManageClips},
+	"GET /goals":                                       {ScopeChannelReadGoals},
+	"GET /guest_star/channel_settings":                 {ScopeChannelReadGuestStar, ScopeChannelManageGuestStar, ScopeModeratorReadGuestStar},
+	"PUT /guest_star/channel_settings":                 {ScopeChannelManageGuestStar},
+	"GET /guest_star/invites":                          {ScopeChannelReadGuestStar, ScopeChannelManageGuestStar, ScopeModeratorReadGuestStar, ScopeModeratorManageGuestStar},
+	"POST /guest_star/invites":                         {ScopeChannelManageGuestStar, ScopeModeratorManageGuestStar},
+	"DELETE /guest_star/invites":                       {ScopeChannelManageGuestStar, ScopeModeratorManageGuestStar},
+	"GET /guest_star/session":                          {ScopeChannelReadGuestStar, ScopeChannelManageGuestStar, ScopeModeratorReadGuestStar, ScopeModeratorManageGuestStar},
+	"POST /guest_star/session":                         {ScopeChannelManageGuestStar},
+	"DELETE /guest_star/session":                       {ScopeChannelManageGuestStar},
+	"POST /guest_star/slot":                            {ScopeChannelManageGuestStar, ScopeModeratorManageGuestStar},
+	"PATCH /guest_star/slot":                           {ScopeChannelManageGuestStar, ScopeModeratorManageGuestStar},
+	"DELETE /guest_star/slot":                          {ScopeChannelManageGuestStar, ScopeModeratorManageGuestStar},
+	"PATCH /guest_star/slot_settings":                  {ScopeChannelManageGuestStar, ScopeModeratorManageGuestStar},
+	"GET /hypetrain/events":                            {ScopeChannelReadHypeTrain},
+	"POST /moderation/automod/message":                 {ScopeModeratorManageAutoMod},
+	"GET /moderation/automod/settings":                 {ScopeModeratorReadAutoModSettings, ScopeModeratorManageAutoModSettings},
+	"PUT /moderation/automod/settings":                 {ScopeModeratorManageAutoModSettings},
+	"GET /moderation/banned":                           {ScopeModerationRead, ScopeModeratorManageBannedUsers},
+	"POST /moderation/bans":                            {ScopeModeratorManageBannedUsers},
+	"DELETE /moderation/bans":                          {ScopeModeratorManageBannedUsers},
+	"GET /moderation/blocked_terms":                    {ScopeModeratorReadBlockedTerms, ScopeModeratorManageBlockedTerms},
+	"POST /moderation/blocked_terms":                   {ScopeModeratorManageBlockedTerms},
+	"DELETE /moderation/blocked_terms":                 {ScopeModeratorManageBlockedTerms},
+	"GET /moderation/channels":                         {ScopeUserReadModeratedChannels},
+	"DELETE /moderation/chat":                          {ScopeModeratorManageChatMessages},
+	"POST /moderation/enforcements/status":             {ScopeModerationRead},
+	"GET /moderation/moderators":                       {ScopeModerationRead, ScopeChannelManageModerators},
+	"POST /moderation/moderators":                      {ScopeChannelManageModerators},
+	"DELETE /moderation/moderators":                    {ScopeChannelManageModerators},
+	"GET /moderation/shield_mode":                      {ScopeModeratorReadShieldMode, ScopeModeratorManageShieldMode},
+	"PUT /moderation/shield_mode":                      {ScopeModeratorManageShieldMode},
+	"GET /moderation/unban_requests":                   {ScopeModeratorReadUnbanRequests, ScopeModeratorManageUnbanRequests},
+	"PATCH /moderation/unban_requests":                 {ScopeModeratorManageUnbanRequests},
+	"POST /moderation/warnings":                        {ScopeModeratorManageWarnings},
+	"GET /polls":                                       {ScopeChannelReadPolls, ScopeChannelManagePolls},
+	"POST /polls":                                      {ScopeChannelManagePolls},
+	"PATCH /polls":                                     {ScopeChannelManagePolls},
+	"GET /predictions":                                 {ScopeChannelReadPredictions, ScopeChannelManagePredictions},
+	"POST /predictions":                                {ScopeChannelManagePredictions},
+	"PATCH /predictions":                               {ScopeChannelManagePredictions},
+	"POST /raids":                                      {ScopeChannelManageRaids},
+	"DELETE /raids":                                    {ScopeChannelManageRaids},
+	"POST /schedule/segment":                           {ScopeChannelManageSchedule},
+	"PATCH /schedule/segment":                          {ScopeChannelManageSchedule},
+	"DELETE /schedule/segment":                         {ScopeChannelManageSchedule},
+	"PATCH /schedule/settings":                         {ScopeChannelManageSchedule},
+	"GET /streams/followed":                            {ScopeUserReadFollows},
+	"GET /streams/key":                                 {ScopeChannelReadStreamKey},
+	"GET /streams/markers":                             {ScopeUserReadBroadcast, ScopeChannelManageBroadcast},
+	"POST /streams/markers":                            {ScopeChannelManageBroadcast},
+	"GET /subscriptions":                               {ScopeChannelReadSubscriptions},
+	"GET /subscriptions/user":                          {ScopeUserReadSubscriptions},
+	"PUT /users":                                       {ScopeUserEdit},
+	"GET /users/blocks":                                {ScopeUserReadBlockedUsers},
+	"PUT /users/blocks":                                {ScopeUserManageBlockedUsers},
+	"DELETE /users/blocks":                             {ScopeUserManageBlockedUsers},
+	"PUT /users/extensions":                            {ScopeUserEditBroadcast},
+	"GET /users/extensions/list":                       {ScopeUserReadBroadcast, ScopeUserEditBroadcast},
+	"DELETE /videos":                                   {ScopeChannelManageVideos},
+	"POST /whispers":                                   {ScopeUserManageWhispers},
+}
+
+// RequiredScopes returns the scopes a user access token needs to call the
+// endpoint with the given method and path, e.g. "GET" and "/subscriptions".
+// Any one of the returned scopes is enough. It returns nil for endpoints that
+// don't require a scope.
+func RequiredScopes(method, path string) []string {
+	return endpointScopes[method+" "+path]
+}
+
+// checkUserAccessTokenScopes returns an *AuthError if the request would be sent
+// with a user access token that is known to lack the scopes the endpoint
+// requires. Nothing is checked while the token's scopes are unknown.
+func (c *Client) checkUserAccessTokenScopes(method, path string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	opts := c.opts
+	if opts.UserAccessToken == "" || opts.UserAccessTokenScopes == nil || opts.ExtensionOpts.SignedJWTToken != "" {
+		return nil
+	}
+
+	required := RequiredScopes(method, path)
+	if len(required) == 0 {
+		return nil
+	}
+
+	for _, scope := range required {
+		for _, granted := range opts.UserAccessTokenScopes {
+			if granted == scope {
+				return nil
+			}
+		}
+	}
+
+	return &AuthError{
+		Endpoint:       method + " " + path,
+		RequiredScopes: required,
+	}
+}
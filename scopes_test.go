@@ -0,0 +1,142 @@
+package helix
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRequiredScopes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		method   string
+		path     string
+		expected []string
+	}{
+		{http.MethodGet, "/subscriptions", []string{ScopeChannelReadSubscriptions}},
+		{http.MethodGet, "/moderation/moderators", []string{ScopeModerationRead, ScopeChannelManageModerators}},
+		{http.MethodPost, "/moderation/moderators", []string{ScopeChannelManageModerators}},
+		{http.MethodGet, "/users", nil},
+	}
+
+	for _, testCase := range testCases {
+		scopes := RequiredScopes(testCase.method, testCase.path)
+		if len(scopes) != len(testCase.expected) {
+			t.Errorf("expected scopes of %s %s to be %v, got %v", testCase.method, testCase.path, testCase.expected, scopes)
+			continue
+		}
+
+		for i := range scopes {
+			if scopes[i] != testCase.expected[i] {
+				t.Errorf("expected scopes of %s %s to be %v, got %v", testCase.method, testCase.path, testCase.expected, scopes)
+			}
+		}
+	}
+}
+
+func TestUserAccessTokenScopesCheck(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		options       *Options
+		validationErr string
+	}{
+		{
+			// Scopes unknown, nothing is checked
+			&Options{ClientID: "my-client-id", UserAccessToken: "user-token"},
+			"",
+		},
+		{
+			&Options{ClientID: "my-client-id", UserAccessToken: "user-token", UserAccessTokenScopes: []string{ScopeChannelReadSubscriptions}},
+			"",
+		},
+		{
+			&Options{ClientID: "my-client-id", UserAccessToken: "user-token", UserAccessTokenScopes: []string{ScopeUserReadEmail}},
+			"error: GET /subscriptions requires a user access token with the channel:read:subscriptions scope",
+		},
+		{
+			// App access tokens aren't checked
+			&Options{ClientID: "my-client-id", AppAccessToken: "app-token", UserAccessTokenScopes: []string{}},
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var requests int32
+		c := newMockClient(testCase.options, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data":[],"pagination":{},"total":0,"points":0}`))
+		})
+
+		_, err := c.GetBroadcasterSubscriptions(&SubscriptionsParams{BroadcasterID: "123"})
+		if err != nil {
+			if err.Error() != testCase.validationErr {
+				t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			}
+
+			if atomic.LoadInt32(&requests) != 0 {
+				t.Errorf("expected no request to be sent, got %d", atomic.LoadInt32(&requests))
+			}
+			continue
+		}
+
+		if testCase.validationErr != "" {
+			t.Errorf("expected error '%v' but got nil", testCase.validationErr)
+		}
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "user-token"}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	// Any one of the listed scopes is enough
+	c.SetUserAccessTokenScopes([]string{ScopeChannelManageModerators})
+	if _, err := c.GetModerators(&GetModeratorsParams{BroadcasterID: "123"}); err != nil {
+		t.Error(err)
+	}
+
+	_, err := c.AddChannelModerator(&AddChannelModeratorParams{BroadcasterID: "123", UserID: "456"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	c.SetUserAccessTokenScopes([]string{ScopeUserReadEmail})
+	_, err = c.GetModerators(&GetModeratorsParams{BroadcasterID: "123"})
+	if err == nil || err.Error() != "error: GET /moderation/moderators requires a user access token with one of the moderation:read, channel:manage:moderators scopes" {
+		t.Errorf("expected missing scope error, got %v", err)
+	}
+
+	// Changing the token forgets the scopes of the previous one
+	c.SetUserAccessToken("other-user-token")
+	if c.GetUserAccessTokenScopes() != nil {
+		t.Errorf("expected scopes to be cleared, got %v", c.GetUserAccessTokenScopes())
+	}
+}
+
+func TestValidateTokenSetsUserAccessTokenScopes(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "user-token"}, newMockHandler(http.StatusOK, `{"client_id":"my-client-id","login":"authduser","scopes":["user:read:email"],"user_id":"12345","expires_in":5243778}`, nil))
+
+	// Validating some other token leaves the client's scopes alone
+	if _, _, err := c.ValidateToken("other-token"); err != nil {
+		t.Error(err)
+	}
+
+	if c.GetUserAccessTokenScopes() != nil {
+		t.Errorf("expected scopes to be unknown, got %v", c.GetUserAccessTokenScopes())
+	}
+
+	if _, _, err := c.ValidateToken(c.GetUserAccessToken()); err != nil {
+		t.Error(err)
+	}
+
+	scopes := c.GetUserAccessTokenScopes()
+	if len(scopes) != 1 || scopes[0] != ScopeUserReadEmail {
+		t.Errorf("expected scopes to be %v, got %v", []string{ScopeUserReadEmail}, scopes)
+	}
+
+	if c.GetUserAccessToken() != "user-token" {
+		t.Errorf("expected user token to be %s, got %s", "user-token", c.GetUserAccessToken())
+	}
+}
@@ -0,0 +1,161 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SelfID can be passed as the broadcaster or moderator id of a request when
+// Options.ResolveSelfIDs is set, to have it replaced with the id of the user
+// the user access token belongs to.
+const SelfID = "self"
+
+// selfIDParams are the parameters whose value may be SelfID.
+var selfIDParams = map[string]bool{
+	"broadcaster_id": true,
+	"moderator_id":   true,
+}
+
+// errSelfIDWithoutUserToken is returned when SelfID is used without a user
+// access token.
+var errSelfIDWithoutUserToken = errors.New("error: a user access token is required to resolve self ids")
+
+// resolveSelfIDs returns the params of a request with the broadcaster and
+// moderator ids set to SelfID replaced by the id of the token's user. The ids
+// are looked for in the params and in the structs nested in them, such as
+// embedded params and JSON bodies. The params passed in are left unchanged, a
+// copy is returned if any id was replaced.
+func (c *Client) resolveSelfIDs(reqData interface{}) (interface{}, error) {
+	if !c.opts.ResolveSelfIDs || reqData == nil {
+		return reqData, nil
+	}
+
+	v := reflect.ValueOf(reqData)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reqData, nil
+	}
+
+	if !hasSelfIDs(v.Elem()) {
+		return reqData, nil
+	}
+
+	userID, err := c.selfUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := reflect.New(v.Elem().Type())
+	resolved.Elem().Set(v.Elem())
+	replaceSelfIDs(resolved.Elem(), userID)
+
+	return resolved.Interface(), nil
+}
+
+// hasSelfIDs reports whether the struct v or a struct nested in it has a
+// broadcaster or moderator id set to SelfID.
+func hasSelfIDs(v reflect.Value) bool {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		if isSelfIDField(field, v.Field(i)) {
+			return true
+		}
+
+		if nested, ok := nestedStruct(v.Field(i)); ok && hasSelfIDs(nested) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replaceSelfIDs sets the broadcaster and moderator ids of the struct v and
+// of the structs nested in it that are set to SelfID to userID. v must be a
+// copy, structs it points to are copied before they are changed.
+func replaceSelfIDs(v reflect.Value, userID string) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if isSelfIDField(field, fieldValue) {
+			fieldValue.SetString(userID)
+			continue
+		}
+
+		nested, ok := nestedStruct(fieldValue)
+		if !ok || !hasSelfIDs(nested) {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Ptr {
+			if !fieldValue.CanSet() {
+				continue
+			}
+			copied := reflect.New(nested.Type())
+			copied.Elem().Set(nested)
+			fieldValue.Set(copied)
+			nested = copied.Elem()
+		}
+		replaceSelfIDs(nested, userID)
+	}
+}
+
+func isSelfIDField(field reflect.StructField, v reflect.Value) bool {
+	if v.Kind() != reflect.String || v.String() != SelfID {
+		return false
+	}
+
+	return selfIDParams[paramName(field.Tag.Get("query"))] || selfIDParams[paramName(field.Tag.Get("json"))]
+}
+
+// nestedStruct returns the struct a field holds or points to.
+func nestedStruct(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+
+	return v, v.Kind() == reflect.Struct
+}
+
+// selfUserID returns the id of the user the user access token belongs to,
+// validating the token the first time it is used.
+func (c *Client) selfUserID() (string, error) {
+	c.mu.RLock()
+	token := c.opts.UserAccessToken
+	userID := c.tokenMetadata[rateLimitKey(token)].userID
+	c.mu.RUnlock()
+
+	if token == "" {
+		return "", errSelfIDWithoutUserToken
+	}
+
+	if userID != "" {
+		return userID, nil
+	}
+
+	isValid, resp, err := c.ValidateToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	if !isValid {
+		return "", fmt.Errorf("error: could not resolve self ids: %w", resp.Err())
+	}
+
+	return resp.Data.UserID, nil
+}
+
+func paramName(tag string) string {
+	return strings.Split(tag, ",")[0]
+}
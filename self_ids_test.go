@@ -0,0 +1,179 @@
+package helix
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolveSelfIDs(t *testing.T) {
+	t.Parallel()
+
+	var validations int32
+	var queries []string
+
+	c := newMockClient(&Options{
+		ClientID:        "my-client-id",
+		UserAccessToken: "my-user-access-token",
+		ResolveSelfIDs:  true,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, authPaths["validate"]) {
+			atomic.AddInt32(&validations, 1)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"client_id":"my-client-id","login":"moderator","scopes":["moderator:read:chatters"],"user_id":"1234","expires_in":3600}`))
+			return
+		}
+
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[],"pagination":{},"total":0}`))
+	})
+
+	params := &GetChatChattersParams{BroadcasterID: "5678", ModeratorID: SelfID}
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetChannelChatChatters(params); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if validations != 1 {
+		t.Errorf("expected the token to be validated once, got %d", validations)
+	}
+
+	for _, query := range queries {
+		if query != "broadcaster_id=5678&moderator_id=1234" {
+			t.Errorf("expected the moderator id to be resolved, got %q", query)
+		}
+	}
+
+	if params.ModeratorID != SelfID {
+		t.Errorf("expected the params to be left unchanged, got moderator id %q", params.ModeratorID)
+	}
+
+	if info := c.TokenInfo(); info.UserID != "1234" {
+		t.Errorf("expected the token's user id to be known, got %q", info.UserID)
+	}
+
+	// JSON bodies are resolved as well
+	type banParams struct {
+		BroadcasterID string `json:"broadcaster_id"`
+		ModeratorID   string `json:"moderator_id"`
+		UserID        string `json:"user_id"`
+	}
+
+	resolved, err := c.resolveSelfIDs(&banParams{SelfID, SelfID, SelfID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := (banParams{"1234", "1234", SelfID}); *resolved.(*banParams) != expected {
+		t.Errorf("expected the broadcaster and moderator ids to be resolved, got %+v", resolved)
+	}
+}
+
+func TestResolveSelfIDsNested(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{
+		ClientID:        "my-client-id",
+		UserAccessToken: "my-user-access-token",
+		ResolveSelfIDs:  true,
+	}, newMockHandler(http.StatusOK, `{"client_id":"my-client-id","login":"moderator","scopes":[],"user_id":"1234","expires_in":3600}`, nil))
+
+	type channelParams struct {
+		BroadcasterID string `query:"broadcaster_id"`
+	}
+
+	type ModeratorBody struct {
+		ModeratorID string `json:"moderator_id"`
+		UserID      string `json:"user_id"`
+	}
+
+	type nestedParams struct {
+		channelParams
+		*ModeratorBody
+		Body    ModeratorBody  `json:"data"`
+		BodyPtr *ModeratorBody `json:"other"`
+	}
+
+	embedded := &ModeratorBody{ModeratorID: SelfID, UserID: SelfID}
+	bodyPtr := &ModeratorBody{ModeratorID: SelfID}
+	params := &nestedParams{
+		channelParams: channelParams{BroadcasterID: SelfID},
+		ModeratorBody: embedded,
+		Body:          ModeratorBody{ModeratorID: SelfID, UserID: "42"},
+		BodyPtr:       bodyPtr,
+	}
+
+	resolved, err := c.resolveSelfIDs(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := resolved.(*nestedParams)
+	if got.BroadcasterID != "1234" {
+		t.Errorf("expected the embedded broadcaster id to be resolved, got %q", got.BroadcasterID)
+	}
+
+	if *got.ModeratorBody != (ModeratorBody{ModeratorID: "1234", UserID: SelfID}) {
+		t.Errorf("expected the embedded moderator id to be resolved, got %+v", *got.ModeratorBody)
+	}
+
+	if got.Body != (ModeratorBody{ModeratorID: "1234", UserID: "42"}) || *got.BodyPtr != (ModeratorBody{ModeratorID: "1234"}) {
+		t.Errorf("expected the body moderator ids to be resolved, got %+v and %+v", got.Body, *got.BodyPtr)
+	}
+
+	// The params and the structs they point to are left unchanged
+	if params.BroadcasterID != SelfID || params.Body.ModeratorID != SelfID || embedded.ModeratorID != SelfID || bodyPtr.ModeratorID != SelfID {
+		t.Errorf("expected the params to be left unchanged, got %+v", params)
+	}
+
+	// Params without self ids are passed as is
+	unchanged := &nestedParams{Body: ModeratorBody{ModeratorID: "5678"}}
+	if resolved, err := c.resolveSelfIDs(unchanged); err != nil || resolved != interface{}(unchanged) {
+		t.Errorf("expected the params to be passed as is, got %+v (%v)", resolved, err)
+	}
+}
+
+func TestResolveSelfIDsErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		options *Options
+		err     string
+	}{
+		{
+			&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token", ResolveSelfIDs: true},
+			"error: a user access token is required to resolve self ids",
+		},
+		{
+			&Options{ClientID: "my-client-id", UserAccessToken: "invalid-access-token", ResolveSelfIDs: true},
+			"error: could not resolve self ids: 401 Unauthorized: invalid access token",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(http.StatusUnauthorized, `{"status":401,"message":"invalid access token"}`, nil))
+
+		_, err := c.GetChannelChatChatters(&GetChatChattersParams{BroadcasterID: "5678", ModeratorID: SelfID})
+		if err == nil || err.Error() != testCase.err {
+			t.Errorf("expected error %q, got %v", testCase.err, err)
+		}
+	}
+
+	// Without the option, self is sent as is
+	var query string
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	if _, err := c.GetChannelChatChatters(&GetChatChattersParams{BroadcasterID: "5678", ModeratorID: SelfID}); err != nil {
+		t.Fatal(err)
+	}
+	if query != "broadcaster_id=5678&moderator_id=self" {
+		t.Errorf("expected self to be sent as is, got %q", query)
+	}
+}
@@ -0,0 +1,39 @@
+package helix
+
+// SharedChatParticipant is a channel taking part in a shared chat session
+type SharedChatParticipant struct {
+	BroadcasterID string `json:"broadcaster_id"`
+}
+
+// SharedChatSession describes an active shared chat session
+type SharedChatSession struct {
+	SessionID         string                  `json:"session_id"`
+	HostBroadcasterID string                  `json:"host_broadcaster_id"`
+	Participants      []SharedChatParticipant `json:"participants"`
+	CreatedAt         Time                    `json:"created_at"`
+	UpdatedAt         Time                    `json:"updated_at"`
+}
+
+// ManySharedChatSessions is the response data in GetSharedChatSessionResponse
+type ManySharedChatSessions struct {
+	Sessions []SharedChatSession `json:"data"`
+}
+
+// GetSharedChatSessionParams are the parameters for GetSharedChatSession
+type GetSharedChatSessionParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+}
+
+// GetSharedChatSessionResponse is the response from GetSharedChatSession
+type GetSharedChatSessionResponse = ResponseOf[ManySharedChatSessions]
+
+// GetSharedChatSession gets the active shared chat session for a channel.
+// The response data is empty if the channel is not in a shared chat session.
+// Requires an app access token or user access token.
+func (c *Client) GetSharedChatSession(params *GetSharedChatSessionParams) (*GetSharedChatSessionResponse, error) {
+	if err := validateRequired("broadcaster_id", "broadcaster id", params.BroadcasterID); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManySharedChatSessions](c, "/shared_chat/session", params)
+}
@@ -0,0 +1,104 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetSharedChatSession(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		params        *GetSharedChatSessionParams
+		respBody      string
+		validationErr string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetSharedChatSessionParams{},
+			``,
+			"error: broadcaster id must be specified",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetSharedChatSessionParams{BroadcasterID: "198704263"},
+			`{"data":[{"session_id":"359bce59-fa4e-41a5-bb0b-4ee8f1bb1c55","host_broadcaster_id":"198704263","participants":[{"broadcaster_id":"198704263"},{"broadcaster_id":"487263401"}],"created_at":"2024-09-29T19:45:37.000Z","updated_at":"2024-09-29T19:45:37.000Z"}]}`,
+			"",
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			&GetSharedChatSessionParams{BroadcasterID: "487263401"},
+			`{"data":[]}`,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetSharedChatSession(testCase.params)
+		if err != nil {
+			if err.Error() == testCase.validationErr {
+				continue
+			}
+			t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.validationErr, err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if testCase.params.BroadcasterID == "487263401" {
+			if len(resp.Data.Sessions) != 0 {
+				t.Errorf("expected no sessions, got %d", len(resp.Data.Sessions))
+			}
+			continue
+		}
+
+		if len(resp.Data.Sessions) != 1 {
+			t.Errorf("expected number of sessions to be %d, got %d", 1, len(resp.Data.Sessions))
+			continue
+		}
+
+		session := resp.Data.Sessions[0]
+		if session.HostBroadcasterID != "198704263" {
+			t.Errorf("expected host broadcaster id to be %s, got %s", "198704263", session.HostBroadcasterID)
+		}
+
+		if len(session.Participants) != 2 {
+			t.Errorf("expected number of participants to be %d, got %d", 2, len(session.Participants))
+		}
+
+		if session.CreatedAt.IsZero() {
+			t.Errorf("expected created at not to be zero")
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetSharedChatSession(&GetSharedChatSessionParams{BroadcasterID: "198704263"})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
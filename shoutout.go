@@ -15,6 +15,13 @@ type SendShoutoutResponse struct {
 // The broadcaster may send a Shoutout once every 2 minutes.
 // They may send the same broadcaster a Shoutout once every 60 minutes.
 func (c *Client) SendShoutout(params *SendShoutoutParams) (*SendShoutoutResponse, error) {
+	if params.FromBroadcasterID == "" || params.ToBroadcasterID == "" {
+		return nil, &ValidationError{Field: "from_broadcaster_id", Message: "from and to broadcaster identifiers must be provided"}
+	}
+	if err := validateRequired("moderator_id", "moderator id", params.ModeratorID); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.post("/chat/shoutouts", nil, params)
 	if err != nil {
 		return nil, err
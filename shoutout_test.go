@@ -72,7 +72,7 @@ func TestSendShoutout(t *testing.T) {
 		ctx:  context.Background(),
 	}
 
-	_, err := c.SendShoutout(&SendShoutoutParams{})
+	_, err := c.SendShoutout(&SendShoutoutParams{FromBroadcasterID: "100249558", ModeratorID: "100249558", ToBroadcasterID: "80085"})
 	if err == nil {
 		t.Error("expected error but got nil")
 	}
@@ -80,4 +80,30 @@ func TestSendShoutout(t *testing.T) {
 	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
 		t.Error("expected error does match return error")
 	}
+
+	// Test validation errors
+	validationCases := []struct {
+		params *SendShoutoutParams
+		err    string
+	}{
+		{
+			&SendShoutoutParams{ModeratorID: "100249558", ToBroadcasterID: "80085"},
+			"error: from and to broadcaster identifiers must be provided",
+		},
+		{
+			&SendShoutoutParams{FromBroadcasterID: "100249558", ModeratorID: "100249558"},
+			"error: from and to broadcaster identifiers must be provided",
+		},
+		{
+			&SendShoutoutParams{FromBroadcasterID: "100249558", ToBroadcasterID: "80085"},
+			"error: moderator id must be specified",
+		},
+	}
+
+	for _, validationCase := range validationCases {
+		_, err := c.SendShoutout(validationCase.params)
+		if err == nil || err.Error() != validationCase.err {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", validationCase.err, err)
+		}
+	}
 }
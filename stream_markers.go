@@ -5,7 +5,7 @@ type Marker struct {
 	CreatedAt       Time   `json:"created_at"`
 	Description     string `json:"description"`
 	PositionSeconds int    `json:"position_seconds"`
-	URL             string `json:"URL"`
+	URL             string `json:"url"`
 }
 
 type VideoMarker struct {
@@ -25,9 +25,50 @@ type ManyStreamMarkers struct {
 	Pagination    Pagination     `json:"pagination"`
 }
 
-type StreamMarkersResponse struct {
-	ResponseCommon
-	Data ManyStreamMarkers
+type StreamMarkersResponse = ResponseOf[ManyStreamMarkers]
+
+// FlatStreamMarker is a Marker along with the user and video it belongs to.
+type FlatStreamMarker struct {
+	Marker
+	UserID    string
+	UserName  string
+	UserLogin string
+	VideoID   string
+}
+
+// Markers flattens the nested users, videos and markers of the response
+// into a single list, in the order they were returned.
+func (m *ManyStreamMarkers) Markers() []FlatStreamMarker {
+	var markers []FlatStreamMarker
+	for _, streamMarker := range m.StreamMarkers {
+		for _, video := range streamMarker.Videos {
+			for _, marker := range video.Markers {
+				markers = append(markers, FlatStreamMarker{
+					Marker:    marker,
+					UserID:    streamMarker.UserID,
+					UserName:  streamMarker.UserName,
+					UserLogin: streamMarker.UserLogin,
+					VideoID:   video.VideoID,
+				})
+			}
+		}
+	}
+
+	return markers
+}
+
+// VideoMarkers returns the markers of the given video, or nil if the
+// response doesn't contain the video.
+func (m *ManyStreamMarkers) VideoMarkers(videoID string) []Marker {
+	for _, streamMarker := range m.StreamMarkers {
+		for _, video := range streamMarker.Videos {
+			if video.VideoID == videoID {
+				return video.Markers
+			}
+		}
+	}
+
+	return nil
 }
 
 // StreamMarkersParams requires _either_ UserID or VideoID set
@@ -50,17 +91,11 @@ type StreamMarkersParams struct {
 //
 // Required Scope: user:read:broadcast
 func (c *Client) GetStreamMarkers(params *StreamMarkersParams) (*StreamMarkersResponse, error) {
-	resp, err := c.get("/streams/markers", &ManyStreamMarkers{}, params)
-	if err != nil {
-		return nil, err
+	if params.UserID != "" && params.VideoID != "" {
+		return nil, &ValidationError{Field: "user_id", Message: "only one of user id or video id can be provided"}
 	}
 
-	markers := &StreamMarkersResponse{}
-	resp.HydrateResponseCommon(&markers.ResponseCommon)
-	markers.Data.StreamMarkers = resp.Data.(*ManyStreamMarkers).StreamMarkers
-	markers.Data.Pagination = resp.Data.(*ManyStreamMarkers).Pagination
-
-	return markers, nil
+	return getResponse[ManyStreamMarkers](c, "/streams/markers", params)
 }
 
 type CreateStreamMarker struct {
@@ -74,16 +109,13 @@ type ManyCreateStreamMarkers struct {
 	CreateStreamMarkers []CreateStreamMarker `json:"data"`
 }
 
-type CreateStreamMarkerResponse struct {
-	ResponseCommon
-	Data ManyCreateStreamMarkers
-}
+type CreateStreamMarkerResponse = ResponseOf[ManyCreateStreamMarkers]
 
 type CreateStreamMarkerParams struct {
-	UserID string `query:"user_id"`
+	UserID string `json:"user_id"`
 
 	// Optional
-	Description string `query:"description"`
+	Description string `json:"description,omitempty"` // Maximum 140 characters
 }
 
 // CreateStreamMarker creates a stream marker for a live stream at the current time.
@@ -93,14 +125,9 @@ type CreateStreamMarkerParams struct {
 //
 // Required Scope: user:edit:broadcast
 func (c *Client) CreateStreamMarker(params *CreateStreamMarkerParams) (*CreateStreamMarkerResponse, error) {
-	resp, err := c.post("/streams/markers", &ManyCreateStreamMarkers{}, params)
-	if err != nil {
-		return nil, err
+	if len([]rune(params.Description)) > 140 {
+		return nil, &ValidationError{Field: "description", Message: "description must not be longer than 140 characters"}
 	}
 
-	markers := &CreateStreamMarkerResponse{}
-	resp.HydrateResponseCommon(&markers.ResponseCommon)
-	markers.Data.CreateStreamMarkers = resp.Data.(*ManyCreateStreamMarkers).CreateStreamMarkers
-
-	return markers, nil
+	return postAsJSONResponse[ManyCreateStreamMarkers](c, "/streams/markers", params)
 }
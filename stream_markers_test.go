@@ -2,7 +2,10 @@ package helix
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -85,6 +88,48 @@ func TestGetStreamMarkers(t *testing.T) {
 	}
 }
 
+func TestStreamMarkersAccessors(t *testing.T) {
+	t.Parallel()
+
+	respBody := `{"data":[{"user_id":"123","user_name":"DisplayName","user_login":"displayname","videos":[{"video_id":"456","markers":[{"id":"m1","description":"first","position_seconds":244,"url":"https://twitch.tv/videos/456?t=0h4m04s"},{"id":"m2","description":"second","position_seconds":300}]},{"video_id":"789","markers":[{"id":"m3","position_seconds":10}]}]}],"pagination":{}}`
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, respBody, nil))
+
+	resp, err := c.GetStreamMarkers(&StreamMarkersParams{UserID: "123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	markers := resp.Data.Markers()
+	if len(markers) != 3 {
+		t.Fatalf("expected 3 markers, got %d", len(markers))
+	}
+
+	if markers[0].ID != "m1" || markers[0].VideoID != "456" || markers[0].UserLogin != "displayname" {
+		t.Errorf("unexpected first marker %+v", markers[0])
+	}
+
+	if markers[0].URL != "https://twitch.tv/videos/456?t=0h4m04s" {
+		t.Errorf("expected marker url to be set, got \"%s\"", markers[0].URL)
+	}
+
+	if markers[2].ID != "m3" || markers[2].VideoID != "789" {
+		t.Errorf("unexpected last marker %+v", markers[2])
+	}
+
+	if videoMarkers := resp.Data.VideoMarkers("456"); len(videoMarkers) != 2 {
+		t.Errorf("expected 2 markers for video 456, got %d", len(videoMarkers))
+	}
+
+	if videoMarkers := resp.Data.VideoMarkers("000"); videoMarkers != nil {
+		t.Errorf("expected no markers for unknown video, got %+v", videoMarkers)
+	}
+
+	_, err = c.GetStreamMarkers(&StreamMarkersParams{UserID: "123", VideoID: "456"})
+	if err == nil || err.Error() != "error: only one of user id or video id can be provided" {
+		t.Errorf("expected user id and video id error, got %v", err)
+	}
+}
+
 func TestCreateStreamMarker(t *testing.T) {
 	t.Parallel()
 
@@ -163,3 +208,33 @@ func TestCreateStreamMarker(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestCreateStreamMarkerBody(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		var params CreateStreamMarkerParams
+		if err := json.Unmarshal(body, &params); err != nil {
+			t.Fatal(err)
+		}
+
+		if params.UserID != "123" || params.Description != "a notable moment" {
+			t.Errorf("unexpected request body %s", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"id":"123","created_at":"2018-08-20T20:10:03Z","description":"a notable moment","position_seconds":244}]}`))
+	})
+
+	_, err := c.CreateStreamMarker(&CreateStreamMarkerParams{UserID: "123", Description: "a notable moment"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = c.CreateStreamMarker(&CreateStreamMarkerParams{UserID: "123", Description: strings.Repeat("a", 141)})
+	if err == nil || err.Error() != "error: description must not be longer than 140 characters" {
+		t.Errorf("expected description error, got %v", err)
+	}
+}
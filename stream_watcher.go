@@ -0,0 +1,268 @@
+package helix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultStreamWatcherInterval is how often a StreamWatcher polls the streams
+// of its channels if no interval is set.
+const DefaultStreamWatcherInterval = time.Minute
+
+// DefaultStreamWatcherDebounce is the number of consecutive polls a channel
+// must be seen live or offline in before a StreamWatcher reports it, if no
+// debounce is set.
+const DefaultStreamWatcherDebounce = 2
+
+// StreamWatcher polls the streams of a set of channels and reports when they
+// go live or offline and when the title or category of a live stream
+// changes. It is a fallback for applications that can't receive EventSub
+// notifications, which are more timely and cheaper on the rate limit.
+//
+// GetStreams briefly drops live streams now and then, so a channel must be
+// seen live or offline in Debounce consecutive polls before OnLive or
+// OnOffline handlers are called. Channels that are already live on the first
+// poll are reported right away.
+type StreamWatcher struct {
+	client *Client
+
+	// Interval between polls, DefaultStreamWatcherInterval if zero.
+	Interval time.Duration
+
+	// Debounce is the number of consecutive polls a channel must be seen
+	// live or offline in before it is reported, DefaultStreamWatcherDebounce
+	// if zero.
+	Debounce int
+
+	mu             sync.Mutex
+	streams        map[string]*watchedStream
+	live           []func(stream Stream)
+	offline        []func(stream Stream)
+	titleChange    []func(stream, previous Stream)
+	categoryChange []func(stream, previous Stream)
+	errors         []func(err error)
+}
+
+// watchedStream is the state of a watched channel.
+type watchedStream struct {
+	polled  bool
+	live    bool
+	stream  Stream // the stream last seen live
+	pending int    // consecutive polls the channel was seen in the other state
+}
+
+// NewStreamWatcher returns a watcher of the streams of the given user IDs.
+func (c *Client) NewStreamWatcher(userIDs ...string) *StreamWatcher {
+	w := &StreamWatcher{
+		client:  c,
+		streams: map[string]*watchedStream{},
+	}
+	w.Watch(userIDs...)
+
+	return w
+}
+
+// Watch adds channels by user ID, they are polled from the next poll on.
+func (w *StreamWatcher) Watch(userIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, userID := range userIDs {
+		if _, ok := w.streams[userID]; !ok {
+			w.streams[userID] = &watchedStream{}
+		}
+	}
+}
+
+// Unwatch removes channels by user ID.
+func (w *StreamWatcher) Unwatch(userIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, userID := range userIDs {
+		delete(w.streams, userID)
+	}
+}
+
+// OnLive registers a handler that is called when a channel goes live.
+func (w *StreamWatcher) OnLive(handler func(stream Stream)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.live = append(w.live, handler)
+}
+
+// OnOffline registers a handler that is called with the last stream seen of
+// a channel that went offline.
+func (w *StreamWatcher) OnOffline(handler func(stream Stream)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.offline = append(w.offline, handler)
+}
+
+// OnTitleChange registers a handler that is called when the title of a live
+// stream changes.
+func (w *StreamWatcher) OnTitleChange(handler func(stream, previous Stream)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.titleChange = append(w.titleChange, handler)
+}
+
+// OnCategoryChange registers a handler that is called when the category of a
+// live stream changes.
+func (w *StreamWatcher) OnCategoryChange(handler func(stream, previous Stream)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.categoryChange = append(w.categoryChange, handler)
+}
+
+// OnError registers a handler for polls that failed, no channel changes
+// state in a failed poll.
+func (w *StreamWatcher) OnError(handler func(err error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.errors = append(w.errors, handler)
+}
+
+// Run polls the streams right away and then at every interval, until ctx is
+// done. It is usually run in its own goroutine.
+func (w *StreamWatcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultStreamWatcherInterval
+	}
+
+	for {
+		if err := w.poll(); err != nil {
+			w.dispatchError(err)
+		}
+
+		if err := w.client.sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// poll gets the live streams of the watched channels and dispatches the
+// changes since the previous poll.
+func (w *StreamWatcher) poll() error {
+	w.mu.Lock()
+	userIDs := make([]string, 0, len(w.streams))
+	for userID := range w.streams {
+		userIDs = append(userIDs, userID)
+	}
+	w.mu.Unlock()
+
+	live := make(map[string]Stream, len(userIDs))
+	for start := 0; start < len(userIDs); start += 100 {
+		end := start + 100
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+
+		resp, err := w.client.GetStreams(&StreamsParams{
+			First:   100,
+			UserIDs: userIDs[start:end],
+		})
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("error: could not get streams: %w", resp.Err())
+		}
+
+		for _, stream := range resp.Data.Streams {
+			live[stream.UserID] = stream
+		}
+	}
+
+	for _, userID := range userIDs {
+		stream, isLive := live[userID]
+		w.update(userID, stream, isLive)
+	}
+
+	return nil
+}
+
+// update records whether a channel was seen live and dispatches the change,
+// if any.
+func (w *StreamWatcher) update(userID string, stream Stream, isLive bool) {
+	w.mu.Lock()
+	state, ok := w.streams[userID]
+	if !ok {
+		// Unwatched during the poll
+		w.mu.Unlock()
+		return
+	}
+
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultStreamWatcherDebounce
+	}
+
+	previous := state.stream
+	var handlers []func(stream Stream)
+	var changes []func(stream, previous Stream)
+	switch {
+	case !state.polled:
+		state.polled = true
+		state.live = isLive
+		if isLive {
+			state.stream = stream
+			handlers = w.live
+		}
+	case isLive == state.live:
+		state.pending = 0
+		if isLive {
+			state.stream = stream
+			if stream.Title != previous.Title {
+				changes = append(changes, w.titleChange...)
+			}
+			if stream.GameID != previous.GameID {
+				changes = append(changes, w.categoryChange...)
+			}
+		}
+	default:
+		state.pending++
+		if state.pending < debounce {
+			break
+		}
+
+		state.pending = 0
+		state.live = isLive
+		if isLive {
+			state.stream = stream
+			handlers = w.live
+		} else {
+			stream = previous
+			handlers = w.offline
+		}
+	}
+	w.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(stream)
+	}
+
+	for _, handler := range changes {
+		handler(stream, previous)
+	}
+}
+
+func (w *StreamWatcher) dispatchError(err error) {
+	w.mu.Lock()
+	handlers := w.errors
+	w.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(err)
+	}
+}
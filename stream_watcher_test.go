@@ -0,0 +1,119 @@
+package helix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestStreamWatcher(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream1A := `{"id":"100","user_id":"1","user_login":"one","title":"A","game_id":"10","type":"live"}`
+	stream1B := `{"id":"100","user_id":"1","user_login":"one","title":"B","game_id":"10","type":"live"}`
+	stream1C := `{"id":"100","user_id":"1","user_login":"one","title":"B","game_id":"20","type":"live"}`
+	stream2 := `{"id":"200","user_id":"2","user_login":"two","title":"X","game_id":"30","type":"live"}`
+
+	polls := [][]string{
+		{stream1A},
+		{stream1B},
+		{stream1C, stream2},
+		{stream2},
+		{stream1C, stream2},
+		{stream2},
+		{stream2},
+	}
+
+	poll := 0
+	clock := newFakeClock()
+	c := newMockClient(&Options{ClientID: "my-client-id", AppAccessToken: "my-app-access-token", Sleeper: clock}, func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Query()["user_id"]) != 2 {
+			t.Errorf("expected 2 user ids, got %v", r.URL.Query()["user_id"])
+		}
+
+		if poll == len(polls) {
+			cancel()
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`))
+			return
+		}
+
+		data := "["
+		for i, stream := range polls[poll] {
+			if i > 0 {
+				data += ","
+			}
+			data += stream
+		}
+		poll++
+
+		w.Write([]byte(`{"data":` + data + `],"pagination":{}}`))
+	})
+
+	var events []string
+	watcher := c.NewStreamWatcher("1", "2")
+	watcher.OnLive(func(stream Stream) {
+		events = append(events, fmt.Sprintf("poll %d: %s live with %q", poll, stream.UserID, stream.Title))
+	})
+	watcher.OnOffline(func(stream Stream) {
+		events = append(events, fmt.Sprintf("poll %d: %s offline after %q", poll, stream.UserID, stream.Title))
+	})
+	watcher.OnTitleChange(func(stream, previous Stream) {
+		events = append(events, fmt.Sprintf("poll %d: %s title %q to %q", poll, stream.UserID, previous.Title, stream.Title))
+	})
+	watcher.OnCategoryChange(func(stream, previous Stream) {
+		events = append(events, fmt.Sprintf("poll %d: %s category %s to %s", poll, stream.UserID, previous.GameID, stream.GameID))
+	})
+	watcher.OnError(func(err error) {
+		events = append(events, err.Error())
+	})
+
+	if err := watcher.Run(ctx); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+
+	expected := []string{
+		`poll 1: 1 live with "A"`,
+		`poll 2: 1 title "A" to "B"`,
+		`poll 3: 1 category 10 to 20`,
+		`poll 4: 2 live with "X"`,
+		`poll 7: 1 offline after "B"`,
+		"error: could not get streams: 401 Unauthorized: Invalid OAuth token",
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("expected events %q, got %q", expected, events)
+	}
+
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("expected event %q, got %q", expected[i], events[i])
+		}
+	}
+}
+
+func TestStreamWatcherUnwatch(t *testing.T) {
+	t.Parallel()
+
+	var userIDs []string
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		userIDs = r.URL.Query()["user_id"]
+		w.Write([]byte(`{"data":[],"pagination":{}}`))
+	})
+
+	watcher := c.NewStreamWatcher("1", "2")
+	watcher.Watch("3", "1")
+	watcher.Unwatch("2")
+
+	if err := watcher.poll(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(userIDs) != 2 {
+		t.Errorf("expected user ids 1 and 3 to be polled, got %v", userIDs)
+	}
+}
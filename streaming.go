@@ -0,0 +1,132 @@
+package helix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StreamedPage is the data of a page whose items were passed to a callback
+// as they were decoded, instead of being kept in memory.
+type StreamedPage struct {
+	Pagination Pagination
+	Total      int
+
+	// Other holds the top-level fields of the page besides data, pagination
+	// and total, such as the points of GetBroadcasterSubscriptions.
+	Other map[string]json.RawMessage
+}
+
+type StreamedResponse = ResponseOf[StreamedPage]
+
+// streamDecoder is the data of a request that decodes the body itself, as
+// it is read, see doRequest.
+type streamDecoder interface {
+	decodeStream(r io.Reader) error
+}
+
+// itemStream decodes the items of a page one by one and passes them to
+// handle.
+type itemStream[T any] struct {
+	handle    func(item T) error
+	page      StreamedPage
+	handleErr error
+}
+
+func (s *itemStream[T]) decodeStream(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch key := token.(string); key {
+		case "data":
+			if err := s.decodeItems(dec); err != nil {
+				return err
+			}
+		case "pagination":
+			err = dec.Decode(&s.page.Pagination)
+		case "total":
+			err = dec.Decode(&s.page.Total)
+		default:
+			var value json.RawMessage
+			if err = dec.Decode(&value); err == nil {
+				if s.page.Other == nil {
+					s.page.Other = map[string]json.RawMessage{}
+				}
+				s.page.Other[key] = value
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return expectJSONDelim(dec, '}')
+}
+
+func (s *itemStream[T]) decodeItems(dec *json.Decoder) error {
+	token, err := dec.Token()
+	if err != nil || token == nil {
+		// A null data field has no items
+		return err
+	}
+
+	if token != json.Delim('[') {
+		return fmt.Errorf("expected data to be an array, got %v", token)
+	}
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+
+		if err := s.handle(item); err != nil {
+			s.handleErr = err
+			return err
+		}
+	}
+
+	return expectJSONDelim(dec, ']')
+}
+
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+
+	return nil
+}
+
+// streamResponse sends a GET request for a page and passes its items to
+// handle as they are decoded. An error returned by handle stops the
+// decoding and is returned as is.
+func streamResponse[T any](c *Client, path string, reqData interface{}, handle func(item T) error) (*StreamedResponse, error) {
+	stream := &itemStream[T]{handle: handle}
+
+	resp, err := c.sendRequest(http.MethodGet, path, stream, reqData, false)
+	if stream.handleErr != nil {
+		return nil, stream.handleErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	streamed := &StreamedResponse{Data: stream.page}
+	resp.HydrateResponseCommon(&streamed.ResponseCommon)
+
+	return streamed, nil
+}
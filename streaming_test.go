@@ -0,0 +1,195 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestStreamChannelFollowers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		respBody      string
+		stopAfter     int
+		expectedIDs   []string
+		expectedTotal int
+		expectedErr   string
+	}{
+		{
+			http.StatusOK,
+			`{"total":3,"data":[{"user_id":"11111","user_name":"UserDisplayName","user_login":"userloginname","followed_at":"2022-05-24T22:22:08Z"},{"user_id":"22222","user_name":"OtherUser","user_login":"otheruser","followed_at":"2022-05-24T22:22:08Z"}],"pagination":{"cursor":"eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19"}}`,
+			0,
+			[]string{"11111", "22222"},
+			3,
+			"",
+		},
+		{
+			http.StatusOK,
+			`{"data":null,"pagination":{},"total":0}`,
+			0,
+			nil,
+			0,
+			"",
+		},
+		{
+			http.StatusOK,
+			`{"total":3,"data":[{"user_id":"11111"},{"user_id":"22222"},{"user_id":"33333"}],"pagination":{}}`,
+			1,
+			[]string{"11111"},
+			0,
+			"stop",
+		},
+		{
+			http.StatusOK,
+			`{"data":{"user_id":"11111"}}`,
+			0,
+			nil,
+			0,
+			"Failed to decode API response: expected data to be an array, got {",
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":"Bad Request","status":400,"message":"Missing required parameter \"broadcaster_id\""}`,
+			0,
+			nil,
+			0,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		var ids []string
+		errStop := errors.New("stop")
+		resp, err := c.StreamChannelFollowers(&GetChannelFollowsParams{BroadcasterID: "123456"}, func(follow ChannelFollow) error {
+			ids = append(ids, follow.UserID)
+			if testCase.stopAfter > 0 && len(ids) == testCase.stopAfter {
+				return errStop
+			}
+			return nil
+		})
+
+		if len(ids) != len(testCase.expectedIDs) {
+			t.Errorf("expected follows %v, got %v", testCase.expectedIDs, ids)
+		}
+
+		for i := range ids {
+			if ids[i] != testCase.expectedIDs[i] {
+				t.Errorf("expected follow %s, got %s", testCase.expectedIDs[i], ids[i])
+			}
+		}
+
+		if testCase.expectedErr != "" {
+			if err == nil || err.Error() != testCase.expectedErr {
+				t.Errorf("expected error %q, got %v", testCase.expectedErr, err)
+			}
+			if testCase.stopAfter > 0 && err != errStop {
+				t.Errorf("expected the error of the handler to be returned as is, got %v", err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+			continue
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if resp.StatusCode == http.StatusBadRequest {
+			if resp.ErrorMessage != `Missing required parameter "broadcaster_id"` {
+				t.Errorf("expected the error message to be decoded, got %q", resp.ErrorMessage)
+			}
+			continue
+		}
+
+		if resp.Data.Total != testCase.expectedTotal {
+			t.Errorf("expected total %d, got %d", testCase.expectedTotal, resp.Data.Total)
+		}
+
+		if len(testCase.expectedIDs) > 0 && resp.Data.Pagination.Cursor != "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19" {
+			t.Errorf("expected the cursor to be decoded, got %q", resp.Data.Pagination.Cursor)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.StreamChannelFollowers(&GetChannelFollowsParams{BroadcasterID: "123456"}, func(follow ChannelFollow) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Errorf("expected error does match return error, got %q", err.Error())
+	}
+}
+
+func TestStreamBroadcasterSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[{"broadcaster_id":"123","user_id":"1","tier":"1000"},{"broadcaster_id":"123","user_id":"2","tier":"3000"}],"pagination":{"cursor":"xyz"},"total":2,"points":7}`, nil))
+
+	tiers := map[string]string{}
+	resp, err := c.StreamBroadcasterSubscriptions(&SubscriptionsParams{BroadcasterID: "123"}, func(sub Subscription) error {
+		tiers[sub.UserID] = sub.Tier
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tiers) != 2 || tiers["1"] != "1000" || tiers["2"] != "3000" {
+		t.Errorf("expected subscriptions of users 1 and 2, got %v", tiers)
+	}
+
+	if resp.Data.Total != 2 || string(resp.Data.Other["points"]) != "7" {
+		t.Errorf("expected total 2 and points 7, got %d and %s", resp.Data.Total, resp.Data.Other["points"])
+	}
+
+	_, err = c.StreamBroadcasterSubscriptions(&SubscriptionsParams{BroadcasterID: "123", First: 101}, nil)
+	if err == nil || err.Error() != "error: first must not be greater than 100" {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestStreamChannelChatChatters(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[{"user_id":"128393656","user_login":"smittysmithers","user_name":"smittysmithers"}],"pagination":{},"total":1}`, nil))
+
+	var logins []string
+	resp, err := c.StreamChannelChatChatters(&GetChatChattersParams{BroadcasterID: "123456", ModeratorID: "654321"}, func(chatter ChatChatter) error {
+		logins = append(logins, chatter.UserLogin)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logins) != 1 || logins[0] != "smittysmithers" || resp.Data.Total != 1 {
+		t.Errorf("expected chatter smittysmithers, got %v of %d", logins, resp.Data.Total)
+	}
+
+	_, err = c.StreamChannelChatChatters(&GetChatChattersParams{BroadcasterID: "123456"}, nil)
+	if err == nil {
+		t.Error("expected a validation error without moderator id")
+	}
+}
@@ -1,23 +1,40 @@
 package helix
 
-import "time"
+import (
+	"time"
+)
+
+// StreamType is the type of a stream, StreamTypeAll is only used to filter
+// streams in GetStreams.
+type StreamType string
+
+// Types of streams
+const (
+	StreamTypeAll  StreamType = "all"
+	StreamTypeLive StreamType = "live"
+)
+
+// IsValid reports whether the type is one Twitch documents.
+func (t StreamType) IsValid() bool {
+	return t == StreamTypeAll || t == StreamTypeLive
+}
 
 type Stream struct {
-	ID           string    `json:"id"`
-	UserID       string    `json:"user_id"`
-	UserLogin    string    `json:"user_login"`
-	UserName     string    `json:"user_name"`
-	GameID       string    `json:"game_id"`
-	GameName     string    `json:"game_name"`
-	TagIDs       []string  `json:"tag_ids"`
-	Tags         []string  `json:"tags"`
-	IsMature     bool      `json:"is_mature"`
-	Type         string    `json:"type"`
-	Title        string    `json:"title"`
-	ViewerCount  int       `json:"viewer_count"`
-	StartedAt    time.Time `json:"started_at"`
-	Language     string    `json:"language"`
-	ThumbnailURL string    `json:"thumbnail_url"`
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	UserLogin    string     `json:"user_login"`
+	UserName     string     `json:"user_name"`
+	GameID       string     `json:"game_id"`
+	GameName     string     `json:"game_name"`
+	TagIDs       []string   `json:"tag_ids"`
+	Tags         []string   `json:"tags"`
+	IsMature     bool       `json:"is_mature"`
+	Type         StreamType `json:"type"` // StreamTypeLive, or empty if an error occurred
+	Title        string     `json:"title"`
+	ViewerCount  int        `json:"viewer_count"`
+	StartedAt    Time       `json:"started_at"`
+	Language     string     `json:"language"`
+	ThumbnailURL string     `json:"thumbnail_url"`
 }
 
 type ManyStreams struct {
@@ -25,20 +42,17 @@ type ManyStreams struct {
 	Pagination Pagination `json:"pagination"`
 }
 
-type StreamsResponse struct {
-	ResponseCommon
-	Data ManyStreams
-}
+type StreamsResponse = ResponseOf[ManyStreams]
 
 type StreamsParams struct {
-	After      string   `query:"after"`
-	Before     string   `query:"before"`
-	First      int      `query:"first,20"`   // Limit 100
-	GameIDs    []string `query:"game_id"`    // Limit 100
-	Language   []string `query:"language"`   // Limit 100
-	Type       string   `query:"type,all"`   // "all" (default), "live" and "vodcast"
-	UserIDs    []string `query:"user_id"`    // limit 100
-	UserLogins []string `query:"user_login"` // limit 100
+	After      string     `query:"after"`
+	Before     string     `query:"before"`
+	First      int        `query:"first,20"`   // Limit 100
+	GameIDs    []string   `query:"game_id"`    // Limit 100
+	Language   []string   `query:"language"`   // Limit 100
+	Type       StreamType `query:"type,all"`   // StreamTypeAll (default) or StreamTypeLive
+	UserIDs    []string   `query:"user_id"`    // limit 100
+	UserLogins []string   `query:"user_login"` // limit 100
 }
 
 type ManyStreamKeys struct {
@@ -47,11 +61,18 @@ type ManyStreamKeys struct {
 	} `json:"data"`
 }
 
-type StreamKeysResponse struct {
-	ResponseCommon
-	Data ManyStreamKeys
+// StreamKey returns the broadcaster's stream key, or an empty string
+// if the response doesn't contain one.
+func (m *ManyStreamKeys) StreamKey() string {
+	if len(m.Data) == 0 {
+		return ""
+	}
+
+	return m.Data[0].StreamKey
 }
 
+type StreamKeysResponse = ResponseOf[ManyStreamKeys]
+
 type StreamKeyParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
 }
@@ -59,17 +80,77 @@ type StreamKeyParams struct {
 // GetStreams returns a list of live channels based on the search parameters.
 // To query offline channels, use SearchChannels.
 func (c *Client) GetStreams(params *StreamsParams) (*StreamsResponse, error) {
-	resp, err := c.get("/streams", &ManyStreams{}, params)
+	if params == nil {
+		params = &StreamsParams{}
+	}
+
+	if err := validateMaxValues("game_id", "game ids", params.GameIDs, 100); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxValues("language", "languages", params.Language, 100); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxValues("user_id", "user ids", params.UserIDs, 100); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxValues("user_login", "user logins", params.UserLogins, 100); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyStreams](c, "/streams", params)
+}
+
+// IsUserLive reports whether the user with the given login is streaming.
+func (c *Client) IsUserLive(login string) (bool, error) {
+	if err := validateRequired("user_login", "user login", login); err != nil {
+		return false, err
+	}
+
+	stream, err := c.getLiveStream(&StreamsParams{UserLogins: []string{login}})
 	if err != nil {
+		return false, err
+	}
+
+	return stream != nil, nil
+}
+
+// GetStreamUptime returns how long the user with the given ID has been
+// streaming, or zero if the user isn't live.
+func (c *Client) GetStreamUptime(userID string) (time.Duration, error) {
+	if err := validateRequired("user_id", "user id", userID); err != nil {
+		return 0, err
+	}
+
+	stream, err := c.getLiveStream(&StreamsParams{UserIDs: []string{userID}})
+	if err != nil || stream == nil {
+		return 0, err
+	}
+
+	return c.now().Sub(stream.StartedAt.Time), nil
+}
+
+// getLiveStream returns the live stream matching params, or nil if there is
+// none.
+func (c *Client) getLiveStream(params *StreamsParams) (*Stream, error) {
+	params.Type = "live"
+
+	resp, err := c.GetStreams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resp.Err(); err != nil {
 		return nil, err
 	}
 
-	streams := &StreamsResponse{}
-	resp.HydrateResponseCommon(&streams.ResponseCommon)
-	streams.Data.Streams = resp.Data.(*ManyStreams).Streams
-	streams.Data.Pagination = resp.Data.(*ManyStreams).Pagination
+	if len(resp.Data.Streams) == 0 {
+		return nil, nil
+	}
 
-	return streams, nil
+	return &resp.Data.Streams[0], nil
 }
 
 type FollowedStreamsParams struct {
@@ -86,31 +167,14 @@ type FollowedStreamsParams struct {
 //
 // Required scope: user:read:follows
 func (c *Client) GetFollowedStream(params *FollowedStreamsParams) (*StreamsResponse, error) {
-	resp, err := c.get("/streams/followed", &ManyStreams{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	streams := &StreamsResponse{}
-	resp.HydrateResponseCommon(&streams.ResponseCommon)
-	streams.Data.Streams = resp.Data.(*ManyStreams).Streams
-	streams.Data.Pagination = resp.Data.(*ManyStreams).Pagination
-
-	return streams, nil
+	return getResponse[ManyStreams](c, "/streams/followed", params)
 }
 
-// GetStreamKey : Returns the secret stream key of the broadcaster
+// GetStreamKey : Returns the secret stream key of the broadcaster, which is
+// needed to stream to the channel, e.g. by restreaming tools. Use
+// Data.StreamKey() to get the key. Only the broadcaster can get their key.
 //
 // Required scope: channel:read:stream_key
 func (c *Client) GetStreamKey(params *StreamKeyParams) (*StreamKeysResponse, error) {
-	resp, err := c.get("/streams/key", &ManyStreamKeys{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	streams := &StreamKeysResponse{}
-	resp.HydrateResponseCommon(&streams.ResponseCommon)
-	streams.Data.Data = resp.Data.(*ManyStreamKeys).Data
-
-	return streams, nil
+	return getResponse[ManyStreamKeys](c, "/streams/key", params)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetStreams(t *testing.T) {
@@ -206,6 +207,15 @@ func TestGetStreamKeys(t *testing.T) {
 		if len(resp.Data.Data) != testCase.Length {
 			t.Errorf("expected \"%d\" streams, got \"%d\"", testCase.Length, len(resp.Data.Data))
 		}
+
+		expectedKey := ""
+		if testCase.Length > 0 {
+			expectedKey = "live_695820277_TF1dAMbU4cQvGKyrk2Q88SvWNCw6Rs"
+		}
+
+		if resp.Data.StreamKey() != expectedKey {
+			t.Errorf("expected stream key to be \"%s\", got \"%s\"", expectedKey, resp.Data.StreamKey())
+		}
 	}
 
 	// Test with HTTP Failure
@@ -230,3 +240,156 @@ func TestGetStreamKeys(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestIsUserLive(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		login         string
+		respBody      string
+		expectedLive  bool
+		expectedError string
+	}{
+		{
+			http.StatusOK,
+			"",
+			``,
+			false,
+			"error: user login must be specified",
+		},
+		{
+			http.StatusOK,
+			"summit1g",
+			`{"data":[{"id":"1","user_id":"26490481","user_login":"summit1g","type":"live","started_at":"2024-01-01T00:00:00Z"}],"pagination":{}}`,
+			true,
+			"",
+		},
+		{
+			http.StatusOK,
+			"lirik",
+			`{"data":[],"pagination":{}}`,
+			false,
+			"",
+		},
+		{
+			http.StatusBadRequest,
+			"not a login",
+			`{"error":"Bad Request","status":400,"message":"Invalid login names in request"}`,
+			false,
+			"400 Bad Request: Invalid login names in request",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("type") != "live" || r.URL.Query().Get("user_login") != testCase.login {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		live, err := c.IsUserLive(testCase.login)
+		if err != nil {
+			if err.Error() != testCase.expectedError {
+				t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.expectedError, err)
+			}
+			continue
+		}
+
+		if live != testCase.expectedLive {
+			t.Errorf("expected live to be %t for %s, got %t", testCase.expectedLive, testCase.login, live)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.IsUserLive("summit1g")
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestGetStreamUptime(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	startedAt := clock.Now().Add(-90 * time.Minute).Format(time.RFC3339)
+
+	testCases := []struct {
+		userID           string
+		respBody         string
+		expectedUptime   time.Duration
+		expectedErrorMsg string
+	}{
+		{
+			"",
+			``,
+			0,
+			"error: user id must be specified",
+		},
+		{
+			"26490481",
+			`{"data":[{"id":"1","user_id":"26490481","type":"live","started_at":"` + startedAt + `"}],"pagination":{}}`,
+			90 * time.Minute,
+			"",
+		},
+		{
+			"23161357",
+			`{"data":[],"pagination":{}}`,
+			0,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id", Clock: clock}, newMockHandler(http.StatusOK, testCase.respBody, nil))
+
+		uptime, err := c.GetStreamUptime(testCase.userID)
+		if err != nil {
+			if err.Error() != testCase.expectedErrorMsg {
+				t.Errorf("Unmatched error, expected '%v', got '%v'", testCase.expectedErrorMsg, err)
+			}
+			continue
+		}
+
+		if uptime != testCase.expectedUptime {
+			t.Errorf("expected uptime to be %v, got %v", testCase.expectedUptime, uptime)
+		}
+	}
+}
+
+func TestStreamTypeIsValid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		streamType StreamType
+		valid      bool
+	}{
+		{StreamTypeLive, true},
+		{StreamTypeAll, true},
+		{"vodcast", false},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		if valid := testCase.streamType.IsValid(); valid != testCase.valid {
+			t.Errorf("expected %q to be valid: %t, got %t", testCase.streamType, testCase.valid, valid)
+		}
+	}
+}
@@ -1,5 +1,9 @@
 package helix
 
+import (
+	"net/http"
+)
+
 type Subscription struct {
 	BroadcasterID    string `json:"broadcaster_id"`
 	BroadcasterLogin string `json:"broadcaster_login"`
@@ -20,6 +24,7 @@ type UserSubscription struct {
 	BroadcasterLogin string `json:"broadcaster_login"`
 	BroadcasterName  string `json:"broadcaster_name"`
 	IsGift           bool   `json:"is_gift"`
+	GifterID         string `json:"gifter_id"`
 	GifterLogin      string `json:"gifter_login"`
 	GifterName       string `json:"gifter_name"`
 	Tier             string `json:"tier"`
@@ -34,17 +39,12 @@ type ManySubscriptions struct {
 
 type ManyUserSubscriptions struct {
 	UserSubscriptions []UserSubscription `json:"data"`
+	IsSubscribed      bool               `json:"-"` // False when Twitch responds with 404 Not Found
 }
 
-type SubscriptionsResponse struct {
-	ResponseCommon
-	Data ManySubscriptions
-}
+type SubscriptionsResponse = ResponseOf[ManySubscriptions]
 
-type UserSubscriptionResponse struct {
-	ResponseCommon
-	Data ManyUserSubscriptions
-}
+type UserSubscriptionResponse = ResponseOf[ManyUserSubscriptions]
 
 type SubscriptionsParams struct {
 	BroadcasterID string   `query:"broadcaster_id"` // Limit 1
@@ -59,38 +59,77 @@ type UserSubscriptionsParams struct {
 	UserID        string `query:"user_id"`
 }
 
-// GetSubscriptions gets subscriptions about one Twitch broadcaster.
-// Broadcasters can only request their own subscriptions.
+// GetBroadcasterSubscriptions gets subscriptions about one Twitch broadcaster.
+// Broadcasters can only request their own subscriptions. Data.Total is the
+// total number of subscribers and Data.Points the broadcaster's subscriber points.
 //
 // Required scope: channel:read:subscriptions
-func (c *Client) GetSubscriptions(params *SubscriptionsParams) (*SubscriptionsResponse, error) {
-	resp, err := c.get("/subscriptions", &ManySubscriptions{}, params)
-	if err != nil {
+func (c *Client) GetBroadcasterSubscriptions(params *SubscriptionsParams) (*SubscriptionsResponse, error) {
+	if err := validateMaxValues("user_id", "user ids", params.UserID, 100); err != nil {
 		return nil, err
 	}
 
-	subscriptions := &SubscriptionsResponse{}
-	resp.HydrateResponseCommon(&subscriptions.ResponseCommon)
-	subscriptions.Data.Subscriptions = resp.Data.(*ManySubscriptions).Subscriptions
-	subscriptions.Data.Pagination = resp.Data.(*ManySubscriptions).Pagination
-	subscriptions.Data.Total = resp.Data.(*ManySubscriptions).Total
-	subscriptions.Data.Points = resp.Data.(*ManySubscriptions).Points
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
 
-	return subscriptions, nil
+	return getResponse[ManySubscriptions](c, "/subscriptions", params)
+}
+
+// StreamBroadcasterSubscriptions gets a page of the subscriptions of a
+// broadcaster like GetBroadcasterSubscriptions, but passes each subscription
+// to handle as it is decoded instead of keeping the page in memory. The
+// broadcaster's subscriber points are in Data.Other["points"]. An error
+// returned by handle stops the request and is returned.
+//
+// Required scope: channel:read:subscriptions
+func (c *Client) StreamBroadcasterSubscriptions(params *SubscriptionsParams, handle func(subscription Subscription) error) (*StreamedResponse, error) {
+	if err := validateMaxValues("user_id", "user ids", params.UserID, 100); err != nil {
+		return nil, err
+	}
+
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
+
+	return streamResponse(c, "/subscriptions", params, handle)
 }
 
-// CheckUserSubscription Check if a specific user is subscribed to a specific channel
+// GetSubscriptions gets subscriptions about one Twitch broadcaster.
+//
+// Deprecated: use GetBroadcasterSubscriptions instead.
+func (c *Client) GetSubscriptions(params *SubscriptionsParams) (*SubscriptionsResponse, error) {
+	return c.GetBroadcasterSubscriptions(params)
+}
+
+// CheckUserSubscription checks if a specific user is subscribed to a specific channel.
+// Twitch responds with 404 Not Found when the user isn't subscribed, which is
+// returned as a regular response with Data.IsSubscribed set to false and no
+// error fields set.
 //
 // Required scope: user:read:subscriptions
 func (c *Client) CheckUserSubscription(params *UserSubscriptionsParams) (*UserSubscriptionResponse, error) {
-	resp, err := c.get("/subscriptions/user", &ManyUserSubscriptions{}, params)
+	resp, err := c.sendRawRequest(http.MethodGet, "/subscriptions/user", &ManyUserSubscriptions{}, params, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.StatusCode != http.StatusNotFound {
+		if err := c.responseError(&resp.ResponseCommon); err != nil {
+			return nil, err
+		}
+	}
+
 	subscriptions := &UserSubscriptionResponse{}
 	resp.HydrateResponseCommon(&subscriptions.ResponseCommon)
 	subscriptions.Data.UserSubscriptions = resp.Data.(*ManyUserSubscriptions).UserSubscriptions
+	subscriptions.Data.IsSubscribed = resp.StatusCode == http.StatusOK && len(subscriptions.Data.UserSubscriptions) > 0
+
+	if resp.StatusCode == http.StatusNotFound {
+		subscriptions.Error = ""
+		subscriptions.ErrorStatus = 0
+		subscriptions.ErrorMessage = ""
+	}
 
 	return subscriptions, nil
 }
@@ -93,6 +93,100 @@ func TestGetSubscriptions(t *testing.T) {
 	}
 }
 
+func TestGetBroadcasterSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		BroadcasterID string
+		UserID        []string
+		respBody      string
+	}{
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			"123",
+			[]string{},
+			`{"data": [{"broadcaster_id":"123","broadcaster_login":"test_user","broadcaster_name":"test_user","is_gift":true,"gifter_id":"456","gifter_login":"another_user","gifter_name":"Another_User","tier":"3000","plan_name":"The Ninjas","user_id":"123","user_id":"123","user_login":"test_user","user_name":"test_user"}],"pagination":{"cursor":"xxxx"},"total":1,"points":6}`,
+		},
+		{
+			http.StatusBadRequest,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			"",
+			[]string{},
+			`{"error":"Bad Request","status":400,"message":"Missing required parameter \"broadcaster_id\""}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetBroadcasterSubscriptions(&SubscriptionsParams{
+			BroadcasterID: testCase.BroadcasterID,
+			UserID:        testCase.UserID,
+		})
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		// Test error cases
+		if testCase.statusCode != http.StatusOK {
+			if resp.Error != "Bad Request" {
+				t.Errorf("expected error to be \"%s\", got \"%s\"", "Bad Request", resp.Error)
+			}
+
+			errMsg := "Missing required parameter \"broadcaster_id\""
+			if resp.ErrorMessage != errMsg {
+				t.Errorf("expected error message to be \"%s\", got \"%s\"", errMsg, resp.ErrorMessage)
+			}
+
+			continue
+		}
+
+		if resp.Data.Total != 1 {
+			t.Errorf("expected total field to be 1 got %d", resp.Data.Total)
+		}
+
+		if resp.Data.Points != 6 {
+			t.Errorf("expected points field to be 6 got %d", resp.Data.Points)
+		}
+
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	_, err := c.GetBroadcasterSubscriptions(&SubscriptionsParams{BroadcasterID: "123", First: 101})
+	if err == nil || err.Error() != "error: first must not be greater than 100" {
+		t.Errorf("expected first error, got %v", err)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.GetBroadcasterSubscriptions(&SubscriptionsParams{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
 func TestChechUserSubscription(t *testing.T) {
 	t.Parallel()
 
@@ -117,6 +211,13 @@ func TestChechUserSubscription(t *testing.T) {
 			"",
 			`{"error":"Bad Request","status":400,"message":"Missing required parameter \"user_id\""}`,
 		},
+		{
+			http.StatusNotFound,
+			&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"},
+			"123",
+			"456",
+			`{"error":"Not Found","status":404,"message":"test_user has no subscription to test_broadcaster"}`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -134,8 +235,25 @@ func TestChechUserSubscription(t *testing.T) {
 			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
 		}
 
+		// Test not subscribed case
+		if testCase.statusCode == http.StatusNotFound {
+			if resp.Data.IsSubscribed {
+				t.Error("expected user to not be subscribed")
+			}
+
+			if resp.Error != "" || resp.ErrorMessage != "" {
+				t.Errorf("expected no error for a 404 response, got \"%s\"", resp.ErrorMessage)
+			}
+
+			continue
+		}
+
 		// Test error cases
 		if testCase.statusCode != http.StatusOK {
+			if resp.Data.IsSubscribed {
+				t.Error("expected user to not be subscribed")
+			}
+
 			if resp.Error != "Bad Request" {
 				t.Errorf("expected error to be \"%s\", got \"%s\"", "Bad Request", resp.Error)
 			}
@@ -147,6 +265,10 @@ func TestChechUserSubscription(t *testing.T) {
 
 			continue
 		}
+
+		if !resp.Data.IsSubscribed {
+			t.Error("expected user to be subscribed")
+		}
 	}
 
 	// Test with HTTP Failure
@@ -0,0 +1,87 @@
+package helix
+
+// TeamInfo describes a Twitch team
+type TeamInfo struct {
+	ID                 string `json:"id"`
+	TeamName           string `json:"team_name"`
+	TeamDisplayName    string `json:"team_display_name"`
+	Info               string `json:"info"` // HTML formatted description of the team
+	ThumbnailURL       string `json:"thumbnail_url"`
+	BackgroundImageURL string `json:"background_image_url"`
+	Banner             string `json:"banner"`
+	CreatedAt          Time   `json:"created_at"`
+	UpdatedAt          Time   `json:"updated_at"`
+}
+
+// ChannelTeam is a team that a broadcaster is a member of
+type ChannelTeam struct {
+	TeamInfo
+	BroadcasterID    string `json:"broadcaster_id"`
+	BroadcasterLogin string `json:"broadcaster_login"`
+	BroadcasterName  string `json:"broadcaster_name"`
+}
+
+// TeamMember is a user that is a member of a team
+type TeamMember struct {
+	UserID    string `json:"user_id"`
+	UserLogin string `json:"user_login"`
+	UserName  string `json:"user_name"`
+}
+
+// Team is a Twitch team along with its members
+type Team struct {
+	TeamInfo
+	Users []TeamMember `json:"users"`
+}
+
+// ManyChannelTeams is the response data in GetChannelTeamsResponse
+type ManyChannelTeams struct {
+	ChannelTeams []ChannelTeam `json:"data"`
+}
+
+// ManyTeams is the response data in GetTeamsResponse
+type ManyTeams struct {
+	Teams []Team `json:"data"`
+}
+
+// GetChannelTeamsParams are the parameters for GetChannelTeams
+type GetChannelTeamsParams struct {
+	BroadcasterID string `query:"broadcaster_id"`
+}
+
+// GetTeamsParams are the parameters for GetTeams, either Name or ID must be set
+type GetTeamsParams struct {
+	Name string `query:"name"`
+	ID   string `query:"id"`
+}
+
+// GetChannelTeamsResponse is the response from GetChannelTeams
+type GetChannelTeamsResponse = ResponseOf[ManyChannelTeams]
+
+// GetTeamsResponse is the response from GetTeams
+type GetTeamsResponse = ResponseOf[ManyTeams]
+
+// GetChannelTeams gets the list of Twitch teams that the broadcaster is a member of.
+// Requires an app access token or user access token.
+func (c *Client) GetChannelTeams(params *GetChannelTeamsParams) (*GetChannelTeamsResponse, error) {
+	if err := validateRequired("broadcaster_id", "broadcaster id", params.BroadcasterID); err != nil {
+		return nil, err
+	}
+
+	return getResponse[ManyChannelTeams](c, "/teams/channel", params)
+}
+
+// GetTeams gets information about the specified Twitch team, including its members.
+// The team is looked up by either its name or ID, but not both.
+// Requires an app access token or user access token.
+func (c *Client) GetTeams(params *GetTeamsParams) (*GetTeamsResponse, error) {
+	if params.Name == "" && params.ID == "" {
+		return nil, &ValidationError{Field: "name", Message: "team name or id must be specified"}
+	}
+
+	if params.Name != "" && params.ID != "" {
+		return nil, &ValidationError{Field: "name", Message: "only one of team name or id can be specified"}
+	}
+
+	return getResponse[ManyTeams](c, "/teams", params)
+}
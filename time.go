@@ -1,6 +1,7 @@
 package helix
 
 import (
+	"strconv"
 	"strings"
 	"time"
 )
@@ -29,20 +30,36 @@ type Time struct {
 // returns datetimes as empty strings, which casuses issues with the native time
 // UnmarshalJSON method when decoding the JSON string. Here we handle that scenario,
 // by returning a zero time value for any JSON time field that is either an
-// empty string or "null".
+// empty string or "null". Some endpoints, such as Get Ad Schedule, return Unix
+// timestamps instead of RFC3339 strings, where 0 means the time isn't set.
 func (t *Time) UnmarshalJSON(b []byte) (err error) {
 	timeStr := strings.Trim(string(b), "\"")
 
-	if timeStr == "" || timeStr == "null" {
+	if timeStr == "" || timeStr == "null" || timeStr == "0" {
 		t.Time = time.Time{}
 		return
 	}
 
+	if seconds, parseErr := strconv.ParseInt(timeStr, 10, 64); parseErr == nil {
+		t.Time = time.Unix(seconds, 0).UTC()
+		return
+	}
+
 	t.Time, err = time.Parse(time.RFC3339, timeStr)
 
 	return
 }
 
+// MarshalJSON encodes the time as an RFC3339 string, and the zero time as an
+// empty string, so that decoded responses encode back to what Twitch sent.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+
+	return t.Time.MarshalJSON()
+}
+
 func isDatetimeTagField(tag string) bool {
 	for _, tagField := range datetimeFields {
 		if tagField == tag {
@@ -3,6 +3,7 @@ package helix
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 type timeTest struct {
@@ -20,6 +21,8 @@ func TestUnmarshalJSON(t *testing.T) {
 		{true, "", ""},
 		{true, "null", ""},
 		{false, "2018-02-05T08:15:59Z", "2018-02-05 08:15:59 +0000 UTC"},
+		{true, "0", ""},
+		{false, "1517818559", "2018-02-05 08:15:59 +0000 UTC"},
 	}
 
 	for _, testCase := range testCases {
@@ -37,3 +40,36 @@ func TestUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		tme      Time
+		expected string
+	}{
+		{Time{}, `{"started_at":""}`},
+		{Time{time.Date(2018, 2, 5, 8, 15, 59, 0, time.UTC)}, `{"started_at":"2018-02-05T08:15:59Z"}`},
+	}
+
+	for _, testCase := range testCases {
+		data, err := json.Marshal(timeTest{T: testCase.tme})
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if string(data) != testCase.expected {
+			t.Errorf("expected JSON to be %s, got %s", testCase.expected, data)
+		}
+
+		decoded := &timeTest{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Error(err)
+		}
+
+		if !decoded.T.Equal(testCase.tme.Time) {
+			t.Errorf("expected decoded time to be %s, got %s", testCase.tme, decoded.T)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package helix
+
+import (
+	"net/http"
+	"time"
+)
+
+// The types of token a client can send, see TokenInfo.
+const (
+	TokenTypeApp       = "app"
+	TokenTypeUser      = "user"
+	TokenTypeExtension = "extension"
+)
+
+// TokenInfo describes the token the client currently sends. Expiry is only
+// known for tokens the client requested, validated or loaded from its token
+// store, ExpiresAt is zero otherwise.
+type TokenInfo struct {
+	Type       string        // One of the TokenType constants, empty if no token is set
+	Scopes     []string      // The scopes the token was granted, if known
+	ExpiresAt  time.Time     // When the token expires, zero if unknown
+	ExpiresIn  time.Duration // The time left until ExpiresAt, zero if unknown
+	UserID     string        // The user the token belongs to, if it was validated
+	CanRefresh bool          // Whether the client refreshes the token itself
+}
+
+// tokenMetadata is what the client learned about a token from Twitch.
+type tokenMetadata struct {
+	scopes    []string
+	expiresAt time.Time
+	userID    string
+}
+
+// TokenInfo returns the type, scopes and expiry of the token the client
+// currently sends, e.g. the user access token if one is set, so services can
+// refresh or alert before it expires.
+func (c *Client) TokenInfo() TokenInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var info TokenInfo
+	switch {
+	case c.opts.ExtensionOpts.SignedJWTToken != "":
+		info.Type = TokenTypeExtension
+	case c.opts.UserAccessToken != "":
+		info.Type = TokenTypeUser
+		info.Scopes = c.opts.UserAccessTokenScopes
+		info.CanRefresh = c.canRefreshToken()
+	case c.opts.AppAccessToken != "":
+		info.Type = TokenTypeApp
+	default:
+		return info
+	}
+
+	if metadata, ok := c.tokenMetadata[rateLimitKey(c.bearerToken())]; ok {
+		if info.Scopes == nil {
+			info.Scopes = metadata.scopes
+		}
+		info.ExpiresAt = metadata.expiresAt
+		info.UserID = metadata.userID
+	}
+
+	if !info.ExpiresAt.IsZero() {
+		info.ExpiresIn = info.ExpiresAt.Sub(c.now())
+		if info.ExpiresIn < 0 {
+			info.ExpiresIn = 0
+		}
+	}
+
+	return info
+}
+
+// recordTokenMetadata remembers the scopes and expiry of a token. An
+// expiresIn of zero means the expiry is unknown. Metadata of tokens that
+// have expired is dropped, so refreshed tokens don't pile up.
+func (c *Client) recordTokenMetadata(token, userID string, scopes []string, expiresIn time.Duration) {
+	if token == "" {
+		return
+	}
+
+	var expiresAt time.Time
+	if expiresIn > 0 {
+		expiresAt = c.now().Add(expiresIn)
+	}
+
+	c.setTokenMetadata(token, tokenMetadata{scopes: scopes, expiresAt: expiresAt, userID: userID})
+}
+
+// setTokenMetadata keeps what is already known about a token unless metadata
+// replaces it. Metadata of other tokens that have expired is dropped, so
+// refreshed tokens don't pile up.
+func (c *Client) setTokenMetadata(token string, metadata tokenMetadata) {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tokenMetadata == nil {
+		c.tokenMetadata = map[string]tokenMetadata{}
+	}
+	key := rateLimitKey(token)
+	current := rateLimitKey(c.bearerToken())
+	for k, m := range c.tokenMetadata {
+		if k != current && !m.expiresAt.IsZero() && m.expiresAt.Before(now) {
+			delete(c.tokenMetadata, k)
+		}
+	}
+
+	if previous, ok := c.tokenMetadata[key]; ok {
+		if metadata.scopes == nil {
+			metadata.scopes = previous.scopes
+		}
+		if metadata.expiresAt.IsZero() {
+			metadata.expiresAt = previous.expiresAt
+		}
+		if metadata.userID == "" {
+			metadata.userID = previous.userID
+		}
+	}
+	c.tokenMetadata[key] = metadata
+}
+
+// requestAccessCredentials requests a token from the token endpoints and
+// remembers its scopes and expiry for TokenInfo.
+func (c *Client) requestAccessCredentials(path string, data interface{}) (*ResponseOf[AccessCredentials], error) {
+	resp, err := postResponse[AccessCredentials](c, path, data)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		c.recordTokenMetadata(resp.Data.AccessToken, "", resp.Data.Scopes, time.Duration(resp.Data.ExpiresIn)*time.Second)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,175 @@
+package helix
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenInfo(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+
+	c := newMockClient(&Options{
+		ClientID:     "my-client-id",
+		ClientSecret: "my-client-secret",
+		Clock:        clock,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, authPaths["validate"]):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"client_id":"my-client-id","login":"justin","scopes":["chat:read"],"user_id":"123","expires_in":600}`))
+		case r.URL.Query().Get("grant_type") == "client_credentials":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"app-access-token","expires_in":3600,"token_type":"bearer"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"user-access-token","refresh_token":"refresh-token","expires_in":14400,"scope":["chat:read","chat:edit"],"token_type":"bearer"}`))
+		}
+	})
+
+	if info := c.TokenInfo(); info.Type != "" {
+		t.Errorf("expected no token type without a token, got %+v", info)
+	}
+
+	resp, err := c.RequestAppAccessToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetAppAccessToken(resp.Data.AccessToken)
+
+	clock.Advance(time.Minute)
+
+	info := c.TokenInfo()
+	if info.Type != TokenTypeApp || info.ExpiresIn != 59*time.Minute || info.CanRefresh {
+		t.Errorf("unexpected app token info %+v", info)
+	}
+
+	// The user access token is sent instead of the app access token
+	userResp, err := c.RequestUserAccessToken("code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUserAccessToken(userResp.Data.AccessToken)
+	c.SetRefreshToken(userResp.Data.RefreshToken)
+
+	info = c.TokenInfo()
+	if info.Type != TokenTypeUser || !info.CanRefresh {
+		t.Errorf("unexpected user token info %+v", info)
+	}
+	if !info.ExpiresAt.Equal(clock.Now().Add(4*time.Hour)) || info.ExpiresIn != 4*time.Hour {
+		t.Errorf("expected the user token to expire in 4h, got %+v", info)
+	}
+	if !reflect.DeepEqual(info.Scopes, []string{"chat:read", "chat:edit"}) {
+		t.Errorf("unexpected scopes %v", info.Scopes)
+	}
+
+	// Validating the token updates its expiry and scopes
+	if _, _, err := c.ValidateToken("user-access-token"); err != nil {
+		t.Fatal(err)
+	}
+
+	info = c.TokenInfo()
+	if info.ExpiresIn != 10*time.Minute || !reflect.DeepEqual(info.Scopes, []string{"chat:read"}) {
+		t.Errorf("expected the validated expiry and scopes, got %+v", info)
+	}
+
+	clock.Advance(time.Hour)
+	if info := c.TokenInfo(); info.ExpiresIn != 0 {
+		t.Errorf("expected an expired token to have no time left, got %v", info.ExpiresIn)
+	}
+
+	// A token the client knows nothing about has no expiry
+	c.SetUserAccessToken("other-user-access-token")
+	if info := c.TokenInfo(); !info.ExpiresAt.IsZero() || info.Scopes != nil {
+		t.Errorf("expected unknown expiry and scopes, got %+v", info)
+	}
+}
+
+func TestTokenInfoFromTokenStore(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	store := NewMemoryTokenStore()
+	expiresAt := clock.Now().Add(2 * time.Hour)
+	if err := store.SetToken(TokenStoreKeyUser, &StoredToken{AccessToken: "stored-user-access-token", ExpiresAt: expiresAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newMockClient(&Options{
+		ClientID:   "my-client-id",
+		TokenStore: store,
+		Clock:      clock,
+	}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	if _, err := c.GetUsers(&UsersParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	info := c.TokenInfo()
+	if info.Type != TokenTypeUser || !info.ExpiresAt.Equal(expiresAt) || info.CanRefresh {
+		t.Errorf("unexpected token info %+v", info)
+	}
+}
+
+func TestTokenInfoForgetsReplacedTokens(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{
+		ClientID:     "my-client-id",
+		ClientSecret: "my-client-secret",
+	}, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, authPaths["revoke"]):
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Query().Get("grant_type") == "client_credentials":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"app-access-token","expires_in":3600,"token_type":"bearer"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token":"user-access-token","refresh_token":"refresh-token","expires_in":14400,"scope":["chat:read"],"token_type":"bearer"}`))
+		}
+	})
+
+	appResp, err := c.RequestAppAccessToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetAppAccessToken(appResp.Data.AccessToken)
+
+	userResp, err := c.RequestUserAccessToken("code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUserAccessToken(userResp.Data.AccessToken)
+
+	for _, token := range []string{"app-access-token", "user-access-token"} {
+		if _, ok := c.tokenMetadata[token]; ok {
+			t.Errorf("expected the metadata of %s not to be keyed by the token", token)
+		}
+		if _, ok := c.tokenMetadata[rateLimitKey(token)]; !ok {
+			t.Errorf("expected the metadata of %s to be kept", token)
+		}
+	}
+
+	// Replacing the app access token forgets what was known about it
+	c.SetAppAccessToken("other-app-access-token")
+	if _, ok := c.tokenMetadata[rateLimitKey("app-access-token")]; ok {
+		t.Error("expected the metadata of the replaced app access token to be removed")
+	}
+
+	// So does revoking the user access token
+	if _, err := c.RevokeUserAccessToken("user-access-token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.tokenMetadata[rateLimitKey("user-access-token")]; ok {
+		t.Error("expected the metadata of the revoked user access token to be removed")
+	}
+
+	if len(c.tokenMetadata) != 0 {
+		t.Errorf("expected no token metadata to be left, got %d entries", len(c.tokenMetadata))
+	}
+}
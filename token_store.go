@@ -0,0 +1,231 @@
+package helix
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Keys of the client's tokens in a TokenStore
+const (
+	TokenStoreKeyApp  = "app"
+	TokenStoreKeyUser = "user"
+)
+
+// StoredToken is an access token kept in a TokenStore.
+type StoredToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"` // Zero if unknown
+}
+
+// ExpiredAt reports whether the token's expiry is known and has passed at
+// now, e.g. time.Now().
+func (t *StoredToken) ExpiredAt(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && now.After(t.ExpiresAt)
+}
+
+// TokenStore keeps the client's app and user access tokens, see
+// Options.TokenStore. GetToken returns nil if no token is stored with the
+// key. Implementations must be safe for concurrent use.
+type TokenStore interface {
+	GetToken(key string) (*StoredToken, error)
+	SetToken(key string, token *StoredToken) error
+}
+
+// MemoryTokenStore is a TokenStore that shares tokens between the clients
+// of a process.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]StoredToken
+}
+
+// NewMemoryTokenStore returns an empty in-memory token store.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]StoredToken{}}
+}
+
+func (s *MemoryTokenStore) GetToken(key string) (*StoredToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	token, ok := s.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) SetToken(key string, token *StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token == nil {
+		delete(s.tokens, key)
+		return nil
+	}
+	s.tokens[key] = *token
+
+	return nil
+}
+
+// FileTokenStore is a TokenStore that keeps tokens in a JSON file, so
+// processes sharing the file use the tokens refreshed by any of them. The file
+// is read on every GetToken and replaced atomically on every SetToken. On Unix
+// systems SetToken holds an advisory lock on the file while it reads and
+// writes it, so processes setting tokens at the same time don't lose each
+// other's updates. Elsewhere only the clients of a single process should
+// share the file.
+type FileTokenStore struct {
+	file jsonFile[StoredToken]
+}
+
+// NewFileTokenStore returns a store that keeps tokens in the file at path.
+// The file is created with owner-only permissions on the first SetToken.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{file: jsonFile[StoredToken]{path: path}}
+}
+
+func (s *FileTokenStore) GetToken(key string) (*StoredToken, error) {
+	token, ok, err := s.file.get(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (s *FileTokenStore) SetToken(key string, token *StoredToken) error {
+	return s.file.set(key, token)
+}
+
+// seedTokenStore saves the tokens passed as options in the token store,
+// unless it already holds tokens of the same kind.
+func (c *Client) seedTokenStore() error {
+	store := c.opts.TokenStore
+	if store == nil {
+		return nil
+	}
+
+	if c.opts.AppAccessToken != "" {
+		token, err := store.GetToken(TokenStoreKeyApp)
+		if err != nil {
+			return err
+		}
+		if token == nil || token.AccessToken == "" {
+			c.storeAppToken(time.Time{})
+		}
+	}
+
+	if c.opts.UserAccessToken != "" {
+		token, err := store.GetToken(TokenStoreKeyUser)
+		if err != nil {
+			return err
+		}
+		if token == nil || token.AccessToken == "" {
+			c.storeUserToken(time.Time{})
+		}
+	}
+
+	return nil
+}
+
+// loadStoredTokens updates the client's tokens with those in the token store,
+// so tokens refreshed by other clients are picked up before each request.
+// Expired tokens that can't be refreshed are ignored.
+func (c *Client) loadStoredTokens() error {
+	store := c.opts.TokenStore
+	if store == nil {
+		return nil
+	}
+
+	appToken, err := store.GetToken(TokenStoreKeyApp)
+	if err != nil {
+		return err
+	}
+
+	userToken, err := store.GetToken(TokenStoreKeyUser)
+	if err != nil {
+		return err
+	}
+
+	now := c.now()
+
+	if appToken != nil && appToken.AccessToken != "" {
+		c.setTokenMetadata(appToken.AccessToken, tokenMetadata{expiresAt: appToken.ExpiresAt})
+	}
+	if userToken != nil && userToken.AccessToken != "" {
+		c.setTokenMetadata(userToken.AccessToken, tokenMetadata{expiresAt: userToken.ExpiresAt})
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if appToken != nil && appToken.AccessToken != "" && !appToken.ExpiredAt(now) {
+		if c.opts.AppAccessToken != appToken.AccessToken {
+			c.forgetToken(c.opts.AppAccessToken)
+		}
+		c.opts.AppAccessToken = appToken.AccessToken
+	}
+
+	if userToken != nil && userToken.AccessToken != "" && (!userToken.ExpiredAt(now) || userToken.RefreshToken != "") {
+		if c.opts.UserAccessToken != userToken.AccessToken {
+			c.opts.UserAccessTokenScopes = nil
+			c.forgetToken(c.opts.UserAccessToken)
+		}
+		c.opts.UserAccessToken = userToken.AccessToken
+		c.opts.RefreshToken = userToken.RefreshToken
+	}
+
+	return nil
+}
+
+// storeAppToken saves the client's app access token in the token store.
+func (c *Client) storeAppToken(expiresAt time.Time) {
+	store := c.opts.TokenStore
+	if store == nil {
+		return
+	}
+
+	c.mu.RLock()
+	token := &StoredToken{AccessToken: c.opts.AppAccessToken, ExpiresAt: expiresAt}
+	c.mu.RUnlock()
+
+	if err := store.SetToken(TokenStoreKeyApp, token); err != nil {
+		c.logTokenStoreError("could not store app access token", err)
+	}
+}
+
+// storeUserToken saves the client's user access and refresh tokens in the
+// token store.
+func (c *Client) storeUserToken(expiresAt time.Time) {
+	store := c.opts.TokenStore
+	if store == nil {
+		return
+	}
+
+	c.mu.RLock()
+	token := &StoredToken{
+		AccessToken:  c.opts.UserAccessToken,
+		RefreshToken: c.opts.RefreshToken,
+		ExpiresAt:    expiresAt,
+	}
+	c.mu.RUnlock()
+
+	if err := store.SetToken(TokenStoreKeyUser, token); err != nil {
+		c.logTokenStoreError("could not store user access token", err)
+	}
+}
+
+// logTokenStoreError reports a token that couldn't be saved to the logger
+// at error level, or to the standard logger if the client's logger isn't an
+// ErrorLogger.
+func (c *Client) logTokenStoreError(msg string, err error) {
+	if logger, ok := c.opts.Logger.(ErrorLogger); ok {
+		logger.Error("helix token store failed", "error", msg+": "+err.Error())
+		return
+	}
+
+	log.Printf("helix: %s: %v", msg, err)
+}
@@ -0,0 +1,78 @@
+package helix
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Defaults of TransportOptions. net/http keeps only 2 idle connections per
+// host, so a busy client keeps opening new connections to the API.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 100
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// defaultHTTPClient is the HTTPClient of clients that set neither HTTPClient
+// nor Transport, so that they share one connection pool.
+var defaultHTTPClient = &http.Client{Transport: NewTransport(nil)}
+
+// TransportOptions tune the connection pool of a transport built by
+// NewTransport. Zero values are replaced with the defaults.
+type TransportOptions struct {
+	MaxIdleConns        int           // Idle connections kept across all hosts
+	MaxIdleConnsPerHost int           // Idle connections kept per host
+	MaxConnsPerHost     int           // Zero means no limit
+	IdleConnTimeout     time.Duration // How long an idle connection is kept
+	TLSClientConfig     *tls.Config
+
+	// Proxy returns the proxy of a request, defaults to
+	// http.ProxyFromEnvironment.
+	Proxy func(*http.Request) (*url.URL, error)
+}
+
+// NewTransport returns a transport with HTTP/2 enabled and a connection pool
+// sized for busy clients. Set it as the Transport of every client that should
+// share its connections, opts may be nil.
+func NewTransport(opts *TransportOptions) *http.Transport {
+	if opts == nil {
+		opts = &TransportOptions{}
+	}
+
+	transport := &http.Transport{
+		Proxy: opts.Proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          opts.MaxIdleConns,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		IdleConnTimeout:       opts.IdleConnTimeout,
+		TLSClientConfig:       opts.TLSClientConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	if transport.Proxy == nil {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	if transport.MaxIdleConns == 0 {
+		transport.MaxIdleConns = DefaultMaxIdleConns
+	}
+
+	if transport.MaxIdleConnsPerHost == 0 {
+		transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	if transport.IdleConnTimeout == 0 {
+		transport.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	return transport
+}
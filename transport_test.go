@@ -0,0 +1,66 @@
+package helix
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+
+	transport := NewTransport(nil)
+	if transport.MaxIdleConns != DefaultMaxIdleConns || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || transport.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("expected default pool settings, got %d, %d and %s", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	if !transport.ForceAttemptHTTP2 || transport.Proxy == nil {
+		t.Error("expected HTTP/2 and the environment proxy to be enabled")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS13}
+	transport = NewTransport(&TransportOptions{
+		MaxIdleConns:        500,
+		MaxIdleConnsPerHost: 200,
+		MaxConnsPerHost:     300,
+		IdleConnTimeout:     time.Minute,
+		TLSClientConfig:     tlsConfig,
+	})
+	if transport.MaxIdleConns != 500 || transport.MaxIdleConnsPerHost != 200 || transport.MaxConnsPerHost != 300 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("expected the given pool settings, got %d, %d, %d and %s", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	if transport.TLSClientConfig != tlsConfig {
+		t.Error("expected the given TLS config")
+	}
+}
+
+func TestNewClientTransport(t *testing.T) {
+	t.Parallel()
+
+	c1, err := NewClient(&Options{ClientID: "my-client-id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c2, err := NewClient(&Options{ClientID: "my-client-id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c1.opts.HTTPClient != c2.opts.HTTPClient {
+		t.Error("expected clients without an HTTP client to share one")
+	}
+
+	transport := NewTransport(nil)
+	c3, err := NewClient(&Options{ClientID: "my-client-id", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	httpClient, ok := c3.opts.HTTPClient.(*http.Client)
+	if !ok || httpClient.Transport != transport {
+		t.Error("expected the HTTP client to use the given transport")
+	}
+}
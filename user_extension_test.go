@@ -2,6 +2,8 @@ package helix
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"testing"
 )
@@ -191,3 +193,69 @@ func TestUpdateUserExtensions(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestUpdateUserExtensionsRearrange(t *testing.T) {
+	t.Parallel()
+
+	active := &UserActiveExtension{
+		Panel: map[string]UserActiveExtensionInfo{
+			"1": {Active: true, ID: "rh6jq1q334hqc2rr1qlzqbvwlfl3x0", Version: "1.1.0"},
+			"2": {Active: true, ID: "wi08ebtatdc7oj83wtl9uxwz807l8b", Version: "1.1.8"},
+		},
+		Component: map[string]UserActiveExtensionInfo{
+			"1": {Active: true, ID: "lqnf3zxk0rv0g7gq92mtmnirjz2cjj", Version: "0.0.1", X: 0, Y: 0},
+		},
+	}
+
+	payload := active.UpdatePayload()
+	payload.Panel["1"], payload.Panel["2"] = payload.Panel["2"], payload.Panel["1"]
+
+	if active.Panel["1"].ID != "rh6jq1q334hqc2rr1qlzqbvwlfl3x0" {
+		t.Error("expected rearranging the payload not to modify the active extensions")
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "my-access-token"}, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		var sent wrappedUpdateUserExtensionsPayload
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Fatal(err)
+		}
+
+		if sent.Panel["1"].ID != "wi08ebtatdc7oj83wtl9uxwz807l8b" || sent.Panel["2"].ID != "rh6jq1q334hqc2rr1qlzqbvwlfl3x0" {
+			t.Errorf("expected panels to be swapped, got %s", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":{"panel":{}, "component": {}, "overlay": {}}}`))
+	})
+
+	if _, err := c.UpdateUserExtensions(payload); err != nil {
+		t.Error(err)
+	}
+
+	testCases := []struct {
+		payload       *UpdateUserExtensionsPayload
+		validationErr string
+	}{
+		{
+			&UpdateUserExtensionsPayload{Panel: map[string]UserActiveExtensionInfo{"4": {}}},
+			`error: panel slot must be between 1 and 3, got "4"`,
+		},
+		{
+			&UpdateUserExtensionsPayload{Overlay: map[string]UserActiveExtensionInfo{"2": {}}},
+			`error: overlay slot must be "1", got "2"`,
+		},
+		{
+			&UpdateUserExtensionsPayload{Component: map[string]UserActiveExtensionInfo{"0": {}}},
+			`error: component slot must be between 1 and 2, got "0"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := c.UpdateUserExtensions(testCase.payload)
+		if err == nil || err.Error() != testCase.validationErr {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", testCase.validationErr, err)
+		}
+	}
+}
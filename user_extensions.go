@@ -1,5 +1,15 @@
 package helix
 
+import "fmt"
+
+// Types of extensions a user has installed
+const (
+	UserExtensionTypeComponent = "component"
+	UserExtensionTypeMobile    = "mobile"
+	UserExtensionTypeOverlay   = "overlay"
+	UserExtensionTypePanel     = "panel"
+)
+
 type UserExtension struct {
 	CanActivate bool     `json:"can_activate"`
 	ID          string   `json:"id"`
@@ -12,26 +22,14 @@ type ManyUserExtensions struct {
 	UserExtensions []UserExtension `json:"data"`
 }
 
-type UserExtensionsResponse struct {
-	ResponseCommon
-	Data ManyUserExtensions
-}
+type UserExtensionsResponse = ResponseOf[ManyUserExtensions]
 
 // GetUserExtensions gets a list of all extensions (both active and inactive) for a specified user,
 // identified by a Bearer token
 //
 // Required scope: user:read:broadcast
 func (c *Client) GetUserExtensions() (*UserExtensionsResponse, error) {
-	resp, err := c.get("/users/extensions/list", &ManyUserExtensions{}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	userExtensions := &UserExtensionsResponse{}
-	resp.HydrateResponseCommon(&userExtensions.ResponseCommon)
-	userExtensions.Data.UserExtensions = resp.Data.(*ManyUserExtensions).UserExtensions
-
-	return userExtensions, nil
+	return getResponse[ManyUserExtensions](c, "/users/extensions/list", nil)
 }
 
 type UserActiveExtensionInfo struct {
@@ -49,14 +47,33 @@ type UserActiveExtension struct {
 	Panel     map[string]UserActiveExtensionInfo `json:"panel"`
 }
 
+// UpdatePayload returns a payload for UpdateUserExtensions containing the
+// currently active extensions, so they can be rearranged and sent back.
+func (e *UserActiveExtension) UpdatePayload() *UpdateUserExtensionsPayload {
+	payload := &UpdateUserExtensionsPayload{
+		Component: make(map[string]UserActiveExtensionInfo, len(e.Component)),
+		Overlay:   make(map[string]UserActiveExtensionInfo, len(e.Overlay)),
+		Panel:     make(map[string]UserActiveExtensionInfo, len(e.Panel)),
+	}
+
+	for slot, info := range e.Component {
+		payload.Component[slot] = info
+	}
+	for slot, info := range e.Overlay {
+		payload.Overlay[slot] = info
+	}
+	for slot, info := range e.Panel {
+		payload.Panel[slot] = info
+	}
+
+	return payload
+}
+
 type UserActiveExtensionSet struct {
 	UserActiveExtensions UserActiveExtension `json:"data"`
 }
 
-type UserActiveExtensionsResponse struct {
-	ResponseCommon
-	Data UserActiveExtensionSet
-}
+type UserActiveExtensionsResponse = ResponseOf[UserActiveExtensionSet]
 
 type UserActiveExtensionsParams struct {
 	UserID string `query:"user_id"` // Optional, limit 1
@@ -67,16 +84,7 @@ type UserActiveExtensionsParams struct {
 //
 // Optional scope: user:read:broadcast or user:edit:broadcast
 func (c *Client) GetUserActiveExtensions(params *UserActiveExtensionsParams) (*UserActiveExtensionsResponse, error) {
-	resp, err := c.get("/users/extensions", &UserActiveExtensionSet{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	userActiveExtensions := &UserActiveExtensionsResponse{}
-	resp.HydrateResponseCommon(&userActiveExtensions.ResponseCommon)
-	userActiveExtensions.Data.UserActiveExtensions = resp.Data.(*UserActiveExtensionSet).UserActiveExtensions
-
-	return userActiveExtensions, nil
+	return getResponse[UserActiveExtensionSet](c, "/users/extensions", params)
 }
 
 type UpdateUserExtensionsPayload struct {
@@ -85,28 +93,57 @@ type UpdateUserExtensionsPayload struct {
 	Panel     map[string]UserActiveExtensionInfo `json:"panel,omitempty"`
 }
 
+// Number of slots of each extension type a user can activate extensions in
+const (
+	userExtensionPanelSlots     = 3
+	userExtensionOverlaySlots   = 1
+	userExtensionComponentSlots = 2
+)
+
+func validateUserExtensionSlots(extensionType string, slots map[string]UserActiveExtensionInfo, max int) error {
+	for slot := range slots {
+		valid := false
+		for i := 1; i <= max; i++ {
+			if slot == fmt.Sprint(i) {
+				valid = true
+				break
+			}
+		}
+
+		if !valid && max == 1 {
+			return fmt.Errorf("error: %s slot must be \"1\", got %q", extensionType, slot)
+		}
+
+		if !valid {
+			return fmt.Errorf("error: %s slot must be between 1 and %d, got %q", extensionType, max, slot)
+		}
+	}
+
+	return nil
+}
+
 type wrappedUpdateUserExtensionsPayload struct {
 	UpdateUserExtensionsPayload `json:"data"`
 }
 
 // UpdateUserExtensions Updates the activation state, extension ID, and/or version number of installed extensions for a specified user, identified by a Bearer token.
 // If you try to activate a given extension under multiple extension types, the last write wins (and there is no guarantee of write order).
+// Panels can be set in slots "1" to "3", overlays in slot "1" and components in slots "1" and "2".
 //
 // Required scope: user:edit:broadcast
 func (c *Client) UpdateUserExtensions(payload *UpdateUserExtensionsPayload) (*UserActiveExtensionsResponse, error) {
-	normalizedPayload := &wrappedUpdateUserExtensionsPayload{UpdateUserExtensionsPayload: *payload}
-	resp, err := c.putAsJSON("/users/extensions", &UserActiveExtensionSet{}, normalizedPayload)
-	if err != nil {
+	if err := validateUserExtensionSlots(UserExtensionTypePanel, payload.Panel, userExtensionPanelSlots); err != nil {
 		return nil, err
 	}
 
-	userActiveExtensions := &UserActiveExtensionsResponse{}
-	userActiveExtensions.StatusCode = resp.StatusCode
-	userActiveExtensions.Header = resp.Header
-	userActiveExtensions.Error = resp.Error
-	userActiveExtensions.ErrorStatus = resp.ErrorStatus
-	userActiveExtensions.ErrorMessage = resp.ErrorMessage
-	userActiveExtensions.Data.UserActiveExtensions = resp.Data.(*UserActiveExtensionSet).UserActiveExtensions
+	if err := validateUserExtensionSlots(UserExtensionTypeOverlay, payload.Overlay, userExtensionOverlaySlots); err != nil {
+		return nil, err
+	}
 
-	return userActiveExtensions, nil
+	if err := validateUserExtensionSlots(UserExtensionTypeComponent, payload.Component, userExtensionComponentSlots); err != nil {
+		return nil, err
+	}
+
+	normalizedPayload := &wrappedUpdateUserExtensionsPayload{UpdateUserExtensionsPayload: *payload}
+	return putAsJSONResponse[UserActiveExtensionSet](c, "/users/extensions", normalizedPayload)
 }
@@ -0,0 +1,229 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUserNotFound is returned by UserResolver when no user has the given
+// login or ID.
+var ErrUserNotFound = errors.New("error: user not found")
+
+// Default cache durations of a UserResolver
+const (
+	DefaultUserResolverTTL         = time.Hour
+	DefaultUserResolverNegativeTTL = 5 * time.Minute
+)
+
+// userResolverPruneSize is the number of cached entries above which expired
+// entries are dropped.
+const userResolverPruneSize = 10000
+
+// UserResolver resolves user logins to IDs and back with GetUsers, caching
+// both directions. Logins and IDs that don't belong to any user are cached
+// too, for a shorter time, so unknown names aren't looked up on every chat
+// message. It is safe for concurrent use.
+type UserResolver struct {
+	client      *Client
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	byLogin map[string]userResolverEntry
+	byID    map[string]userResolverEntry
+}
+
+type userResolverEntry struct {
+	value     string // Empty for users that don't exist
+	expiresAt time.Time
+}
+
+// NewUserResolver returns a resolver that caches users for ttl and unknown
+// users for negativeTTL, DefaultUserResolverTTL and
+// DefaultUserResolverNegativeTTL if they aren't positive.
+func (c *Client) NewUserResolver(ttl, negativeTTL time.Duration) *UserResolver {
+	if ttl <= 0 {
+		ttl = DefaultUserResolverTTL
+	}
+
+	if negativeTTL <= 0 {
+		negativeTTL = DefaultUserResolverNegativeTTL
+	}
+
+	return &UserResolver{
+		client:      c,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		byLogin:     map[string]userResolverEntry{},
+		byID:        map[string]userResolverEntry{},
+	}
+}
+
+// ID returns the ID of the user with the given login, or ErrUserNotFound.
+func (r *UserResolver) ID(login string) (string, error) {
+	ids, err := r.IDs([]string{login})
+	if err != nil {
+		return "", err
+	}
+
+	id, ok := ids[strings.ToLower(login)]
+	if !ok {
+		return "", ErrUserNotFound
+	}
+
+	return id, nil
+}
+
+// Login returns the login of the user with the given ID, or ErrUserNotFound.
+func (r *UserResolver) Login(id string) (string, error) {
+	logins, err := r.Logins([]string{id})
+	if err != nil {
+		return "", err
+	}
+
+	login, ok := logins[id]
+	if !ok {
+		return "", ErrUserNotFound
+	}
+
+	return login, nil
+}
+
+// IDs resolves many logins at once, looking up those that aren't cached in
+// batches of 100. The returned map is keyed by lowercase login and lacks the
+// logins that don't belong to any user.
+func (r *UserResolver) IDs(logins []string) (map[string]string, error) {
+	keys := make([]string, len(logins))
+	for i, login := range logins {
+		keys[i] = strings.ToLower(login)
+	}
+
+	return r.resolve(keys, true)
+}
+
+// Logins resolves many IDs at once, looking up those that aren't cached in
+// batches of 100. The returned map is keyed by ID and lacks the IDs that
+// don't belong to any user.
+func (r *UserResolver) Logins(ids []string) (map[string]string, error) {
+	return r.resolve(ids, false)
+}
+
+func (r *UserResolver) resolve(keys []string, byLogin bool) (map[string]string, error) {
+	results := make(map[string]string, len(keys))
+	var missing []string
+
+	now := r.client.now()
+
+	r.mu.Lock()
+	cache := r.byID
+	if byLogin {
+		cache = r.byLogin
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		entry, ok := cache[key]
+		if !ok || !now.Before(entry.expiresAt) {
+			missing = append(missing, key)
+			continue
+		}
+
+		if entry.value != "" {
+			results[key] = entry.value
+		}
+	}
+	r.mu.Unlock()
+
+	for start := 0; start < len(missing); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		if err := r.fetch(missing[start:end], byLogin, results); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// fetch looks up the users with the given logins or IDs, caches them and adds
+// them to results.
+func (r *UserResolver) fetch(keys []string, byLogin bool, results map[string]string) error {
+	params := &UsersParams{IDs: keys}
+	if byLogin {
+		params = &UsersParams{Logins: keys}
+	}
+
+	resp, err := r.client.GetUsers(params)
+	if err != nil {
+		return err
+	}
+
+	if err := resp.Err(); err != nil {
+		return fmt.Errorf("error: could not get users: %w", err)
+	}
+
+	now := r.client.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := make(map[string]bool, len(resp.Data.Users))
+	for _, user := range resp.Data.Users {
+		login := strings.ToLower(user.Login)
+
+		r.byLogin[login] = userResolverEntry{value: user.ID, expiresAt: now.Add(r.ttl)}
+		r.byID[user.ID] = userResolverEntry{value: login, expiresAt: now.Add(r.ttl)}
+
+		if byLogin {
+			found[login] = true
+			results[login] = user.ID
+		} else {
+			found[user.ID] = true
+			results[user.ID] = login
+		}
+	}
+
+	cache := r.byID
+	if byLogin {
+		cache = r.byLogin
+	}
+
+	for _, key := range keys {
+		if !found[key] {
+			cache[key] = userResolverEntry{expiresAt: now.Add(r.negativeTTL)}
+		}
+	}
+
+	if len(r.byLogin)+len(r.byID) > userResolverPruneSize {
+		r.prune(now)
+	}
+
+	return nil
+}
+
+// prune drops expired entries, so the cache of a long running bot doesn't
+// keep every viewer it ever saw.
+func (r *UserResolver) prune(now time.Time) {
+	for key, entry := range r.byLogin {
+		if !now.Before(entry.expiresAt) {
+			delete(r.byLogin, key)
+		}
+	}
+
+	for key, entry := range r.byID {
+		if !now.Before(entry.expiresAt) {
+			delete(r.byID, key)
+		}
+	}
+}
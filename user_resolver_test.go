@@ -0,0 +1,154 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// userResolverHandler serves users whose login is "user" followed by their ID,
+// for IDs below 1000.
+func userResolverHandler(requests *int, lookups *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		query := r.URL.Query()
+		var users []string
+		for _, id := range query["id"] {
+			*lookups++
+			if len(id) < 4 {
+				users = append(users, fmt.Sprintf(`{"id":"%s","login":"user%s"}`, id, id))
+			}
+		}
+		for _, login := range query["login"] {
+			*lookups++
+			if id := strings.TrimPrefix(login, "user"); id != login && len(id) < 4 {
+				users = append(users, fmt.Sprintf(`{"id":"%s","login":"User%s"}`, id, id))
+			}
+		}
+
+		w.Write([]byte(`{"data":[` + strings.Join(users, ",") + `]}`))
+	}
+}
+
+func TestUserResolver(t *testing.T) {
+	t.Parallel()
+
+	var requests, lookups int
+	clock := newFakeClock()
+	c := newMockClient(&Options{ClientID: "my-client-id", Clock: clock}, userResolverHandler(&requests, &lookups))
+	resolver := c.NewUserResolver(time.Hour, time.Minute)
+
+	id, err := resolver.ID("User42")
+	if err != nil || id != "42" {
+		t.Errorf("expected id 42, got %q (%v)", id, err)
+	}
+
+	// Both directions are cached
+	login, err := resolver.Login("42")
+	if err != nil || login != "user42" {
+		t.Errorf("expected login user42, got %q (%v)", login, err)
+	}
+
+	if _, err := resolver.ID("user42"); err != nil {
+		t.Error(err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request to be sent, got %d", requests)
+	}
+
+	// Unknown users are cached for the negative TTL
+	for i := 0; i < 2; i++ {
+		if _, err := resolver.ID("nobody"); !errors.Is(err, ErrUserNotFound) {
+			t.Errorf("expected %v, got %v", ErrUserNotFound, err)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("expected 2 requests to be sent, got %d", requests)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if _, err := resolver.ID("nobody"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected %v, got %v", ErrUserNotFound, err)
+	}
+
+	if _, err := resolver.ID("user42"); err != nil {
+		t.Error(err)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests to be sent, got %d", requests)
+	}
+
+	// Known users expire after the TTL
+	clock.Advance(time.Hour)
+	if _, err := resolver.ID("user42"); err != nil {
+		t.Error(err)
+	}
+
+	if requests != 4 {
+		t.Errorf("expected 4 requests to be sent, got %d", requests)
+	}
+}
+
+func TestUserResolverBatches(t *testing.T) {
+	t.Parallel()
+
+	var requests, lookups int
+	c := newMockClient(&Options{ClientID: "my-client-id"}, userResolverHandler(&requests, &lookups))
+	resolver := c.NewUserResolver(0, 0)
+
+	ids := make([]string, 0, 250)
+	for i := 0; i < 250; i++ {
+		ids = append(ids, fmt.Sprint(i*5))
+	}
+
+	logins, err := resolver.Logins(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// IDs from 1000 on don't exist
+	if len(logins) != 200 || logins["995"] != "user995" {
+		t.Errorf("expected 200 logins, got %d", len(logins))
+	}
+
+	if requests != 3 || lookups != 250 {
+		t.Errorf("expected 250 lookups in 3 requests, got %d in %d", lookups, requests)
+	}
+
+	if _, err := resolver.Logins(ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected cached logins to be reused, got %d requests", requests)
+	}
+}
+
+func TestUserResolverErrors(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusUnauthorized, `{"error":"Unauthorized","status":401,"message":"OAuth token is missing"}`, nil))
+	resolver := c.NewUserResolver(0, 0)
+
+	_, err := resolver.ID("user42")
+	if err == nil || err.Error() != "error: could not get users: 401 Unauthorized: OAuth token is missing" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// Test with HTTP Failure
+	c = newMockClient(&Options{ClientID: "my-client-id"}, nil)
+	c.opts.HTTPClient = &badMockHTTPClient{newMockHandler(0, "", nil)}
+	resolver = c.NewUserResolver(0, 0)
+
+	_, err = resolver.Login("42")
+	if err == nil || err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package helix
 
-import "time"
+import (
+	"errors"
+	"net/http"
+)
 
 type User struct {
 	ID              string `json:"id"`
@@ -20,14 +23,11 @@ type ManyUsers struct {
 	Users []User `json:"data"`
 }
 
-type UsersResponse struct {
-	ResponseCommon
-	Data ManyUsers
-}
+type UsersResponse = ResponseOf[ManyUsers]
 
 type UsersParams struct {
-	IDs    []string `query:"id"`    // Limit 100
-	Logins []string `query:"login"` // Limit 100
+	IDs    []string `query:"id"`    // Limit 100, combined with Logins
+	Logins []string `query:"login"` // Limit 100, combined with IDs
 }
 
 // GetUsers gets information about one or more specified Twitch users.
@@ -36,16 +36,11 @@ type UsersParams struct {
 //
 // Optional scope: user:read:email
 func (c *Client) GetUsers(params *UsersParams) (*UsersResponse, error) {
-	resp, err := c.get("/users", &ManyUsers{}, params)
-	if err != nil {
-		return nil, err
+	if len(params.IDs)+len(params.Logins) > 100 {
+		return nil, &ValidationError{Field: "id", Message: "only 100 ids and logins can be specified in total"}
 	}
 
-	users := &UsersResponse{}
-	resp.HydrateResponseCommon(&users.ResponseCommon)
-	users.Data.Users = resp.Data.(*ManyUsers).Users
-
-	return users, nil
+	return getResponse[ManyUsers](c, "/users", params)
 }
 
 type UpdateUserParams struct {
@@ -57,25 +52,17 @@ type UpdateUserParams struct {
 //
 // Required scope: user:edit
 func (c *Client) UpdateUser(params *UpdateUserParams) (*UsersResponse, error) {
-	resp, err := c.put("/users", &ManyUsers{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	users := &UsersResponse{}
-	resp.HydrateResponseCommon(&users.ResponseCommon)
-	users.Data.Users = resp.Data.(*ManyUsers).Users
-
-	return users, nil
+	return putResponse[ManyUsers](c, "/users", params)
 }
 
 type UserFollow struct {
-	FromID     string    `json:"from_id"`
-	FromLogin  string    `json:"from_login"`
-	FromName   string    `json:"from_name"`
-	ToID       string    `json:"to_id"`
-	ToName     string    `json:"to_name"`
-	FollowedAt time.Time `json:"followed_at"`
+	FromID     string `json:"from_id"`
+	FromLogin  string `json:"from_login"`
+	FromName   string `json:"from_name"`
+	ToID       string `json:"to_id"`
+	ToLogin    string `json:"to_login"`
+	ToName     string `json:"to_name"`
+	FollowedAt Time   `json:"followed_at"`
 }
 
 type ManyFollows struct {
@@ -84,10 +71,7 @@ type ManyFollows struct {
 	Pagination Pagination   `json:"pagination"`
 }
 
-type UsersFollowsResponse struct {
-	ResponseCommon
-	Data ManyFollows
-}
+type UsersFollowsResponse = ResponseOf[ManyFollows]
 
 type UsersFollowsParams struct {
 	After  string `query:"after"`
@@ -100,12 +84,26 @@ type UsersFollowsParams struct {
 // Information returned is sorted in order, most recent follow first. This can return
 // information like “who is lirik following,” “who is following lirik,” or “is user X
 // following user Y.”
+//
+// Since Twitch removed the endpoint, ErrUsersFollowsRemoved is returned when
+// it responds with 410 Gone or 404 Not Found.
+//
+// Deprecated: Twitch has removed the users/follows endpoint, use GetFollowedChannels
+// or GetChannelFollowers instead, or GetUsersFollowsCompat as a drop-in replacement.
 func (c *Client) GetUsersFollows(params *UsersFollowsParams) (*UsersFollowsResponse, error) {
-	resp, err := c.get("/users/follows", &ManyFollows{}, params)
+	resp, err := c.sendRawRequest(http.MethodGet, "/users/follows", &ManyFollows{}, params, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusNotFound {
+		return nil, ErrUsersFollowsRemoved
+	}
+
+	if err := c.responseError(&resp.ResponseCommon); err != nil {
+		return nil, err
+	}
+
 	users := &UsersFollowsResponse{}
 	resp.HydrateResponseCommon(&users.ResponseCommon)
 	users.Data.Total = resp.Data.(*ManyFollows).Total
@@ -115,6 +113,91 @@ func (c *Client) GetUsersFollows(params *UsersFollowsParams) (*UsersFollowsRespo
 	return users, nil
 }
 
+// ErrUsersFollowsRemoved is returned by GetUsersFollows since Twitch removed
+// the users/follows endpoint.
+var ErrUsersFollowsRemoved = errors.New("error: twitch has removed the users/follows endpoint, use GetFollowedChannels, GetChannelFollowers or GetUsersFollowsCompat instead")
+
+// ChannelFollowersParams maps the parameters to those of GetChannelFollowers,
+// which lists the followers of ToID, or checks whether FromID follows ToID.
+func (p *UsersFollowsParams) ChannelFollowersParams() *GetChannelFollowsParams {
+	return &GetChannelFollowsParams{
+		BroadcasterID: p.ToID,
+		UserID:        p.FromID,
+		First:         p.First,
+		After:         p.After,
+	}
+}
+
+// FollowedChannelParams maps the parameters to those of GetFollowedChannels,
+// which lists the channels FromID follows, or checks whether FromID follows
+// ToID.
+func (p *UsersFollowsParams) FollowedChannelParams() *GetFollowedChannelParams {
+	return &GetFollowedChannelParams{
+		UserID:        p.FromID,
+		BroadcasterID: p.ToID,
+		First:         p.First,
+		After:         p.After,
+	}
+}
+
+// GetUsersFollowsCompat is a drop-in replacement for GetUsersFollows on top
+// of the endpoints that replaced users/follows. If only FromID is set, the
+// channels the user follows are listed with GetFollowedChannels, which
+// requires a user access token of FromID with the user:read:follows scope.
+// Otherwise the followers of ToID are listed with GetChannelFollowers, which
+// requires a user access token of the broadcaster or one of their moderators
+// with the moderator:read:followers scope. The new endpoints don't return the
+// logins and names of the user that is looked up, so those are left empty.
+func (c *Client) GetUsersFollowsCompat(params *UsersFollowsParams) (*UsersFollowsResponse, error) {
+	if params.FromID == "" && params.ToID == "" {
+		return nil, &ValidationError{Field: "from_id", Message: "from id or to id must be specified"}
+	}
+
+	follows := &UsersFollowsResponse{}
+
+	if params.ToID == "" {
+		resp, err := c.GetFollowedChannels(params.FollowedChannelParams())
+		if err != nil {
+			return nil, err
+		}
+
+		follows.ResponseCommon = resp.ResponseCommon
+		follows.Data.Total = int(resp.Data.Total)
+		follows.Data.Pagination = resp.Data.Pagination
+		for _, channel := range resp.Data.FollowedChannels {
+			follows.Data.Follows = append(follows.Data.Follows, UserFollow{
+				FromID:     params.FromID,
+				ToID:       channel.BroadcasterID,
+				ToLogin:    channel.BroadcaserLogin,
+				ToName:     channel.BroadcasterName,
+				FollowedAt: channel.Followed,
+			})
+		}
+
+		return follows, nil
+	}
+
+	resp, err := c.GetChannelFollowers(params.ChannelFollowersParams())
+	if err != nil {
+		return nil, err
+	}
+
+	follows.ResponseCommon = resp.ResponseCommon
+	follows.Data.Total = resp.Data.Total
+	follows.Data.Pagination = resp.Data.Pagination
+	for _, follower := range resp.Data.Channels {
+		follows.Data.Follows = append(follows.Data.Follows, UserFollow{
+			FromID:     follower.UserID,
+			FromLogin:  follower.UserLogin,
+			FromName:   follower.Username,
+			ToID:       params.ToID,
+			FollowedAt: follower.Followed,
+		})
+	}
+
+	return follows, nil
+}
+
 type UserBlocked struct {
 	UserID      string `json:"user_id"`
 	UserLogin   string `json:"user_login"`
@@ -126,10 +209,7 @@ type ManyUsersBlocked struct {
 	Pagination Pagination    `json:"pagination"`
 }
 
-type UsersBlockedResponse struct {
-	ResponseCommon
-	Data ManyUsersBlocked
-}
+type UsersBlockedResponse = ResponseOf[ManyUsersBlocked]
 
 type UsersBlockedParams struct {
 	BroadcasterID string `query:"broadcaster_id"`
@@ -137,23 +217,38 @@ type UsersBlockedParams struct {
 	First         int    `query:"first,20"` // Limit 100
 }
 
-// GetUsersBlocked : Gets a specified user’s block list.
+// GetUserBlockList : Gets a specified user’s block list. The list is sorted
+// by when the user was blocked, oldest first.
 //
 // Required scope: user:read:blocked_users
-func (c *Client) GetUsersBlocked(params *UsersBlockedParams) (*UsersBlockedResponse, error) {
-	resp, err := c.get("/users/blocks", &ManyUsersBlocked{}, params)
-	if err != nil {
+func (c *Client) GetUserBlockList(params *UsersBlockedParams) (*UsersBlockedResponse, error) {
+	if err := validateFirst(params.First, 100); err != nil {
 		return nil, err
 	}
 
-	users := &UsersBlockedResponse{}
-	resp.HydrateResponseCommon(&users.ResponseCommon)
-	users.Data.Users = resp.Data.(*ManyUsersBlocked).Users
-	users.Data.Pagination = resp.Data.(*ManyUsersBlocked).Pagination
+	return getResponse[ManyUsersBlocked](c, "/users/blocks", params)
+}
 
-	return users, nil
+// GetUsersBlocked : Gets a specified user’s block list.
+//
+// Deprecated: use GetUserBlockList instead.
+func (c *Client) GetUsersBlocked(params *UsersBlockedParams) (*UsersBlockedResponse, error) {
+	return c.GetUserBlockList(params)
 }
 
+// Contexts in which a user can be blocked
+const (
+	BlockSourceContextChat    = "chat"
+	BlockSourceContextWhisper = "whisper"
+)
+
+// Reasons for blocking a user
+const (
+	BlockReasonHarassment = "harassment"
+	BlockReasonSpam       = "spam"
+	BlockReasonOther      = "other"
+)
+
 type BlockUserResponse struct {
 	ResponseCommon
 }
@@ -165,6 +260,8 @@ type BlockUserParams struct {
 }
 
 // BlockUser : Blocks the specified user on behalf of the authenticated user.
+// SourceContext and Reason are optional, see the BlockSourceContext and
+// BlockReason constants for their valid values.
 //
 // Required scope: user:manage:blocked_users
 func (c *Client) BlockUser(params *BlockUserParams) (*BlockUserResponse, error) {
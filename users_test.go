@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetUsers(t *testing.T) {
@@ -270,6 +271,102 @@ func TestGetUsersFollows(t *testing.T) {
 	}
 }
 
+func TestGetUsersFollowsRemoved(t *testing.T) {
+	t.Parallel()
+
+	for _, statusCode := range []int{http.StatusGone, http.StatusNotFound} {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(statusCode, `{"error":"Gone","status":410,"message":"The API is no longer available"}`, nil))
+
+		_, err := c.GetUsersFollows(&UsersFollowsParams{FromID: "23161357"})
+		if err != ErrUsersFollowsRemoved {
+			t.Errorf("expected %v for status %d, got %v", ErrUsersFollowsRemoved, statusCode, err)
+		}
+	}
+}
+
+func TestGetUsersFollowsCompat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		params   *UsersFollowsParams
+		path     string
+		query    string
+		respBody string
+		expected UserFollow
+		total    int
+	}{
+		{
+			&UsersFollowsParams{FromID: "23161357", First: 1},
+			"/channels/followed",
+			"first=1&user_id=23161357",
+			`{"total":89,"data":[{"broadcaster_id":"23528098","broadcaster_login":"avoidingthepuddle","broadcaster_name":"AvoidingThePuddle","followed_at":"2017-10-01T03:57:21Z"}],"pagination":{"cursor":"eyJiIjpudWxsLCJhIjoiMTUwMzUwMDY2MDYwNzAyNTAwMCJ9"}}`,
+			UserFollow{FromID: "23161357", ToID: "23528098", ToLogin: "avoidingthepuddle", ToName: "AvoidingThePuddle", FollowedAt: Time{time.Date(2017, 10, 1, 3, 57, 21, 0, time.UTC)}},
+			89,
+		},
+		{
+			&UsersFollowsParams{ToID: "23528098", First: 1},
+			"/channels/followers",
+			"broadcaster_id=23528098&first=1",
+			`{"total":12345,"data":[{"user_id":"23161357","user_login":"lirik","user_name":"LIRIK","followed_at":"2017-10-01T03:57:21Z"}],"pagination":{"cursor":"eyJiIjpudWxsLCJhIjoiMTUwMzUwMDY2MDYwNzAyNTAwMCJ9"}}`,
+			UserFollow{FromID: "23161357", FromLogin: "lirik", FromName: "LIRIK", ToID: "23528098", FollowedAt: Time{time.Date(2017, 10, 1, 3, 57, 21, 0, time.UTC)}},
+			12345,
+		},
+		{
+			&UsersFollowsParams{FromID: "23161357", ToID: "23528098"},
+			"/channels/followers",
+			"broadcaster_id=23528098&user_id=23161357",
+			`{"total":12345,"data":[{"user_id":"23161357","user_login":"lirik","user_name":"LIRIK","followed_at":"2017-10-01T03:57:21Z"}],"pagination":{}}`,
+			UserFollow{FromID: "23161357", FromLogin: "lirik", FromName: "LIRIK", ToID: "23528098", FollowedAt: Time{time.Date(2017, 10, 1, 3, 57, 21, 0, time.UTC)}},
+			12345,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != testCase.path || r.URL.RawQuery != testCase.query {
+				t.Errorf("expected request to %s?%s, got %s?%s", testCase.path, testCase.query, r.URL.Path, r.URL.RawQuery)
+			}
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.GetUsersFollowsCompat(testCase.params)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.Data.Total != testCase.total {
+			t.Errorf("expected total to be %d, got %d", testCase.total, resp.Data.Total)
+		}
+
+		if len(resp.Data.Follows) != 1 || resp.Data.Follows[0] != testCase.expected {
+			t.Errorf("expected follows to be [%+v], got %+v", testCase.expected, resp.Data.Follows)
+		}
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, "", nil))
+	_, err := c.GetUsersFollowsCompat(&UsersFollowsParams{})
+	if err == nil || err.Error() != "error: from id or to id must be specified" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c = &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err = c.GetUsersFollowsCompat(&UsersFollowsParams{ToID: "23528098"})
+	if err == nil || err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
 func TestGetUsersBlocked(t *testing.T) {
 	t.Parallel()
 
@@ -375,6 +472,152 @@ func TestGetUsersBlocked(t *testing.T) {
 	}
 }
 
+func TestGetUserBlockList(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode    int
+		options       *Options
+		BroadcasterID string
+		First         int
+		respBody      string
+	}{
+		{
+			http.StatusBadRequest,
+			&Options{ClientID: "my-client-id"},
+			"",
+			1,
+			`{"error":"Bad Request","status":400,"message":"Missing required parameter \"broadcaster_id\""}`,
+		},
+		{
+			http.StatusOK,
+			&Options{ClientID: "my-client-id"},
+			"23161357",
+			1,
+			`{"data":[{"user_id":"199340135","user_login":"jlarkyzus","display_name":"JLArkyzus"}],"pagination":{"cursor":"xxx"}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(testCase.options, newMockHandler(testCase.statusCode, testCase.respBody, nil))
+
+		resp, err := c.GetUserBlockList(&UsersBlockedParams{
+			First:         testCase.First,
+			BroadcasterID: testCase.BroadcasterID,
+		})
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be \"%d\", got \"%d\"", testCase.statusCode, resp.StatusCode)
+		}
+
+		if resp.StatusCode == http.StatusBadRequest {
+			if resp.Error != "Bad Request" {
+				t.Errorf("expected error to be \"%s\", got \"%s\"", "Bad Request", resp.Error)
+			}
+
+			if resp.ErrorStatus != http.StatusBadRequest {
+				t.Errorf("expected error status to be \"%d\", got \"%d\"", http.StatusBadRequest, resp.ErrorStatus)
+			}
+
+			expectedErrMsg := "Missing required parameter \"broadcaster_id\""
+			if resp.ErrorMessage != expectedErrMsg {
+				t.Errorf("expected error message to be \"%s\", got \"%s\"", expectedErrMsg, resp.ErrorMessage)
+			}
+
+			continue
+		}
+
+		if len(resp.Data.Users) != testCase.First {
+			t.Errorf("expected result length to be \"%d\", got \"%d\"", testCase.First, len(resp.Data.Users))
+		}
+
+		userID := "199340135"
+		if resp.Data.Users[0].UserID != userID {
+			t.Errorf("expected user id to be \"%s\", got \"%s\"", userID, resp.Data.Users[0].UserID)
+		}
+
+		userLogin := "jlarkyzus"
+		if resp.Data.Users[0].UserLogin != userLogin {
+			t.Errorf("expected user id to be \"%s\", got \"%s\"", userLogin, resp.Data.Users[0].UserLogin)
+		}
+
+		displayName := "JLArkyzus"
+		if resp.Data.Users[0].DisplayName != displayName {
+			t.Errorf("expected user id to be \"%s\", got \"%s\"", displayName, resp.Data.Users[0].DisplayName)
+		}
+
+		cursor := "xxx"
+		if resp.Data.Pagination.Cursor != cursor {
+			t.Errorf("expected cursor to be \"%s\", got \"%s\"", cursor, resp.Data.Pagination.Cursor)
+		}
+	}
+
+	// Test with HTTP Failure
+	options := &Options{
+		ClientID: "my-client-id",
+		HTTPClient: &badMockHTTPClient{
+			newMockHandler(0, "", nil),
+		},
+	}
+	c := &Client{
+		opts: options,
+		ctx:  context.Background(),
+	}
+
+	_, err := c.GetUserBlockList(&UsersBlockedParams{})
+	if err == nil {
+		t.Error("expected error but got nil")
+	}
+
+	if err.Error() != "Failed to execute API request: Oops, that's bad :(" {
+		t.Error("expected error does match return error")
+	}
+}
+
+func TestGetUserBlockListFirst(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	_, err := c.GetUserBlockList(&UsersBlockedParams{BroadcasterID: "23161357", First: 101})
+	if err == nil || err.Error() != "error: first must not be greater than 100" {
+		t.Errorf("expected first error, got %v", err)
+	}
+}
+
+func TestBlockUserQuery(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected method to be %s, got %s", http.MethodPut, r.Method)
+		}
+
+		query := r.URL.Query()
+		if query.Get("target_user_id") != "199340135" || query.Get("source_context") != "whisper" || query.Get("reason") != "harassment" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := c.BlockUser(&BlockUserParams{
+		TargetUserID:  "199340135",
+		SourceContext: BlockSourceContextWhisper,
+		Reason:        BlockReasonHarassment,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code to be %d, got %d", http.StatusNoContent, resp.StatusCode)
+	}
+}
+
 func TestBlockUser(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,33 @@
+package helix
+
+import "fmt"
+
+// validateRequired returns a *ValidationError if the value of the parameter
+// is empty. The name is how the message refers to it, e.g. "broadcaster id".
+func validateRequired(field, name, value string) error {
+	if value == "" {
+		return &ValidationError{Field: field, Message: name + " must be specified"}
+	}
+
+	return nil
+}
+
+// validateMaxValues returns a *ValidationError if more than max values are
+// given for a repeated parameter, such as the 100 user IDs of most endpoints.
+func validateMaxValues(field, name string, values []string, max int) error {
+	if len(values) > max {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("only %d %s can be specified", max, name)}
+	}
+
+	return nil
+}
+
+// validateFirst returns a *ValidationError if more than max items per page
+// are requested.
+func validateFirst(first, max int) error {
+	if first > max {
+		return &ValidationError{Field: "first", Message: fmt.Sprintf("first must not be greater than %d", max)}
+	}
+
+	return nil
+}
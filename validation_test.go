@@ -0,0 +1,113 @@
+package helix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		request func(c *Client) error
+		field   string
+		message string
+	}{
+		{
+			func(c *Client) error {
+				_, err := c.GetUsers(&UsersParams{IDs: make([]string, 60), Logins: make([]string, 41)})
+				return err
+			},
+			"id",
+			"error: only 100 ids and logins can be specified in total",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.GetStreams(&StreamsParams{UserIDs: make([]string, 101)})
+				return err
+			},
+			"user_id",
+			"error: only 100 user ids can be specified",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.BanUser(&BanUserParams{})
+				return err
+			},
+			"broadcaster_id",
+			"error: broadcaster id and moderator id must be provided",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.GetChannelInformation(&GetChannelInformationParams{BroadcasterIDs: make([]string, 101)})
+				return err
+			},
+			"broadcaster_id",
+			"error: only 100 broadcaster ids can be specified",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.GetEmoteSets(&GetEmoteSetsParams{EmoteSetIDs: make([]string, 26)})
+				return err
+			},
+			"emote_set_id",
+			"error: only 25 emote set ids can be specified",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.GetChatSettings(&GetChatSettingsParams{})
+				return err
+			},
+			"broadcaster_id",
+			"error: broadcaster id must be specified",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.GetTeams(&GetTeamsParams{Name: "staff", ID: "123"})
+				return err
+			},
+			"name",
+			"error: only one of team name or id can be specified",
+		},
+		{
+			func(c *Client) error {
+				_, err := c.CreateEventSubSubscription(&EventSubSubscription{
+					Type:      EventSubTypeChannelFollow,
+					Transport: EventSubTransport{Method: "webhook", Callback: "http://example.com"},
+				})
+				return err
+			},
+			"transport.callback",
+			"error: callback must use https",
+		},
+	}
+
+	for _, testCase := range testCases {
+		sent := false
+		c := newMockClient(&Options{ClientID: "my-client-id"}, func(w http.ResponseWriter, r *http.Request) {
+			sent = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		err := testCase.request(c)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error, got %v", err)
+			continue
+		}
+
+		if validationErr.Field != testCase.field {
+			t.Errorf("expected field to be %s, got %s", testCase.field, validationErr.Field)
+		}
+
+		if err.Error() != testCase.message {
+			t.Errorf("expected error to be \"%s\", got \"%s\"", testCase.message, err.Error())
+		}
+
+		if sent {
+			t.Errorf("expected request for %s not to be sent", testCase.field)
+		}
+	}
+}
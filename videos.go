@@ -1,5 +1,49 @@
 package helix
 
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Time periods of videos returned by GetVideos
+const (
+	VideoPeriodAll   = "all"
+	VideoPeriodDay   = "day"
+	VideoPeriodWeek  = "week"
+	VideoPeriodMonth = "month"
+)
+
+// Sort orders of videos returned by GetVideos
+const (
+	VideoSortTime     = "time"
+	VideoSortTrending = "trending"
+	VideoSortViews    = "views"
+)
+
+// VideoType is the type of a video, VideoTypeAll is only used to filter
+// videos in GetVideos.
+type VideoType string
+
+// Types of videos
+const (
+	VideoTypeAll       VideoType = "all"
+	VideoTypeArchive   VideoType = "archive"
+	VideoTypeHighlight VideoType = "highlight"
+	VideoTypeUpload    VideoType = "upload"
+)
+
+// IsValid reports whether the type is one Twitch documents.
+func (t VideoType) IsValid() bool {
+	switch t {
+	case VideoTypeAll, VideoTypeArchive, VideoTypeHighlight, VideoTypeUpload:
+		return true
+	}
+
+	return false
+}
+
 type Video struct {
 	ID            string              `json:"id"`
 	UserID        string              `json:"user_id"`
@@ -8,23 +52,89 @@ type Video struct {
 	StreamID      string              `json:"stream_id"`
 	Title         string              `json:"title"`
 	Description   string              `json:"description"`
-	CreatedAt     string              `json:"created_at"`
-	PublishedAt   string              `json:"published_at"`
+	CreatedAt     Time                `json:"created_at"`
+	PublishedAt   Time                `json:"published_at"`
 	URL           string              `json:"url"`
 	ThumbnailURL  string              `json:"thumbnail_url"`
 	Viewable      string              `json:"viewable"`
 	ViewCount     int                 `json:"view_count"`
 	Language      string              `json:"language"`
-	Type          string              `json:"type"`
+	Type          VideoType           `json:"type"`
 	Duration      string              `json:"duration"`
-	MutedSegments []VideoMutedSegment `json:"muted_segments"`
+	MutedSegments []VideoMutedSegment `json:"muted_segments"` // Only set for archives, empty if none are muted
 }
 
+// VideoMutedSegment is a segment of a video that was muted for copyrighted
+// music. Duration and Offest are in seconds, Offest being the segment's start.
 type VideoMutedSegment struct {
 	Duration int `json:"duration"`
 	Offest   int `json:"offset"`
 }
 
+// Start returns the offset of the muted segment from the start of the video.
+func (s VideoMutedSegment) Start() time.Duration {
+	return time.Duration(s.Offest) * time.Second
+}
+
+// End returns the offset of the end of the muted segment from the start of the video.
+func (s VideoMutedSegment) End() time.Duration {
+	return time.Duration(s.Offest+s.Duration) * time.Second
+}
+
+// IsMutedAt reports whether the video is muted at the given offset
+// from the start of the video.
+func (v *Video) IsMutedAt(offset time.Duration) bool {
+	for _, segment := range v.MutedSegments {
+		if offset >= segment.Start() && offset < segment.End() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MutedDuration returns how much of the video is muted, segments that
+// overlap are only counted once.
+func (v *Video) MutedDuration() time.Duration {
+	segments := make([]VideoMutedSegment, len(v.MutedSegments))
+	copy(segments, v.MutedSegments)
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].Offest < segments[j].Offest
+	})
+
+	var muted, end time.Duration
+	for _, segment := range segments {
+		start := segment.Start()
+		if start < end {
+			start = end
+		}
+
+		if segment.End() > start {
+			muted += segment.End() - start
+			end = segment.End()
+		}
+	}
+
+	return muted
+}
+
+// ParseDuration returns the length of the video, see ParseVideoDuration.
+func (v *Video) ParseDuration() (time.Duration, error) {
+	return ParseVideoDuration(v.Duration)
+}
+
+var videoDurationRegexp = regexp.MustCompile(`^(\d+h)?(\d+m)?(\d+s)?$`)
+
+// ParseVideoDuration parses the duration of a video as returned by Twitch,
+// such as "3h8m33s" or "45s".
+func ParseVideoDuration(duration string) (time.Duration, error) {
+	if duration == "" || !videoDurationRegexp.MatchString(duration) {
+		return 0, fmt.Errorf("error: invalid video duration %q", duration)
+	}
+
+	return time.ParseDuration(duration)
+}
+
 type ManyVideos struct {
 	Videos     []Video    `json:"data"`
 	Pagination Pagination `json:"pagination"`
@@ -36,54 +146,80 @@ type VideosParams struct {
 	GameID string   `query:"game_id"` // Limit 1
 
 	// Optional
-	After    string `query:"after"`
-	Before   string `query:"before"`
-	First    int    `query:"first,20"`   // Limit 100
-	Language string `query:"language"`   // Limit 1
-	Period   string `query:"period,all"` // "all" (default), "day", "month", and "week"
-	Sort     string `query:"sort,time"`  // "time" (default), "trending", and "views"
-	Type     string `query:"type,all"`   // "all" (default), "upload", "archive", and "highlight"
+	After    string    `query:"after"`
+	Before   string    `query:"before"`
+	First    int       `query:"first,20"`   // Limit 100
+	Language string    `query:"language"`   // Limit 1
+	Period   string    `query:"period,all"` // "all" (default), "day", "month", and "week"
+	Sort     string    `query:"sort,time"`  // "time" (default), "trending", and "views"
+	Type     VideoType `query:"type,all"`   // VideoTypeAll (default), VideoTypeUpload, VideoTypeArchive or VideoTypeHighlight
 }
 
 type DeleteVideosParams struct {
 	IDs []string `query:"id"` // Limit 5
 }
 
-type VideosResponse struct {
-	ResponseCommon
-	Data ManyVideos
+type ManyDeletedVideos struct {
+	DeletedVideoIDs []string `json:"data"`
 }
 
-type DeleteVideosResponse struct {
-	ResponseCommon
+type VideosResponse = ResponseOf[ManyVideos]
+
+type DeleteVideosResponse = ResponseOf[ManyDeletedVideos]
+
+func isValidVideoFilter(value string, valid ...string) bool {
+	if value == "" {
+		return true
+	}
+
+	for _, v := range valid {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
 }
 
 // GetVideos gets video information by video ID (one or more), user ID (one only),
-// or game ID (one only).
+// or game ID (one only). Period, Sort and Type can only be used when filtering
+// by user ID or game ID.
 func (c *Client) GetVideos(params *VideosParams) (*VideosResponse, error) {
-	resp, err := c.get("/videos", &ManyVideos{}, params)
-	if err != nil {
+	if err := validateMaxValues("id", "video ids", params.IDs, 100); err != nil {
 		return nil, err
 	}
 
-	videos := &VideosResponse{}
-	resp.HydrateResponseCommon(&videos.ResponseCommon)
-	videos.Data.Videos = resp.Data.(*ManyVideos).Videos
-	videos.Data.Pagination = resp.Data.(*ManyVideos).Pagination
+	if err := validateFirst(params.First, 100); err != nil {
+		return nil, err
+	}
+
+	if !isValidVideoFilter(params.Period, VideoPeriodAll, VideoPeriodDay, VideoPeriodWeek, VideoPeriodMonth) {
+		return nil, &ValidationError{Field: "period", Message: "period must be one of all, day, week or month"}
+	}
+
+	if !isValidVideoFilter(params.Sort, VideoSortTime, VideoSortTrending, VideoSortViews) {
+		return nil, &ValidationError{Field: "sort", Message: "sort must be one of time, trending or views"}
+	}
 
-	return videos, nil
+	if params.Type != "" && !params.Type.IsValid() {
+		return nil, &ValidationError{Field: "type", Message: "type must be one of all, archive, highlight or upload"}
+	}
+
+	if len(params.IDs) > 0 && (params.Period != "" || params.Sort != "" || params.Type != "") {
+		return nil, &ValidationError{Field: "id", Message: "period, sort and type can't be used with video ids"}
+	}
+
+	return getResponse[ManyVideos](c, "/videos", params)
 }
 
-// DeleteVideos delete one or more videos (max 5)
+// DeleteVideos delete one or more videos (max 5). The response contains the
+// IDs of the videos that were deleted. If any of the IDs can't be deleted,
+// none of the videos are deleted.
 // Required scope: channel:manage:videos
 func (c *Client) DeleteVideos(params *DeleteVideosParams) (*DeleteVideosResponse, error) {
-	resp, err := c.delete("/videos", &DeleteVideosResponse{}, params)
-	if err != nil {
-		return nil, err
+	if len(params.IDs) > 5 {
+		return nil, &ValidationError{Field: "id", Message: "only 5 video ids can be deleted at once"}
 	}
 
-	videos := &DeleteVideosResponse{}
-	resp.HydrateResponseCommon(&videos.ResponseCommon)
-
-	return videos, nil
+	return deleteResponse[ManyDeletedVideos](c, "/videos", params)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetVideos(t *testing.T) {
@@ -69,6 +70,15 @@ func TestGetVideos(t *testing.T) {
 		if resp.Data.Videos[0].Language != testCase.VideosParams.Language {
 			t.Errorf("expected video language to be %s, got %s", testCase.VideosParams.Language, resp.Data.Videos[0].Language)
 		}
+
+		segments := resp.Data.Videos[0].MutedSegments
+		if len(segments) != 1 || segments[0].Start() != 2*time.Minute || segments[0].End() != 150*time.Second {
+			t.Errorf("expected a muted segment from 2m0s to 2m30s, got %+v", segments)
+		}
+
+		if !resp.Data.Videos[0].IsMutedAt(130*time.Second) || resp.Data.Videos[0].IsMutedAt(150*time.Second) {
+			t.Error("expected video to be muted at 2m10s and not at 2m30s")
+		}
 	}
 
 	// Test with HTTP Failure
@@ -93,6 +103,49 @@ func TestGetVideos(t *testing.T) {
 	}
 }
 
+func TestGetVideosValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		params        *VideosParams
+		validationErr string
+	}{
+		{
+			&VideosParams{UserID: "30080751", First: 101},
+			"error: first must not be greater than 100",
+		},
+		{
+			&VideosParams{IDs: make([]string, 101)},
+			"error: only 100 video ids can be specified",
+		},
+		{
+			&VideosParams{UserID: "30080751", Period: "year"},
+			"error: period must be one of all, day, week or month",
+		},
+		{
+			&VideosParams{UserID: "30080751", Sort: "oldest"},
+			"error: sort must be one of time, trending or views",
+		},
+		{
+			&VideosParams{UserID: "30080751", Type: "clip"},
+			"error: type must be one of all, archive, highlight or upload",
+		},
+		{
+			&VideosParams{IDs: []string{"224404190"}, Sort: VideoSortViews},
+			"error: period, sort and type can't be used with video ids",
+		},
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	for _, testCase := range testCases {
+		_, err := c.GetVideos(testCase.params)
+		if err == nil || err.Error() != testCase.validationErr {
+			t.Errorf("expected error to be \"%s\", got \"%v\"", testCase.validationErr, err)
+		}
+	}
+}
+
 func TestDeleteVideos(t *testing.T) {
 	t.Parallel()
 
@@ -112,7 +165,7 @@ func TestDeleteVideos(t *testing.T) {
 			http.StatusOK,
 			&Options{ClientID: "my-client-id"},
 			&DeleteVideosParams{IDs: []string{"456741"}},
-			"",
+			`{"data":["456741"]}`,
 		},
 	}
 
@@ -144,6 +197,17 @@ func TestDeleteVideos(t *testing.T) {
 
 			continue
 		}
+
+		if len(resp.Data.DeletedVideoIDs) != 1 || resp.Data.DeletedVideoIDs[0] != "456741" {
+			t.Errorf("expected deleted video ids to be [456741], got %v", resp.Data.DeletedVideoIDs)
+		}
+	}
+
+	c := newMockClient(&Options{ClientID: "my-client-id"}, newMockHandler(http.StatusOK, `{"data":[]}`, nil))
+
+	_, err := c.DeleteVideos(&DeleteVideosParams{IDs: []string{"1", "2", "3", "4", "5", "6"}})
+	if err == nil || err.Error() != "error: only 5 video ids can be deleted at once" {
+		t.Errorf("expected too many ids error, got %v", err)
 	}
 
 	// Test with HTTP Failure
@@ -153,12 +217,12 @@ func TestDeleteVideos(t *testing.T) {
 			newMockHandler(0, "", nil),
 		},
 	}
-	c := &Client{
+	c = &Client{
 		opts: options,
 		ctx:  context.Background(),
 	}
 
-	_, err := c.DeleteVideos(&DeleteVideosParams{})
+	_, err = c.DeleteVideos(&DeleteVideosParams{})
 	if err == nil {
 		t.Error("expected error but got nil")
 	}
@@ -167,3 +231,84 @@ func TestDeleteVideos(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestParseVideoDuration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		duration    string
+		expected    time.Duration
+		expectedErr string
+	}{
+		{"3h8m33s", 3*time.Hour + 8*time.Minute + 33*time.Second, ""},
+		{"12m0s", 12 * time.Minute, ""},
+		{"45s", 45 * time.Second, ""},
+		{"100h", 100 * time.Hour, ""},
+		{"", 0, `error: invalid video duration ""`},
+		{"1.5h", 0, `error: invalid video duration "1.5h"`},
+		{"3m8h", 0, `error: invalid video duration "3m8h"`},
+		{"-45s", 0, `error: invalid video duration "-45s"`},
+	}
+
+	for _, testCase := range testCases {
+		video := &Video{Duration: testCase.duration}
+
+		duration, err := video.ParseDuration()
+		if testCase.expectedErr != "" {
+			if err == nil || err.Error() != testCase.expectedErr {
+				t.Errorf("expected error %q for %q, got %v", testCase.expectedErr, testCase.duration, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("expected no error for %q, got %v", testCase.duration, err)
+		}
+
+		if duration != testCase.expected {
+			t.Errorf("expected %q to be %v, got %v", testCase.duration, testCase.expected, duration)
+		}
+	}
+}
+
+func TestVideoMutedDuration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		segments []VideoMutedSegment
+		expected time.Duration
+	}{
+		{nil, 0},
+		{[]VideoMutedSegment{{Duration: 30, Offest: 120}}, 30 * time.Second},
+		{[]VideoMutedSegment{{Duration: 30, Offest: 600}, {Duration: 30, Offest: 120}}, time.Minute},
+		{[]VideoMutedSegment{{Duration: 60, Offest: 120}, {Duration: 60, Offest: 150}, {Duration: 10, Offest: 160}}, 90 * time.Second},
+	}
+
+	for _, testCase := range testCases {
+		video := &Video{MutedSegments: testCase.segments}
+
+		if muted := video.MutedDuration(); muted != testCase.expected {
+			t.Errorf("expected %+v to mute %v, got %v", testCase.segments, testCase.expected, muted)
+		}
+	}
+}
+
+func TestVideoTypeIsValid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		videoType VideoType
+		valid     bool
+	}{
+		{VideoTypeArchive, true},
+		{VideoTypeAll, true},
+		{"vodcast", false},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		if valid := testCase.videoType.IsValid(); valid != testCase.valid {
+			t.Errorf("expected %q to be valid: %t, got %t", testCase.videoType, testCase.valid, valid)
+		}
+	}
+}
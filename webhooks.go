@@ -17,10 +17,7 @@ type ManyWebhookSubscriptions struct {
 	Pagination           Pagination            `json:"pagination"`
 }
 
-type WebhookSubscriptionsResponse struct {
-	ResponseCommon
-	Data ManyWebhookSubscriptions
-}
+type WebhookSubscriptionsResponse = ResponseOf[ManyWebhookSubscriptions]
 
 type WebhookSubscriptionsParams struct {
 	After string `query:"after"`
@@ -30,18 +27,7 @@ type WebhookSubscriptionsParams struct {
 // GetWebhookSubscriptions gets webhook subscriptions, in order of expiration.
 // Requires an app access token.
 func (c *Client) GetWebhookSubscriptions(params *WebhookSubscriptionsParams) (*WebhookSubscriptionsResponse, error) {
-	resp, err := c.get("/webhooks/subscriptions", &ManyWebhookSubscriptions{}, params)
-	if err != nil {
-		return nil, err
-	}
-
-	webhooks := &WebhookSubscriptionsResponse{}
-	resp.HydrateResponseCommon(&webhooks.ResponseCommon)
-	webhooks.Data.Total = resp.Data.(*ManyWebhookSubscriptions).Total
-	webhooks.Data.WebhookSubscriptions = resp.Data.(*ManyWebhookSubscriptions).WebhookSubscriptions
-	webhooks.Data.Pagination = resp.Data.(*ManyWebhookSubscriptions).Pagination
-
-	return webhooks, nil
+	return getResponse[ManyWebhookSubscriptions](c, "/webhooks/subscriptions", params)
 }
 
 type WebhookSubscriptionResponse struct {
@@ -0,0 +1,257 @@
+package helix
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// websocketGUID is appended to the handshake key to derive the accept key,
+// see RFC 6455 section 1.3.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Opcodes of websocket frames
+const (
+	websocketOpContinuation = 0x0
+	websocketOpText         = 0x1
+	websocketOpBinary       = 0x2
+	websocketOpClose        = 0x8
+	websocketOpPing         = 0x9
+	websocketOpPong         = 0xa
+)
+
+// websocketMaxMessageSize caps the size of a received message, EventSub
+// messages are far smaller.
+const websocketMaxMessageSize = 1 << 20
+
+// errWebsocketClosed is returned by ReadMessage once the server closed the
+// connection.
+var errWebsocketClosed = errors.New("error: websocket closed by server")
+
+// websocketConn is a minimal client side websocket connection that reads
+// text and binary messages and answers pings. EventSub never expects the
+// client to send messages, so there is no way to write any.
+type websocketConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+}
+
+// dialWebsocket opens a websocket connection to a ws:// or wss:// URL.
+func dialWebsocket(ctx context.Context, rawURL string) (EventSubWebsocketConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		port := "443"
+		if u.Scheme == "ws" {
+			port = "80"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	var conn net.Conn
+	switch u.Scheme {
+	case "wss":
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: u.Hostname()}}
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	case "ws":
+		dialer := &net.Dialer{}
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	default:
+		return nil, fmt.Errorf("error: unsupported websocket scheme: %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := websocketHandshake(ctx, conn, u)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func websocketHandshake(ctx context.Context, conn net.Conn, u *url.URL) (*websocketConn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        u,
+		Host:       u.Host,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     {key},
+			"Sec-WebSocket-Version": {"13"},
+		},
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("error: websocket handshake failed with status %d", resp.StatusCode)
+	}
+
+	accept := sha1.Sum([]byte(key + websocketGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(accept[:]) {
+		return nil, errors.New("error: websocket handshake returned an invalid accept key")
+	}
+
+	return &websocketConn{conn: conn, reader: reader}, nil
+}
+
+// ReadMessage returns the next text or binary message, reassembling
+// fragmented messages and answering pings while waiting.
+func (c *websocketConn) ReadMessage() ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case websocketOpText, websocketOpBinary, websocketOpContinuation:
+			if len(message)+len(payload) > websocketMaxMessageSize {
+				return nil, errors.New("error: websocket message too large")
+			}
+
+			message = append(message, payload...)
+			if fin {
+				return message, nil
+			}
+		case websocketOpPing:
+			if err := c.writeFrame(websocketOpPong, payload); err != nil {
+				return nil, err
+			}
+		case websocketOpClose:
+			c.writeFrame(websocketOpClose, payload)
+			return nil, errWebsocketClosed
+		}
+	}
+}
+
+func (c *websocketConn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if length > websocketMaxMessageSize {
+		return false, 0, nil, errors.New("error: websocket frame too large")
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// writeFrame writes a single masked frame, clients must mask every frame.
+func (c *websocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	frame := []byte{0x80 | opcode}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, 0x80|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, 0x80|126, byte(len(payload)>>8), byte(len(payload)))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close sends a close frame and closes the connection.
+func (c *websocketConn) Close() error {
+	c.writeFrame(websocketOpClose, []byte{0x03, 0xe8}) // 1000, normal closure
+	return c.conn.Close()
+}
@@ -1,5 +1,21 @@
 package helix
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors reported by SendUserWhisperResponse.Err, matching the status codes
+// documented for the Send Whisper endpoint.
+var (
+	ErrWhisperBadRequest        = errors.New("whisper: bad request")
+	ErrWhisperUnauthorized      = errors.New("whisper: unauthorized")
+	ErrWhisperForbidden         = errors.New("whisper: forbidden")
+	ErrWhisperRecipientNotFound = errors.New("whisper: recipient not found")
+	ErrWhisperRateLimited       = errors.New("whisper: rate limited")
+)
+
 type SendUserWhisperParams struct {
 	FromUserID string `query:"from_user_id"`
 	ToUserID   string `query:"to_user_id"`
@@ -10,6 +26,40 @@ type SendUserWhisperResponse struct {
 	ResponseCommon
 }
 
+// Err maps a failed whisper response onto one of the ErrWhisper* errors,
+// wrapped with the message returned by Twitch. It returns nil if the
+// whisper was accepted.
+//
+//   - 400: the sender has no verified phone number, or the message is empty
+//   - 401: the token is missing the user:manage:whispers scope or doesn't match from_user_id
+//   - 403: the sender is suspended, or the recipient doesn't accept whispers from the sender
+//   - 404: the recipient doesn't exist
+//   - 429: the sender exceeded the number of whispers or recipients allowed
+func (r *SendUserWhisperResponse) Err() error {
+	var err error
+
+	switch r.StatusCode {
+	case http.StatusBadRequest:
+		err = ErrWhisperBadRequest
+	case http.StatusUnauthorized:
+		err = ErrWhisperUnauthorized
+	case http.StatusForbidden:
+		err = ErrWhisperForbidden
+	case http.StatusNotFound:
+		err = ErrWhisperRecipientNotFound
+	case http.StatusTooManyRequests:
+		err = ErrWhisperRateLimited
+	default:
+		return nil
+	}
+
+	if r.ErrorMessage == "" {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", err, r.ErrorMessage)
+}
+
 // SendUserWhisper
 // Requires user access token with user:manage:whispers scope.
 // The user sending the whisper must have a verified phone number.
@@ -31,3 +81,12 @@ func (c *Client) SendUserWhisper(params *SendUserWhisperParams) (*SendUserWhispe
 
 	return whisperResp, nil
 }
+
+// SendWhisper is a shorthand for SendUserWhisper.
+func (c *Client) SendWhisper(fromUserID, toUserID, message string) (*SendUserWhisperResponse, error) {
+	return c.SendUserWhisper(&SendUserWhisperParams{
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		Message:    message,
+	})
+}
@@ -2,6 +2,7 @@ package helix
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 )
@@ -75,3 +76,72 @@ func TestSendUserWhisper(t *testing.T) {
 		t.Error("expected error does match return error")
 	}
 }
+
+func TestSendWhisper(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		statusCode  int
+		respBody    string
+		expectedErr error
+	}{
+		{
+			http.StatusNoContent,
+			``,
+			nil,
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":"Bad Request","status":400,"message":"The user in the from_user_id query parameter must have a verified phone number."}`,
+			ErrWhisperBadRequest,
+		},
+		{
+			http.StatusUnauthorized,
+			`{"error":"Unauthorized","status":401,"message":"Missing scope: user:manage:whispers"}`,
+			ErrWhisperUnauthorized,
+		},
+		{
+			http.StatusForbidden,
+			`{"error":"Forbidden","status":403,"message":"The recipient's settings prevent whispers from the sender."}`,
+			ErrWhisperForbidden,
+		},
+		{
+			http.StatusNotFound,
+			`{"error":"Not Found","status":404,"message":"The ID in to_user_id was not found."}`,
+			ErrWhisperRecipientNotFound,
+		},
+		{
+			http.StatusTooManyRequests,
+			`{"error":"Too Many Requests","status":429,"message":"The sender has exceeded the number of whispers they may send."}`,
+			ErrWhisperRateLimited,
+		},
+	}
+
+	for _, testCase := range testCases {
+		c := newMockClient(&Options{ClientID: "my-client-id", UserAccessToken: "user-access-token"}, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("from_user_id") != "100249559" {
+				t.Errorf("expected from_user_id to be \"%s\", got \"%s\"", "100249559", r.URL.Query().Get("from_user_id"))
+			}
+
+			if r.URL.Query().Get("to_user_id") != "100249558" {
+				t.Errorf("expected to_user_id to be \"%s\", got \"%s\"", "100249558", r.URL.Query().Get("to_user_id"))
+			}
+
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.respBody))
+		})
+
+		resp, err := c.SendWhisper("100249559", "100249558", "hello twitch chat")
+		if err != nil {
+			t.Error(err)
+		}
+
+		if resp.StatusCode != testCase.statusCode {
+			t.Errorf("expected status code to be %d, got %d", testCase.statusCode, resp.StatusCode)
+		}
+
+		if !errors.Is(resp.Err(), testCase.expectedErr) {
+			t.Errorf("expected error to be \"%v\", got \"%v\"", testCase.expectedErr, resp.Err())
+		}
+	}
+}